@@ -13,32 +13,41 @@ type Client struct {
 	TokenExpire int64  `json:"token_expire,string"`
 	UserCount   int64  `json:"user_count,string"`
 	GroupCount  int64  `json:"group_count,string"`
+	// Retention of the messages, in seconds and per topic
+	MessageMaxAge   int64 `json:"message_max_age,string"`
+	MessageMaxCount int64 `json:"message_max_count,string"`
 }
 
 func (c *Client) Fill(v *api.Client) {
 	*c = Client{
-		ID:          v.ID,
-		CreatedAt:   v.CreatedAt,
-		UpdatedAt:   v.UpdatedAt,
-		Name:        v.Name,
-		TokenSecret: string(v.TokenSecret),
-		TokenExpire: v.TokenExpire,
-		UserCount:   v.UserCount,
-		GroupCount:  v.GroupCount,
+		ID:              v.ID,
+		CreatedAt:       v.CreatedAt,
+		UpdatedAt:       v.UpdatedAt,
+		Name:            v.Name,
+		TokenSecret:     string(v.TokenSecret),
+		TokenExpire:     v.TokenExpire,
+		UserCount:       v.UserCount,
+		GroupCount:      v.GroupCount,
+		MessageMaxAge:   v.MessageMaxAge,
+		MessageMaxCount: v.MessageMaxCount,
 	}
 }
 
 type CreateClientReq struct {
-	Name        string `json:"name"`
-	TokenSecret string `json:"token_secret"`
-	TokenExpire int64  `json:"token_expire"`
+	Name            string `json:"name"`
+	TokenSecret     string `json:"token_secret"`
+	TokenExpire     int64  `json:"token_expire"`
+	MessageMaxAge   int64  `json:"message_max_age"`
+	MessageMaxCount int64  `json:"message_max_count"`
 }
 
 func (r *CreateClientReq) FillToProto() *api.CreateClientReq {
 	return &api.CreateClientReq{
-		Name:        r.Name,
-		TokenSecret: r.TokenSecret,
-		TokenExpire: r.TokenExpire,
+		Name:            r.Name,
+		TokenSecret:     r.TokenSecret,
+		TokenExpire:     r.TokenExpire,
+		MessageMaxAge:   r.MessageMaxAge,
+		MessageMaxCount: r.MessageMaxCount,
 	}
 }
 
@@ -48,10 +57,12 @@ type NewClient struct {
 }
 
 type UpdateClientReq struct {
-	ID          string  `json:"id"`
-	Name        *string `json:"name"`
-	TokenSecret *string `json:"token_secret"`
-	TokenExpire *int64  `json:"token_expire"`
+	ID              string  `json:"id"`
+	Name            *string `json:"name"`
+	TokenSecret     *string `json:"token_secret"`
+	TokenExpire     *int64  `json:"token_expire"`
+	MessageMaxAge   *int64  `json:"message_max_age"`
+	MessageMaxCount *int64  `json:"message_max_count"`
 }
 
 func (r *UpdateClientReq) FillToProto() *api.UpdateClientReq {
@@ -71,5 +82,15 @@ func (r *UpdateClientReq) FillToProto() *api.UpdateClientReq {
 			Value: *r.TokenExpire,
 		}
 	}
+	if r.MessageMaxAge != nil {
+		req.MessageMaxAge = &api.Int64Value{
+			Value: *r.MessageMaxAge,
+		}
+	}
+	if r.MessageMaxCount != nil {
+		req.MessageMaxCount = &api.Int64Value{
+			Value: *r.MessageMaxCount,
+		}
+	}
 	return req
 }
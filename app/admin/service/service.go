@@ -3,13 +3,13 @@ package service
 import (
 	"context"
 	"github.com/micro/go-micro/v2/client"
-	"github.com/micro/go-micro/v2/client/grpc"
 	"github.com/micro/go-micro/v2/metadata"
 	"github.com/micro/go-micro/v2/registry"
 	"mercury/app/admin/model"
 	chatApi "mercury/app/logic/api"
 	"mercury/x/ecode"
 	"mercury/x/log"
+	"mercury/x/microx"
 	"strconv"
 	"strings"
 	"time"
@@ -27,18 +27,20 @@ type Service struct {
 	service chatApi.ChatAdminService
 }
 
-func NewService(log log.Logger) (*Service, error) {
+// NewService creates the admin service calling the logic service registered as logicService
+// in r.
+func NewService(logicService string, r registry.Registry, log log.Logger) (*Service, error) {
 	opts := []client.Option{
 		client.Retries(2),
 		client.Retry(ecode.RetryOnMicroError),
 		client.WrapCall(ecode.MicroCallFunc),
 	}
 
-	c := grpc.NewClient(opts...)
+	c := microx.NewClient(r, opts...)
 
 	return &Service{
 		log:     log,
-		service: chatApi.NewChatAdminService("mercury.logic", c),
+		service: chatApi.NewChatAdminService(logicService, c),
 	}, nil
 }
 
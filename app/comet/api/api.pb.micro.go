@@ -44,7 +44,11 @@ func NewChatEndpoints() []*api.Endpoint {
 
 type ChatService interface {
 	PushMessage(ctx context.Context, in *PushMessageReq, opts ...client.CallOption) (*Empty, error)
+	PushMessages(ctx context.Context, in *PushMessagesReq, opts ...client.CallOption) (*Empty, error)
+	PushUIDMessage(ctx context.Context, in *PushUIDMessageReq, opts ...client.CallOption) (*Empty, error)
+	PushTopicMessage(ctx context.Context, in *PushTopicMessageReq, opts ...client.CallOption) (*Empty, error)
 	BroadcastMessage(ctx context.Context, in *BroadcastMessageReq, opts ...client.CallOption) (*Empty, error)
+	BroadcastRoom(ctx context.Context, in *BroadcastRoomReq, opts ...client.CallOption) (*Empty, error)
 }
 
 type chatService struct {
@@ -69,6 +73,36 @@ func (c *chatService) PushMessage(ctx context.Context, in *PushMessageReq, opts
 	return out, nil
 }
 
+func (c *chatService) PushMessages(ctx context.Context, in *PushMessagesReq, opts ...client.CallOption) (*Empty, error) {
+	req := c.c.NewRequest(c.name, "Chat.PushMessages", in)
+	out := new(Empty)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatService) PushUIDMessage(ctx context.Context, in *PushUIDMessageReq, opts ...client.CallOption) (*Empty, error) {
+	req := c.c.NewRequest(c.name, "Chat.PushUIDMessage", in)
+	out := new(Empty)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatService) PushTopicMessage(ctx context.Context, in *PushTopicMessageReq, opts ...client.CallOption) (*Empty, error) {
+	req := c.c.NewRequest(c.name, "Chat.PushTopicMessage", in)
+	out := new(Empty)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chatService) BroadcastMessage(ctx context.Context, in *BroadcastMessageReq, opts ...client.CallOption) (*Empty, error) {
 	req := c.c.NewRequest(c.name, "Chat.BroadcastMessage", in)
 	out := new(Empty)
@@ -79,17 +113,35 @@ func (c *chatService) BroadcastMessage(ctx context.Context, in *BroadcastMessage
 	return out, nil
 }
 
+func (c *chatService) BroadcastRoom(ctx context.Context, in *BroadcastRoomReq, opts ...client.CallOption) (*Empty, error) {
+	req := c.c.NewRequest(c.name, "Chat.BroadcastRoom", in)
+	out := new(Empty)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Chat service
 
 type ChatHandler interface {
 	PushMessage(context.Context, *PushMessageReq, *Empty) error
+	PushMessages(context.Context, *PushMessagesReq, *Empty) error
+	PushUIDMessage(context.Context, *PushUIDMessageReq, *Empty) error
+	PushTopicMessage(context.Context, *PushTopicMessageReq, *Empty) error
 	BroadcastMessage(context.Context, *BroadcastMessageReq, *Empty) error
+	BroadcastRoom(context.Context, *BroadcastRoomReq, *Empty) error
 }
 
 func RegisterChatHandler(s server.Server, hdlr ChatHandler, opts ...server.HandlerOption) error {
 	type chat interface {
 		PushMessage(ctx context.Context, in *PushMessageReq, out *Empty) error
+		PushMessages(ctx context.Context, in *PushMessagesReq, out *Empty) error
+		PushUIDMessage(ctx context.Context, in *PushUIDMessageReq, out *Empty) error
+		PushTopicMessage(ctx context.Context, in *PushTopicMessageReq, out *Empty) error
 		BroadcastMessage(ctx context.Context, in *BroadcastMessageReq, out *Empty) error
+		BroadcastRoom(ctx context.Context, in *BroadcastRoomReq, out *Empty) error
 	}
 	type Chat struct {
 		chat
@@ -106,6 +158,22 @@ func (h *chatHandler) PushMessage(ctx context.Context, in *PushMessageReq, out *
 	return h.ChatHandler.PushMessage(ctx, in, out)
 }
 
+func (h *chatHandler) PushMessages(ctx context.Context, in *PushMessagesReq, out *Empty) error {
+	return h.ChatHandler.PushMessages(ctx, in, out)
+}
+
+func (h *chatHandler) PushUIDMessage(ctx context.Context, in *PushUIDMessageReq, out *Empty) error {
+	return h.ChatHandler.PushUIDMessage(ctx, in, out)
+}
+
+func (h *chatHandler) PushTopicMessage(ctx context.Context, in *PushTopicMessageReq, out *Empty) error {
+	return h.ChatHandler.PushTopicMessage(ctx, in, out)
+}
+
 func (h *chatHandler) BroadcastMessage(ctx context.Context, in *BroadcastMessageReq, out *Empty) error {
 	return h.ChatHandler.BroadcastMessage(ctx, in, out)
 }
+
+func (h *chatHandler) BroadcastRoom(ctx context.Context, in *BroadcastRoomReq, out *Empty) error {
+	return h.ChatHandler.BroadcastRoom(ctx, in, out)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"mercury/x"
+)
+
+// BroadcastFilter selects the sessions receiving a broadcast. A broadcast always belongs to
+// a client, sessions of the other clients never match.
+type BroadcastFilter struct {
+	// ID of the client the sessions belong to, required
+	ClientID string
+	// Platforms of the sessions, any if empty
+	Platforms []string
+	// Minimum protocol version of the sessions, any if empty
+	MinVersion string
+	// IDs of the users of the sessions, any if empty
+	UIDs []string
+}
+
+// Filter returns the sessions in the store matching the filter.
+func (f *BroadcastFilter) Filter(ss SessionStore) []*Session {
+	if f.ClientID == "" {
+		return nil
+	}
+
+	var sessions []*Session
+	if len(f.UIDs) > 0 {
+		// A user may be listed more than once, its sessions only receive the broadcast once
+		seen := make(map[string]bool)
+		for _, uid := range f.UIDs {
+			for _, s := range ss.GetByUID(uid) {
+				if !seen[s.sid] {
+					seen[s.sid] = true
+					sessions = append(sessions, s)
+				}
+			}
+		}
+	} else {
+		sessions = ss.GetByClient(f.ClientID)
+	}
+
+	minVersion := x.ParseVersion(f.MinVersion)
+	matched := sessions[:0]
+	for _, s := range sessions {
+		if f.match(s, minVersion) {
+			matched = append(matched, s)
+		}
+	}
+	return matched
+}
+
+func (f *BroadcastFilter) match(s *Session, minVersion int) bool {
+	if s.clientID != f.ClientID {
+		return false
+	}
+	if minVersion > 0 && x.VersionCompare(s.version, minVersion) < 0 {
+		return false
+	}
+	if len(f.Platforms) > 0 {
+		for _, platform := range f.Platforms {
+			if s.platform == platform {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
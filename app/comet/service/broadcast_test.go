@@ -0,0 +1,35 @@
+package service
+
+import (
+	"mercury/x"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastFilter(t *testing.T) {
+	ss := &sessionStore{cache: NewDefaultCache(), rooms: newRoomStore(0)}
+	add := func(sid, clientID, uid, platform, version string) {
+		s := newTestSession(sid)
+		s.clientID = clientID
+		s.platform = platform
+		s.version = x.ParseVersion(version)
+		ss.cache.Store(sid, s)
+		ss.cache.Bind(sid, clientID, uid)
+	}
+	add("s1", "c1", "u1", "ios", "0.2.0")
+	add("s2", "c1", "u1", "web", "0.1.0")
+	add("s3", "c1", "u2", "android", "0.2.0")
+	add("s4", "c2", "u3", "ios", "0.2.0")
+
+	require.Empty(t, (&BroadcastFilter{}).Filter(ss))
+	require.Len(t, (&BroadcastFilter{ClientID: "c1"}).Filter(ss), 3)
+	require.Len(t, (&BroadcastFilter{ClientID: "c2"}).Filter(ss), 1)
+	require.Len(t, (&BroadcastFilter{ClientID: "c1", Platforms: []string{"ios", "web"}}).Filter(ss), 2)
+	require.Len(t, (&BroadcastFilter{ClientID: "c1", MinVersion: "0.2"}).Filter(ss), 2)
+	require.Len(t, (&BroadcastFilter{ClientID: "c1", UIDs: []string{"u1"}}).Filter(ss), 2)
+	// A user listed twice gets the broadcast once per session
+	require.Len(t, (&BroadcastFilter{ClientID: "c1", UIDs: []string{"u1", "u2", "u1"}}).Filter(ss), 3)
+	// Users of other clients never match
+	require.Empty(t, (&BroadcastFilter{ClientID: "c1", UIDs: []string{"u3"}}).Filter(ss))
+}
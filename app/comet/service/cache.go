@@ -1,82 +1,256 @@
 package service
 
 import (
+	"hash/fnv"
 	"mercury/app/comet/stats"
 	"sync"
+	"sync/atomic"
 )
 
 type Cache interface {
 	Length() int
 	Existed(key string) bool
 	Store(key string, value *Session)
+	// Bind indexes the session stored under key by client ID and user ID.
+	Bind(key, clientID, uid string)
 	Load(key string) *Session
+	LoadByUID(uid string) []*Session
+	LoadByClient(clientID string) []*Session
+	// CountByClient returns the number of bound sessions of each client.
+	CountByClient() map[string]int
 	All() []*Session
 	Delete(key string)
 	Shutdown()
 }
 
+// Number of shards of the default cache, must be a power of two.
+const defaultCacheShards = 64
+
+var registerStats sync.Once
+
 func NewDefaultCache() Cache {
-	stats.RegisterInt("LiveSessions")
-	stats.RegisterInt("TotalSessions")
+	registerStats.Do(func() {
+		stats.RegisterInt("LiveSessions")
+		stats.RegisterInt("TotalSessions")
+	})
 
-	return &defaultCache{
-		mux: sync.RWMutex{},
-		kv:  make(map[string]*Session),
-	}
+	return newShardedCache(defaultCacheShards)
 }
 
-type defaultCache struct {
-	mux sync.RWMutex
+// shardedCache spreads sessions over several independently locked shards, so sessions of
+// different users do not contend for the same lock. Sessions are sharded by session ID,
+// the user and client indexes are sharded by their own keys.
+type shardedCache struct {
+	mask uint32
 	// All sessions indexed by session ID
-	kv map[string]*Session
+	sessions []*sessionShard
+	// Sessions indexed by user ID, then by session ID
+	users []*indexShard
+	// Sessions indexed by client ID, then by session ID
+	clients []*indexShard
+	length  int64
+}
+
+type cacheEntry struct {
+	session  *Session
+	clientID string
+	uid      string
+}
+
+type sessionShard struct {
+	mux sync.RWMutex
+	kv  map[string]*cacheEntry
+}
+
+type indexShard struct {
+	mux sync.RWMutex
+	kv  map[string]map[string]*Session
+}
+
+func newShardedCache(shards int) *shardedCache {
+	c := &shardedCache{
+		mask:     uint32(shards - 1),
+		sessions: make([]*sessionShard, shards),
+		users:    make([]*indexShard, shards),
+		clients:  make([]*indexShard, shards),
+	}
+	for i := 0; i < shards; i++ {
+		c.sessions[i] = &sessionShard{kv: make(map[string]*cacheEntry)}
+		c.users[i] = &indexShard{kv: make(map[string]map[string]*Session)}
+		c.clients[i] = &indexShard{kv: make(map[string]map[string]*Session)}
+	}
+	return c
+}
+
+func (c *shardedCache) shard(key string) uint32 {
+	return shardOf(key, c.mask)
+}
+
+// shardOf hashes the key to one of mask+1 shards.
+func shardOf(key string, mask uint32) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return h.Sum32() & mask
 }
 
-func (c *defaultCache) Length() int {
-	return len(c.kv)
+func (c *shardedCache) Length() int {
+	return int(atomic.LoadInt64(&c.length))
 }
 
-func (c *defaultCache) Existed(key string) bool {
-	c.mux.RLock()
-	defer c.mux.RUnlock()
-	_, ok := c.kv[key]
+func (c *shardedCache) Existed(key string) bool {
+	shard := c.sessions[c.shard(key)]
+	shard.mux.RLock()
+	defer shard.mux.RUnlock()
+	_, ok := shard.kv[key]
 	return ok
 }
 
-func (c *defaultCache) Store(key string, value *Session) {
-	c.mux.Lock()
-	defer c.mux.Unlock()
-	c.kv[key] = value
+func (c *shardedCache) Store(key string, value *Session) {
+	shard := c.sessions[c.shard(key)]
+	shard.mux.Lock()
+	old, ok := shard.kv[key]
+	shard.kv[key] = &cacheEntry{session: value}
+	if ok {
+		c.unindex(key, old)
+	}
+	shard.mux.Unlock()
 
-	stats.Set("LiveSessions", len(c.kv), false)
+	if !ok {
+		stats.Set("LiveSessions", int(atomic.AddInt64(&c.length, 1)), false)
+	}
 	stats.Set("TotalSessions", 1, true)
 }
 
-func (c *defaultCache) Load(key string) *Session {
-	c.mux.RLock()
-	defer c.mux.RUnlock()
-	return c.kv[key]
+func (c *shardedCache) Bind(key, clientID, uid string) {
+	shard := c.sessions[c.shard(key)]
+	shard.mux.Lock()
+	entry, ok := shard.kv[key]
+	if !ok {
+		shard.mux.Unlock()
+		return
+	}
+	old := *entry
+	entry.clientID = clientID
+	entry.uid = uid
+	// Index while holding the session shard, a concurrent Delete has to wait until the
+	// indexes are complete and can then clean them up.
+	c.unindex(key, &old)
+	if clientID != "" {
+		c.clients[c.shard(clientID)].add(clientID, key, entry.session)
+	}
+	if uid != "" {
+		c.users[c.shard(uid)].add(uid, key, entry.session)
+	}
+	shard.mux.Unlock()
+}
+
+func (c *shardedCache) Load(key string) *Session {
+	shard := c.sessions[c.shard(key)]
+	shard.mux.RLock()
+	defer shard.mux.RUnlock()
+	if entry, ok := shard.kv[key]; ok {
+		return entry.session
+	}
+	return nil
+}
+
+func (c *shardedCache) LoadByUID(uid string) []*Session {
+	return c.users[c.shard(uid)].load(uid)
 }
 
-func (c *defaultCache) All() []*Session {
-	c.mux.RLock()
-	defer c.mux.RUnlock()
-	var sessions []*Session
-	for _, s := range c.kv {
-		sessions = append(sessions, s)
+func (c *shardedCache) LoadByClient(clientID string) []*Session {
+	return c.clients[c.shard(clientID)].load(clientID)
+}
+
+func (c *shardedCache) CountByClient() map[string]int {
+	counts := make(map[string]int)
+	for _, shard := range c.clients {
+		shard.mux.RLock()
+		for clientID, m := range shard.kv {
+			counts[clientID] = len(m)
+		}
+		shard.mux.RUnlock()
+	}
+	return counts
+}
+
+func (c *shardedCache) All() []*Session {
+	sessions := make([]*Session, 0, c.Length())
+	for _, shard := range c.sessions {
+		shard.mux.RLock()
+		for _, entry := range shard.kv {
+			sessions = append(sessions, entry.session)
+		}
+		shard.mux.RUnlock()
 	}
 	return sessions
 }
 
-func (c *defaultCache) Delete(key string) {
-	c.mux.RLock()
-	defer c.mux.RUnlock()
-	delete(c.kv, key)
+func (c *shardedCache) Delete(key string) {
+	shard := c.sessions[c.shard(key)]
+	shard.mux.Lock()
+	entry, ok := shard.kv[key]
+	if ok {
+		delete(shard.kv, key)
+		c.unindex(key, entry)
+	}
+	shard.mux.Unlock()
+
+	if ok {
+		stats.Set("LiveSessions", int(atomic.AddInt64(&c.length, -1)), false)
+	}
+}
+
+func (c *shardedCache) Shutdown() {
+	for _, s := range c.All() {
+		select {
+		case s.stop <- s.serialize(nil, NoErrShutdown()):
+		default:
+		}
+	}
+}
 
-	stats.Set("LiveSessions", len(c.kv), false)
+// unindex removes the session stored under key from the user and client indexes.
+func (c *shardedCache) unindex(key string, entry *cacheEntry) {
+	if entry.clientID != "" {
+		c.clients[c.shard(entry.clientID)].remove(entry.clientID, key)
+	}
+	if entry.uid != "" {
+		c.users[c.shard(entry.uid)].remove(entry.uid, key)
+	}
 }
 
-func (c *defaultCache) Shutdown() {
-	for _, s := range c.kv {
-		s.stop <- s.serialize(nil, NoErrShutdown())
+func (s *indexShard) add(index, key string, value *Session) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	m, ok := s.kv[index]
+	if !ok {
+		m = make(map[string]*Session)
+		s.kv[index] = m
 	}
+	m[key] = value
+}
+
+func (s *indexShard) remove(index, key string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	m, ok := s.kv[index]
+	if !ok {
+		return
+	}
+	delete(m, key)
+	if len(m) == 0 {
+		delete(s.kv, index)
+	}
+}
+
+func (s *indexShard) load(index string) []*Session {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	m := s.kv[index]
+	sessions := make([]*Session, 0, len(m))
+	for _, session := range m {
+		sessions = append(sessions, session)
+	}
+	return sessions
 }
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSession(sid string) *Session {
+	return &Session{
+		sid:  sid,
+		stop: make(chan []byte, 1),
+	}
+}
+
+func TestCacheIndex(t *testing.T) {
+	c := NewDefaultCache()
+
+	c.Store("s1", newTestSession("s1"))
+	c.Store("s2", newTestSession("s2"))
+	c.Store("s3", newTestSession("s3"))
+	require.Equal(t, 3, c.Length())
+	require.True(t, c.Existed("s1"))
+	require.Empty(t, c.LoadByUID("u1"))
+
+	c.Bind("s1", "c1", "u1")
+	c.Bind("s2", "c1", "u1")
+	c.Bind("s3", "c2", "u2")
+	// Binding a missing session is a no-op
+	c.Bind("s4", "c2", "u2")
+	require.Len(t, c.LoadByUID("u1"), 2)
+	require.Len(t, c.LoadByUID("u2"), 1)
+	require.Len(t, c.LoadByClient("c1"), 2)
+	require.Len(t, c.LoadByClient("c2"), 1)
+
+	// Rebinding moves the session to the new index
+	c.Bind("s2", "c2", "u2")
+	require.Len(t, c.LoadByUID("u1"), 1)
+	require.Len(t, c.LoadByUID("u2"), 2)
+	require.Equal(t, map[string]int{"c1": 1, "c2": 2}, c.CountByClient())
+
+	c.Delete("s1")
+	c.Delete("s1")
+	require.Equal(t, 2, c.Length())
+	require.Nil(t, c.Load("s1"))
+	require.Empty(t, c.LoadByUID("u1"))
+	require.Empty(t, c.LoadByClient("c1"))
+
+	c.Shutdown()
+	require.Len(t, c.All(), 2)
+}
+
+// Run with -race.
+func TestCacheConcurrent(t *testing.T) {
+	c := NewDefaultCache()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			uid := "u" + strconv.Itoa(g%4)
+			for i := 0; i < 500; i++ {
+				sid := strconv.Itoa(g) + "-" + strconv.Itoa(i)
+				c.Store(sid, newTestSession(sid))
+				c.Bind(sid, "c", uid)
+				_ = c.Load(sid)
+				_ = c.LoadByUID(uid)
+				_ = c.LoadByClient("c")
+				_ = c.Length()
+				if i%2 == 0 {
+					c.Delete(sid)
+				}
+			}
+		}(g)
+	}
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				_ = c.All()
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 16*250, c.Length())
+	require.Len(t, c.All(), 16*250)
+	require.Len(t, c.LoadByClient("c"), 16*250)
+	require.Len(t, c.LoadByUID("u0"), 4*250)
+}
+
+const benchmarkSessions = 100000
+
+func newBenchmarkCache(b *testing.B) (Cache, []string) {
+	c := NewDefaultCache()
+	sids := make([]string, benchmarkSessions)
+	for i := range sids {
+		sids[i] = "sid" + strconv.Itoa(i)
+		c.Store(sids[i], newTestSession(sids[i]))
+		c.Bind(sids[i], "c"+strconv.Itoa(i%10), "u"+strconv.Itoa(i/2))
+	}
+	b.ResetTimer()
+	return c, sids
+}
+
+func BenchmarkCacheLoad(b *testing.B) {
+	c, sids := newBenchmarkCache(b)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_ = c.Load(sids[i%benchmarkSessions])
+			i++
+		}
+	})
+}
+
+func BenchmarkCacheLoadByUID(b *testing.B) {
+	c, _ := newBenchmarkCache(b)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_ = c.LoadByUID("u" + strconv.Itoa(i%(benchmarkSessions/2)))
+			i++
+		}
+	})
+}
+
+func BenchmarkCacheStoreDelete(b *testing.B) {
+	c, sids := newBenchmarkCache(b)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			sid := sids[i%benchmarkSessions]
+			c.Delete(sid)
+			c.Store(sid, newTestSession(sid))
+			c.Bind(sid, "c", "u"+strconv.Itoa(i))
+			i++
+		}
+	})
+}
+
+func BenchmarkCacheAll(b *testing.B) {
+	c, _ := newBenchmarkCache(b)
+	for i := 0; i < b.N; i++ {
+		_ = c.All()
+	}
+}
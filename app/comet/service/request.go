@@ -110,6 +110,59 @@ func (r *PushMessageRequest) Unmarshal(data []byte) error {
 	return jsoniter.Unmarshal(data, r)
 }
 
+type JoinRoomRequest struct {
+	// Client-provided message id
+	MID  string `json:"mid,omitempty"`
+	Room string `json:"room" validate:"required,max=64"`
+}
+
+func (r *JoinRoomRequest) Validate() bool {
+	if err := validate.Struct(r); err != nil {
+		return false
+	}
+	return true
+}
+
+func (r *JoinRoomRequest) Unmarshal(data []byte) error {
+	return jsoniter.Unmarshal(data, r)
+}
+
+type LeaveRoomRequest struct {
+	// Client-provided message id
+	MID  string `json:"mid,omitempty"`
+	Room string `json:"room" validate:"required,max=64"`
+}
+
+func (r *LeaveRoomRequest) Validate() bool {
+	if err := validate.Struct(r); err != nil {
+		return false
+	}
+	return true
+}
+
+func (r *LeaveRoomRequest) Unmarshal(data []byte) error {
+	return jsoniter.Unmarshal(data, r)
+}
+
+type RoomMessageRequest struct {
+	// Client-provided message id
+	MID  string `json:"mid,omitempty"`
+	Room string `json:"room" validate:"required,max=64"`
+	// The body of the message, delivered to the room as it is
+	Body types.Content `json:"body" validate:"required"`
+}
+
+func (r *RoomMessageRequest) Validate() bool {
+	if err := validate.Struct(r); err != nil {
+		return false
+	}
+	return true
+}
+
+func (r *RoomMessageRequest) Unmarshal(data []byte) error {
+	return jsoniter.Unmarshal(data, r)
+}
+
 type NotificationRequest struct {
 	// Client-provided message id
 	MID      string         `json:"mid,omitempty"`
@@ -57,6 +57,12 @@ func NoErrShutdown() []byte {
 	return resp
 }
 
+// NoErrReconnect means the server is draining and the client should reconnect to another server.
+func NoErrReconnect() []byte {
+	resp, _ := NewResponse(ecode.ResetContent.ResetMessage("reconnect"), "", 0, nil).Marshal()
+	return resp
+}
+
 // ErrMalformed bad request.
 func ErrBadRequest(mid string, timestamp int64) []byte {
 	resp, _ := NewResponse(ecode.ErrBadRequest, mid, timestamp, nil).Marshal()
@@ -87,6 +93,24 @@ func ErrVersionNotSupported(mid string, timestamp int64) []byte {
 	return resp
 }
 
+// ErrTooManyRooms the session is already in too many rooms.
+func ErrTooManyRooms(mid string, timestamp int64) []byte {
+	resp, _ := NewResponse(ecode.ErrForbidden.ResetMessage("too many rooms"), mid, timestamp, nil).Marshal()
+	return resp
+}
+
+// ErrNotInRoom the session has not joined the room.
+func ErrNotInRoom(mid string, timestamp int64) []byte {
+	resp, _ := NewResponse(ecode.ErrForbidden.ResetMessage("not in room"), mid, timestamp, nil).Marshal()
+	return resp
+}
+
+// ErrTooManyRequests the session sends faster than allowed.
+func ErrTooManyRequests(mid string, timestamp int64) []byte {
+	resp, _ := NewResponse(ecode.ErrTooManyRequests, mid, timestamp, nil).Marshal()
+	return resp
+}
+
 // ErrInternalServer database or other server error.
 func ErrInternalServer(mid string, timestamp int64, message string) []byte {
 	resp, _ := NewResponse(ecode.ErrInternalServer.ResetMessage(message), mid, timestamp, nil).Marshal()
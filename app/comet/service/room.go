@@ -0,0 +1,127 @@
+package service
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Maximum number of rooms a session can be in at the same time.
+const maxRoomsPerSession = 32
+
+// roomStore holds the local membership of the rooms. Rooms are ephemeral, a room exists
+// as long as a session of this server is in it and nothing about it is persisted.
+type roomStore struct {
+	// Sessions indexed by room key, then by session ID
+	shards []*indexShard
+
+	mux sync.Mutex
+	// Maximum number of messages delivered to a room per second, unlimited if 0
+	rateLimit int
+	limiters  map[string]*limiter
+}
+
+func newRoomStore(rateLimit int) *roomStore {
+	rs := &roomStore{
+		shards:    make([]*indexShard, defaultCacheShards),
+		rateLimit: rateLimit,
+		limiters:  make(map[string]*limiter),
+	}
+	for i := range rs.shards {
+		rs.shards[i] = &indexShard{kv: make(map[string]map[string]*Session)}
+	}
+	return rs
+}
+
+func (rs *roomStore) shard(key string) *indexShard {
+	return rs.shards[shardOf(key, defaultCacheShards-1)]
+}
+
+func (rs *roomStore) join(key string, s *Session) {
+	rs.shard(key).add(key, s.sid, s)
+}
+
+func (rs *roomStore) leave(key string, s *Session) {
+	shard := rs.shard(key)
+	shard.remove(key, s.sid)
+
+	shard.mux.RLock()
+	_, ok := shard.kv[key]
+	shard.mux.RUnlock()
+	if !ok {
+		rs.mux.Lock()
+		delete(rs.limiters, key)
+		rs.mux.Unlock()
+	}
+}
+
+func (rs *roomStore) sessions(key string) []*Session {
+	return rs.shard(key).load(key)
+}
+
+// setRateLimit changes the maximum number of messages delivered to a room per second,
+// the rooms start counting anew.
+func (rs *roomStore) setRateLimit(rateLimit int) {
+	rs.mux.Lock()
+	defer rs.mux.Unlock()
+	if rateLimit != rs.rateLimit {
+		rs.rateLimit = rateLimit
+		rs.limiters = make(map[string]*limiter)
+	}
+}
+
+// allow reports whether one more message may be delivered to the room now.
+func (rs *roomStore) allow(key string) bool {
+	rs.mux.Lock()
+	defer rs.mux.Unlock()
+	if rs.rateLimit <= 0 {
+		return true
+	}
+
+	l, ok := rs.limiters[key]
+	if !ok {
+		l = newLimiter(rs.rateLimit)
+		rs.limiters[key] = l
+	}
+	return l.allow()
+}
+
+// Sample picks each session with the probability of rate. All sessions are returned
+// if rate is not in (0, 1).
+func Sample(sessions []*Session, rate float64) []*Session {
+	if rate <= 0 || rate >= 1 {
+		return sessions
+	}
+
+	var sampled []*Session
+	for _, s := range sessions {
+		if rand.Float64() < rate {
+			sampled = append(sampled, s)
+		}
+	}
+	return sampled
+}
+
+// limiter allows up to limit events per second, counted in a fixed one second window.
+type limiter struct {
+	limit  int
+	count  int
+	window time.Time
+}
+
+func newLimiter(limit int) *limiter {
+	return &limiter{limit: limit}
+}
+
+func (l *limiter) allow() bool {
+	now := time.Now().Truncate(time.Second)
+	if !now.Equal(l.window) {
+		l.window = now
+		l.count = 0
+	}
+	if l.count >= l.limit {
+		return false
+	}
+	l.count++
+	return true
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"mercury/x/types"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomStore(t *testing.T) {
+	rs := newRoomStore(2)
+
+	s1, s2 := newTestSession("s1"), newTestSession("s2")
+	rs.join("c1:live", s1)
+	rs.join("c1:live", s2)
+	rs.join("c2:live", s2)
+	require.Len(t, rs.sessions("c1:live"), 2)
+	require.Len(t, rs.sessions("c2:live"), 1)
+
+	require.True(t, rs.allow("c1:live"))
+	require.True(t, rs.allow("c1:live"))
+	require.False(t, rs.allow("c1:live"))
+	require.True(t, rs.allow("c2:live"))
+
+	rs.leave("c1:live", s1)
+	rs.leave("c1:live", s2)
+	require.Empty(t, rs.sessions("c1:live"))
+	require.NotContains(t, rs.limiters, "c1:live")
+}
+
+func TestSample(t *testing.T) {
+	sessions := make([]*Session, 1000)
+	require.Len(t, Sample(sessions, 0), 1000)
+	require.Len(t, Sample(sessions, 1), 1000)
+	require.Empty(t, Sample(nil, 0.5))
+
+	n := len(Sample(sessions, 0.1))
+	require.True(t, n > 0 && n < 500)
+}
+
+func TestTryQueueOut(t *testing.T) {
+	s := newTestSession("s1")
+	s.send = make(chan outFrame, 1)
+
+	require.True(t, s.TryQueueOut(types.OperationRoom, []byte(`{}`)))
+	// The queue is full, the message is dropped without blocking
+	require.False(t, s.TryQueueOut(types.OperationRoom, []byte(`{}`)))
+	require.Len(t, s.send, 1)
+}
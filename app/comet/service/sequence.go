@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"mercury/x/log"
+	"mercury/x/stat"
+	"mercury/x/types"
+)
+
+// Maximum number of messages of a topic held back waiting for a missing sequence
+const maxPendingPerTopic = 64
+
+// outMessage is a message waiting to be queued to the session.
+type outMessage struct {
+	operation types.Operation
+	body      []byte
+	// Unix time in milliseconds the message was pushed to logic, 0 if unknown
+	pushedAt int64
+	// Trace context of the message, nil if it is not traced
+	ctx context.Context
+	// The session already has the message, it only advances the sequence
+	acked bool
+}
+
+// topicSequence tracks the delivered sequence of one topic.
+type topicSequence struct {
+	// Last sequence delivered
+	last int64
+	// Messages after a gap, by sequence
+	pending map[int64]outMessage
+	// Fires when the gap was not filled in time
+	timer *time.Timer
+}
+
+// sequencer delivers the messages of each topic to the session in sequence order. A
+// message arriving ahead of its predecessors is held back until they arrive or until
+// the timeout expired, then the gap is skipped. Messages with a sequence which was
+// already delivered are dropped as duplicates.
+//
+// A topic starts from the sequence of the topic when the session connected, or from the
+// first message of the topic if it is unknown. The messages the session sent itself are
+// acknowledged instead of delivered, they still advance the sequence.
+type sequencer struct {
+	timeout time.Duration
+	out     func(outMessage) bool
+
+	mux      sync.Mutex
+	topics   map[string]*topicSequence
+	ready    []outMessage
+	draining bool
+	stopped  bool
+}
+
+func newSequencer(timeout time.Duration, out func(outMessage) bool) *sequencer {
+	return &sequencer{
+		timeout: timeout,
+		out:     out,
+		topics:  make(map[string]*topicSequence),
+	}
+}
+
+// start sets the last sequence of the topic already delivered to the session, the
+// messages up to it are dropped. A topic which was started or received messages is left
+// as it is.
+func (q *sequencer) start(topic string, last int64) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	if q.stopped || last < 0 {
+		return
+	}
+	if _, ok := q.topics[topic]; !ok {
+		q.topics[topic] = &topicSequence{last: last, pending: make(map[int64]outMessage)}
+	}
+}
+
+// ack advances the sequence of the topic past the message the session sent itself, the
+// message is not delivered to the session.
+func (q *sequencer) ack(topic string, sequence int64) {
+	q.push(topic, sequence, outMessage{acked: true})
+}
+
+// push hands over the message with the sequence of the topic.
+func (q *sequencer) push(topic string, sequence int64, m outMessage) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	if q.stopped {
+		return
+	}
+
+	ts, ok := q.topics[topic]
+	if !ok {
+		// The first message of a topic not started starts the sequence.
+		ts = &topicSequence{last: sequence - 1, pending: make(map[int64]outMessage)}
+		q.topics[topic] = ts
+	}
+	if _, ok := ts.pending[sequence]; ok || sequence <= ts.last {
+		if !m.acked {
+			stat.CometSequence.Incr("duplicate")
+		}
+		return
+	}
+
+	ts.pending[sequence] = m
+	if sequence != ts.last+1 {
+		stat.CometSequence.Incr("reordered")
+	}
+	q.advance(ts)
+	if len(ts.pending) > maxPendingPerTopic {
+		q.skip(topic, ts)
+	}
+	q.schedule(topic, ts)
+	q.drain()
+}
+
+// advance moves the messages following the last delivered sequence to the ready queue.
+func (q *sequencer) advance(ts *topicSequence) {
+	for {
+		m, ok := ts.pending[ts.last+1]
+		if !ok {
+			return
+		}
+		delete(ts.pending, ts.last+1)
+		ts.last++
+		if !m.acked {
+			q.ready = append(q.ready, m)
+		}
+	}
+}
+
+// skip gives up on the missing sequences before the first pending message.
+func (q *sequencer) skip(topic string, ts *topicSequence) {
+	next := int64(-1)
+	for sequence := range ts.pending {
+		if next < 0 || sequence < next {
+			next = sequence
+		}
+	}
+	if next < 0 {
+		return
+	}
+
+	stat.CometSequence.Incr("gap")
+	log.Debug("[Sequence] gap skipped", "topic", topic, "from", ts.last+1, "to", next-1)
+	ts.last = next - 1
+	q.advance(ts)
+}
+
+// schedule starts the gap timer of a topic with pending messages and stops it otherwise.
+func (q *sequencer) schedule(topic string, ts *topicSequence) {
+	if len(ts.pending) == 0 {
+		if ts.timer != nil {
+			ts.timer.Stop()
+			ts.timer = nil
+		}
+		return
+	}
+	if ts.timer != nil {
+		return
+	}
+
+	ts.timer = time.AfterFunc(q.timeout, func() {
+		q.mux.Lock()
+		defer q.mux.Unlock()
+		if q.stopped {
+			return
+		}
+		ts.timer = nil
+		q.skip(topic, ts)
+		q.schedule(topic, ts)
+		q.drain()
+	})
+}
+
+// drain starts queueing the ready messages unless that is already going on.
+func (q *sequencer) drain() {
+	if q.draining || len(q.ready) == 0 {
+		return
+	}
+	q.draining = true
+	go func() {
+		for {
+			q.mux.Lock()
+			if len(q.ready) == 0 || q.stopped {
+				q.ready = nil
+				q.draining = false
+				q.mux.Unlock()
+				return
+			}
+			m := q.ready[0]
+			q.ready = q.ready[1:]
+			q.mux.Unlock()
+
+			q.out(m)
+		}
+	}()
+}
+
+// stop drops the pending messages, nothing is delivered after it.
+func (q *sequencer) stop() {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	q.stopped = true
+	for _, ts := range q.topics {
+		if ts.timer != nil {
+			ts.timer.Stop()
+		}
+	}
+	q.topics = nil
+	q.ready = nil
+}
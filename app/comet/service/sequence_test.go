@@ -0,0 +1,133 @@
+package service
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"mercury/x/types"
+)
+
+type recorder struct {
+	mux    sync.Mutex
+	bodies []string
+}
+
+func (r *recorder) out(m outMessage) bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.bodies = append(r.bodies, string(m.body))
+	return true
+}
+
+func (r *recorder) received() []string {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return append([]string(nil), r.bodies...)
+}
+
+func pushSequence(q *sequencer, topic string, sequences ...int64) {
+	for _, seq := range sequences {
+		q.push(topic, seq, outMessage{operation: types.OperationPush, body: []byte(strconv.FormatInt(seq, 10))})
+	}
+}
+
+func TestSequencerReorder(t *testing.T) {
+	r := new(recorder)
+	q := newSequencer(time.Minute, r.out)
+	defer q.stop()
+
+	pushSequence(q, "t1", 1, 3, 4, 2, 2, 1, 5)
+	pushSequence(q, "t2", 10, 11)
+	require.Eventually(t, func() bool {
+		return len(r.received()) == 7
+	}, time.Second, 10*time.Millisecond)
+
+	var t1 []string
+	for _, b := range r.received() {
+		if len(b) == 1 {
+			t1 = append(t1, b)
+		}
+	}
+	require.Equal(t, []string{"1", "2", "3", "4", "5"}, t1)
+}
+
+func TestSequencerGap(t *testing.T) {
+	r := new(recorder)
+	q := newSequencer(50*time.Millisecond, r.out)
+	defer q.stop()
+
+	pushSequence(q, "t", 1, 3, 4)
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, []string{"1"}, r.received())
+
+	// The gap is skipped after the timeout, the late message is dropped
+	require.Eventually(t, func() bool {
+		return len(r.received()) == 3
+	}, time.Second, 10*time.Millisecond)
+	pushSequence(q, "t", 2, 5)
+	require.Eventually(t, func() bool {
+		return len(r.received()) == 4
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"1", "3", "4", "5"}, r.received())
+}
+
+func TestSequencerOverflow(t *testing.T) {
+	r := new(recorder)
+	q := newSequencer(time.Minute, r.out)
+	defer q.stop()
+
+	pushSequence(q, "t", 1)
+	for seq := int64(3); seq <= maxPendingPerTopic+3; seq++ {
+		pushSequence(q, "t", seq)
+	}
+	require.Eventually(t, func() bool {
+		return len(r.received()) == maxPendingPerTopic+2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSequencerStart(t *testing.T) {
+	r := new(recorder)
+	q := newSequencer(time.Minute, r.out)
+	defer q.stop()
+
+	// The first two messages after the session connected arrive swapped
+	q.start("t", 10)
+	pushSequence(q, "t", 12, 11, 10, 13)
+	require.Eventually(t, func() bool {
+		return len(r.received()) == 3
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"11", "12", "13"}, r.received())
+
+	// A topic which received messages is not started again
+	q.start("t", 20)
+	pushSequence(q, "t", 14)
+	require.Eventually(t, func() bool {
+		return len(r.received()) == 4
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSequencerAck(t *testing.T) {
+	r := new(recorder)
+	q := newSequencer(50*time.Millisecond, r.out)
+	defer q.stop()
+
+	// The session sent 2 itself, the following messages are not held back
+	q.start("t", 0)
+	pushSequence(q, "t", 1)
+	q.ack("t", 2)
+	pushSequence(q, "t", 3, 2)
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, []string{"1", "3"}, r.received())
+
+	// The ack arrives after the following message
+	pushSequence(q, "t", 5)
+	q.ack("t", 4)
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, []string{"1", "3", "5"}, r.received())
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	require.Empty(t, q.topics["t"].pending)
+}
@@ -5,10 +5,14 @@ import (
 	chatApi "mercury/app/logic/api"
 	"mercury/x/ecode"
 	"mercury/x/log"
+	"mercury/x/microx"
+	"mercury/x/tracing"
 	"mercury/x/types"
+	"sync"
+	"time"
 
 	"github.com/micro/go-micro/v2/client"
-	"github.com/micro/go-micro/v2/client/grpc"
+	"github.com/micro/go-micro/v2/registry"
 )
 
 type Servicer interface {
@@ -16,26 +20,58 @@ type Servicer interface {
 	Close()
 }
 
+type ConfigProvider interface {
+	RoomRateLimit() int
+	RoomSendRateLimit() int
+	ReorderTimeout() time.Duration
+}
+
+// How often the session stats are reported
+const reportInterval = 10 * time.Second
+
 type Service struct {
 	chatService  chatApi.ChatService
 	log          log.Logger
 	sessionStore SessionStore
+	done         chan struct{}
+	closeOnce    sync.Once
 }
 
-func NewService(l log.Logger) (*Service, error) {
+// NewService creates the comet service calling the logic service registered as logicService
+// in r.
+func NewService(config ConfigProvider, logicService string, r registry.Registry, l log.Logger) (*Service, error) {
 	opts := []client.Option{
 		client.Retries(2),
 		client.Retry(ecode.RetryOnMicroError),
 		client.WrapCall(ecode.MicroCallFunc),
+		client.WrapCall(tracing.CallWrapper),
 	}
 
-	c := grpc.NewClient(opts...)
+	c := microx.NewClient(r, opts...)
 
-	return &Service{
-		chatService:  chatApi.NewChatService("mercury.logic", c),
+	s := &Service{
+		chatService:  chatApi.NewChatService(logicService, c),
 		log:          l,
-		sessionStore: NewSessionStore(),
-	}, nil
+		sessionStore: NewSessionStore(config),
+		done:         make(chan struct{}),
+	}
+	go s.report()
+	return s, nil
+}
+
+// report reports the session stats periodically until the service is closed.
+func (s *Service) report() {
+	ticker := time.NewTicker(reportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.sessionStore.ReportStats()
+		case <-s.done:
+			return
+		}
+	}
 }
 
 func (s *Service) SessionStore() SessionStore {
@@ -43,24 +79,30 @@ func (s *Service) SessionStore() SessionStore {
 }
 
 func (s *Service) Close() {
-	s.sessionStore.Shutdown()
+	s.closeOnce.Do(func() {
+		s.sessionStore.Shutdown()
+		close(s.done)
+	})
 }
 
-func (s *Service) connect(ctx context.Context, token, sid, serverID string) (string, types.ID, error) {
+// connect returns the client and the user of the token, with the topics of the user by
+// their sequences.
+func (s *Service) connect(ctx context.Context, token, sid, serverID string) (string, types.ID, map[string]int64, error) {
 	resp, err := s.chatService.Connect(ctx, &chatApi.ConnectReq{
 		JWTToken: token,
 		SID:      sid,
 		ServerID: serverID,
 	})
 	if err != nil {
-		return "", 0, err
+		return "", 0, nil, err
 	}
 
-	return resp.ClientID, types.ParseUID(resp.UID), nil
+	return resp.ClientID, types.ParseUID(resp.UID), resp.Topics, nil
 }
 
-func (s *Service) heartbeat(ctx context.Context, uid, sid, serverID string) error {
+func (s *Service) heartbeat(ctx context.Context, clientID, uid, sid, serverID string) error {
 	_, err := s.chatService.Heartbeat(ctx, &chatApi.HeartbeatReq{
+		ClientID: clientID,
 		UID:      uid,
 		SID:      sid,
 		ServerID: serverID,
@@ -71,16 +113,13 @@ func (s *Service) heartbeat(ctx context.Context, uid, sid, serverID string) erro
 	return nil
 }
 
-func (s *Service) pushMessage(ctx context.Context, req *chatApi.PushMessageReq) (int64, int64, error) {
-	resp, err := s.chatService.PushMessage(ctx, req)
-	if err != nil {
-		return 0, 0, err
-	}
-	return resp.MessageId, resp.Sequence, nil
+func (s *Service) pushMessage(ctx context.Context, req *chatApi.PushMessageReq) (*chatApi.PushMessageResp, error) {
+	return s.chatService.PushMessage(ctx, req)
 }
 
-func (s *Service) readMessage(ctx context.Context, uid, topic string, sequence int64) error {
+func (s *Service) readMessage(ctx context.Context, clientID, uid, topic string, sequence int64) error {
 	_, err := s.chatService.ReadMessage(ctx, &chatApi.ReadMessageReq{
+		ClientID: clientID,
 		UID:      uid,
 		Topic:    topic,
 		Sequence: sequence,
@@ -91,13 +130,22 @@ func (s *Service) readMessage(ctx context.Context, uid, topic string, sequence i
 	return nil
 }
 
-func (s *Service) keypress(ctx context.Context, uid, topic string) error {
+func (s *Service) keypress(ctx context.Context, clientID, uid, topic string) error {
 	_, err := s.chatService.Keypress(ctx, &chatApi.KeypressReq{
-		UID:   uid,
-		Topic: topic,
+		ClientID: clientID,
+		UID:      uid,
+		Topic:    topic,
 	})
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+func (s *Service) pushRoomMessage(ctx context.Context, req *chatApi.PushRoomMessageReq) error {
+	_, err := s.chatService.PushRoomMessage(ctx, req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
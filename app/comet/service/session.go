@@ -2,11 +2,15 @@ package service
 
 import (
 	"context"
+	"go.opentelemetry.io/otel/trace"
 	chatApi "mercury/app/logic/api"
 	"mercury/x"
 	"mercury/x/ecode"
 	"mercury/x/log"
+	"mercury/x/stat"
+	"mercury/x/tracing"
 	"mercury/x/types"
+	"sync"
 	"time"
 
 	"mercury/x/websocket"
@@ -77,9 +81,23 @@ type Session struct {
 	id types.ID
 	// Time when the session received any packer from client.
 	lastAction time.Time
+	// Logger with the session ID, the client ID and the user ID once the session is bound,
+	// only touched by the read loop.
+	log log.Logger
+	// Keys of the rooms the session is in, only touched by the read loop.
+	rooms map[string]struct{}
+	// Keys of the group topics the session is in, in the topic push mode.
+	topicsMux sync.Mutex
+	topics    map[string]struct{}
+	// Set once the session left its topics, it joins no other one.
+	topicsLeft bool
+	// Limits the messages the session sends to rooms, only touched by the read loop.
+	roomLimiter *limiter
+	// Puts the messages of each topic in sequence order.
+	sequencer *sequencer
 	// Outbound messages, buffered.
 	// The content must be serialized in format suitable for the session.
-	send chan []byte
+	send chan outFrame
 	// Channel for shutting down the session, buffer 1.
 	// Content in the same format as for 'send'.
 	stop chan []byte
@@ -87,6 +105,11 @@ type Session struct {
 	srv *Service
 }
 
+// SID returns the session ID.
+func (s *Session) SID() string {
+	return s.sid
+}
+
 func (s *Session) readLoop() {
 	defer func() {
 		s.ws.Close()
@@ -126,7 +149,7 @@ func (s *Session) writeLoop() {
 
 	for {
 		select {
-		case msg, ok := <-s.send:
+		case f, ok := <-s.send:
 			if !ok {
 				// Channel closed.
 				return
@@ -135,10 +158,20 @@ func (s *Session) writeLoop() {
 				log.Warn("[Websocket] outbound queue limit exceeded", log.Ctx{"sid": s.sid})
 				return
 			}
-			if err := s.ws.WriteBinaryMessage(msg); err != nil {
+			if err := s.ws.WriteBinaryMessage(f.data); err != nil {
 				log.Error("[Websocket] failed to write binary message", log.Ctx{"error": err, "sid": s.sid})
 				return
 			}
+			if f.pushedAt > 0 {
+				stat.PushLatency.Timing(f.operation.String(), x.UnixMilli(time.Now())-f.pushedAt)
+			}
+			if f.ctx != nil {
+				_, span := tracing.Start(f.ctx, "websocket write",
+					trace.WithSpanKind(trace.SpanKindProducer),
+					trace.WithTimestamp(f.queuedAt),
+					trace.WithAttributes(tracing.String("sid", s.sid)))
+				span.End()
+			}
 		case msg := <-s.stop:
 			// Shutdown requested, don't care if the message is delivered
 			if msg != nil {
@@ -170,7 +203,7 @@ func (s *Session) dispatchRaw(raw []byte) {
 	s.dispatch(&p)
 }
 
-type handlerFunc func(message *ServerMessage) []byte
+type handlerFunc func(ctx context.Context, message *ServerMessage) []byte
 
 func (s *Session) route(o types.Operation) handlerFunc {
 	var handler handlerFunc
@@ -185,6 +218,12 @@ func (s *Session) route(o types.Operation) handlerFunc {
 		handler = s.pushMessage
 	case types.OperationNotification:
 		handler = s.notification
+	case types.OperationJoinRoom:
+		handler = s.joinRoom
+	case types.OperationLeaveRoom:
+		handler = s.leaveRoom
+	case types.OperationRoom:
+		handler = s.roomMessage
 	default:
 		// Unknown operation
 		log.Debug("[Dispatch] unknown operation", log.Ctx{"sid": s.sid})
@@ -203,39 +242,54 @@ func (s *Session) dispatch(p *Protocol) {
 		return
 	}
 
+	ctx, span := tracing.Start(s.ctx, "websocket "+p.Operation.String(),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(tracing.String("sid", s.sid)))
+	defer span.End()
+	ctx = log.NewContext(ctx, tracing.Logger(ctx, s.log))
+	s.logger(ctx).Debug("[Dispatch] request is received", "operation", p.Operation.String())
+
 	message := &ServerMessage{
 		Data:      p.Body,
 		Timestamp: timestamp,
 	}
-	data := handler(message)
+	data := handler(ctx, message)
 	if data != nil {
 		s.queueOut(p, data)
 		return
 	}
 }
 
-func (s *Session) handshake(message *ServerMessage) []byte {
+// logger returns the logger of the request in ctx.
+func (s *Session) logger(ctx context.Context) log.Logger {
+	if l, ok := log.FromContext(ctx); ok {
+		return l
+	}
+	return s.log
+}
+
+func (s *Session) handshake(ctx context.Context, message *ServerMessage) []byte {
 	var req HandshakeRequest
 	if err := s.deserialize(&req, message.Data); err != nil {
-		log.Warn("[Handshake] failed to deserialize", log.Ctx{"error": err, "sid": s.sid})
+		s.logger(ctx).Warn("[Handshake] failed to deserialize", "error", err)
 		return ErrBadRequest("", message.Timestamp)
 	}
 
 	if s.version == 0 {
 		version := x.ParseVersion(req.Version)
 		if version == 0 {
-			log.Debug("[Handshake] failed to parse version", "sid", s.sid)
+			s.logger(ctx).Debug("[Handshake] failed to parse version")
 			return ErrMalformed(req.MID, message.Timestamp)
 		}
 		// Check version compatibility
 		if x.VersionCompare(version, minSupportedVersionValue) < 0 {
-			log.Debug("[Handshake] unsupported version", "sid", s.sid)
+			s.logger(ctx).Debug("[Handshake] unsupported version")
 			return ErrVersionNotSupported(req.MID, message.Timestamp)
 		}
 
-		clientID, id, err := s.srv.connect(s.ctx, req.Token, s.sid, s.serverID)
+		clientID, id, topics, err := s.srv.connect(ctx, req.Token, s.sid, s.serverID)
 		if err != nil {
-			log.Error("[Handshake] failed to connect", log.Ctx{"error": err, "sid": s.sid, "token": req.Token})
+			s.logger(ctx).Error("[Handshake] failed to connect", "error", err)
 			return ErrInternalServer(req.MID, message.Timestamp, err.Error())
 		}
 
@@ -253,6 +307,10 @@ func (s *Session) handshake(message *ServerMessage) []byte {
 		if s.id.IsZero() {
 			s.clientID = clientID
 			s.id = id
+			s.log = s.log.New("client_id", clientID, log.UIDKey, id.UID())
+			s.startOrdered(topics)
+			s.srv.sessionStore.Bind(s)
+			s.srv.sessionStore.JoinTopics(s, topics)
 		}
 	}
 	s.language = req.Language
@@ -260,10 +318,10 @@ func (s *Session) handshake(message *ServerMessage) []byte {
 	return NoErr(req.MID, message.Timestamp, nil)
 }
 
-func (s *Session) heartbeat(message *ServerMessage) []byte {
+func (s *Session) heartbeat(ctx context.Context, message *ServerMessage) []byte {
 	var req HeartbeatRequest
 	if err := s.deserialize(&req, message.Data); err != nil {
-		log.Warn("[Heartbeat] failed to deserialize", "sid", s.sid, "error", err)
+		s.logger(ctx).Warn("[Heartbeat] failed to deserialize", "error", err)
 		return ErrBadRequest("", message.Timestamp)
 	}
 
@@ -271,24 +329,24 @@ func (s *Session) heartbeat(message *ServerMessage) []byte {
 		return ErrAuthRequired(req.MID, message.Timestamp)
 	}
 
-	if err := s.srv.heartbeat(s.ctx, s.id.UID(), s.sid, s.serverID); err != nil {
-		log.Error("[Heartbeat] failed to heartbeat", "sid", s.sid, "uid", s, s.id.UID(), "error", err)
+	if err := s.srv.heartbeat(ctx, s.clientID, s.id.UID(), s.sid, s.serverID); err != nil {
+		s.logger(ctx).Error("[Heartbeat] failed to heartbeat", "error", err)
 		return ErrInternalServer(req.MID, message.Timestamp, err.Error())
 	}
 
 	return NoErr(req.MID, message.Timestamp, nil)
 }
 
-func (s *Session) connect(message *ServerMessage) []byte {
+func (s *Session) connect(ctx context.Context, message *ServerMessage) []byte {
 	var req ConnectRequest
 	if err := s.deserialize(&req, message.Data); err != nil {
-		log.Warn("[Connect] failed to deserialize", "sid", s.sid, "error", err)
+		s.logger(ctx).Warn("[Connect] failed to deserialize", "error", err)
 		return ErrBadRequest("", message.Timestamp)
 	}
 
-	clientID, uid, err := s.srv.connect(s.ctx, req.Token, s.sid, s.serverID)
+	clientID, uid, topics, err := s.srv.connect(ctx, req.Token, s.sid, s.serverID)
 	if err != nil {
-		log.Error("[Connect] failed to connect", "sid", s.sid, "error", err)
+		s.logger(ctx).Error("[Connect] failed to connect", "error", err)
 		return ErrInternalServer(req.MID, message.Timestamp, err.Error())
 	}
 
@@ -297,15 +355,19 @@ func (s *Session) connect(message *ServerMessage) []byte {
 	if s.id.IsZero() {
 		s.clientID = clientID
 		s.id = uid
+		s.log = s.log.New("client_id", clientID, log.UIDKey, uid.UID())
+		s.startOrdered(topics)
+		s.srv.sessionStore.Bind(s)
+		s.srv.sessionStore.JoinTopics(s, topics)
 	}
 
 	return NoErr(req.MID, message.Timestamp, nil)
 }
 
-func (s *Session) pushMessage(message *ServerMessage) []byte {
+func (s *Session) pushMessage(ctx context.Context, message *ServerMessage) []byte {
 	var req PushMessageRequest
 	if err := s.deserialize(&req, message.Data); err != nil {
-		log.Warn("[PushMessage] failed to deserialize", "sid", s.sid, "error", err)
+		s.logger(ctx).Warn("[PushMessage] failed to deserialize", "error", err)
 		return ErrBadRequest("", message.Timestamp)
 	}
 
@@ -313,7 +375,7 @@ func (s *Session) pushMessage(message *ServerMessage) []byte {
 		return ErrAuthRequired(req.MID, message.Timestamp)
 	}
 
-	messageID, sequence, err := s.srv.pushMessage(s.ctx, &chatApi.PushMessageReq{
+	pushed, err := s.srv.pushMessage(ctx, &chatApi.PushMessageReq{
 		ClientID:    s.clientID,
 		SID:         s.sid,
 		MessageType: chatApi.MessageType(req.MessageType),
@@ -324,21 +386,22 @@ func (s *Session) pushMessage(message *ServerMessage) []byte {
 		Mentions:    req.Mentions,
 	})
 	if err != nil {
-		log.Error("[PushMessage] failed to push message", "sid", s.sid, "error", err)
+		s.logger(ctx).Error("[PushMessage] failed to push message", "error", err)
 		return ErrInternalServer(req.MID, message.Timestamp, err.Error())
 	}
+	s.ackOrdered(pushed.Topic, pushed.Sequence)
 
 	resp := &PushMessageResponse{
-		MessageID: messageID,
-		Sequence:  sequence,
+		MessageID: pushed.MessageId,
+		Sequence:  pushed.Sequence,
 	}
 	return NoErr(req.MID, message.Timestamp, resp)
 }
 
-func (s *Session) notification(message *ServerMessage) []byte {
+func (s *Session) notification(ctx context.Context, message *ServerMessage) []byte {
 	var req NotificationRequest
 	if err := s.deserialize(&req, message.Data); err != nil {
-		log.Warn("[Notification] failed to deserialize", "sid", s.sid, "error", err)
+		s.logger(ctx).Warn("[Notification] failed to deserialize", "error", err)
 		return ErrBadRequest("", message.Timestamp)
 	}
 
@@ -349,12 +412,87 @@ func (s *Session) notification(message *ServerMessage) []byte {
 	var err error
 	switch req.What {
 	case types.WhatTypeKeypress:
-		err = s.srv.keypress(s.ctx, s.id.UID(), req.Topic)
+		err = s.srv.keypress(ctx, s.clientID, s.id.UID(), req.Topic)
 	case types.WhatTypeRead:
-		err = s.srv.readMessage(s.ctx, s.id.UID(), req.Topic, req.Sequence)
+		err = s.srv.readMessage(ctx, s.clientID, s.id.UID(), req.Topic, req.Sequence)
 	}
 	if err != nil {
-		log.Error("[Notification] failed to send notification", "sid", s.sid, "error", err)
+		s.logger(ctx).Error("[Notification] failed to send notification", "error", err)
+		return ErrInternalServer(req.MID, message.Timestamp, err.Error())
+	}
+
+	return NoErr(req.MID, message.Timestamp, nil)
+}
+
+func (s *Session) joinRoom(ctx context.Context, message *ServerMessage) []byte {
+	var req JoinRoomRequest
+	if err := s.deserialize(&req, message.Data); err != nil {
+		s.logger(ctx).Warn("[JoinRoom] failed to deserialize", "error", err)
+		return ErrBadRequest("", message.Timestamp)
+	}
+
+	if s.id.IsZero() {
+		return ErrAuthRequired(req.MID, message.Timestamp)
+	}
+
+	key := types.RoomKey(s.clientID, req.Room)
+	if _, ok := s.rooms[key]; !ok {
+		if len(s.rooms) >= maxRoomsPerSession {
+			return ErrTooManyRooms(req.MID, message.Timestamp)
+		}
+		s.rooms[key] = struct{}{}
+		s.srv.sessionStore.JoinRoom(key, s)
+	}
+
+	return NoErr(req.MID, message.Timestamp, nil)
+}
+
+func (s *Session) leaveRoom(ctx context.Context, message *ServerMessage) []byte {
+	var req LeaveRoomRequest
+	if err := s.deserialize(&req, message.Data); err != nil {
+		s.logger(ctx).Warn("[LeaveRoom] failed to deserialize", "error", err)
+		return ErrBadRequest("", message.Timestamp)
+	}
+
+	if s.id.IsZero() {
+		return ErrAuthRequired(req.MID, message.Timestamp)
+	}
+
+	key := types.RoomKey(s.clientID, req.Room)
+	if _, ok := s.rooms[key]; ok {
+		delete(s.rooms, key)
+		s.srv.sessionStore.LeaveRoom(key, s)
+	}
+
+	return NoErr(req.MID, message.Timestamp, nil)
+}
+
+func (s *Session) roomMessage(ctx context.Context, message *ServerMessage) []byte {
+	var req RoomMessageRequest
+	if err := s.deserialize(&req, message.Data); err != nil {
+		s.logger(ctx).Warn("[RoomMessage] failed to deserialize", "error", err)
+		return ErrBadRequest("", message.Timestamp)
+	}
+
+	if s.id.IsZero() {
+		return ErrAuthRequired(req.MID, message.Timestamp)
+	}
+
+	if _, ok := s.rooms[types.RoomKey(s.clientID, req.Room)]; !ok {
+		return ErrNotInRoom(req.MID, message.Timestamp)
+	}
+
+	if s.roomLimiter != nil && !s.roomLimiter.allow() {
+		return ErrTooManyRequests(req.MID, message.Timestamp)
+	}
+
+	if err := s.srv.pushRoomMessage(ctx, &chatApi.PushRoomMessageReq{
+		ClientID: s.clientID,
+		Sender:   s.id.UID(),
+		Room:     req.Room,
+		Body:     req.Body,
+	}); err != nil {
+		s.logger(ctx).Error("[RoomMessage] failed to push room message", "error", err)
 		return ErrInternalServer(req.MID, message.Timestamp, err.Error())
 	}
 
@@ -389,14 +527,38 @@ func (s *Session) deserialize(v requester, body []byte) error {
 	return nil
 }
 
+// outFrame is a serialized message waiting to be written to the connection.
+type outFrame struct {
+	operation types.Operation
+	data      []byte
+	// Unix time in milliseconds the message was pushed to logic, 0 if unknown
+	pushedAt int64
+	// Trace context of the message, nil if it is not traced
+	ctx      context.Context
+	queuedAt time.Time
+}
+
 // queueOut attempts to send a ServerComMessage to a session; if the send buffer is full,
 // timeout is `sendTimeout`.
 func (s *Session) queueOut(p *Protocol, body []byte) bool {
+	return s.queueFrame(p, body, outMessage{})
+}
+
+// queueFrame queues the serialized message, the push latency and the trace of m are
+// recorded once it is written.
+func (s *Session) queueFrame(p *Protocol, body []byte, m outMessage) bool {
 	if s == nil {
 		return true
 	}
+	f := outFrame{
+		operation: p.Operation,
+		data:      s.serialize(p, body),
+		pushedAt:  m.pushedAt,
+		ctx:       m.ctx,
+		queuedAt:  time.Now(),
+	}
 	select {
-	case s.send <- s.serialize(p, body):
+	case s.send <- f:
 	case <-time.After(sendTimeout):
 		log.Debug("[QueueOut] timeout", "sid", s.sid)
 		return false
@@ -410,3 +572,73 @@ func (s *Session) QueueOut(operation types.Operation, body []byte) bool {
 	}
 	return s.queueOut(p, body)
 }
+
+// TryQueueOut queues the message unless the queue of the session is full, the message is
+// dropped then. It never blocks, it is meant for the fan-out of a message to many sessions.
+func (s *Session) TryQueueOut(operation types.Operation, body []byte) bool {
+	f := outFrame{
+		operation: operation,
+		data:      s.serialize(&Protocol{Operation: operation}, body),
+		queuedAt:  time.Now(),
+	}
+	select {
+	case s.send <- f:
+		return true
+	default:
+		stat.CometDropped.Incr(operation.String())
+		log.Debug("[TryQueueOut] queue is full, message dropped", "sid", s.sid)
+		return false
+	}
+}
+
+// QueueOrdered queues the message of the topic after the messages with lower sequences.
+// Messages without a topic or a sequence are queued right away. pushedAt is the time the
+// message was pushed to logic in unix milliseconds, the push latency is recorded once the
+// message is written. The write is traced if ctx carries a trace context.
+func (s *Session) QueueOrdered(ctx context.Context, operation types.Operation, topic string, sequence, pushedAt int64, body []byte) {
+	m := outMessage{operation: operation, body: body, pushedAt: pushedAt, ctx: ctx}
+	if s.sequencer == nil || topic == "" || sequence <= 0 {
+		go s.queueMessage(m)
+		return
+	}
+	s.sequencer.push(topic, sequence, m)
+}
+
+// startOrdered starts the topics of the session from their sequences when it connected,
+// the messages up to them are not queued. A sequence of 0 is unknown, the topic starts
+// from its first message.
+func (s *Session) startOrdered(topics map[string]int64) {
+	if s.sequencer == nil {
+		return
+	}
+	for topic, sequence := range topics {
+		if sequence > 0 {
+			s.sequencer.start(topic, sequence)
+		}
+	}
+}
+
+// ackOrdered advances the topic past the message the session sent, the session is skipped
+// when it is pushed.
+func (s *Session) ackOrdered(topic string, sequence int64) {
+	if s.sequencer == nil || topic == "" || sequence <= 0 {
+		return
+	}
+	s.sequencer.ack(topic, sequence)
+}
+
+func (s *Session) queueMessage(m outMessage) bool {
+	return s.queueFrame(&Protocol{Operation: m.operation}, m.body, m)
+}
+
+// Reconnect asks the client to reconnect to another server and terminates the session.
+// It never blocks, a session that is already stopping is left alone.
+func (s *Session) Reconnect() {
+	p := &Protocol{
+		Operation: types.OperationReconnect,
+	}
+	select {
+	case s.stop <- s.serialize(p, NoErrReconnect()):
+	default:
+	}
+}
@@ -6,27 +6,56 @@ import (
 	"mercury/x/ecode"
 	"mercury/x/ksuid"
 	"mercury/x/log"
+	"mercury/x/stat"
 	"mercury/x/websocket"
+	"sync/atomic"
+	"time"
 )
 
 type SessionStore interface {
 	NewSession(ctx context.Context, conn interface{}, serverID string, srv Servicer) error
 	Get(sid string) *Session
+	GetByUID(uid string) []*Session
+	GetByClient(clientID string) []*Session
 	GetAll() []*Session
+	Bind(s *Session)
+	Count() int
 	Delete(s *Session)
+	JoinRoom(key string, s *Session)
+	LeaveRoom(key string, s *Session)
+	GetByRoom(key string) []*Session
+	JoinTopics(s *Session, topics map[string]int64)
+	JoinTopic(clientID, topic string, uids ...string)
+	GetByTopic(clientID, topic string) []*Session
+	AllowRoom(key string) bool
+	SetRateLimits(roomRateLimit, roomSendRateLimit int)
+	Drain(ctx context.Context, batchSize int, interval time.Duration)
+	ReportStats()
 	Shutdown()
 }
 
 // SessionStore holds live sessions. Long polling sessions are stored in a linked list with
 // most recent sessions on top. In addition all sessions are stored in a map indexed by session ID.
 type sessionStore struct {
-	cache Cache
+	cache  Cache
+	rooms  *roomStore
+	topics *topicStore
+	// Maximum number of messages a session may send to rooms per second, unlimited if 0
+	roomSendRateLimit int32
+	// How long the messages of a topic are held back waiting for a missing sequence
+	reorderTimeout time.Duration
+	// Clients reported by the last ReportStats
+	reported map[string]struct{}
 }
 
 // NewSessionStore initializes a session store.
-func NewSessionStore() *sessionStore {
+func NewSessionStore(config ConfigProvider) *sessionStore {
 	ss := &sessionStore{
-		cache: NewDefaultCache(),
+		cache:             NewDefaultCache(),
+		rooms:             newRoomStore(config.RoomRateLimit()),
+		topics:            newTopicStore(),
+		roomSendRateLimit: int32(config.RoomSendRateLimit()),
+		reorderTimeout:    config.ReorderTimeout(),
 	}
 
 	return ss
@@ -38,6 +67,7 @@ func (ss *sessionStore) NewSession(ctx context.Context, conn interface{}, server
 	s.ctx = ctx
 	s.sid = ksuid.New().String()
 	s.serverID = serverID
+	s.log = log.New("sid", s.sid)
 	var ok bool
 	if s.srv, ok = srv.(*Service); !ok {
 		return ecode.ErrInternalServer
@@ -57,10 +87,16 @@ func (ss *sessionStore) NewSession(ctx context.Context, conn interface{}, server
 
 	if s.proto != NONE {
 		//s.subs = make(map[string]*Subscription)
-		s.send = make(chan []byte, sendQueueLimit+32) // buffered
-		s.stop = make(chan []byte, 1)                 // Buffered by 1 just to make it non-blocking
+		s.send = make(chan outFrame, sendQueueLimit+32) // buffered
+		s.stop = make(chan []byte, 1)                   // Buffered by 1 just to make it non-blocking
 	}
 
+	s.rooms = make(map[string]struct{})
+	if limit := atomic.LoadInt32(&ss.roomSendRateLimit); limit > 0 {
+		s.roomLimiter = newLimiter(int(limit))
+	}
+	s.sequencer = newSequencer(ss.reorderTimeout, s.queueMessage)
+
 	ss.cache.Store(s.sid, &s)
 
 	if s.proto == WEBSOCKET {
@@ -79,19 +115,125 @@ func (ss *sessionStore) Get(sid string) *Session {
 	return ss.cache.Load(sid)
 }
 
+// GetByUID fetches all sessions of the user from the store.
+func (ss *sessionStore) GetByUID(uid string) []*Session {
+	return ss.cache.LoadByUID(uid)
+}
+
+// GetByClient fetches all sessions belonging to the client from the store.
+func (ss *sessionStore) GetByClient(clientID string) []*Session {
+	return ss.cache.LoadByClient(clientID)
+}
+
 // GetAll get all sessions from the store
 func (ss *sessionStore) GetAll() []*Session {
 	return ss.cache.All()
 }
 
-// Delete removes session from the store.
+// Count returns the number of live sessions.
+func (ss *sessionStore) Count() int {
+	return ss.cache.Length()
+}
+
+// Bind indexes an authenticated session by its client ID and user ID.
+func (ss *sessionStore) Bind(s *Session) {
+	ss.cache.Bind(s.sid, s.clientID, s.id.UID())
+}
+
+// Delete removes session from the store and from all of its rooms and topics.
 func (ss *sessionStore) Delete(s *Session) {
+	for key := range s.rooms {
+		ss.rooms.leave(key, s)
+	}
+	ss.topics.leaveAll(s)
+	if s.sequencer != nil {
+		s.sequencer.stop()
+	}
 	ss.cache.Delete(s.sid)
 	if s.proto == WEBSOCKET {
 		log.Info("[Websocket] session deleted", "sid", s.sid, "count", ss.cache.Length())
 	}
 }
 
+// JoinRoom adds the session to the room.
+func (ss *sessionStore) JoinRoom(key string, s *Session) {
+	ss.rooms.join(key, s)
+}
+
+// LeaveRoom removes the session from the room.
+func (ss *sessionStore) LeaveRoom(key string, s *Session) {
+	ss.rooms.leave(key, s)
+}
+
+// GetByRoom fetches the local sessions in the room.
+func (ss *sessionStore) GetByRoom(key string) []*Session {
+	return ss.rooms.sessions(key)
+}
+
+// AllowRoom reports whether the rate cap of the room allows delivering one more message.
+func (ss *sessionStore) AllowRoom(key string) bool {
+	return ss.rooms.allow(key)
+}
+
+// SetRateLimits changes the rate limits of the rooms, the send rate limit applies to
+// the sessions created afterwards.
+func (ss *sessionStore) SetRateLimits(roomRateLimit, roomSendRateLimit int) {
+	ss.rooms.setRateLimit(roomRateLimit)
+	atomic.StoreInt32(&ss.roomSendRateLimit, int32(roomSendRateLimit))
+}
+
+// Drain asks every live session to reconnect to another server. Sessions are told in batches
+// of batchSize with a pause of interval between batches, so the remaining servers are not hit
+// by all the reconnects at once. Drain returns early if ctx is done.
+func (ss *sessionStore) Drain(ctx context.Context, batchSize int, interval time.Duration) {
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+
+	sessions := ss.cache.All()
+	for i := 0; i < len(sessions); i += batchSize {
+		end := i + batchSize
+		if end > len(sessions) {
+			end = len(sessions)
+		}
+		for _, s := range sessions[i:end] {
+			s.Reconnect()
+		}
+		log.Info("[SessionStore] draining", "notified", end, "total", len(sessions))
+
+		if end == len(sessions) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// ReportStats reports the live sessions of each client and the number of outbound messages
+// queued to the sessions. Clients without sessions left are reported once more with 0.
+func (ss *sessionStore) ReportStats() {
+	reported := make(map[string]struct{})
+	for clientID, count := range ss.cache.CountByClient() {
+		stat.CometSessions.State(clientID, int64(count))
+		reported[clientID] = struct{}{}
+	}
+	for clientID := range ss.reported {
+		if _, ok := reported[clientID]; !ok {
+			stat.CometSessions.State(clientID, 0)
+		}
+	}
+	ss.reported = reported
+
+	var queued int
+	for _, s := range ss.cache.All() {
+		queued += len(s.send)
+	}
+	stat.Queue.State("mercury.comet", int64(queued), "send")
+}
+
 // Shutdown terminates sessionStore. No need to clean up.
 // Don't send to clustered sessions, their servers are not being shut down.
 func (ss *sessionStore) Shutdown() {
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"mercury/x/types"
+	"strings"
+)
+
+// topicStore holds the local membership of the group topics in the topic push mode. The
+// sessions of a user join its groups when it connects and the groups it joins afterwards.
+// The sessions of a P2P topic are the ones of its two users, they are not held.
+type topicStore struct {
+	// Sessions indexed by topic key, then by session ID
+	shards []*indexShard
+}
+
+func newTopicStore() *topicStore {
+	ts := &topicStore{
+		shards: make([]*indexShard, defaultCacheShards),
+	}
+	for i := range ts.shards {
+		ts.shards[i] = &indexShard{kv: make(map[string]map[string]*Session)}
+	}
+	return ts
+}
+
+// topicKey scopes the topic to the client.
+func topicKey(clientID, topic string) string {
+	return clientID + ":" + topic
+}
+
+// isP2P reports whether the topic is a P2P topic.
+func isP2P(topic string) bool {
+	return strings.HasPrefix(topic, "p2p")
+}
+
+func (ts *topicStore) shard(key string) *indexShard {
+	return ts.shards[shardOf(key, defaultCacheShards-1)]
+}
+
+// join adds the session to the topic, a session which left all its topics joins none.
+func (ts *topicStore) join(key string, s *Session) {
+	s.topicsMux.Lock()
+	defer s.topicsMux.Unlock()
+	if s.topicsLeft {
+		return
+	}
+	if s.topics == nil {
+		s.topics = make(map[string]struct{})
+	}
+	if _, ok := s.topics[key]; ok {
+		return
+	}
+	s.topics[key] = struct{}{}
+	ts.shard(key).add(key, s.sid, s)
+}
+
+// leaveAll removes the session from all its topics once it is deleted.
+func (ts *topicStore) leaveAll(s *Session) {
+	s.topicsMux.Lock()
+	defer s.topicsMux.Unlock()
+	for key := range s.topics {
+		ts.shard(key).remove(key, s.sid)
+	}
+	s.topics = nil
+	s.topicsLeft = true
+}
+
+func (ts *topicStore) sessions(key string) []*Session {
+	return ts.shard(key).load(key)
+}
+
+// JoinTopics adds the session to its group topics, the P2P topics are left out.
+func (ss *sessionStore) JoinTopics(s *Session, topics map[string]int64) {
+	for topic := range topics {
+		if !isP2P(topic) {
+			ss.topics.join(topicKey(s.clientID, topic), s)
+		}
+	}
+}
+
+// JoinTopic adds the local sessions of the users of the client to the group topic.
+func (ss *sessionStore) JoinTopic(clientID, topic string, uids ...string) {
+	if isP2P(topic) {
+		return
+	}
+	key := topicKey(clientID, topic)
+	for _, uid := range uids {
+		for _, s := range ss.cache.LoadByUID(uid) {
+			if s.clientID == clientID {
+				ss.topics.join(key, s)
+			}
+		}
+	}
+}
+
+// GetByTopic fetches the local sessions of the client in the topic, the ones of its two
+// users for a P2P topic.
+func (ss *sessionStore) GetByTopic(clientID, topic string) []*Session {
+	if !isP2P(topic) {
+		return ss.topics.sessions(topicKey(clientID, topic))
+	}
+
+	u1, u2, err := types.ParseP2P(topic)
+	if err != nil {
+		return nil
+	}
+	var sessions []*Session
+	for _, uid := range []types.ID{u1, u2} {
+		for _, s := range ss.cache.LoadByUID(uid.UID()) {
+			if s.clientID == clientID {
+				sessions = append(sessions, s)
+			}
+		}
+	}
+	return sessions
+}
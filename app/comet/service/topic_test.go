@@ -0,0 +1,48 @@
+package service
+
+import (
+	"mercury/x/types"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicStore(t *testing.T) {
+	ss := &sessionStore{cache: NewDefaultCache(), rooms: newRoomStore(0), topics: newTopicStore()}
+	u1, u2, u3 := types.ID(1), types.ID(2), types.ID(3)
+	add := func(sid, clientID string, uid types.ID) *Session {
+		s := newTestSession(sid)
+		s.clientID = clientID
+		s.id = uid
+		ss.cache.Store(sid, s)
+		ss.Bind(s)
+		return s
+	}
+	s1 := add("s1", "c1", u1)
+	s2 := add("s2", "c1", u2)
+	add("s3", "c2", u2)
+	add("s4", "c1", u3)
+
+	// Sessions join their group topics when they connect, P2P topics are never held
+	p2p := u1.P2PName(u2)
+	ss.JoinTopics(s1, map[string]int64{"grp1": 0, p2p: 0})
+	require.Len(t, ss.GetByTopic("c1", "grp1"), 1)
+	require.Empty(t, ss.GetByTopic("c2", "grp1"))
+	require.Empty(t, ss.topics.sessions(topicKey("c1", p2p)))
+
+	// Members joining later are added by user, only the sessions of the client join
+	ss.JoinTopic("c1", "grp1", u1.UID(), u2.UID())
+	require.Len(t, ss.GetByTopic("c1", "grp1"), 2)
+	require.Empty(t, ss.GetByTopic("c2", "grp1"))
+
+	// The sessions of a P2P topic are the ones of its two users in the client
+	require.Len(t, ss.GetByTopic("c1", p2p), 2)
+	require.Len(t, ss.GetByTopic("c2", p2p), 1)
+	require.Empty(t, ss.GetByTopic("c1", "p2pinvalid"))
+
+	// Deleted sessions leave their topics and never join again
+	ss.Delete(s2)
+	require.Len(t, ss.GetByTopic("c1", "grp1"), 1)
+	ss.JoinTopics(s2, map[string]int64{"grp2": 0})
+	require.Empty(t, ss.GetByTopic("c1", "grp2"))
+}
@@ -1,5 +1,10 @@
 package model
 
 type Config struct {
+	// Config sealed with a data key
 	Ciphertext string `json:"ciphertext"`
+	// Data key sealed with the secret of the credential the config is served to
+	Key string `json:"key"`
+	// Version of the configuration, see config.Config.Version
+	Version string `json:"version"`
 }
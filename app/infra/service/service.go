@@ -1,44 +1,122 @@
 package service
 
 import (
+	"context"
 	"github.com/ghodss/yaml"
 	"mercury/app/infra/model"
 	"mercury/config"
+	"mercury/config/api"
+	"mercury/x/ecode"
 	"mercury/x/log"
-	"mercury/x/secretboxer"
-	"strconv"
+	"net/http"
+	"sync"
+	"time"
 )
 
 type Servicer interface {
-	LoadConfig() (*model.Config, error)
+	// Authenticate returns the credential which signed the request.
+	Authenticate(req *http.Request) (*config.InfraCredential, error)
+	LoadConfig(cred *config.InfraCredential) (*model.Config, error)
+	WatchConfig(ctx context.Context, cred *config.InfraCredential, version string) (*model.Config, error)
+	SetConfig(cfg *config.Config)
+	// Check reports whether the config can be served.
+	Check() error
 }
 
 type Service struct {
-	boxer  *secretboxer.PassphraseBoxer
+	log log.Logger
+
+	mu     sync.RWMutex
 	config *config.Config
-	log    log.Logger
+	// Closed and replaced whenever the config changes
+	changed chan struct{}
 }
 
 func NewService(cfg *config.Config, l log.Logger) (*Service, error) {
 	s := &Service{
-		boxer:  secretboxer.NewPassphraseBoxer(strconv.Itoa(config.CurrentConfigRevision), secretboxer.EncodingTypeStd),
-		config: cfg,
-		log:    l,
+		config:  cfg,
+		changed: make(chan struct{}),
+		log:     l,
+	}
+	if cfg.Infra == nil || len(cfg.Infra.Credentials) == 0 {
+		l.Warn("[NewService] no infra credentials are configured, the config is served to no one")
 	}
 
 	return s, nil
 }
 
-func (s *Service) LoadConfig() (*model.Config, error) {
-	b, err := yaml.Marshal(s.config)
+func (s *Service) current() *config.Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config
+}
+
+func (s *Service) Authenticate(req *http.Request) (*config.InfraCredential, error) {
+	name := req.Header.Get(api.HeaderCredential)
+	if name == "" {
+		return nil, ecode.ErrUnauthorized
+	}
+	cred, ok := s.current().Infra.Credential(name)
+	if !ok || cred.Secret == "" {
+		s.log.Warn("[Authenticate] unknown credential", "name", name)
+		return nil, ecode.ErrUnauthorized
+	}
+	if err := api.Verify(req, cred.Secret, time.Now()); err != nil {
+		s.log.Warn("[Authenticate] invalid signature", "name", name)
+		return nil, ecode.ErrUnauthorized
+	}
+	return cred, nil
+}
+
+func (s *Service) LoadConfig(cred *config.InfraCredential) (*model.Config, error) {
+	resp, err := cred.Seal(s.current())
 	if err != nil {
 		return nil, err
 	}
 
-	ciphertext, err := s.boxer.Seal(b)
+	return &model.Config{Ciphertext: resp.Ciphertext, Key: resp.Key, Version: resp.Version}, nil
+}
+
+// WatchConfig returns the config as soon as the version of the view of the credential
+// differs from version, or once ctx is done.
+func (s *Service) WatchConfig(ctx context.Context, cred *config.InfraCredential, version string) (*model.Config, error) {
+	for {
+		s.mu.RLock()
+		changed := s.changed
+		s.mu.RUnlock()
+
+		// Sealing is costly, it only happens once the config is returned
+		current, err := s.viewVersion(cred)
+		if err != nil || current != version {
+			return s.LoadConfig(cred)
+		}
+
+		select {
+		case <-ctx.Done():
+			return s.LoadConfig(cred)
+		case <-changed:
+		}
+	}
+}
+
+func (s *Service) viewVersion(cred *config.InfraCredential) (string, error) {
+	b, err := yaml.Marshal(cred.View(s.current()))
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	return config.VersionOf(b), nil
+}
+
+// SetConfig replaces the config served and wakes up the watchers.
+func (s *Service) SetConfig(cfg *config.Config) {
+	s.mu.Lock()
+	s.config = cfg
+	close(s.changed)
+	s.changed = make(chan struct{})
+	s.mu.Unlock()
+}
 
-	return &model.Config{Ciphertext: ciphertext}, nil
+func (s *Service) Check() error {
+	_, err := yaml.Marshal(s.current())
+	return err
 }
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"mercury/config"
+	"mercury/config/api"
+	"mercury/x/ecode"
+	"mercury/x/log"
+)
+
+func TestWatchConfig(t *testing.T) {
+	s, err := NewService(config.DefaultConfig(), log.New())
+	require.NoError(t, err)
+	cred := &config.InfraCredential{Name: "mercury.job", Secret: "job-secret"}
+	current, err := s.LoadConfig(cred)
+	require.NoError(t, err)
+	require.NotEmpty(t, current.Version)
+	require.NotEmpty(t, current.Key)
+
+	// An outdated version is answered right away
+	c, err := s.WatchConfig(context.Background(), cred, "outdated")
+	require.NoError(t, err)
+	require.Equal(t, current.Version, c.Version)
+
+	// The current version is held until the wait is over
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	c, err = s.WatchConfig(ctx, cred, current.Version)
+	require.NoError(t, err)
+	require.Equal(t, current.Version, c.Version)
+
+	// or until the view of the credential changes
+	done := make(chan string)
+	go func() {
+		c, err := s.WatchConfig(context.Background(), cred, current.Version)
+		require.NoError(t, err)
+		done <- c.Version
+	}()
+	changed := config.DefaultConfig()
+	changed.Redis.Password = "not in the view"
+	s.SetConfig(changed)
+	changed = config.DefaultConfig()
+	changed.LogLevel = "info"
+	s.SetConfig(changed)
+	select {
+	case version := <-done:
+		require.NotEqual(t, current.Version, version)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch was not woken up by the change")
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Infra.Credentials = []*config.InfraCredential{{Name: "mercury.comet", Secret: "comet-secret"}}
+	s, err := NewService(cfg, log.New())
+	require.NoError(t, err)
+
+	request := func(name, secret string, at time.Time) error {
+		req := httptest.NewRequest("GET", "/infra/v1/config?version=1", nil)
+		timestamp := strconv.FormatInt(at.Unix(), 10)
+		req.Header.Set(api.HeaderCredential, name)
+		req.Header.Set(api.HeaderTimestamp, timestamp)
+		req.Header.Set(api.HeaderSignature, api.Sign(secret, req.Method, req.URL.RequestURI(), timestamp))
+		_, err := s.Authenticate(req)
+		return err
+	}
+
+	require.NoError(t, request("mercury.comet", "comet-secret", time.Now()))
+	require.Equal(t, ecode.ErrUnauthorized, request("mercury.comet", "other", time.Now()))
+	require.Equal(t, ecode.ErrUnauthorized, request("mercury.logic", "comet-secret", time.Now()))
+	require.Equal(t, ecode.ErrUnauthorized, request("mercury.comet", "comet-secret", time.Now().Add(-time.Hour)))
+
+	_, err = s.Authenticate(httptest.NewRequest("GET", "/infra/v1/config", nil))
+	require.Equal(t, ecode.ErrUnauthorized, err)
+}
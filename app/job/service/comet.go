@@ -3,71 +3,344 @@ package service
 import (
 	"context"
 	"fmt"
+	"github.com/cenkalti/backoff/v4"
 	"github.com/micro/go-micro/v2/client"
+	"hash/fnv"
 	cApi "mercury/app/comet/api"
+	"mercury/config"
+	"mercury/x/ecode"
 	"mercury/x/log"
-	"sync/atomic"
+	"mercury/x/stat"
+	"time"
 )
 
+var ErrQueueFull = ecode.NewError("dispatch queue is full")
+
+const defaultBatchInterval = 10 * time.Millisecond
+
+// DispatchOptions configures how job dispatches requests to a comet.
+type DispatchOptions struct {
+	// Capacity of the push queue and of the request queue of each worker
+	QueueSize int
+	// Number of workers sending requests, the requests of a topic are always sent in
+	// order by the same worker
+	Workers int
+	// Maximum number of pushes sent in one request
+	BatchSize int
+	// How long to wait for a batch to fill up
+	BatchInterval time.Duration
+	// Number of retries of a failed request
+	MaxRetries int
+	// How long to wait for room in a full queue
+	EnqueueTimeout time.Duration
+}
+
+// NewDispatchOptions reads the dispatch options from the job service config.
+func NewDispatchOptions(srvCfg *config.Service) DispatchOptions {
+	return DispatchOptions{
+		QueueSize:      srvCfg.DispatchQueueSize(),
+		Workers:        srvCfg.DispatchWorkers(),
+		BatchSize:      srvCfg.DispatchBatchSize(),
+		BatchInterval:  srvCfg.DispatchBatchInterval(),
+		MaxRetries:     srvCfg.DispatchMaxRetries(),
+		EnqueueTimeout: srvCfg.DispatchEnqueueTimeout(),
+	}
+}
+
+type marshaler interface {
+	Marshal() ([]byte, error)
+}
+
+// DeadLetterFunc receives the requests which could not be delivered to the comet.
+type DeadLetterFunc func(serverID, endpoint string, req marshaler, err error)
+
+// request is a call to an endpoint of the comet.
+type request struct {
+	endpoint string
+	req      marshaler
+	call     func(ctx context.Context) error
+}
+
+// Comet dispatches requests to one comet node. Pushes are batched into a single
+// PushMessages request, every request is retried with backoff and handed to the
+// dead-letter function once it can not be delivered. Queues are bounded, a request
+// which can not be queued in time is dead-lettered as well.
+//
+// Requests are spread over the workers by the hash of their key (the topic of a push),
+// a worker sends one request at a time, so the requests of a topic keep their order.
 type Comet struct {
-	ctx              context.Context
-	cancel           context.CancelFunc
-	serverID         string
-	callOption       client.CallOption
-	pushChan         []chan *cApi.PushMessageReq
-	broadcastChan    []chan *cApi.BroadcastMessageReq
-	pushChanNum      uint64
-	broadcastChanNum uint64
-	routineSize      uint64
-}
-
-func NewComet(id, address string) (*Comet, error) {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	serverID    string
+	callOptions []client.CallOption
+	opts        DispatchOptions
+	deadLetter  DeadLetterFunc
+	workers     []*worker
+}
+
+// worker batches and sends the requests of its keys.
+type worker struct {
+	pushChan chan *cApi.PushMessageReq
+	reqChan  chan *request
+}
+
+func NewComet(id, address string, opts DispatchOptions, deadLetter DeadLetterFunc) (*Comet, error) {
 	if address == "" {
 		return nil, fmt.Errorf("invalid node address: %v", address)
 	}
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 1
+	}
+	if opts.BatchInterval <= 0 {
+		opts.BatchInterval = defaultBatchInterval
+	}
 
-	routineSize := 32
 	c := &Comet{
-		serverID:      id,
-		callOption:    client.WithAddress(address),
-		pushChan:      make([]chan *cApi.PushMessageReq, routineSize),
-		broadcastChan: make([]chan *cApi.BroadcastMessageReq, routineSize),
-		routineSize:   uint64(routineSize),
+		serverID: id,
+		// The requests are retried by send, not by the client
+		callOptions: []client.CallOption{client.WithAddress(address), client.WithRetries(0)},
+		opts:        opts,
+		deadLetter:  deadLetter,
+		workers:     make([]*worker, opts.Workers),
 	}
 	c.ctx, c.cancel = context.WithCancel(context.Background())
 
-	for i := 0; i < routineSize; i++ {
-		c.pushChan[i] = make(chan *cApi.PushMessageReq, 1024)
-		go c.process(c.pushChan[i], c.broadcastChan[i])
+	for i := range c.workers {
+		c.workers[i] = &worker{
+			pushChan: make(chan *cApi.PushMessageReq, opts.QueueSize),
+			reqChan:  make(chan *request, opts.QueueSize),
+		}
+	}
+	// The workers report the queues of each other, so they start once all are created
+	for _, w := range c.workers {
+		go c.batch(w)
+		go c.process(w)
 	}
 	return c, nil
 }
 
-func (c *Comet) Push(req *cApi.PushMessageReq) {
-	idx := atomic.AddUint64(&c.pushChanNum, 1) % c.routineSize
-	c.pushChan[idx] <- req
+// worker returns the worker of the key.
+func (c *Comet) worker(key string) *worker {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return c.workers[h.Sum32()%uint32(len(c.workers))]
+}
+
+func (c *Comet) Push(req *cApi.PushMessageReq) error {
+	key := req.Topic
+	if key == "" && len(req.SIDs) > 0 {
+		key = req.SIDs[0]
+	}
+
+	w := c.worker(key)
+	select {
+	case w.pushChan <- req:
+		return nil
+	default:
+	}
+
+	// The queue is full, wait for room
+	timer := time.NewTimer(c.opts.EnqueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case w.pushChan <- req:
+		return nil
+	case <-timer.C:
+		c.drop("Chat.PushMessages", &cApi.PushMessagesReq{Messages: []*cApi.PushMessageReq{req}}, ErrQueueFull)
+		return ErrQueueFull
+	case <-c.ctx.Done():
+		return c.ctx.Err()
+	}
+}
+
+func (c *Comet) PushUID(req *cApi.PushUIDMessageReq) error {
+	return c.enqueue(req.Topic, &request{
+		endpoint: "Chat.PushUIDMessage",
+		req:      req,
+		call: func(ctx context.Context) error {
+			_, err := grpcClient.PushUIDMessage(ctx, req, c.callOptions...)
+			return err
+		},
+	})
+}
+
+func (c *Comet) PushTopic(req *cApi.PushTopicMessageReq) error {
+	return c.enqueue(req.Topic, &request{
+		endpoint: "Chat.PushTopicMessage",
+		req:      req,
+		call: func(ctx context.Context) error {
+			_, err := grpcClient.PushTopicMessage(ctx, req, c.callOptions...)
+			return err
+		},
+	})
 }
 
-func (c *Comet) Broadcast(req *cApi.BroadcastMessageReq) {
-	idx := atomic.AddUint64(&c.broadcastChanNum, 1) % c.routineSize
-	c.broadcastChan[idx] <- req
+func (c *Comet) Broadcast(req *cApi.BroadcastMessageReq) error {
+	return c.enqueue(req.ClientID, &request{
+		endpoint: "Chat.BroadcastMessage",
+		req:      req,
+		call: func(ctx context.Context) error {
+			_, err := grpcClient.BroadcastMessage(ctx, req, c.callOptions...)
+			return err
+		},
+	})
 }
 
-func (c *Comet) process(pushChan chan *cApi.PushMessageReq, broadcastChan chan *cApi.BroadcastMessageReq) {
+func (c *Comet) BroadcastRoom(req *cApi.BroadcastRoomReq) error {
+	return c.enqueue(req.Room, &request{
+		endpoint: "Chat.BroadcastRoom",
+		req:      req,
+		call: func(ctx context.Context) error {
+			_, err := grpcClient.BroadcastRoom(ctx, req, c.callOptions...)
+			return err
+		},
+	})
+}
+
+func (c *Comet) enqueue(key string, r *request) error {
+	w := c.worker(key)
+	select {
+	case w.reqChan <- r:
+		return nil
+	default:
+	}
+
+	// The queue is full, wait for room
+	timer := time.NewTimer(c.opts.EnqueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case w.reqChan <- r:
+		return nil
+	case <-timer.C:
+		c.drop(r.endpoint, r.req, ErrQueueFull)
+		return ErrQueueFull
+	case <-c.ctx.Done():
+		return c.ctx.Err()
+	}
+}
+
+// batch collects the pushes of the worker into PushMessages requests, a batch is sent
+// when it is full or when the batch interval elapsed.
+func (c *Comet) batch(w *worker) {
+	ticker := time.NewTicker(c.opts.BatchInterval)
+	defer ticker.Stop()
+
+	var messages []*cApi.PushMessageReq
+	flush := func() {
+		if len(messages) == 0 {
+			return
+		}
+		req := &cApi.PushMessagesReq{Messages: messages}
+		messages = nil
+		r := &request{
+			endpoint: "Chat.PushMessages",
+			req:      req,
+			call: func(ctx context.Context) error {
+				_, err := grpcClient.PushMessages(ctx, req, c.callOptions...)
+				return err
+			},
+		}
+		select {
+		case w.reqChan <- r:
+		case <-c.ctx.Done():
+			c.drop(r.endpoint, r.req, c.ctx.Err())
+		}
+	}
+
 	for {
 		select {
-		case req := <-pushChan:
-			_, err := grpcClient.PushMessage(c.ctx, req, c.callOption)
-			if err != nil {
-				log.Error("failed to push message", "error", err)
+		case req := <-w.pushChan:
+			messages = append(messages, req)
+			if len(messages) >= c.opts.BatchSize {
+				flush()
 			}
-		case req := <-broadcastChan:
-			_, err := grpcClient.BroadcastMessage(c.ctx, req, c.callOption)
-			if err != nil {
-				log.Error("failed to broadcast message", "error", err)
+		case <-ticker.C:
+			flush()
+			if w == c.workers[0] {
+				c.reportQueues()
 			}
 		case <-c.ctx.Done():
-			return
+			// Hand whatever is left to the dead-letter function.
+			for {
+				select {
+				case req := <-w.pushChan:
+					messages = append(messages, req)
+				default:
+					if len(messages) > 0 {
+						c.drop("Chat.PushMessages", &cApi.PushMessagesReq{Messages: messages}, c.ctx.Err())
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+// reportQueues reports the number of queued pushes and requests of all workers.
+func (c *Comet) reportQueues() {
+	var pushes, requests int
+	for _, w := range c.workers {
+		pushes += len(w.pushChan)
+		requests += len(w.reqChan)
+	}
+	stat.JobQueue.State(c.serverID, int64(pushes), "push")
+	stat.JobQueue.State(c.serverID, int64(requests), "request")
+}
+
+func (c *Comet) process(w *worker) {
+	for {
+		select {
+		case r := <-w.reqChan:
+			c.send(r)
+		case <-c.ctx.Done():
+			for {
+				select {
+				case r := <-w.reqChan:
+					c.drop(r.endpoint, r.req, c.ctx.Err())
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// send calls the endpoint with retries, the request is dead-lettered if all attempts failed
+// or if it failed with an error a retry can not fix.
+func (c *Comet) send(r *request) {
+	b := backoff.WithContext(backoff.WithMaxRetries(backoff.NewExponentialBackOff(), uint64(c.opts.MaxRetries)), c.ctx)
+
+	attempt := 0
+	err := backoff.Retry(func() error {
+		if attempt > 0 {
+			stat.JobDispatch.Incr(c.serverID, r.endpoint, "retry")
 		}
+		attempt++
+
+		start := time.Now()
+		err := r.call(c.ctx)
+		stat.JobDispatch.Timing(c.serverID, int64(time.Since(start)/time.Millisecond), r.endpoint)
+		if err != nil && !ecode.Retryable(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, b)
+	if err != nil {
+		log.Error("[Dispatch] failed to send request", "serverID", c.serverID, "endpoint", r.endpoint, "attempts", attempt, "error", err)
+		c.drop(r.endpoint, r.req, err)
+		return
+	}
+	stat.JobDispatch.Incr(c.serverID, r.endpoint, "ok")
+}
+
+func (c *Comet) drop(endpoint string, req marshaler, err error) {
+	stat.JobDispatch.Incr(c.serverID, endpoint, "dead_letter")
+	if c.deadLetter != nil {
+		c.deadLetter(c.serverID, endpoint, req, err)
 	}
 }
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/stretchr/testify/require"
+	cApi "mercury/app/comet/api"
+	"mercury/x/ecode"
+)
+
+type fakeChatService struct {
+	cApi.ChatService
+
+	mux     sync.Mutex
+	batches [][]*cApi.PushMessageReq
+	fails   int
+	// Error of the failed calls, a transport error if nil
+	err   error
+	calls int
+}
+
+func (f *fakeChatService) PushMessages(ctx context.Context, in *cApi.PushMessagesReq, opts ...client.CallOption) (*cApi.Empty, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	f.calls++
+	var callOpts client.CallOptions
+	for _, o := range opts {
+		o(&callOpts)
+	}
+	if callOpts.Retries != 0 {
+		return nil, errors.New("the client retries the call")
+	}
+	if f.fails > 0 {
+		f.fails--
+		if f.err != nil {
+			return nil, f.err
+		}
+		return nil, errors.New("unavailable")
+	}
+	f.batches = append(f.batches, in.Messages)
+	return &cApi.Empty{}, nil
+}
+
+func (f *fakeChatService) count() (batches, messages int) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	for _, b := range f.batches {
+		messages += len(b)
+	}
+	return len(f.batches), messages
+}
+
+func testDispatchOptions() DispatchOptions {
+	return DispatchOptions{
+		QueueSize:      16,
+		Workers:        1,
+		BatchSize:      10,
+		BatchInterval:  20 * time.Millisecond,
+		MaxRetries:     2,
+		EnqueueTimeout: 10 * time.Millisecond,
+	}
+}
+
+func TestCometBatch(t *testing.T) {
+	fake := &fakeChatService{fails: 1}
+	grpcClient = fake
+
+	c, err := NewComet("test", "127.0.0.1:0", testDispatchOptions(), nil)
+	require.NoError(t, err)
+	defer c.cancel()
+
+	for i := 0; i < 25; i++ {
+		require.NoError(t, c.Push(&cApi.PushMessageReq{SIDs: []string{"sid"}}))
+	}
+	require.Eventually(t, func() bool {
+		_, messages := fake.count()
+		return messages == 25
+	}, 5*time.Second, 10*time.Millisecond)
+
+	batches, _ := fake.count()
+	require.Equal(t, 3, batches)
+}
+
+func TestCometDeadLetter(t *testing.T) {
+	fake := &fakeChatService{fails: 100}
+	grpcClient = fake
+
+	var mux sync.Mutex
+	var dead []string
+	c, err := NewComet("test", "127.0.0.1:0", testDispatchOptions(), func(serverID, endpoint string, req marshaler, err error) {
+		mux.Lock()
+		defer mux.Unlock()
+		dead = append(dead, endpoint)
+	})
+	require.NoError(t, err)
+	defer c.cancel()
+
+	require.NoError(t, c.Push(&cApi.PushMessageReq{SIDs: []string{"sid"}}))
+	require.Eventually(t, func() bool {
+		mux.Lock()
+		defer mux.Unlock()
+		return len(dead) == 1 && dead[0] == "Chat.PushMessages"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestCometPermanentError(t *testing.T) {
+	fake := &fakeChatService{fails: 100, err: ecode.ErrBadRequest}
+	grpcClient = fake
+
+	dead := make(chan error, 1)
+	c, err := NewComet("test", "127.0.0.1:0", testDispatchOptions(), func(serverID, endpoint string, req marshaler, err error) {
+		dead <- err
+	})
+	require.NoError(t, err)
+	defer c.cancel()
+
+	// The request is dead-lettered without retries
+	require.NoError(t, c.Push(&cApi.PushMessageReq{SIDs: []string{"sid"}}))
+	select {
+	case err := <-dead:
+		require.True(t, ecode.EqualError(ecode.ErrBadRequest, err))
+	case <-time.After(5 * time.Second):
+		t.Fatal("request is not dead-lettered")
+	}
+	fake.mux.Lock()
+	defer fake.mux.Unlock()
+	require.Equal(t, 1, fake.calls)
+}
+
+func TestCometOrder(t *testing.T) {
+	fake := &fakeChatService{}
+	grpcClient = fake
+
+	opts := testDispatchOptions()
+	opts.Workers = 8
+	opts.BatchSize = 3
+	c, err := NewComet("test", "127.0.0.1:0", opts, nil)
+	require.NoError(t, err)
+	defer c.cancel()
+
+	for i := 0; i < 50; i++ {
+		for _, topic := range []string{"t1", "t2", "t3"} {
+			require.NoError(t, c.Push(&cApi.PushMessageReq{Topic: topic, Sequence: int64(i + 1)}))
+		}
+	}
+	require.Eventually(t, func() bool {
+		_, messages := fake.count()
+		return messages == 150
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The pushes of a topic arrive in the order they were pushed
+	last := make(map[string]int64)
+	fake.mux.Lock()
+	defer fake.mux.Unlock()
+	for _, b := range fake.batches {
+		for _, m := range b {
+			require.Equal(t, last[m.Topic]+1, m.Sequence)
+			last[m.Topic] = m.Sequence
+		}
+	}
+}
+
+func TestCometZeroOptions(t *testing.T) {
+	fake := &fakeChatService{}
+	grpcClient = fake
+
+	var mux sync.Mutex
+	var dead int
+	opts := testDispatchOptions()
+	opts.BatchInterval = 0
+	opts.EnqueueTimeout = 0
+	c, err := NewComet("test", "127.0.0.1:0", opts, func(serverID, endpoint string, req marshaler, err error) {
+		mux.Lock()
+		defer mux.Unlock()
+		dead++
+	})
+	require.NoError(t, err)
+	defer c.cancel()
+
+	// Requests are queued while there is room, even without an enqueue timeout
+	for i := 0; i < 5; i++ {
+		require.NoError(t, c.Push(&cApi.PushMessageReq{SIDs: []string{"sid"}}))
+	}
+	require.Eventually(t, func() bool {
+		_, messages := fake.count()
+		return messages == 5
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mux.Lock()
+	defer mux.Unlock()
+	require.Zero(t, dead)
+}
@@ -3,13 +3,14 @@ package service
 import (
 	"github.com/micro/go-micro/v2/broker"
 	"github.com/micro/go-micro/v2/client"
-	"github.com/micro/go-micro/v2/client/grpc"
 	"github.com/micro/go-micro/v2/registry"
 	"github.com/micro/go-micro/v2/server"
 	cApi "mercury/app/comet/api"
+	"mercury/app/logic/api"
 	"mercury/config"
 	"mercury/x/ecode"
 	"mercury/x/log"
+	"mercury/x/microx"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +18,8 @@ import (
 
 type Servicer interface {
 	Init(options server.Options)
+	CometCount() int
+	Resubscribe()
 	Close()
 }
 
@@ -31,21 +34,33 @@ type Service struct {
 	registry     registry.Registry
 	stopChan     chan struct{}
 	watchChan    chan bool
+	// Logs the messages received, only a sample of them is written
+	receiveLog log.Logger
+	// Subscribers by topic name, guarded by mutex
+	subscribers map[string]broker.Subscriber
 }
 
 type ConfigProvider interface {
+	GetService(name string) (*config.Service, bool)
+	ServiceName(name string) string
 	Topic() config.Topic
+	LogSampling() *config.LogSampling
 }
 
 func NewService(config ConfigProvider, l log.Logger) (*Service, error) {
-	return &Service{
+	s := &Service{
 		config:       config,
 		cometServers: make(map[string]*Comet),
 		log:          l,
+		receiveLog:   l,
 		mutex:        &sync.Mutex{},
 		stopChan:     make(chan struct{}),
 		watchChan:    make(chan bool, 1),
-	}, nil
+	}
+	if c := config.LogSampling(); c != nil {
+		s.receiveLog = log.Sampled(l, c.First, c.Thereafter, time.Second)
+	}
+	return s, nil
 }
 
 func (s *Service) Init(options server.Options) {
@@ -62,63 +77,164 @@ func (s *Service) withRegistry(r registry.Registry) {
 			client.Retries(2),
 			client.Retry(ecode.RetryOnMicroError),
 			client.WrapCall(ecode.MicroCallFunc),
-			client.Registry(r),
 		}
 
-		c := grpc.NewClient(opts...)
+		c := microx.NewClient(r, opts...)
 
-		grpcClient = cApi.NewChatService("mercury.comet", c)
+		grpcClient = cApi.NewChatService(s.config.ServiceName("mercury.comet"), c)
 
 		go s.watchComet()
 	}
 }
 
+// Queue shared by the job servers, each message is handled by one of them.
+const subscribeQueue = "mercury.job"
+
 func (s *Service) withBroker(b broker.Broker) {
 	if s.broker == nil {
 		s.broker = b
+		s.subscribe()
+	}
+}
 
-		topic := s.config.Topic()
-		pushMessageTopic, ok := topic.Get("push_message")
-		if ok {
-			if _, err := s.broker.Subscribe(pushMessageTopic, s.subscribePushMessage); err != nil {
-				s.log.Error("[WatchComet] failed to subscribe topic", "topic", pushMessageTopic, "error", err)
-				return
+// subscribe subscribes the topics of the config which are not subscribed yet and
+// unsubscribes the topics which are no longer configured.
+func (s *Service) subscribe() {
+	handlers := map[string]broker.Handler{
+		"push_message":           s.subscribePushMessage,
+		"push_uid_message":       s.subscribePushUIDMessage,
+		"push_topic_message":     s.subscribePushTopicMessage,
+		"broadcast_room_message": s.subscribeBroadcastRoomMessage,
+		"broadcast_message":      s.subscribeBroadcastMessage,
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.subscribers == nil {
+		s.subscribers = make(map[string]broker.Subscriber)
+	}
+	topic := s.config.Topic()
+	for name, handler := range handlers {
+		t, _ := topic.Get(name)
+		if sub, ok := s.subscribers[name]; ok {
+			if sub.Topic() == t {
+				continue
 			}
-		}
-		broadcastMessageTopic, ok := topic.Get("broadcast_message")
-		if ok {
-			if _, err := s.broker.Subscribe(broadcastMessageTopic, s.subscribeBroadcastMessage); err != nil {
-				s.log.Error("[WatchComet] failed to subscribe topic", "topic", broadcastMessageTopic, "error", err)
-				return
+			if err := sub.Unsubscribe(); err != nil {
+				s.log.Error("[Subscribe] failed to unsubscribe topic", "topic", sub.Topic(), "error", err)
 			}
+			delete(s.subscribers, name)
 		}
+		if t == "" {
+			continue
+		}
+		sub, err := s.broker.Subscribe(t, handler, broker.Queue(subscribeQueue))
+		if err != nil {
+			s.log.Error("[Subscribe] failed to subscribe topic", "topic", t, "error", err)
+			continue
+		}
+		s.subscribers[name] = sub
+	}
+}
+
+// Resubscribe follows the changes of the topics in the config.
+func (s *Service) Resubscribe() {
+	if s.broker != nil {
+		s.subscribe()
+	}
+}
+
+// comet returns the comet with the server ID.
+func (s *Service) comet(serverID string) (*Comet, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	c, ok := s.cometServers[serverID]
+	return c, ok
+}
+
+// CometCount returns the number of comets synced from the registry.
+func (s *Service) CometCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.cometServers)
+}
+
+// comets returns all known comets.
+func (s *Service) comets() []*Comet {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	comets := make([]*Comet, 0, len(s.cometServers))
+	for _, c := range s.cometServers {
+		comets = append(comets, c)
+	}
+	return comets
+}
+
+func (s *Service) dispatchOptions() DispatchOptions {
+	srvCfg, ok := s.config.GetService("mercury.job")
+	if !ok {
+		srvCfg = &config.Service{Name: "mercury.job"}
+	}
+	return NewDispatchOptions(srvCfg)
+}
+
+// publishDeadLetter publishes a request which could not be delivered to a comet to the
+// dead-letter topic, so that it can be inspected or replayed.
+func (s *Service) publishDeadLetter(serverID, endpoint string, req marshaler, err error) {
+	s.log.Warn("[DeadLetter] undeliverable request", "serverID", serverID, "endpoint", endpoint, "error", err)
+
+	topic := s.config.Topic()
+	deadLetterTopic, ok := topic.Get("dead_letter")
+	if !ok || s.broker == nil {
+		return
+	}
+
+	body, mErr := req.Marshal()
+	if mErr != nil {
+		s.log.Error("[DeadLetter] failed to marshal request", "error", mErr)
+		return
+	}
+	dl := &api.DeadLetter{
+		ServerID:  serverID,
+		Endpoint:  endpoint,
+		Request:   body,
+		Error:     err.Error(),
+		CreatedAt: time.Now().Unix(),
+	}
+	b, mErr := dl.Marshal()
+	if mErr != nil {
+		s.log.Error("[DeadLetter] failed to marshal dead letter", "error", mErr)
+		return
+	}
+	if pErr := s.broker.Publish(deadLetterTopic, &broker.Message{Body: b}); pErr != nil {
+		s.log.Error("[DeadLetter] failed to publish", "topic", deadLetterTopic, "error", pErr)
 	}
 }
 
 func (s *Service) Close() {
-	for id, old := range s.cometServers {
+	for _, old := range s.comets() {
 		old.cancel()
-		log.Info("[Close] job server close", "id", id)
+		log.Info("[Close] job server close", "id", old.serverID)
 	}
 	s.stopChan <- struct{}{}
 }
 
 func (s *Service) watchComet() {
+	// Watch before the first sync, so that no comet registered in between is missed
+	cometServiceName := s.config.ServiceName("mercury.comet")
+	watcher, err := s.registry.Watch(registry.WatchService(cometServiceName))
+	if err != nil {
+		panic("failed to watch service:" + err.Error())
+	}
 	if err := s.syncCometNodes(); err != nil {
 		panic("failed to sync comet nodes:" + err.Error())
 	}
 
-	go s.watch()
+	go s.watch(watcher)
 	go s.sync()
 }
 
-func (s *Service) watch() {
-	cometServiceName := "mercury.comet"
-	watcher, err := s.registry.Watch(registry.WatchService(cometServiceName))
-	if err != nil {
-		panic("failed to watch service:" + err.Error())
-	}
-
+func (s *Service) watch(watcher registry.Watcher) {
 	for {
 		result, err := watcher.Next()
 		if err != nil {
@@ -130,7 +246,8 @@ func (s *Service) watch() {
 			break
 		}
 
-		if result.Action != "create" {
+		// A draining comet deregisters itself, stop routing to it right away.
+		if result.Action != "create" && result.Action != "delete" {
 			continue
 		}
 
@@ -162,50 +279,48 @@ func (s *Service) sync() {
 func (s *Service) syncCometNodes() error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	cometServiceName := "mercury.comet"
+	cometServiceName := s.config.ServiceName("mercury.comet")
 	cometServices, err := s.registry.GetService(cometServiceName)
-	if err != nil {
+	if err != nil && err != registry.ErrNotFound {
 		s.log.Error("[syncCometNodes] failed to new comet", "error", err)
 		return err
 	}
 
-	if len(cometServices) > 0 {
-		var nodes []*registry.Node
-		for _, cometService := range cometServices {
-			nodes = append(nodes, cometService.Nodes...)
-		}
+	var nodes []*registry.Node
+	for _, cometService := range cometServices {
+		nodes = append(nodes, cometService.Nodes...)
+	}
 
-		comets := make(map[string]*Comet)
-		for _, node := range nodes {
-			if !strings.HasPrefix(node.Id, cometServiceName) {
-				continue
-			}
+	comets := make(map[string]*Comet)
+	for _, node := range nodes {
+		if !strings.HasPrefix(node.Id, cometServiceName) {
+			continue
+		}
 
-			id := strings.TrimPrefix(node.Id, cometServiceName+"-")
-			if old, ok := s.cometServers[id]; ok {
-				comets[id] = old
-				continue
-			}
+		id := strings.TrimPrefix(node.Id, cometServiceName+"-")
+		if old, ok := s.cometServers[id]; ok {
+			comets[id] = old
+			continue
+		}
 
-			c, err := NewComet(id, node.Address)
-			if err != nil {
-				s.log.Error("[syncCometNodes] can not new comet", "error", err)
-				return err
-			}
+		c, err := NewComet(id, node.Address, s.dispatchOptions(), s.publishDeadLetter)
+		if err != nil {
+			s.log.Error("[syncCometNodes] can not new comet", "error", err)
+			return err
+		}
 
-			comets[id] = c
+		comets[id] = c
 
-			s.log.Info("[syncCometNodes] new comet", "id", id, "address", node.Address)
-		}
+		s.log.Info("[syncCometNodes] new comet", "id", id, "address", node.Address)
+	}
 
-		for id, old := range s.cometServers {
-			if _, ok := comets[id]; !ok {
-				old.cancel()
-				s.log.Info("[syncCometNodes] delete comet", "id", id)
-			}
+	for id, old := range s.cometServers {
+		if _, ok := comets[id]; !ok {
+			old.cancel()
+			s.log.Info("[syncCometNodes] delete comet", "id", id)
 		}
-
-		s.cometServers = comets
 	}
+
+	s.cometServers = comets
 	return nil
 }
@@ -1,14 +1,18 @@
 package service
 
 import (
+	"context"
 	"github.com/micro/go-micro/v2/broker"
 	cApi "mercury/app/comet/api"
 	"mercury/app/logic/api"
 	"mercury/x/ecode"
+	"mercury/x/tracing"
 )
 
 func (s *Service) subscribePushMessage(e broker.Event) error {
-	s.log.Info("subscribe", "topic", e.Topic())
+	ctx, span := tracing.StartConsumer(e.Topic(), e.Message())
+	defer span.End()
+	tracing.Logger(ctx, s.receiveLog).Info("subscribe", "topic", e.Topic())
 
 	if e.Message() == nil {
 		return ecode.NewError("message can not be nil")
@@ -18,7 +22,47 @@ func (s *Service) subscribePushMessage(e broker.Event) error {
 	if err := pm.Unmarshal(e.Message().Body); err != nil {
 		return err
 	}
-	if err := s.pushMessage(pm.Operation, pm.ServerID, pm.SIDs, pm.Data); err != nil {
+	if err := s.pushMessage(ctx, pm); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) subscribePushUIDMessage(e broker.Event) error {
+	ctx, span := tracing.StartConsumer(e.Topic(), e.Message())
+	defer span.End()
+	tracing.Logger(ctx, s.receiveLog).Info("subscribe", "topic", e.Topic())
+
+	if e.Message() == nil {
+		return ecode.NewError("message can not be nil")
+	}
+
+	pm := new(api.PushUIDMessage)
+	if err := pm.Unmarshal(e.Message().Body); err != nil {
+		return err
+	}
+	if err := s.pushUIDMessage(ctx, pm); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) subscribePushTopicMessage(e broker.Event) error {
+	ctx, span := tracing.StartConsumer(e.Topic(), e.Message())
+	defer span.End()
+	tracing.Logger(ctx, s.receiveLog).Info("subscribe", "topic", e.Topic())
+
+	if e.Message() == nil {
+		return ecode.NewError("message can not be nil")
+	}
+
+	pm := new(api.PushTopicMessage)
+	if err := pm.Unmarshal(e.Message().Body); err != nil {
+		return err
+	}
+	if err := s.pushTopicMessage(ctx, pm); err != nil {
 		return err
 	}
 
@@ -26,7 +70,9 @@ func (s *Service) subscribePushMessage(e broker.Event) error {
 }
 
 func (s *Service) subscribeBroadcastMessage(e broker.Event) error {
-	s.log.Info("subscribe", "topic", e.Topic())
+	ctx, span := tracing.StartConsumer(e.Topic(), e.Message())
+	defer span.End()
+	tracing.Logger(ctx, s.receiveLog).Info("subscribe", "topic", e.Topic())
 
 	if e.Message() == nil {
 		return ecode.NewError("message can not be nil")
@@ -36,41 +82,150 @@ func (s *Service) subscribeBroadcastMessage(e broker.Event) error {
 	if err := bm.Unmarshal(e.Message().Body); err != nil {
 		return err
 	}
-	if err := s.broadcastMessage(bm.Servers, bm.Data); err != nil {
+	if err := s.broadcastMessage(bm); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) subscribeBroadcastRoomMessage(e broker.Event) error {
+	ctx, span := tracing.StartConsumer(e.Topic(), e.Message())
+	defer span.End()
+	tracing.Logger(ctx, s.receiveLog).Info("subscribe", "topic", e.Topic())
+
+	if e.Message() == nil {
+		return ecode.NewError("message can not be nil")
+	}
+
+	bm := new(api.BroadcastRoomMessage)
+	if err := bm.Unmarshal(e.Message().Body); err != nil {
+		return err
+	}
+	if err := s.broadcastRoomMessage(bm.Room, bm.Data, bm.SampleRate); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (s *Service) pushMessage(op int32, serverID string, sids []string, data []byte) error {
-	if comet, ok := s.cometServers[serverID]; ok {
-		comet.Push(&cApi.PushMessageReq{
-			Operation: op,
-			SIDs:      sids,
-			Data:      data,
-		})
+func (s *Service) pushMessage(ctx context.Context, pm *api.PushMessage) error {
+	if comet, ok := s.comet(pm.ServerID); ok {
+		req := &cApi.PushMessageReq{
+			Operation: pm.Operation,
+			SIDs:      pm.SIDs,
+			Data:      pm.Data,
+			Topic:     pm.Topic,
+			Sequence:  pm.Sequence,
+			PushedAt:  pm.PushedAt,
+			Trace:     make(map[string]string),
+		}
+		tracing.Inject(ctx, req.Trace)
+		// Pushes which can not be queued are dead-lettered, do not have the broker redeliver them.
+		if err := comet.Push(req); err != nil {
+			tracing.Logger(ctx, s.log).Warn("[pushMessage] failed to dispatch", "serverID", pm.ServerID, "error", err)
+		}
+	}
+	return nil
+}
+
+// pushUIDMessage sends the message to every comet, each of them delivers it to the local
+// sessions of the users.
+func (s *Service) pushUIDMessage(ctx context.Context, pm *api.PushUIDMessage) error {
+	req := &cApi.PushUIDMessageReq{
+		Operation: pm.Operation,
+		UIDs:      pm.UIDs,
+		SkipSID:   pm.SkipSID,
+		Data:      pm.Data,
+		Topic:     pm.Topic,
+		Sequence:  pm.Sequence,
+		PushedAt:  pm.PushedAt,
+		Trace:     make(map[string]string),
+	}
+	tracing.Inject(ctx, req.Trace)
+	for _, comet := range s.comets() {
+		if err := comet.PushUID(req); err != nil {
+			tracing.Logger(ctx, s.log).Warn("[pushUIDMessage] failed to dispatch", "serverID", comet.serverID, "error", err)
+		}
+	}
+	return nil
+}
+
+// pushTopicMessage sends the message to every comet, each of them delivers it to its local
+// sessions in the topic.
+func (s *Service) pushTopicMessage(ctx context.Context, pm *api.PushTopicMessage) error {
+	req := &cApi.PushTopicMessageReq{
+		Operation: pm.Operation,
+		ClientID:  pm.ClientID,
+		Topic:     pm.Topic,
+		SkipSID:   pm.SkipSID,
+		Data:      pm.Data,
+		Sequence:  pm.Sequence,
+		PushedAt:  pm.PushedAt,
+		Trace:     make(map[string]string),
+		JoinUIDs:  pm.JoinUIDs,
+	}
+	tracing.Inject(ctx, req.Trace)
+	for _, comet := range s.comets() {
+		if err := comet.PushTopic(req); err != nil {
+			tracing.Logger(ctx, s.log).Warn("[pushTopicMessage] failed to dispatch", "serverID", comet.serverID, "error", err)
+		}
 	}
 	return nil
 }
 
-func (s *Service) broadcastMessage(servers map[string]*api.StringSliceValue, data []byte) error {
-	if len(servers) > 0 {
-		for serverID, value := range servers {
-			if comet, ok := s.cometServers[serverID]; ok {
+func (s *Service) broadcastMessage(bm *api.BroadcastMessage) error {
+	if len(bm.Servers) > 0 {
+		for serverID, value := range bm.Servers {
+			if comet, ok := s.comet(serverID); ok {
 				if value != nil {
-					go comet.Push(&cApi.PushMessageReq{
+					if err := comet.Push(&cApi.PushMessageReq{
 						SIDs: value.Value,
-						Data: data,
-					})
+						Data: bm.Data,
+					}); err != nil {
+						s.log.Warn("[broadcastMessage] failed to dispatch", "serverID", serverID, "error", err)
+					}
 				}
 			}
 		}
-	} else {
-		for _, comet := range s.cometServers {
-			go comet.Broadcast(&cApi.BroadcastMessageReq{
-				Data: data,
-			})
+		return nil
+	}
+
+	req := &cApi.BroadcastMessageReq{
+		Data:       bm.Data,
+		ClientID:   bm.ClientID,
+		Platforms:  bm.Platforms,
+		MinVersion: bm.MinVersion,
+		UIDs:       bm.UIDs,
+	}
+	comets := s.comets()
+	if len(bm.ServerIDs) > 0 {
+		comets = comets[:0]
+		for _, serverID := range bm.ServerIDs {
+			if comet, ok := s.comet(serverID); ok {
+				comets = append(comets, comet)
+			}
+		}
+	}
+	for _, comet := range comets {
+		if err := comet.Broadcast(req); err != nil {
+			s.log.Warn("[broadcastMessage] failed to dispatch", "serverID", comet.serverID, "error", err)
+		}
+	}
+	return nil
+}
+
+// broadcastRoomMessage sends the message to every comet, each of them delivers it to its
+// local sessions in the room.
+func (s *Service) broadcastRoomMessage(room string, data []byte, sampleRate float64) error {
+	req := &cApi.BroadcastRoomReq{
+		Room:       room,
+		Data:       data,
+		SampleRate: sampleRate,
+	}
+	for _, comet := range s.comets() {
+		if err := comet.BroadcastRoom(req); err != nil {
+			s.log.Warn("[broadcastRoomMessage] failed to dispatch", "serverID", comet.serverID, "error", err)
 		}
 	}
 	return nil
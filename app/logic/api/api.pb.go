@@ -4,6 +4,7 @@
 package api
 
 import (
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/golang/protobuf/proto"
@@ -328,14 +329,17 @@ func (m *StringSliceValue) XXX_DiscardUnknown() {
 var xxx_messageInfo_StringSliceValue proto.InternalMessageInfo
 
 type Client struct {
-	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CreatedAt            int64    `protobuf:"varint,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt            int64    `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Name                 string   `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
-	TokenSecret          []byte   `protobuf:"bytes,5,opt,name=token_secret,json=tokenSecret,proto3" json:"token_secret,omitempty"`
-	TokenExpire          int64    `protobuf:"varint,6,opt,name=token_expire,json=tokenExpire,proto3" json:"token_expire,omitempty"`
-	UserCount            int64    `protobuf:"varint,7,opt,name=user_count,json=userCount,proto3" json:"user_count,omitempty"`
-	GroupCount           int64    `protobuf:"varint,8,opt,name=group_count,json=groupCount,proto3" json:"group_count,omitempty"`
+	ID          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt   int64  `protobuf:"varint,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   int64  `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Name        string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	TokenSecret []byte `protobuf:"bytes,5,opt,name=token_secret,json=tokenSecret,proto3" json:"token_secret,omitempty"`
+	TokenExpire int64  `protobuf:"varint,6,opt,name=token_expire,json=tokenExpire,proto3" json:"token_expire,omitempty"`
+	UserCount   int64  `protobuf:"varint,7,opt,name=user_count,json=userCount,proto3" json:"user_count,omitempty"`
+	GroupCount  int64  `protobuf:"varint,8,opt,name=group_count,json=groupCount,proto3" json:"group_count,omitempty"`
+	// Retention of the messages, the max age in seconds and the max count per topic, 0 keeps them
+	MessageMaxAge        int64    `protobuf:"varint,9,opt,name=message_max_age,json=messageMaxAge,proto3" json:"message_max_age,omitempty"`
+	MessageMaxCount      int64    `protobuf:"varint,10,opt,name=message_max_count,json=messageMaxCount,proto3" json:"message_max_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -511,10 +515,15 @@ func (m *Message) XXX_DiscardUnknown() {
 var xxx_messageInfo_Message proto.InternalMessageInfo
 
 type PushMessage struct {
-	Operation            int32    `protobuf:"varint,1,opt,name=operation,proto3" json:"operation,omitempty"`
-	ServerID             string   `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
-	SIDs                 []string `protobuf:"bytes,3,rep,name=sids,proto3" json:"sids,omitempty"`
-	Data                 []byte   `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	Operation int32    `protobuf:"varint,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	ServerID  string   `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	SIDs      []string `protobuf:"bytes,3,rep,name=sids,proto3" json:"sids,omitempty"`
+	Data      []byte   `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// Topic and sequence of the message, messages of the same topic are delivered in sequence order
+	Topic    string `protobuf:"bytes,5,opt,name=topic,proto3" json:"topic,omitempty"`
+	Sequence int64  `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Unix time in milliseconds the message was pushed to logic, for the push latency
+	PushedAt             int64    `protobuf:"varint,7,opt,name=pushed_at,json=pushedAt,proto3" json:"pushed_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -553,19 +562,125 @@ func (m *PushMessage) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PushMessage proto.InternalMessageInfo
 
+// PushUIDMessage is published once for all recipients, every comet delivers it to the
+// local sessions of the users. It carries the notifications of a few users in the topic
+// push mode.
+type PushUIDMessage struct {
+	Operation            int32    `protobuf:"varint,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	UIDs                 []string `protobuf:"bytes,2,rep,name=uids,proto3" json:"uids,omitempty"`
+	SkipSID              string   `protobuf:"bytes,3,opt,name=skip_sid,json=skipSid,proto3" json:"skip_sid,omitempty"`
+	Data                 []byte   `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	Topic                string   `protobuf:"bytes,5,opt,name=topic,proto3" json:"topic,omitempty"`
+	Sequence             int64    `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	PushedAt             int64    `protobuf:"varint,7,opt,name=pushed_at,json=pushedAt,proto3" json:"pushed_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PushUIDMessage) Reset()         { *m = PushUIDMessage{} }
+func (m *PushUIDMessage) String() string { return proto.CompactTextString(m) }
+func (*PushUIDMessage) ProtoMessage()    {}
+func (*PushUIDMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+}
+func (m *PushUIDMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PushUIDMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PushUIDMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PushUIDMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushUIDMessage.Merge(m, src)
+}
+func (m *PushUIDMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *PushUIDMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushUIDMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PushUIDMessage proto.InternalMessageInfo
+
+// PushTopicMessage is published once for a topic, every comet delivers it to its local
+// sessions in the topic.
+type PushTopicMessage struct {
+	Operation int32  `protobuf:"varint,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	ClientID  string `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Topic     string `protobuf:"bytes,3,opt,name=topic,proto3" json:"topic,omitempty"`
+	SkipSID   string `protobuf:"bytes,4,opt,name=skip_sid,json=skipSid,proto3" json:"skip_sid,omitempty"`
+	Data      []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	Sequence  int64  `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	PushedAt  int64  `protobuf:"varint,7,opt,name=pushed_at,json=pushedAt,proto3" json:"pushed_at,omitempty"`
+	// Users joining the group topic, their sessions join it before the message is delivered.
+	// A message without data only joins them.
+	JoinUIDs             []string `protobuf:"bytes,8,rep,name=join_uids,json=joinUids,proto3" json:"join_uids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PushTopicMessage) Reset()         { *m = PushTopicMessage{} }
+func (m *PushTopicMessage) String() string { return proto.CompactTextString(m) }
+func (*PushTopicMessage) ProtoMessage()    {}
+func (*PushTopicMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
+}
+func (m *PushTopicMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PushTopicMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PushTopicMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PushTopicMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushTopicMessage.Merge(m, src)
+}
+func (m *PushTopicMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *PushTopicMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushTopicMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PushTopicMessage proto.InternalMessageInfo
+
 type BroadcastMessage struct {
-	Servers              map[string]*StringSliceValue `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Data                 []byte                       `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
-	XXX_unrecognized     []byte                       `json:"-"`
-	XXX_sizecache        int32                        `json:"-"`
+	Servers    map[string]*StringSliceValue `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Data       []byte                       `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	ClientID   string                       `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Platforms  []string                     `protobuf:"bytes,4,rep,name=platforms,proto3" json:"platforms,omitempty"`
+	MinVersion string                       `protobuf:"bytes,5,opt,name=min_version,json=minVersion,proto3" json:"min_version,omitempty"`
+	UIDs       []string                     `protobuf:"bytes,6,rep,name=uids,proto3" json:"uids,omitempty"`
+	// Only comets with these server IDs if not empty
+	ServerIDs            []string `protobuf:"bytes,7,rep,name=server_ids,json=serverIds,proto3" json:"server_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *BroadcastMessage) Reset()         { *m = BroadcastMessage{} }
 func (m *BroadcastMessage) String() string { return proto.CompactTextString(m) }
 func (*BroadcastMessage) ProtoMessage()    {}
 func (*BroadcastMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
 }
 func (m *BroadcastMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -594,6 +709,141 @@ func (m *BroadcastMessage) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_BroadcastMessage proto.InternalMessageInfo
 
+type BroadcastRoomMessage struct {
+	Room                 string   `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	SampleRate           float64  `protobuf:"fixed64,3,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BroadcastRoomMessage) Reset()         { *m = BroadcastRoomMessage{} }
+func (m *BroadcastRoomMessage) String() string { return proto.CompactTextString(m) }
+func (*BroadcastRoomMessage) ProtoMessage()    {}
+func (*BroadcastRoomMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
+}
+func (m *BroadcastRoomMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BroadcastRoomMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BroadcastRoomMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BroadcastRoomMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BroadcastRoomMessage.Merge(m, src)
+}
+func (m *BroadcastRoomMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *BroadcastRoomMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_BroadcastRoomMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BroadcastRoomMessage proto.InternalMessageInfo
+
+// InvalidateLocalMessage is published by the logic which changed the members of a group or
+// the sessions of users, every logic drops them from its local cache.
+type InvalidateLocalMessage struct {
+	ClientID string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// The members of the group if not 0
+	GroupID int64 `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// The sessions of the users
+	UIDs                 []string `protobuf:"bytes,3,rep,name=uids,proto3" json:"uids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InvalidateLocalMessage) Reset()         { *m = InvalidateLocalMessage{} }
+func (m *InvalidateLocalMessage) String() string { return proto.CompactTextString(m) }
+func (*InvalidateLocalMessage) ProtoMessage()    {}
+func (*InvalidateLocalMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
+}
+func (m *InvalidateLocalMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InvalidateLocalMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InvalidateLocalMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InvalidateLocalMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvalidateLocalMessage.Merge(m, src)
+}
+func (m *InvalidateLocalMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *InvalidateLocalMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvalidateLocalMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvalidateLocalMessage proto.InternalMessageInfo
+
+// DeadLetter is a request job failed to deliver to a comet.
+type DeadLetter struct {
+	ServerID string `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	// Endpoint of the comet the request was sent to, e.g. Chat.PushMessages
+	Endpoint string `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// The marshaled request
+	Request              []byte   `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeadLetter) Reset()         { *m = DeadLetter{} }
+func (m *DeadLetter) String() string { return proto.CompactTextString(m) }
+func (*DeadLetter) ProtoMessage()    {}
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
+}
+func (m *DeadLetter) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeadLetter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeadLetter.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DeadLetter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetter.Merge(m, src)
+}
+func (m *DeadLetter) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeadLetter) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetter proto.InternalMessageInfo
+
 // ---------------------------------------- Service Request ----------------------------------------
 type GetClientReq struct {
 	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
@@ -606,7 +856,7 @@ func (m *GetClientReq) Reset()         { *m = GetClientReq{} }
 func (m *GetClientReq) String() string { return proto.CompactTextString(m) }
 func (*GetClientReq) ProtoMessage()    {}
 func (*GetClientReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
 }
 func (m *GetClientReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -639,6 +889,8 @@ type CreateClientReq struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	TokenSecret          string   `protobuf:"bytes,2,opt,name=token_secret,json=tokenSecret,proto3" json:"token_secret,omitempty"`
 	TokenExpire          int64    `protobuf:"varint,3,opt,name=token_expire,json=tokenExpire,proto3" json:"token_expire,omitempty"`
+	MessageMaxAge        int64    `protobuf:"varint,4,opt,name=message_max_age,json=messageMaxAge,proto3" json:"message_max_age,omitempty"`
+	MessageMaxCount      int64    `protobuf:"varint,5,opt,name=message_max_count,json=messageMaxCount,proto3" json:"message_max_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -648,7 +900,7 @@ func (m *CreateClientReq) Reset()         { *m = CreateClientReq{} }
 func (m *CreateClientReq) String() string { return proto.CompactTextString(m) }
 func (*CreateClientReq) ProtoMessage()    {}
 func (*CreateClientReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
 }
 func (m *CreateClientReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -682,6 +934,8 @@ type UpdateClientReq struct {
 	Name                 *StringValue `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	TokenSecret          *StringValue `protobuf:"bytes,3,opt,name=token_secret,json=tokenSecret,proto3" json:"token_secret,omitempty"`
 	TokenExpire          *Int64Value  `protobuf:"bytes,4,opt,name=token_expire,json=tokenExpire,proto3" json:"token_expire,omitempty"`
+	MessageMaxAge        *Int64Value  `protobuf:"bytes,5,opt,name=message_max_age,json=messageMaxAge,proto3" json:"message_max_age,omitempty"`
+	MessageMaxCount      *Int64Value  `protobuf:"bytes,6,opt,name=message_max_count,json=messageMaxCount,proto3" json:"message_max_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -691,7 +945,7 @@ func (m *UpdateClientReq) Reset()         { *m = UpdateClientReq{} }
 func (m *UpdateClientReq) String() string { return proto.CompactTextString(m) }
 func (*UpdateClientReq) ProtoMessage()    {}
 func (*UpdateClientReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
 }
 func (m *UpdateClientReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -731,7 +985,7 @@ func (m *DeleteClientReq) Reset()         { *m = DeleteClientReq{} }
 func (m *DeleteClientReq) String() string { return proto.CompactTextString(m) }
 func (*DeleteClientReq) ProtoMessage()    {}
 func (*DeleteClientReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
 }
 func (m *DeleteClientReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -772,7 +1026,7 @@ func (m *GenerateTokenReq) Reset()         { *m = GenerateTokenReq{} }
 func (m *GenerateTokenReq) String() string { return proto.CompactTextString(m) }
 func (*GenerateTokenReq) ProtoMessage()    {}
 func (*GenerateTokenReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
 }
 func (m *GenerateTokenReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -813,7 +1067,7 @@ func (m *CreateUserReq) Reset()         { *m = CreateUserReq{} }
 func (m *CreateUserReq) String() string { return proto.CompactTextString(m) }
 func (*CreateUserReq) ProtoMessage()    {}
 func (*CreateUserReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
 }
 func (m *CreateUserReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -855,7 +1109,7 @@ func (m *UpdateActivatedReq) Reset()         { *m = UpdateActivatedReq{} }
 func (m *UpdateActivatedReq) String() string { return proto.CompactTextString(m) }
 func (*UpdateActivatedReq) ProtoMessage()    {}
 func (*UpdateActivatedReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
 }
 func (m *UpdateActivatedReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -896,7 +1150,7 @@ func (m *DeleteUserReq) Reset()         { *m = DeleteUserReq{} }
 func (m *DeleteUserReq) String() string { return proto.CompactTextString(m) }
 func (*DeleteUserReq) ProtoMessage()    {}
 func (*DeleteUserReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
 }
 func (m *DeleteUserReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -937,7 +1191,7 @@ func (m *GenerateUserTokenReq) Reset()         { *m = GenerateUserTokenReq{} }
 func (m *GenerateUserTokenReq) String() string { return proto.CompactTextString(m) }
 func (*GenerateUserTokenReq) ProtoMessage()    {}
 func (*GenerateUserTokenReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
 }
 func (m *GenerateUserTokenReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -979,7 +1233,7 @@ func (m *AddFriendReq) Reset()         { *m = AddFriendReq{} }
 func (m *AddFriendReq) String() string { return proto.CompactTextString(m) }
 func (*AddFriendReq) ProtoMessage()    {}
 func (*AddFriendReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
 }
 func (m *AddFriendReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1020,7 +1274,7 @@ func (m *GetFriendsReq) Reset()         { *m = GetFriendsReq{} }
 func (m *GetFriendsReq) String() string { return proto.CompactTextString(m) }
 func (*GetFriendsReq) ProtoMessage()    {}
 func (*GetFriendsReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
 }
 func (m *GetFriendsReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1062,7 +1316,7 @@ func (m *DeleteFriendReq) Reset()         { *m = DeleteFriendReq{} }
 func (m *DeleteFriendReq) String() string { return proto.CompactTextString(m) }
 func (*DeleteFriendReq) ProtoMessage()    {}
 func (*DeleteFriendReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
 }
 func (m *DeleteFriendReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1105,7 +1359,7 @@ func (m *CreateGroupReq) Reset()         { *m = CreateGroupReq{} }
 func (m *CreateGroupReq) String() string { return proto.CompactTextString(m) }
 func (*CreateGroupReq) ProtoMessage()    {}
 func (*CreateGroupReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
 }
 func (m *CreateGroupReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1146,7 +1400,7 @@ func (m *GetGroupsReq) Reset()         { *m = GetGroupsReq{} }
 func (m *GetGroupsReq) String() string { return proto.CompactTextString(m) }
 func (*GetGroupsReq) ProtoMessage()    {}
 func (*GetGroupsReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{30}
 }
 func (m *GetGroupsReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1188,7 +1442,7 @@ func (m *AddMemberReq) Reset()         { *m = AddMemberReq{} }
 func (m *AddMemberReq) String() string { return proto.CompactTextString(m) }
 func (*AddMemberReq) ProtoMessage()    {}
 func (*AddMemberReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{31}
 }
 func (m *AddMemberReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1229,7 +1483,7 @@ func (m *GetMembersReq) Reset()         { *m = GetMembersReq{} }
 func (m *GetMembersReq) String() string { return proto.CompactTextString(m) }
 func (*GetMembersReq) ProtoMessage()    {}
 func (*GetMembersReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{32}
 }
 func (m *GetMembersReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1269,7 +1523,7 @@ func (m *ListenReq) Reset()         { *m = ListenReq{} }
 func (m *ListenReq) String() string { return proto.CompactTextString(m) }
 func (*ListenReq) ProtoMessage()    {}
 func (*ListenReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{33}
 }
 func (m *ListenReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1311,7 +1565,7 @@ func (m *ConnectReq) Reset()         { *m = ConnectReq{} }
 func (m *ConnectReq) String() string { return proto.CompactTextString(m) }
 func (*ConnectReq) ProtoMessage()    {}
 func (*ConnectReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{34}
 }
 func (m *ConnectReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1343,6 +1597,7 @@ var xxx_messageInfo_ConnectReq proto.InternalMessageInfo
 type DisconnectReq struct {
 	UID                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
 	SID                  string   `protobuf:"bytes,2,opt,name=sid,proto3" json:"sid,omitempty"`
+	ClientID             string   `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1352,7 +1607,7 @@ func (m *DisconnectReq) Reset()         { *m = DisconnectReq{} }
 func (m *DisconnectReq) String() string { return proto.CompactTextString(m) }
 func (*DisconnectReq) ProtoMessage()    {}
 func (*DisconnectReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{30}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{35}
 }
 func (m *DisconnectReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1385,6 +1640,7 @@ type HeartbeatReq struct {
 	UID                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
 	SID                  string   `protobuf:"bytes,2,opt,name=sid,proto3" json:"sid,omitempty"`
 	ServerID             string   `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	ClientID             string   `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1394,7 +1650,7 @@ func (m *HeartbeatReq) Reset()         { *m = HeartbeatReq{} }
 func (m *HeartbeatReq) String() string { return proto.CompactTextString(m) }
 func (*HeartbeatReq) ProtoMessage()    {}
 func (*HeartbeatReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{31}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{36}
 }
 func (m *HeartbeatReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1425,6 +1681,7 @@ var xxx_messageInfo_HeartbeatReq proto.InternalMessageInfo
 
 type PullMessageReq struct {
 	UID                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	ClientID             string   `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1434,7 +1691,7 @@ func (m *PullMessageReq) Reset()         { *m = PullMessageReq{} }
 func (m *PullMessageReq) String() string { return proto.CompactTextString(m) }
 func (*PullMessageReq) ProtoMessage()    {}
 func (*PullMessageReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{32}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{37}
 }
 func (m *PullMessageReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1463,6 +1720,56 @@ func (m *PullMessageReq) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PullMessageReq proto.InternalMessageInfo
 
+type SearchMessagesReq struct {
+	ClientID string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	UID      string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Query    string `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	// Restricts the search to the topic and the sender if they are set
+	Topic  string `protobuf:"bytes,4,opt,name=topic,proto3" json:"topic,omitempty"`
+	Sender string `protobuf:"bytes,5,opt,name=sender,proto3" json:"sender,omitempty"`
+	// Range of the creation time, 0 does not bound it
+	StartAt              int64         `protobuf:"varint,6,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	EndAt                int64         `protobuf:"varint,7,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
+	ContentTypes         []ContentType `protobuf:"varint,8,rep,packed,name=content_types,json=contentTypes,proto3,enum=chat.logic.service.ContentType" json:"content_types,omitempty"`
+	Limit                int32         `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *SearchMessagesReq) Reset()         { *m = SearchMessagesReq{} }
+func (m *SearchMessagesReq) String() string { return proto.CompactTextString(m) }
+func (*SearchMessagesReq) ProtoMessage()    {}
+func (*SearchMessagesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{38}
+}
+func (m *SearchMessagesReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SearchMessagesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SearchMessagesReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SearchMessagesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchMessagesReq.Merge(m, src)
+}
+func (m *SearchMessagesReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *SearchMessagesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchMessagesReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchMessagesReq proto.InternalMessageInfo
+
 type PushMessageReq struct {
 	ClientID             string      `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
 	SID                  string      `protobuf:"bytes,2,opt,name=sid,proto3" json:"sid,omitempty"`
@@ -1481,7 +1788,7 @@ func (m *PushMessageReq) Reset()         { *m = PushMessageReq{} }
 func (m *PushMessageReq) String() string { return proto.CompactTextString(m) }
 func (*PushMessageReq) ProtoMessage()    {}
 func (*PushMessageReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{33}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{39}
 }
 func (m *PushMessageReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1514,6 +1821,7 @@ type ReadMessageReq struct {
 	UID                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
 	Topic                string   `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
 	Sequence             int64    `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	ClientID             string   `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1523,7 +1831,7 @@ func (m *ReadMessageReq) Reset()         { *m = ReadMessageReq{} }
 func (m *ReadMessageReq) String() string { return proto.CompactTextString(m) }
 func (*ReadMessageReq) ProtoMessage()    {}
 func (*ReadMessageReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{34}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{40}
 }
 func (m *ReadMessageReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1552,26 +1860,28 @@ func (m *ReadMessageReq) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ReadMessageReq proto.InternalMessageInfo
 
-type KeypressReq struct {
-	UID                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Topic                string   `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+type PushRoomMessageReq struct {
+	ClientID             string   `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Sender               string   `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	Room                 string   `protobuf:"bytes,3,opt,name=room,proto3" json:"room,omitempty"`
+	Body                 []byte   `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KeypressReq) Reset()         { *m = KeypressReq{} }
-func (m *KeypressReq) String() string { return proto.CompactTextString(m) }
-func (*KeypressReq) ProtoMessage()    {}
-func (*KeypressReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{35}
+func (m *PushRoomMessageReq) Reset()         { *m = PushRoomMessageReq{} }
+func (m *PushRoomMessageReq) String() string { return proto.CompactTextString(m) }
+func (*PushRoomMessageReq) ProtoMessage()    {}
+func (*PushRoomMessageReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{41}
 }
-func (m *KeypressReq) XXX_Unmarshal(b []byte) error {
+func (m *PushRoomMessageReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KeypressReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PushRoomMessageReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KeypressReq.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PushRoomMessageReq.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1581,37 +1891,46 @@ func (m *KeypressReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *KeypressReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KeypressReq.Merge(m, src)
+func (m *PushRoomMessageReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushRoomMessageReq.Merge(m, src)
 }
-func (m *KeypressReq) XXX_Size() int {
+func (m *PushRoomMessageReq) XXX_Size() int {
 	return m.Size()
 }
-func (m *KeypressReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_KeypressReq.DiscardUnknown(m)
+func (m *PushRoomMessageReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushRoomMessageReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KeypressReq proto.InternalMessageInfo
+var xxx_messageInfo_PushRoomMessageReq proto.InternalMessageInfo
 
-type GetClientResp struct {
-	Client               *Client  `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+type BroadcastReq struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Body  []byte `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	// Only sessions on one of the platforms if not empty, e.g. web, ios, android
+	Platforms []string `protobuf:"bytes,3,rep,name=platforms,proto3" json:"platforms,omitempty"`
+	// Only sessions with at least this protocol version if not empty
+	MinVersion string `protobuf:"bytes,4,opt,name=min_version,json=minVersion,proto3" json:"min_version,omitempty"`
+	// Only sessions of the users if not empty
+	UIDs []string `protobuf:"bytes,5,rep,name=uids,proto3" json:"uids,omitempty"`
+	// Only sessions on the comets with these server IDs if not empty
+	ServerIDs            []string `protobuf:"bytes,6,rep,name=server_ids,json=serverIds,proto3" json:"server_ids,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetClientResp) Reset()         { *m = GetClientResp{} }
-func (m *GetClientResp) String() string { return proto.CompactTextString(m) }
-func (*GetClientResp) ProtoMessage()    {}
-func (*GetClientResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{36}
+func (m *BroadcastReq) Reset()         { *m = BroadcastReq{} }
+func (m *BroadcastReq) String() string { return proto.CompactTextString(m) }
+func (*BroadcastReq) ProtoMessage()    {}
+func (*BroadcastReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{42}
 }
-func (m *GetClientResp) XXX_Unmarshal(b []byte) error {
+func (m *BroadcastReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetClientResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *BroadcastReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetClientResp.Marshal(b, m, deterministic)
+		return xxx_messageInfo_BroadcastReq.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1621,38 +1940,40 @@ func (m *GetClientResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *GetClientResp) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetClientResp.Merge(m, src)
+func (m *BroadcastReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BroadcastReq.Merge(m, src)
 }
-func (m *GetClientResp) XXX_Size() int {
+func (m *BroadcastReq) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetClientResp) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetClientResp.DiscardUnknown(m)
+func (m *BroadcastReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_BroadcastReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetClientResp proto.InternalMessageInfo
+var xxx_messageInfo_BroadcastReq proto.InternalMessageInfo
 
-type CreateClientResp struct {
-	ClientID             string   `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	ClientSecret         string   `protobuf:"bytes,2,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+type BroadcastRoomReq struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Room                 string   `protobuf:"bytes,2,opt,name=room,proto3" json:"room,omitempty"`
+	Body                 []byte   `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	SampleRate           float64  `protobuf:"fixed64,4,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateClientResp) Reset()         { *m = CreateClientResp{} }
-func (m *CreateClientResp) String() string { return proto.CompactTextString(m) }
-func (*CreateClientResp) ProtoMessage()    {}
-func (*CreateClientResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{37}
+func (m *BroadcastRoomReq) Reset()         { *m = BroadcastRoomReq{} }
+func (m *BroadcastRoomReq) String() string { return proto.CompactTextString(m) }
+func (*BroadcastRoomReq) ProtoMessage()    {}
+func (*BroadcastRoomReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{43}
 }
-func (m *CreateClientResp) XXX_Unmarshal(b []byte) error {
+func (m *BroadcastRoomReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CreateClientResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *BroadcastRoomReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CreateClientResp.Marshal(b, m, deterministic)
+		return xxx_messageInfo_BroadcastRoomReq.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1662,21 +1983,144 @@ func (m *CreateClientResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *CreateClientResp) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateClientResp.Merge(m, src)
+func (m *BroadcastRoomReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BroadcastRoomReq.Merge(m, src)
 }
-func (m *CreateClientResp) XXX_Size() int {
+func (m *BroadcastRoomReq) XXX_Size() int {
 	return m.Size()
 }
-func (m *CreateClientResp) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateClientResp.DiscardUnknown(m)
+func (m *BroadcastRoomReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_BroadcastRoomReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateClientResp proto.InternalMessageInfo
+var xxx_messageInfo_BroadcastRoomReq proto.InternalMessageInfo
 
-type TokenResp struct {
-	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Lifetime             string   `protobuf:"bytes,2,opt,name=lifetime,proto3" json:"lifetime,omitempty"`
+type KeypressReq struct {
+	UID                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Topic                string   `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	ClientID             string   `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeypressReq) Reset()         { *m = KeypressReq{} }
+func (m *KeypressReq) String() string { return proto.CompactTextString(m) }
+func (*KeypressReq) ProtoMessage()    {}
+func (*KeypressReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{44}
+}
+func (m *KeypressReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeypressReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KeypressReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KeypressReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeypressReq.Merge(m, src)
+}
+func (m *KeypressReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeypressReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeypressReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeypressReq proto.InternalMessageInfo
+
+type GetClientResp struct {
+	Client               *Client  `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClientResp) Reset()         { *m = GetClientResp{} }
+func (m *GetClientResp) String() string { return proto.CompactTextString(m) }
+func (*GetClientResp) ProtoMessage()    {}
+func (*GetClientResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{45}
+}
+func (m *GetClientResp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetClientResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetClientResp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetClientResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientResp.Merge(m, src)
+}
+func (m *GetClientResp) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetClientResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientResp proto.InternalMessageInfo
+
+type CreateClientResp struct {
+	ClientID             string   `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret         string   `protobuf:"bytes,2,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateClientResp) Reset()         { *m = CreateClientResp{} }
+func (m *CreateClientResp) String() string { return proto.CompactTextString(m) }
+func (*CreateClientResp) ProtoMessage()    {}
+func (*CreateClientResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{46}
+}
+func (m *CreateClientResp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateClientResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateClientResp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CreateClientResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateClientResp.Merge(m, src)
+}
+func (m *CreateClientResp) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateClientResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateClientResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateClientResp proto.InternalMessageInfo
+
+type TokenResp struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Lifetime             string   `protobuf:"bytes,2,opt,name=lifetime,proto3" json:"lifetime,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1686,7 +2130,7 @@ func (m *TokenResp) Reset()         { *m = TokenResp{} }
 func (m *TokenResp) String() string { return proto.CompactTextString(m) }
 func (*TokenResp) ProtoMessage()    {}
 func (*TokenResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{38}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{47}
 }
 func (m *TokenResp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1726,7 +2170,7 @@ func (m *CreateUserResp) Reset()         { *m = CreateUserResp{} }
 func (m *CreateUserResp) String() string { return proto.CompactTextString(m) }
 func (*CreateUserResp) ProtoMessage()    {}
 func (*CreateUserResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{39}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{48}
 }
 func (m *CreateUserResp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1766,7 +2210,7 @@ func (m *GetFriendsResp) Reset()         { *m = GetFriendsResp{} }
 func (m *GetFriendsResp) String() string { return proto.CompactTextString(m) }
 func (*GetFriendsResp) ProtoMessage()    {}
 func (*GetFriendsResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{40}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{49}
 }
 func (m *GetFriendsResp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1806,7 +2250,7 @@ func (m *CreateGroupResp) Reset()         { *m = CreateGroupResp{} }
 func (m *CreateGroupResp) String() string { return proto.CompactTextString(m) }
 func (*CreateGroupResp) ProtoMessage()    {}
 func (*CreateGroupResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{41}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{50}
 }
 func (m *CreateGroupResp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1846,7 +2290,7 @@ func (m *GetGroupsResp) Reset()         { *m = GetGroupsResp{} }
 func (m *GetGroupsResp) String() string { return proto.CompactTextString(m) }
 func (*GetGroupsResp) ProtoMessage()    {}
 func (*GetGroupsResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{42}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{51}
 }
 func (m *GetGroupsResp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1886,7 +2330,7 @@ func (m *GetMembersResp) Reset()         { *m = GetMembersResp{} }
 func (m *GetMembersResp) String() string { return proto.CompactTextString(m) }
 func (*GetMembersResp) ProtoMessage()    {}
 func (*GetMembersResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{43}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{52}
 }
 func (m *GetMembersResp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1916,18 +2360,22 @@ func (m *GetMembersResp) XXX_DiscardUnknown() {
 var xxx_messageInfo_GetMembersResp proto.InternalMessageInfo
 
 type ConnectResp struct {
-	ClientID             string   `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	UID                  string   `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ClientID string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	UID      string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	// Topics of the user by their sequence when the user connected, the session delivers
+	// the messages after them and joins the topics in the topic push mode. A sequence is
+	// 0 when it is unknown.
+	Topics               map[string]int64 `protobuf:"bytes,3,rep,name=topics,proto3" json:"topics,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *ConnectResp) Reset()         { *m = ConnectResp{} }
 func (m *ConnectResp) String() string { return proto.CompactTextString(m) }
 func (*ConnectResp) ProtoMessage()    {}
 func (*ConnectResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{44}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{53}
 }
 func (m *ConnectResp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1967,7 +2415,7 @@ func (m *PullMessageResp) Reset()         { *m = PullMessageResp{} }
 func (m *PullMessageResp) String() string { return proto.CompactTextString(m) }
 func (*PullMessageResp) ProtoMessage()    {}
 func (*PullMessageResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{45}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{54}
 }
 func (m *PullMessageResp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1996,9 +2444,50 @@ func (m *PullMessageResp) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PullMessageResp proto.InternalMessageInfo
 
+type SearchMessagesResp struct {
+	Messages             []*Message `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SearchMessagesResp) Reset()         { *m = SearchMessagesResp{} }
+func (m *SearchMessagesResp) String() string { return proto.CompactTextString(m) }
+func (*SearchMessagesResp) ProtoMessage()    {}
+func (*SearchMessagesResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{55}
+}
+func (m *SearchMessagesResp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SearchMessagesResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SearchMessagesResp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SearchMessagesResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchMessagesResp.Merge(m, src)
+}
+func (m *SearchMessagesResp) XXX_Size() int {
+	return m.Size()
+}
+func (m *SearchMessagesResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchMessagesResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchMessagesResp proto.InternalMessageInfo
+
 type PushMessageResp struct {
 	MessageId            int64    `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
 	Sequence             int64    `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Topic                string   `protobuf:"bytes,3,opt,name=topic,proto3" json:"topic,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2008,7 +2497,7 @@ func (m *PushMessageResp) Reset()         { *m = PushMessageResp{} }
 func (m *PushMessageResp) String() string { return proto.CompactTextString(m) }
 func (*PushMessageResp) ProtoMessage()    {}
 func (*PushMessageResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{46}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{56}
 }
 func (m *PushMessageResp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2051,8 +2540,13 @@ func init() {
 	proto.RegisterType((*TopicMessages)(nil), "chat.logic.service.TopicMessages")
 	proto.RegisterType((*Message)(nil), "chat.logic.service.Message")
 	proto.RegisterType((*PushMessage)(nil), "chat.logic.service.PushMessage")
+	proto.RegisterType((*PushUIDMessage)(nil), "chat.logic.service.PushUIDMessage")
+	proto.RegisterType((*PushTopicMessage)(nil), "chat.logic.service.PushTopicMessage")
 	proto.RegisterType((*BroadcastMessage)(nil), "chat.logic.service.BroadcastMessage")
 	proto.RegisterMapType((map[string]*StringSliceValue)(nil), "chat.logic.service.BroadcastMessage.ServersEntry")
+	proto.RegisterType((*BroadcastRoomMessage)(nil), "chat.logic.service.BroadcastRoomMessage")
+	proto.RegisterType((*InvalidateLocalMessage)(nil), "chat.logic.service.InvalidateLocalMessage")
+	proto.RegisterType((*DeadLetter)(nil), "chat.logic.service.DeadLetter")
 	proto.RegisterType((*GetClientReq)(nil), "chat.logic.service.GetClientReq")
 	proto.RegisterType((*CreateClientReq)(nil), "chat.logic.service.CreateClientReq")
 	proto.RegisterType((*UpdateClientReq)(nil), "chat.logic.service.UpdateClientReq")
@@ -2074,8 +2568,12 @@ func init() {
 	proto.RegisterType((*DisconnectReq)(nil), "chat.logic.service.DisconnectReq")
 	proto.RegisterType((*HeartbeatReq)(nil), "chat.logic.service.HeartbeatReq")
 	proto.RegisterType((*PullMessageReq)(nil), "chat.logic.service.PullMessageReq")
+	proto.RegisterType((*SearchMessagesReq)(nil), "chat.logic.service.SearchMessagesReq")
 	proto.RegisterType((*PushMessageReq)(nil), "chat.logic.service.PushMessageReq")
 	proto.RegisterType((*ReadMessageReq)(nil), "chat.logic.service.ReadMessageReq")
+	proto.RegisterType((*PushRoomMessageReq)(nil), "chat.logic.service.PushRoomMessageReq")
+	proto.RegisterType((*BroadcastReq)(nil), "chat.logic.service.BroadcastReq")
+	proto.RegisterType((*BroadcastRoomReq)(nil), "chat.logic.service.BroadcastRoomReq")
 	proto.RegisterType((*KeypressReq)(nil), "chat.logic.service.KeypressReq")
 	proto.RegisterType((*GetClientResp)(nil), "chat.logic.service.GetClientResp")
 	proto.RegisterType((*CreateClientResp)(nil), "chat.logic.service.CreateClientResp")
@@ -2086,137 +2584,184 @@ func init() {
 	proto.RegisterType((*GetGroupsResp)(nil), "chat.logic.service.GetGroupsResp")
 	proto.RegisterType((*GetMembersResp)(nil), "chat.logic.service.GetMembersResp")
 	proto.RegisterType((*ConnectResp)(nil), "chat.logic.service.ConnectResp")
+	proto.RegisterMapType((map[string]int64)(nil), "chat.logic.service.ConnectResp.TopicsEntry")
 	proto.RegisterType((*PullMessageResp)(nil), "chat.logic.service.PullMessageResp")
+	proto.RegisterType((*SearchMessagesResp)(nil), "chat.logic.service.SearchMessagesResp")
 	proto.RegisterType((*PushMessageResp)(nil), "chat.logic.service.PushMessageResp")
 }
 
 func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
 
 var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 1959 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0x4b, 0x77, 0xdb, 0xc6,
-	0x15, 0x26, 0x48, 0x8a, 0x22, 0x2e, 0x29, 0x89, 0x99, 0xb8, 0x29, 0xcd, 0x26, 0xa4, 0x35, 0xf6,
-	0x69, 0x15, 0xb7, 0x95, 0x1b, 0xa6, 0xa7, 0x0f, 0xf7, 0x29, 0x4a, 0x8e, 0x2c, 0x47, 0xee, 0xf1,
-	0x81, 0x24, 0xb7, 0x27, 0x39, 0x2d, 0x0b, 0x01, 0x63, 0x1a, 0x11, 0x09, 0x20, 0x98, 0xa1, 0x1d,
-	0x2d, 0xba, 0xef, 0x4f, 0xe8, 0xa2, 0xbf, 0xa0, 0x5d, 0x75, 0xdf, 0x55, 0x57, 0x59, 0x66, 0xd3,
-	0xad, 0x4e, 0xa2, 0xfc, 0x89, 0x2e, 0x7b, 0xe6, 0x01, 0x60, 0x40, 0x01, 0x90, 0xa5, 0x3e, 0x56,
-	0xd9, 0x61, 0x2e, 0x2e, 0xbe, 0xb9, 0xaf, 0xb9, 0x73, 0x3f, 0x12, 0x4c, 0x3b, 0xf4, 0x36, 0xc3,
-	0x28, 0x60, 0x01, 0x42, 0xce, 0x73, 0x9b, 0x6d, 0x4e, 0x83, 0x89, 0xe7, 0x6c, 0x52, 0x12, 0xbd,
-	0xf0, 0x1c, 0xd2, 0xfb, 0xee, 0xc4, 0x63, 0xcf, 0xe7, 0xc7, 0x9b, 0x4e, 0x30, 0xbb, 0x37, 0x09,
-	0x26, 0xc1, 0x3d, 0xa1, 0x7a, 0x3c, 0x7f, 0x26, 0x56, 0x62, 0x21, 0x9e, 0x24, 0x04, 0x5e, 0x86,
-	0xa5, 0x07, 0xb3, 0x90, 0x9d, 0xe2, 0xdb, 0xd0, 0x3a, 0x60, 0x91, 0xe7, 0x4f, 0x9e, 0xda, 0xd3,
-	0x39, 0x41, 0x37, 0x60, 0xe9, 0x05, 0x7f, 0xe8, 0x1a, 0xb7, 0x8c, 0x0d, 0xd3, 0x92, 0x0b, 0x8c,
-	0x01, 0xf6, 0x7c, 0xf6, 0x83, 0xef, 0xe7, 0xe8, 0xd4, 0x62, 0x9d, 0x75, 0x30, 0x47, 0x41, 0x30,
-	0xcd, 0x51, 0x69, 0x6a, 0x30, 0xa3, 0x53, 0x46, 0x68, 0x8e, 0x4e, 0x3b, 0xd6, 0xd9, 0x80, 0x8e,
-	0xb4, 0xe7, 0x60, 0xea, 0x39, 0xe4, 0x82, 0x66, 0x2d, 0x35, 0xea, 0x5f, 0x06, 0x34, 0xb6, 0xa7,
-	0x1e, 0xf1, 0x19, 0x7a, 0x03, 0xaa, 0x9e, 0x2b, 0x4d, 0x1e, 0x35, 0xce, 0xcf, 0x06, 0xd5, 0xbd,
-	0x1d, 0xab, 0xea, 0xb9, 0xe8, 0x2d, 0x00, 0x27, 0x22, 0x36, 0x23, 0xee, 0xd8, 0x66, 0xdd, 0xaa,
-	0x30, 0xd7, 0x54, 0x92, 0x2d, 0xc6, 0x5f, 0xcf, 0x43, 0x37, 0x7e, 0x5d, 0x93, 0xaf, 0x95, 0x64,
-	0x8b, 0x21, 0x04, 0x75, 0xdf, 0x9e, 0x91, 0x6e, 0x5d, 0x84, 0x42, 0x3c, 0xa3, 0x75, 0x68, 0xb3,
-	0xe0, 0x84, 0xf8, 0x63, 0x4a, 0x9c, 0x88, 0xb0, 0xee, 0x92, 0xb0, 0xbd, 0x25, 0x64, 0x07, 0x42,
-	0x94, 0xaa, 0x90, 0x4f, 0x42, 0x2f, 0x22, 0xdd, 0x86, 0xc0, 0x95, 0x2a, 0x0f, 0x84, 0x48, 0x6c,
-	0x4c, 0x49, 0x34, 0x76, 0x82, 0xb9, 0xcf, 0xba, 0xcb, 0x6a, 0x63, 0x4a, 0xa2, 0x6d, 0x2e, 0x40,
-	0x03, 0x68, 0x4d, 0xa2, 0x60, 0x1e, 0xaa, 0xf7, 0x4d, 0xf1, 0x1e, 0x84, 0x48, 0x28, 0xe0, 0xbf,
-	0x19, 0xb0, 0xb4, 0xcb, 0x97, 0x0b, 0x1e, 0x1a, 0x8b, 0x1e, 0xc6, 0x2e, 0x54, 0x35, 0x17, 0x6e,
-	0x42, 0x6d, 0xe2, 0xb9, 0xc2, 0x5d, 0x73, 0xb4, 0x7c, 0x7e, 0x36, 0xa8, 0xed, 0xee, 0xed, 0x58,
-	0x5c, 0x86, 0x30, 0xb4, 0x3d, 0x9f, 0x45, 0x81, 0x3b, 0x77, 0x98, 0x17, 0xf8, 0xca, 0xf3, 0x8c,
-	0x8c, 0x27, 0x23, 0x78, 0xe9, 0x93, 0x48, 0xb8, 0x6e, 0x5a, 0x72, 0x81, 0x6e, 0x41, 0xeb, 0x31,
-	0x99, 0x1d, 0x2b, 0x0f, 0x62, 0x9f, 0x35, 0x11, 0x66, 0xb0, 0x72, 0x18, 0x84, 0x9e, 0xf3, 0x98,
-	0x50, 0x6a, 0x4f, 0x08, 0xe5, 0x40, 0x8c, 0x0b, 0xe2, 0x52, 0x13, 0x0b, 0xf4, 0x43, 0x68, 0xce,
-	0x94, 0x46, 0xb7, 0x7a, 0xab, 0xb6, 0xd1, 0x1a, 0x7e, 0x63, 0xf3, 0x62, 0xb9, 0x6f, 0x2a, 0x14,
-	0x2b, 0x51, 0xe6, 0x70, 0x32, 0x5c, 0x32, 0x8f, 0x72, 0x81, 0xff, 0x5c, 0x85, 0x65, 0xa5, 0xab,
-	0x55, 0x49, 0xed, 0x2a, 0x55, 0xb2, 0x0e, 0x6d, 0xb5, 0xc9, 0x98, 0x9d, 0x86, 0x44, 0x06, 0xce,
-	0x6a, 0x29, 0xd9, 0xe1, 0x69, 0xc8, 0x91, 0x1b, 0x94, 0xf8, 0x2e, 0x89, 0x54, 0xc4, 0xd4, 0x0a,
-	0xf5, 0xa0, 0x19, 0x11, 0x87, 0x78, 0x2f, 0x92, 0x70, 0x25, 0xeb, 0xd4, 0xfd, 0x86, 0xee, 0x7e,
-	0x0f, 0x9a, 0x94, 0x7c, 0x3c, 0x27, 0xbe, 0x43, 0x54, 0x5d, 0x24, 0x6b, 0x6e, 0x88, 0x13, 0xf8,
-	0x8c, 0xf8, 0x4c, 0x1a, 0xd2, 0x94, 0x86, 0x28, 0x99, 0x30, 0x04, 0x41, 0xfd, 0x38, 0x70, 0x4f,
-	0xbb, 0xa6, 0x28, 0x4b, 0xf1, 0xcc, 0x21, 0x67, 0xc4, 0xe7, 0xb9, 0xa3, 0x5d, 0x10, 0x07, 0x28,
-	0x59, 0xe3, 0x3f, 0x1a, 0xd0, 0x7a, 0x32, 0xa7, 0xcf, 0xe3, 0x10, 0xbd, 0x09, 0x66, 0x10, 0x92,
-	0xc8, 0x16, 0xd9, 0xe7, 0x91, 0x5a, 0xb2, 0x52, 0x01, 0x7a, 0x1b, 0x4c, 0x1e, 0x7f, 0x12, 0x8d,
-	0x3d, 0x57, 0x96, 0xd4, 0xa8, 0x7d, 0x7e, 0x36, 0x68, 0x1e, 0x08, 0xe1, 0xde, 0x0e, 0xb7, 0x55,
-	0x3c, 0xb9, 0xe8, 0x4d, 0xa8, 0x53, 0xcf, 0xa5, 0xdd, 0x1a, 0xdf, 0x70, 0xd4, 0x3c, 0x3f, 0x1b,
-	0xd4, 0x0f, 0xf6, 0x76, 0xa8, 0x25, 0xa4, 0xdc, 0x4c, 0xd7, 0x66, 0xb6, 0x88, 0x56, 0xdb, 0x12,
-	0xcf, 0xf8, 0x9f, 0x06, 0x74, 0x46, 0x51, 0x60, 0xbb, 0x8e, 0x4d, 0x59, 0x6c, 0xcf, 0xfb, 0xb0,
-	0x2c, 0x21, 0xa9, 0x38, 0xfb, 0xad, 0xe1, 0x3b, 0x79, 0xc5, 0xb0, 0xf8, 0xd9, 0xa6, 0x34, 0x88,
-	0x3e, 0xf0, 0x59, 0x74, 0x6a, 0xc5, 0x08, 0xc9, 0xae, 0xd5, 0x74, 0xd7, 0xde, 0xef, 0xa1, 0xad,
-	0x2b, 0xa3, 0x0e, 0xd4, 0x4e, 0xc8, 0xa9, 0x2a, 0x49, 0xfe, 0x88, 0xee, 0xc7, 0xcd, 0x87, 0x7f,
-	0xd6, 0x1a, 0xde, 0xc9, 0x33, 0x60, 0xb1, 0x63, 0xa9, 0x16, 0x75, 0xbf, 0xfa, 0x23, 0x03, 0xdf,
-	0x81, 0xf6, 0x2e, 0x61, 0xb2, 0x51, 0x59, 0xe4, 0x63, 0x99, 0xf7, 0x13, 0xe2, 0xa7, 0x65, 0x7f,
-	0x42, 0x7c, 0x7c, 0x02, 0x6b, 0xdb, 0xa2, 0xe2, 0x52, 0xc5, 0xf8, 0xec, 0x1a, 0x25, 0xed, 0x47,
-	0x9e, 0xeb, 0xd2, 0xf6, 0x53, 0xbb, 0xd0, 0x7e, 0xf0, 0x97, 0x06, 0xac, 0x1d, 0x89, 0x36, 0x77,
-	0x89, 0x59, 0xe8, 0x5d, 0xad, 0x7f, 0xb4, 0x86, 0x83, 0x62, 0xdf, 0xa5, 0xdb, 0xd2, 0xc8, 0xd1,
-	0x82, 0x91, 0xb5, 0x57, 0xfb, 0x38, 0xe3, 0xc5, 0xd6, 0x82, 0x17, 0x75, 0x81, 0xd1, 0xcf, 0xc3,
-	0x48, 0x6f, 0xa6, 0xac, 0x97, 0xdf, 0x82, 0xb5, 0x1d, 0x32, 0x25, 0x97, 0x3a, 0x89, 0x8f, 0xa1,
-	0xb3, 0x4b, 0x7c, 0x5e, 0xe4, 0xe4, 0x90, 0x0b, 0xb8, 0xe6, 0xdb, 0x60, 0x3a, 0xe2, 0xb3, 0x71,
-	0x72, 0xb1, 0x88, 0x52, 0x97, 0x58, 0xbc, 0xd4, 0xe5, 0xeb, 0x3d, 0x17, 0xdd, 0x86, 0x15, 0xa5,
-	0x9a, 0x49, 0x4a, 0x5b, 0x0a, 0xa5, 0x3f, 0xf8, 0xc7, 0xb0, 0x22, 0xf3, 0x7b, 0x44, 0x49, 0x54,
-	0x1c, 0xef, 0x9c, 0x7e, 0x8d, 0x1d, 0x40, 0x32, 0x59, 0x5b, 0x0e, 0xf3, 0x5e, 0xf0, 0xa6, 0x54,
-	0xfc, 0xfd, 0x4d, 0xa8, 0xcd, 0x93, 0xb3, 0x29, 0x7a, 0xfb, 0x11, 0xef, 0xed, 0x73, 0x8f, 0x9f,
-	0x48, 0xd3, 0x8e, 0x01, 0x44, 0x4a, 0x9a, 0x56, 0x2a, 0xc0, 0xbf, 0x84, 0x15, 0x19, 0xac, 0x72,
-	0xfb, 0x8a, 0xf1, 0xf1, 0x2e, 0xdc, 0x88, 0xa3, 0xc8, 0x31, 0x92, 0x48, 0x5e, 0x19, 0x68, 0x06,
-	0xed, 0x2d, 0xd7, 0x7d, 0x2f, 0xf2, 0x88, 0x7f, 0x3d, 0x4f, 0xbf, 0x03, 0xf0, 0x4c, 0x7c, 0x3d,
-	0x9e, 0x27, 0xf7, 0xdc, 0xca, 0xf9, 0xd9, 0xc0, 0x94, 0x98, 0x5c, 0xcf, 0x94, 0x0a, 0x47, 0x9e,
-	0xf0, 0x7c, 0x97, 0x30, 0xf9, 0x8a, 0x5e, 0xcb, 0xe0, 0x30, 0x2e, 0xb4, 0xff, 0x9b, 0xcd, 0x0c,
-	0x56, 0x65, 0x35, 0x89, 0x21, 0xe0, 0x4a, 0xe5, 0x74, 0xe1, 0x8e, 0xaf, 0x95, 0xdd, 0xf1, 0x75,
-	0xed, 0x8e, 0xc7, 0xbf, 0x10, 0x9d, 0x4c, 0x6c, 0x79, 0xbd, 0x40, 0x7d, 0x20, 0x32, 0x2b, 0x87,
-	0x82, 0x52, 0x80, 0x49, 0x16, 0x20, 0x99, 0x4f, 0x14, 0x76, 0x2d, 0x07, 0x5b, 0xa6, 0x51, 0x62,
-	0xd3, 0xeb, 0x80, 0xf3, 0x01, 0x76, 0xdf, 0xa3, 0xac, 0xa4, 0x6a, 0xf1, 0x1f, 0x00, 0xb6, 0x03,
-	0xdf, 0x27, 0x0e, 0x53, 0x3d, 0xe2, 0xa3, 0x97, 0x6c, 0xac, 0xe9, 0xc9, 0x1e, 0xf1, 0xe8, 0xd7,
-	0x87, 0xb2, 0xfa, 0x9b, 0x1f, 0xbd, 0x64, 0x87, 0xf1, 0xb6, 0x34, 0xbb, 0xed, 0x01, 0xdf, 0x96,
-	0x7a, 0x6e, 0xf6, 0x52, 0xad, 0x95, 0x5d, 0xaa, 0xf8, 0x01, 0xac, 0xec, 0x78, 0xd4, 0x49, 0x2d,
-	0x50, 0xf1, 0x30, 0x72, 0x0a, 0xaa, 0x78, 0x47, 0x1c, 0x40, 0xfb, 0x21, 0xb1, 0x23, 0x76, 0x4c,
-	0xec, 0xeb, 0xa3, 0x5c, 0xc5, 0xee, 0x6f, 0xc3, 0xea, 0x93, 0xf9, 0x74, 0x1a, 0xcf, 0x6c, 0xa5,
-	0x5b, 0xe2, 0x7f, 0x54, 0xb9, 0x76, 0x32, 0x92, 0x5c, 0xb1, 0x19, 0x97, 0x18, 0x3c, 0xca, 0x99,
-	0xe3, 0x56, 0xf3, 0xaf, 0xa5, 0xc7, 0xe9, 0x6c, 0xf7, 0x9f, 0x0f, 0x7a, 0xa3, 0x85, 0xb1, 0xad,
-	0x51, 0xbc, 0xef, 0x76, 0x3a, 0xca, 0xe5, 0xcf, 0x75, 0xcb, 0x05, 0x73, 0x5d, 0x73, 0x61, 0xae,
-	0xfb, 0x2d, 0xac, 0x5a, 0xc4, 0x76, 0x5f, 0x29, 0xe2, 0xe9, 0x24, 0x5a, 0x2d, 0x9a, 0x44, 0x6b,
-	0xd9, 0x49, 0x14, 0xff, 0x1c, 0x5a, 0xef, 0x93, 0xd3, 0x30, 0x22, 0x94, 0x5e, 0x07, 0x1b, 0x6f,
-	0x8b, 0xc3, 0x1a, 0xdf, 0xcb, 0x34, 0x44, 0x43, 0x68, 0xc8, 0x14, 0x0a, 0x90, 0xd6, 0xb0, 0x97,
-	0x1b, 0x1d, 0xa9, 0xaf, 0x34, 0xf9, 0xb5, 0x9d, 0x1d, 0x99, 0x68, 0xf8, 0x5f, 0xbf, 0xb6, 0x7f,
-	0x06, 0xa6, 0xba, 0xc8, 0x68, 0x58, 0xd0, 0x51, 0x7a, 0xd0, 0x9c, 0x7a, 0xcf, 0x08, 0xf3, 0x92,
-	0x3e, 0x9b, 0xac, 0x79, 0xe1, 0xeb, 0xb7, 0x3e, 0x0d, 0xcb, 0x0a, 0xff, 0x2e, 0xac, 0xea, 0x17,
-	0x11, 0x0d, 0x51, 0x17, 0x96, 0x65, 0xcf, 0xa7, 0x8a, 0xf9, 0xc6, 0x4b, 0x3c, 0x8a, 0xc7, 0x45,
-	0x75, 0x01, 0xd0, 0x10, 0xdd, 0x83, 0x25, 0xc1, 0x10, 0x55, 0x04, 0x6f, 0xe6, 0x45, 0x50, 0x6a,
-	0x4b, 0x3d, 0x3c, 0x12, 0x49, 0x88, 0xdb, 0x39, 0x0d, 0xd1, 0x3b, 0xd0, 0x10, 0x6f, 0xe2, 0x59,
-	0xbb, 0x04, 0x42, 0x29, 0x2a, 0x9b, 0x93, 0xae, 0x2b, 0x6d, 0x9e, 0xc9, 0x65, 0x6c, 0xb3, 0x5a,
-	0xe2, 0x03, 0x68, 0x25, 0xcd, 0xf3, 0x6a, 0xa9, 0x2a, 0xb9, 0x52, 0x3e, 0x84, 0xb5, 0x4c, 0x6b,
-	0xa1, 0x21, 0x7a, 0x08, 0xab, 0xa2, 0xca, 0xc6, 0x09, 0x8f, 0x94, 0xee, 0xac, 0xe7, 0xb9, 0x93,
-	0xa1, 0xa4, 0xd6, 0x0a, 0xd3, 0x97, 0x78, 0x9f, 0x83, 0x6b, 0x9d, 0x88, 0x0a, 0xbe, 0x1d, 0x37,
-	0x91, 0x98, 0x4b, 0x5a, 0xa6, 0x92, 0xec, 0xb9, 0x99, 0x43, 0x53, 0xcd, 0x1e, 0x9a, 0xbb, 0xf7,
-	0x39, 0x45, 0x4e, 0x5b, 0xc9, 0xd7, 0xe0, 0x35, 0x6d, 0x79, 0xe0, 0xf9, 0x93, 0x29, 0xe9, 0x54,
-	0xd0, 0x0d, 0xe8, 0x68, 0x62, 0x11, 0xed, 0x8e, 0x71, 0xf7, 0x2f, 0x86, 0x08, 0x5e, 0xd2, 0x0f,
-	0xde, 0x00, 0xa4, 0x2d, 0x8f, 0xfc, 0x13, 0x3f, 0x78, 0xe9, 0x77, 0x2a, 0xe8, 0x75, 0x58, 0xd3,
-	0xe4, 0x87, 0xe4, 0x13, 0xd6, 0x01, 0x0e, 0xa9, 0x09, 0xf7, 0x66, 0xf6, 0x84, 0x74, 0x6e, 0xa0,
-	0xaf, 0xc3, 0xeb, 0x9a, 0x74, 0x3f, 0x70, 0x04, 0xc7, 0xeb, 0xf4, 0x17, 0xd4, 0xb7, 0xe6, 0xae,
-	0x17, 0x74, 0x36, 0x16, 0xa4, 0x4f, 0x3d, 0x97, 0x04, 0x9d, 0xe1, 0xc2, 0x7e, 0xef, 0x79, 0x53,
-	0xd2, 0xf9, 0xe9, 0xf0, 0xf3, 0x2a, 0x98, 0xdb, 0xcf, 0x6d, 0xb6, 0xe5, 0xce, 0x3c, 0x1f, 0x59,
-	0x60, 0x26, 0x67, 0x1d, 0xdd, 0xca, 0x2d, 0x29, 0x8d, 0x1e, 0xf5, 0xd6, 0x2f, 0xd1, 0xa0, 0x21,
-	0xae, 0xa0, 0x0f, 0xa1, 0xad, 0x1f, 0x7d, 0x74, 0x3b, 0xb7, 0x5d, 0x64, 0xf9, 0x54, 0xef, 0xce,
-	0xe5, 0x4a, 0x02, 0xfc, 0x09, 0xb4, 0x75, 0x72, 0x94, 0x0f, 0xbe, 0x40, 0x9f, 0x7a, 0xb9, 0x67,
-	0x45, 0xfe, 0xc2, 0x26, 0x10, 0x75, 0x26, 0x92, 0x8f, 0xb8, 0xc0, 0x55, 0x4a, 0x11, 0x87, 0x7f,
-	0x6d, 0xc1, 0x1a, 0x0f, 0xb1, 0x54, 0xff, 0x2a, 0xd0, 0xff, 0xab, 0x40, 0xa3, 0xa7, 0xbc, 0x49,
-	0x6a, 0xdc, 0x10, 0xdd, 0xc9, 0x0f, 0x5b, 0x96, 0x3e, 0xf6, 0xde, 0xca, 0xef, 0x35, 0xea, 0x26,
-	0xc1, 0x15, 0x74, 0x04, 0x90, 0xde, 0x0c, 0x68, 0xbd, 0x38, 0x62, 0x8a, 0x8f, 0xf5, 0xf0, 0x65,
-	0x2a, 0x02, 0xf6, 0x69, 0x4c, 0xec, 0x13, 0xae, 0x88, 0xbe, 0x59, 0x1c, 0x55, 0x9d, 0x50, 0x96,
-	0x87, 0x61, 0x1f, 0x20, 0xa5, 0x87, 0xf9, 0xe6, 0x66, 0xe8, 0x63, 0x39, 0xda, 0xef, 0xe0, 0xb5,
-	0x0b, 0x54, 0x11, 0x6d, 0x94, 0x05, 0x56, 0x67, 0x94, 0x97, 0x07, 0xf7, 0x11, 0x98, 0x09, 0x83,
-	0xcc, 0x3f, 0x09, 0x3a, 0xc1, 0x2c, 0xb7, 0xf5, 0x08, 0x20, 0xbd, 0x95, 0x51, 0xd1, 0xa1, 0x49,
-	0xe9, 0x63, 0x7e, 0xa2, 0xb2, 0x17, 0xbb, 0x5e, 0xa9, 0xca, 0xca, 0x92, 0x4a, 0x7d, 0x45, 0x43,
-	0x7f, 0x03, 0x2d, 0x6d, 0x24, 0x40, 0x25, 0xf5, 0x12, 0x93, 0xc6, 0xde, 0xed, 0x4b, 0x75, 0x84,
-	0xad, 0xb2, 0xb1, 0xc8, 0x41, 0xa1, 0xb0, 0xb1, 0x24, 0xb4, 0xb0, 0xb0, 0xb1, 0xa4, 0x93, 0x46,
-	0x92, 0x22, 0x39, 0x38, 0x14, 0xa6, 0x28, 0x61, 0x8a, 0xaf, 0x92, 0x22, 0x35, 0x84, 0x14, 0xa6,
-	0x28, 0xa5, 0x86, 0x85, 0x29, 0xd2, 0xe6, 0x18, 0x5c, 0x41, 0x0f, 0xa1, 0x21, 0xf9, 0x20, 0xca,
-	0x2d, 0xb8, 0x84, 0x2b, 0xf6, 0xca, 0x7e, 0xa0, 0xc6, 0x95, 0xef, 0x19, 0xc3, 0xbf, 0xd7, 0xa1,
-	0xce, 0xbb, 0x35, 0xda, 0x87, 0x65, 0x35, 0x02, 0xa1, 0x7e, 0xc1, 0xfc, 0xaf, 0xa8, 0x5d, 0x6f,
-	0x50, 0xfa, 0x5e, 0x18, 0xc8, 0x0f, 0x65, 0x42, 0x07, 0x0b, 0x0e, 0xa5, 0x4e, 0x17, 0xcb, 0xa3,
-	0xf8, 0x08, 0xcc, 0x84, 0x15, 0xe6, 0x67, 0x44, 0x27, 0x8d, 0x97, 0xd6, 0xa2, 0xfe, 0xab, 0x72,
-	0x6e, 0xbc, 0xb3, 0x1c, 0x2f, 0xbf, 0x16, 0x17, 0xa6, 0xaf, 0x18, 0x39, 0x99, 0xf7, 0x8a, 0x90,
-	0x75, 0xae, 0x59, 0x84, 0x9c, 0x19, 0x1a, 0x71, 0x05, 0xfd, 0x0a, 0x5a, 0x1a, 0x65, 0xca, 0x47,
-	0xce, 0x72, 0xaa, 0xf2, 0x18, 0x3c, 0x84, 0x66, 0xcc, 0x91, 0x50, 0x6e, 0x32, 0x35, 0x06, 0x55,
-	0x8a, 0x34, 0x1a, 0x7c, 0xfa, 0x45, 0xbf, 0xf2, 0xd9, 0x17, 0xfd, 0xca, 0xa7, 0xe7, 0x7d, 0xe3,
-	0xb3, 0xf3, 0xbe, 0xf1, 0xf9, 0x79, 0xdf, 0xf8, 0xd3, 0x97, 0xfd, 0xca, 0x07, 0x4b, 0x9b, 0x3f,
-	0xb1, 0x43, 0xef, 0xb8, 0x21, 0xfe, 0xd3, 0x7b, 0xf7, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x90,
-	0x0b, 0x5a, 0xe3, 0x23, 0x1c, 0x00, 0x00,
+	// 2673 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0xcf, 0x93, 0x1b, 0x47,
+	0xf5, 0xdf, 0xd1, 0xe8, 0xe7, 0x93, 0x76, 0x57, 0xee, 0x6c, 0xf2, 0x95, 0x15, 0x67, 0x7f, 0x8c,
+	0xfd, 0x35, 0xc6, 0x09, 0x1b, 0xa2, 0x50, 0x40, 0x0c, 0x14, 0xac, 0x76, 0xed, 0xf5, 0x3a, 0x36,
+	0x95, 0x9a, 0xfd, 0x01, 0x95, 0x54, 0x21, 0x66, 0x35, 0x6d, 0xb9, 0xbd, 0xd2, 0xcc, 0x78, 0xba,
+	0x65, 0x7b, 0x0f, 0x14, 0x57, 0x28, 0x4e, 0xdc, 0x28, 0x8a, 0x13, 0x47, 0x38, 0xf1, 0x17, 0x50,
+	0x05, 0x97, 0x54, 0xc1, 0x21, 0x55, 0x1c, 0xb8, 0x6d, 0x25, 0xcb, 0x1f, 0x91, 0x2b, 0xd5, 0xdd,
+	0xf3, 0xa3, 0x67, 0x76, 0x34, 0x92, 0x36, 0x90, 0x13, 0xb7, 0xe9, 0x37, 0x4f, 0xaf, 0x5f, 0x7f,
+	0xfa, 0x75, 0xbf, 0xf7, 0x3e, 0x23, 0xa8, 0x59, 0x1e, 0xd9, 0xf4, 0x7c, 0x97, 0xb9, 0x08, 0xf5,
+	0x9f, 0x58, 0x6c, 0x73, 0xe8, 0x0e, 0x48, 0x7f, 0x93, 0x62, 0xff, 0x39, 0xe9, 0xe3, 0xf6, 0xd7,
+	0x06, 0x84, 0x3d, 0x19, 0x1f, 0x6f, 0xf6, 0xdd, 0xd1, 0xdb, 0x03, 0x77, 0xe0, 0xbe, 0x2d, 0x54,
+	0x8f, 0xc7, 0x8f, 0xc5, 0x48, 0x0c, 0xc4, 0x93, 0x34, 0x61, 0x54, 0xa0, 0x74, 0x77, 0xe4, 0xb1,
+	0x53, 0xe3, 0x3a, 0xd4, 0xf7, 0x99, 0x4f, 0x9c, 0xc1, 0x91, 0x35, 0x1c, 0x63, 0xb4, 0x02, 0xa5,
+	0xe7, 0xfc, 0xa1, 0xa5, 0xad, 0x6b, 0xb7, 0x6a, 0xa6, 0x1c, 0x18, 0x06, 0xc0, 0x9e, 0xc3, 0xbe,
+	0xf9, 0x8d, 0x0c, 0x1d, 0x3d, 0xd4, 0xd9, 0x80, 0x5a, 0xd7, 0x75, 0x87, 0x19, 0x2a, 0x55, 0xc5,
+	0x4c, 0xf7, 0x94, 0x61, 0x9a, 0xa1, 0xd3, 0x08, 0x75, 0x6e, 0x41, 0x53, 0xfa, 0xb3, 0x3f, 0x24,
+	0x7d, 0x7c, 0x41, 0x53, 0x8f, 0x9d, 0xfa, 0x7b, 0x01, 0xca, 0xdb, 0x43, 0x82, 0x1d, 0x86, 0x5e,
+	0x83, 0x02, 0xb1, 0xa5, 0xcb, 0xdd, 0xf2, 0xf9, 0xd9, 0x5a, 0x61, 0x6f, 0xc7, 0x2c, 0x10, 0x1b,
+	0xbd, 0x01, 0xd0, 0xf7, 0xb1, 0xc5, 0xb0, 0xdd, 0xb3, 0x58, 0xab, 0x20, 0xdc, 0xad, 0x05, 0x92,
+	0x2d, 0xc6, 0x5f, 0x8f, 0x3d, 0x3b, 0x7c, 0xad, 0xcb, 0xd7, 0x81, 0x64, 0x8b, 0x21, 0x04, 0x45,
+	0xc7, 0x1a, 0xe1, 0x56, 0x51, 0x40, 0x21, 0x9e, 0xd1, 0x06, 0x34, 0x98, 0x7b, 0x82, 0x9d, 0x1e,
+	0xc5, 0x7d, 0x1f, 0xb3, 0x56, 0x49, 0xf8, 0x5e, 0x17, 0xb2, 0x7d, 0x21, 0x8a, 0x55, 0xf0, 0x4b,
+	0x8f, 0xf8, 0xb8, 0x55, 0x16, 0x76, 0xa5, 0xca, 0x5d, 0x21, 0x12, 0x13, 0x53, 0xec, 0xf7, 0xfa,
+	0xee, 0xd8, 0x61, 0xad, 0x4a, 0x30, 0x31, 0xc5, 0xfe, 0x36, 0x17, 0xa0, 0x35, 0xa8, 0x0f, 0x7c,
+	0x77, 0xec, 0x05, 0xef, 0xab, 0xe2, 0x3d, 0x08, 0x91, 0x54, 0xb8, 0x09, 0xcb, 0x23, 0x4c, 0xa9,
+	0x35, 0xc0, 0xbd, 0x91, 0xf5, 0xb2, 0x67, 0x0d, 0x70, 0xab, 0x26, 0x94, 0x16, 0x03, 0xf1, 0x23,
+	0xeb, 0xe5, 0xd6, 0x00, 0xa3, 0xdb, 0x70, 0x45, 0xd5, 0x93, 0xe6, 0x40, 0x68, 0x2e, 0xc7, 0x9a,
+	0xc2, 0xa6, 0xf1, 0x27, 0x0d, 0x4a, 0xbb, 0x7c, 0x8a, 0x14, 0x6a, 0x5a, 0x1a, 0xb5, 0x10, 0x96,
+	0x82, 0x02, 0xcb, 0x55, 0xd0, 0x07, 0xc4, 0x16, 0x10, 0xd6, 0xba, 0x95, 0xf3, 0xb3, 0x35, 0x7d,
+	0x77, 0x6f, 0xc7, 0xe4, 0x32, 0x64, 0x40, 0x83, 0x38, 0xcc, 0x77, 0xed, 0x71, 0x9f, 0x11, 0xd7,
+	0x09, 0xd0, 0x4c, 0xc8, 0xf8, 0x06, 0xbb, 0x2f, 0x1c, 0xec, 0x0b, 0x38, 0x6b, 0xa6, 0x1c, 0xa0,
+	0x75, 0xa8, 0x3f, 0xc2, 0xa3, 0xe3, 0x00, 0x95, 0x10, 0x47, 0x45, 0x64, 0x30, 0x58, 0x3c, 0x70,
+	0x3d, 0xd2, 0x7f, 0x24, 0xd7, 0x42, 0xb9, 0x21, 0xc6, 0x05, 0x61, 0xf8, 0x8a, 0x01, 0xfa, 0x16,
+	0x54, 0x83, 0xd5, 0xd2, 0x56, 0x61, 0x5d, 0xbf, 0x55, 0xef, 0xbc, 0xbe, 0x79, 0xf1, 0x08, 0x6d,
+	0x06, 0x56, 0xcc, 0xea, 0x48, 0x31, 0x27, 0x31, 0x93, 0xb1, 0x21, 0x07, 0xc6, 0xef, 0x0a, 0x50,
+	0x09, 0x74, 0x95, 0xc8, 0xd3, 0xe7, 0x89, 0xbc, 0x0d, 0x68, 0x84, 0x1b, 0xc3, 0x4e, 0x3d, 0x2c,
+	0x81, 0x33, 0xeb, 0x81, 0xec, 0xe0, 0xd4, 0xe3, 0x96, 0xcb, 0x14, 0x3b, 0x36, 0xf6, 0x03, 0xc4,
+	0x82, 0x11, 0x6a, 0x43, 0xd5, 0xc7, 0x7d, 0x4c, 0x9e, 0x47, 0x70, 0x45, 0xe3, 0x78, 0xf9, 0x65,
+	0x75, 0xf9, 0x6d, 0xa8, 0x52, 0xfc, 0x6c, 0x8c, 0x9d, 0x3e, 0x0e, 0x62, 0x2d, 0x1a, 0x73, 0x47,
+	0xfa, 0xae, 0xc3, 0xb0, 0xc3, 0xa4, 0x23, 0x55, 0xe9, 0x48, 0x20, 0x13, 0x8e, 0x20, 0x28, 0x1e,
+	0xbb, 0xf6, 0xa9, 0x88, 0xb0, 0x86, 0x29, 0x9e, 0xb9, 0xc9, 0x11, 0x76, 0xf8, 0xde, 0xd1, 0x16,
+	0x88, 0x43, 0x19, 0x8d, 0x8d, 0x7f, 0x6a, 0x50, 0xff, 0x60, 0x4c, 0x9f, 0x84, 0x10, 0x5d, 0x83,
+	0x9a, 0xeb, 0x61, 0xdf, 0x12, 0xbb, 0xcf, 0x91, 0x2a, 0x99, 0xb1, 0x00, 0x7d, 0x15, 0x6a, 0x1c,
+	0x7f, 0xec, 0xf7, 0x88, 0x2d, 0x43, 0xaa, 0xdb, 0x38, 0x3f, 0x5b, 0xab, 0xee, 0x0b, 0xe1, 0xde,
+	0x0e, 0xf7, 0x55, 0x3c, 0xd9, 0xe8, 0x1a, 0x14, 0x29, 0xb1, 0x69, 0x4b, 0xe7, 0x13, 0x76, 0xab,
+	0xe7, 0x67, 0x6b, 0xc5, 0xfd, 0xbd, 0x1d, 0x6a, 0x0a, 0x29, 0x77, 0xd3, 0xb6, 0x98, 0x25, 0xd0,
+	0x6a, 0x98, 0xe2, 0x39, 0xc6, 0xa3, 0x34, 0x09, 0x8f, 0x72, 0x0a, 0x8f, 0xd7, 0xa1, 0xe6, 0x8d,
+	0xe9, 0x13, 0xb9, 0x6d, 0x01, 0x58, 0x52, 0xb0, 0xc5, 0xf8, 0xca, 0x96, 0xf8, 0xca, 0x0e, 0xf7,
+	0x76, 0x66, 0x5b, 0xdc, 0x35, 0x28, 0x8e, 0x89, 0x2d, 0x83, 0x2e, 0xf0, 0xf8, 0x50, 0x78, 0xcc,
+	0xa5, 0xe8, 0x26, 0x54, 0xe9, 0x09, 0xf1, 0x7a, 0x34, 0x3a, 0x39, 0xf5, 0xf3, 0xb3, 0xb5, 0xca,
+	0xfe, 0x09, 0xf1, 0xf6, 0xf7, 0x76, 0xcc, 0x0a, 0x7f, 0xb9, 0x4f, 0xec, 0x2f, 0x63, 0x65, 0xbf,
+	0x2e, 0x40, 0x93, 0xaf, 0x4c, 0x3d, 0x4d, 0xd3, 0x37, 0xae, 0x2f, 0x6e, 0xdf, 0xd4, 0xc6, 0xc9,
+	0x2b, 0x99, 0x6f, 0x9c, 0x7c, 0xbd, 0x67, 0xc7, 0xce, 0xea, 0xaa, 0xb3, 0xea, 0xf2, 0x8b, 0x33,
+	0x2c, 0xbf, 0xa4, 0x2c, 0xff, 0xb2, 0x0b, 0xe5, 0x5e, 0x3f, 0x75, 0x89, 0xd3, 0x13, 0xdb, 0x52,
+	0x5d, 0xd7, 0x43, 0xaf, 0x1f, 0xb8, 0xc4, 0x11, 0x5b, 0x53, 0xe5, 0xaf, 0x0f, 0x89, 0x4d, 0x8d,
+	0x5f, 0xe9, 0xd0, 0xec, 0xfa, 0xae, 0x65, 0xf7, 0x2d, 0xca, 0x42, 0x4c, 0xde, 0x87, 0x8a, 0x8c,
+	0x47, 0x2a, 0x92, 0x51, 0xbd, 0xf3, 0x4e, 0xd6, 0x4d, 0x92, 0xfe, 0xd9, 0xa6, 0x8c, 0x66, 0x7a,
+	0xd7, 0x61, 0xfe, 0xa9, 0x19, 0x5a, 0x88, 0x56, 0x56, 0x50, 0x56, 0x96, 0x80, 0x55, 0xcf, 0x85,
+	0xf5, 0x1a, 0xd4, 0xbc, 0xa1, 0xc5, 0x1e, 0xbb, 0xfe, 0x88, 0xb6, 0x8a, 0xe2, 0x14, 0xc6, 0x02,
+	0x9e, 0x44, 0x46, 0xc4, 0xe9, 0xf1, 0x89, 0xf8, 0xfe, 0xc9, 0x38, 0x81, 0x11, 0x71, 0x8e, 0xa4,
+	0x24, 0x0a, 0xce, 0x72, 0x66, 0x70, 0xbe, 0x05, 0x10, 0x9d, 0x4b, 0xda, 0xaa, 0x08, 0x9d, 0xc5,
+	0xf3, 0xb3, 0xb5, 0x5a, 0x78, 0x30, 0xa9, 0x59, 0x0b, 0x4f, 0x26, 0x6d, 0xff, 0x14, 0x1a, 0xea,
+	0x12, 0x51, 0x13, 0xf4, 0x13, 0x7c, 0x1a, 0xdc, 0xc2, 0xfc, 0x11, 0xdd, 0x09, 0x73, 0x38, 0x5f,
+	0x6c, 0xbd, 0x73, 0x23, 0x0b, 0xb6, 0x74, 0xe2, 0x0f, 0x32, 0xfd, 0x9d, 0xc2, 0xb7, 0x35, 0xa3,
+	0x07, 0x2b, 0x11, 0xaa, 0xa6, 0xeb, 0x8e, 0xc2, 0x0d, 0x41, 0x50, 0xf4, 0x5d, 0x77, 0x14, 0x4c,
+	0x25, 0x9e, 0x33, 0x71, 0x5d, 0x83, 0x3a, 0xb5, 0x46, 0xde, 0x10, 0xf7, 0x7c, 0x8b, 0xc9, 0x0b,
+	0x57, 0x33, 0x41, 0x8a, 0x4c, 0x8b, 0x61, 0xe3, 0x97, 0x1a, 0xbc, 0xb6, 0xe7, 0x3c, 0xb7, 0x86,
+	0x84, 0xe7, 0xff, 0x87, 0x6e, 0xdf, 0x1a, 0x86, 0x73, 0x24, 0xf6, 0x44, 0xcb, 0xdd, 0x93, 0x9b,
+	0x50, 0x95, 0xa9, 0x3b, 0x38, 0x14, 0xba, 0x0c, 0x6a, 0x91, 0x58, 0x79, 0x50, 0x8b, 0x97, 0xf2,
+	0x2e, 0x1b, 0xa7, 0xee, 0xb2, 0x18, 0x7c, 0xe3, 0xf7, 0x1a, 0xc0, 0x0e, 0xb6, 0xec, 0x87, 0x98,
+	0x31, 0xec, 0x27, 0xef, 0x48, 0x2d, 0xf7, 0x8e, 0x6c, 0x43, 0x15, 0x3b, 0xb6, 0xe7, 0x12, 0x87,
+	0x05, 0x09, 0x3a, 0x1a, 0xa3, 0x16, 0x54, 0x7c, 0x7e, 0x48, 0xa8, 0xcc, 0x67, 0x0d, 0x33, 0x1c,
+	0xf2, 0x03, 0x8a, 0x7d, 0xdf, 0x0d, 0x53, 0x8d, 0x1c, 0xa4, 0x72, 0x58, 0x29, 0x95, 0xc3, 0x8c,
+	0x1b, 0xd0, 0xd8, 0xc5, 0x4c, 0x62, 0x60, 0xe2, 0x67, 0xf2, 0x94, 0x9f, 0x60, 0x27, 0xce, 0xbd,
+	0x27, 0xd8, 0x31, 0xfe, 0xac, 0xc1, 0xf2, 0xb6, 0xf8, 0x4d, 0xac, 0x19, 0x56, 0x10, 0x5a, 0x4e,
+	0x61, 0x25, 0x9d, 0xcf, 0x2d, 0xac, 0xf4, 0x8b, 0x85, 0x55, 0x46, 0x61, 0x54, 0x9c, 0xb9, 0x30,
+	0x2a, 0x65, 0x17, 0x46, 0x9f, 0x17, 0x60, 0xf9, 0xd0, 0xb3, 0x13, 0x2b, 0xc8, 0x5c, 0x2b, 0x7a,
+	0x57, 0xa9, 0x8c, 0xea, 0x9d, 0xb5, 0xc9, 0x21, 0x2e, 0xa3, 0x5b, 0x2e, 0xbc, 0x9b, 0x5a, 0xb8,
+	0x3e, 0xdb, 0x8f, 0x13, 0xc8, 0x6c, 0xa5, 0x90, 0x29, 0x0a, 0x1b, 0xab, 0x59, 0x36, 0xe2, 0x3a,
+	0x3e, 0x89, 0xdc, 0xbd, 0x8b, 0xc8, 0x95, 0x66, 0xb2, 0x92, 0x42, 0xf6, 0x41, 0x16, 0xb2, 0xe5,
+	0x99, 0x2c, 0x5d, 0x40, 0xfe, 0x2b, 0xb0, 0xbc, 0x83, 0x87, 0x78, 0x2a, 0xf0, 0xc6, 0x31, 0x34,
+	0x77, 0xb1, 0xc3, 0x33, 0x13, 0x3e, 0xe0, 0x02, 0xae, 0x39, 0xc7, 0xa1, 0xbd, 0x0e, 0x8b, 0x81,
+	0x6a, 0x22, 0xf8, 0x1a, 0x52, 0x28, 0x31, 0x36, 0xde, 0x83, 0x45, 0x19, 0xc7, 0x87, 0x14, 0xfb,
+	0x93, 0x63, 0x20, 0xa3, 0x3a, 0x36, 0xfa, 0x80, 0x64, 0x00, 0x6d, 0xf5, 0x19, 0x79, 0xce, 0x8f,
+	0xcf, 0xe4, 0xdf, 0x5f, 0x05, 0x7d, 0x1c, 0x25, 0x54, 0x51, 0x49, 0x1f, 0xf2, 0x4a, 0x7a, 0x4c,
+	0xc4, 0x7d, 0x6f, 0x85, 0x06, 0x44, 0x98, 0x54, 0xcd, 0x58, 0x60, 0xfc, 0x00, 0x16, 0x25, 0x58,
+	0xf9, 0xfe, 0x4d, 0xb6, 0x6f, 0xec, 0xc2, 0x4a, 0x88, 0x22, 0xb7, 0x11, 0x21, 0x39, 0xb7, 0xa1,
+	0x11, 0x34, 0xb6, 0x6c, 0xfb, 0x9e, 0x4f, 0xb0, 0x73, 0xb9, 0x95, 0xbe, 0x05, 0xf0, 0x58, 0xfc,
+	0x9a, 0xe7, 0xe9, 0x20, 0x0b, 0x8a, 0xe4, 0x23, 0x6d, 0x72, 0xbd, 0x9a, 0x54, 0x38, 0x24, 0x62,
+	0xe5, 0xbb, 0x98, 0xc9, 0x57, 0xf4, 0x52, 0x0e, 0x7b, 0x61, 0xa0, 0x7d, 0x69, 0x3e, 0x33, 0x58,
+	0x92, 0xd1, 0x24, 0x32, 0xc3, 0x5c, 0xe1, 0x74, 0xa1, 0xa3, 0xd2, 0xf3, 0x3a, 0xaa, 0xa2, 0xd2,
+	0x51, 0x19, 0xdf, 0x17, 0x57, 0xb6, 0x98, 0xf2, 0x72, 0x40, 0x7d, 0x28, 0x76, 0x56, 0xb6, 0x60,
+	0xb9, 0x06, 0x06, 0x49, 0x03, 0x51, 0x37, 0x18, 0xd8, 0xd6, 0x33, 0x6c, 0xcb, 0x6d, 0x94, 0xb6,
+	0xe9, 0x65, 0x8c, 0x73, 0x0a, 0xe2, 0x21, 0xa1, 0x2c, 0x27, 0x6a, 0x8d, 0x9f, 0x01, 0x6c, 0xbb,
+	0x8e, 0x83, 0xfb, 0x2c, 0xb8, 0x23, 0x9e, 0xbe, 0x60, 0x3d, 0x45, 0x2f, 0xa8, 0x06, 0x7f, 0x74,
+	0x20, 0xa3, 0xbf, 0xfa, 0xf4, 0x05, 0x3b, 0x08, 0xa7, 0xa5, 0xc9, 0x69, 0x79, 0x91, 0xca, 0x65,
+	0xc9, 0xf4, 0xac, 0xe7, 0xa5, 0x67, 0xc3, 0x83, 0xc5, 0x1d, 0x42, 0xfb, 0xb1, 0x07, 0x01, 0x1e,
+	0x5a, 0x46, 0x40, 0xe5, 0xcf, 0x38, 0x63, 0x91, 0x68, 0xfc, 0x56, 0x83, 0xc6, 0x7d, 0x6c, 0xf9,
+	0xec, 0x18, 0x5b, 0x5f, 0x6c, 0xc6, 0x19, 0xd7, 0x98, 0x74, 0xae, 0x98, 0xeb, 0xdc, 0x11, 0xef,
+	0xa7, 0x86, 0x61, 0x9d, 0x35, 0xc5, 0xbb, 0xd9, 0x1b, 0x0e, 0xe3, 0x8f, 0x05, 0xb8, 0xb2, 0x8f,
+	0x2d, 0xbf, 0x1f, 0x36, 0xa1, 0x74, 0xce, 0x8c, 0x90, 0x73, 0xce, 0x57, 0xa0, 0xf4, 0x6c, 0x8c,
+	0xfd, 0xd3, 0xb0, 0x99, 0x11, 0x83, 0xb8, 0xc5, 0x29, 0xaa, 0x2d, 0x4e, 0xdc, 0xc3, 0x97, 0x12,
+	0x3d, 0xfc, 0x55, 0xa8, 0x52, 0x66, 0xf9, 0xac, 0x67, 0xc9, 0xdc, 0xa8, 0x9b, 0x15, 0x31, 0xde,
+	0x62, 0xe8, 0x55, 0x28, 0x63, 0x47, 0x69, 0x5d, 0x4a, 0xd8, 0xe1, 0x7d, 0xcb, 0x0e, 0x2c, 0xaa,
+	0x7d, 0xba, 0xec, 0x5d, 0x96, 0xb2, 0xcb, 0x84, 0xed, 0xb8, 0x79, 0x37, 0x1b, 0x4a, 0x27, 0x2f,
+	0xf8, 0x8c, 0x21, 0x19, 0x11, 0x26, 0x7a, 0xf9, 0x92, 0x29, 0x07, 0xc6, 0x5f, 0x0a, 0xb2, 0xad,
+	0x55, 0xb6, 0x61, 0x3e, 0xa8, 0x26, 0x05, 0x4d, 0x37, 0x83, 0xe5, 0x98, 0xe0, 0xf3, 0xa3, 0x98,
+	0xf9, 0xf8, 0xe2, 0x34, 0x48, 0x37, 0x45, 0x6a, 0x94, 0xd7, 0xb5, 0x59, 0xb0, 0xca, 0x64, 0x3d,
+	0x2a, 0x13, 0x58, 0x8f, 0x6a, 0x8a, 0xf5, 0xf8, 0x85, 0x06, 0x4b, 0x26, 0xb6, 0xec, 0xd9, 0x62,
+	0x39, 0x0a, 0x97, 0xc2, 0xa4, 0xf6, 0x5d, 0x4f, 0x75, 0xb5, 0x73, 0x9c, 0xaa, 0x9f, 0x03, 0xe2,
+	0xdb, 0xa9, 0x74, 0x49, 0x73, 0x6e, 0x69, 0x8c, 0x79, 0x21, 0x81, 0x79, 0xd8, 0x6b, 0xe9, 0xc9,
+	0x5e, 0x4b, 0xe0, 0x54, 0x8c, 0x71, 0x32, 0xfe, 0xaa, 0x41, 0x23, 0x6e, 0xd6, 0xf2, 0x72, 0x9b,
+	0xf8, 0x69, 0x41, 0x81, 0x38, 0xd1, 0xd3, 0xea, 0x53, 0x7a, 0xda, 0xe2, 0xc4, 0x9e, 0xb6, 0x34,
+	0x43, 0x4f, 0x5b, 0xce, 0xef, 0x69, 0x8d, 0x67, 0x4a, 0xfb, 0xcf, 0xb1, 0xcc, 0x5d, 0x88, 0xc0,
+	0xa5, 0x90, 0x81, 0x8b, 0xae, 0x2c, 0x2e, 0xd5, 0x83, 0x16, 0x2f, 0xf4, 0xa0, 0x04, 0xea, 0xef,
+	0xe3, 0x53, 0xcf, 0xc7, 0x94, 0x5e, 0x2a, 0x80, 0xe6, 0xc8, 0x0b, 0xdb, 0x22, 0xdb, 0x86, 0x85,
+	0x35, 0xf5, 0x50, 0x07, 0xca, 0xf2, 0xa5, 0x98, 0xaf, 0xde, 0x69, 0x67, 0x1e, 0x17, 0xa9, 0x1f,
+	0x68, 0xf2, 0xba, 0x3b, 0xd9, 0xdb, 0x51, 0xef, 0x3f, 0x5e, 0x77, 0x7f, 0x0f, 0x6a, 0x41, 0x25,
+	0x4a, 0xbd, 0x09, 0xf8, 0xb7, 0xa1, 0x3a, 0x24, 0x8f, 0x31, 0x23, 0x51, 0xa1, 0x14, 0x8d, 0x8d,
+	0x37, 0xc3, 0x42, 0x4b, 0x96, 0xc5, 0xd4, 0xcb, 0x41, 0xd5, 0xb8, 0x0d, 0x4b, 0x6a, 0x25, 0x49,
+	0x3d, 0xde, 0x33, 0xcb, 0xa2, 0x8d, 0x06, 0x1f, 0x1f, 0xc2, 0xa1, 0xd1, 0x0d, 0xfb, 0xda, 0xa0,
+	0x82, 0xa3, 0x1e, 0x7a, 0x1b, 0x4a, 0xa2, 0xbf, 0x0f, 0x10, 0xbc, 0x9a, 0x85, 0xa0, 0xd4, 0x96,
+	0x7a, 0x46, 0x57, 0x6c, 0x42, 0x58, 0x8f, 0x51, 0x0f, 0xbd, 0x03, 0x65, 0xf1, 0x26, 0x64, 0x97,
+	0x72, 0x4c, 0x04, 0x8a, 0x81, 0xcf, 0x51, 0xd9, 0x24, 0x7d, 0x1e, 0xc9, 0x61, 0xe8, 0x73, 0x30,
+	0x34, 0xfe, 0xa1, 0x41, 0x3d, 0x2a, 0x7f, 0xe6, 0xdb, 0xab, 0x9c, 0x8c, 0xb8, 0x0d, 0x65, 0x11,
+	0x7e, 0xf2, 0xc0, 0xd6, 0x3b, 0x6f, 0x4e, 0xb8, 0x68, 0xc3, 0x69, 0x37, 0x05, 0xcb, 0x18, 0x90,
+	0x61, 0xc1, 0x4f, 0xdb, 0xef, 0x41, 0x5d, 0x11, 0x67, 0x10, 0x48, 0x2b, 0x2a, 0x81, 0xa4, 0xab,
+	0xd4, 0xd0, 0x47, 0xb0, 0x9c, 0xa8, 0x22, 0xa8, 0x87, 0xee, 0xc3, 0x92, 0xb0, 0xdb, 0x8b, 0x78,
+	0x7f, 0x89, 0xe7, 0x46, 0x96, 0x6b, 0x89, 0x4f, 0x08, 0xe6, 0x22, 0x53, 0x87, 0xc6, 0x23, 0x40,
+	0xe9, 0x4a, 0x82, 0x7a, 0x89, 0x2f, 0x0a, 0xda, 0x1c, 0x5f, 0x14, 0x8c, 0x63, 0x58, 0x4e, 0xa4,
+	0x5a, 0x2a, 0x3e, 0xb7, 0x84, 0x59, 0x32, 0xfc, 0x94, 0x60, 0xd6, 0x02, 0x89, 0x64, 0x74, 0xa2,
+	0xa4, 0x50, 0x48, 0x25, 0x85, 0x4c, 0x62, 0xf5, 0xf6, 0x1d, 0xfe, 0xdd, 0x24, 0xce, 0xa0, 0xaf,
+	0xc2, 0x15, 0x65, 0xb8, 0x4f, 0x9c, 0xc1, 0x10, 0x37, 0x17, 0xd0, 0x0a, 0x34, 0x15, 0xb1, 0x88,
+	0xa9, 0xa6, 0x76, 0xfb, 0x0f, 0x32, 0x42, 0xa2, 0x34, 0xf8, 0x1a, 0x20, 0x65, 0x78, 0xe8, 0x9c,
+	0x38, 0xee, 0x0b, 0xa7, 0xb9, 0x80, 0x5e, 0x81, 0x65, 0x45, 0x7e, 0x80, 0x5f, 0xb2, 0x26, 0x70,
+	0x93, 0x8a, 0x70, 0x6f, 0x64, 0x0d, 0x70, 0x73, 0x05, 0xfd, 0x1f, 0xbc, 0xa2, 0x48, 0x39, 0xb1,
+	0xc6, 0x33, 0x66, 0x73, 0x35, 0xa5, 0xbe, 0x35, 0xb6, 0x89, 0xdb, 0xbc, 0x95, 0x92, 0x1e, 0x11,
+	0x1b, 0xbb, 0xcd, 0x4e, 0x6a, 0xbe, 0x7b, 0x64, 0x88, 0x9b, 0xdf, 0xed, 0x7c, 0x5a, 0x80, 0xda,
+	0xf6, 0x13, 0x8b, 0x6d, 0xd9, 0x23, 0xe2, 0x20, 0x13, 0x6a, 0xd1, 0x8d, 0x86, 0xd6, 0x33, 0x0f,
+	0x8e, 0x42, 0x57, 0xb5, 0x37, 0xa6, 0x68, 0x50, 0xcf, 0x58, 0x40, 0x1f, 0x41, 0x43, 0xbd, 0xe0,
+	0xd0, 0xf5, 0xcc, 0xd0, 0x4e, 0xd2, 0x5b, 0xed, 0x1b, 0xd3, 0x95, 0x84, 0xf1, 0x0f, 0xa0, 0xa1,
+	0xf2, 0x4a, 0xd9, 0xc6, 0x53, 0xcc, 0x53, 0x3b, 0xf3, 0x46, 0x90, 0x9f, 0x72, 0x85, 0x45, 0x95,
+	0x30, 0xc9, 0xb6, 0x98, 0xa2, 0x54, 0x72, 0x2d, 0x76, 0xfe, 0xd6, 0x80, 0x65, 0x0e, 0xb1, 0x54,
+	0xff, 0x1f, 0xd0, 0xff, 0x2d, 0xa0, 0xd1, 0x11, 0x4f, 0x05, 0x0a, 0x85, 0x85, 0x6e, 0x64, 0xc3,
+	0x96, 0x64, 0xb9, 0xda, 0x6f, 0x64, 0x5f, 0x68, 0x41, 0xbe, 0x34, 0x16, 0xd0, 0x21, 0x40, 0x9c,
+	0xff, 0xd0, 0xc6, 0x64, 0xc4, 0x02, 0xda, 0xa8, 0x6d, 0x4c, 0x53, 0x11, 0x66, 0x8f, 0x60, 0x39,
+	0x45, 0x69, 0xa1, 0x9b, 0x93, 0x51, 0x55, 0x79, 0xaf, 0x7c, 0x18, 0x1e, 0x02, 0x48, 0xd8, 0x26,
+	0xbb, 0x9b, 0x60, 0xb9, 0xf2, 0xad, 0xfd, 0x04, 0xae, 0x5c, 0x60, 0xb4, 0xd0, 0xad, 0x3c, 0x60,
+	0x55, 0xe2, 0x6b, 0x3a, 0xb8, 0x0f, 0xa0, 0x16, 0x11, 0x5d, 0xd9, 0x27, 0x41, 0xe5, 0xc1, 0xf2,
+	0x7d, 0x3d, 0x04, 0x88, 0x6b, 0x0f, 0x34, 0xe9, 0xd0, 0xc4, 0x2c, 0x57, 0xdb, 0x98, 0xa6, 0x12,
+	0xc6, 0xbe, 0x4a, 0x6d, 0xe5, 0x45, 0xea, 0x8c, 0x8e, 0xfe, 0x18, 0xea, 0x4a, 0xe1, 0x83, 0x72,
+	0xe2, 0x25, 0xe4, 0xb6, 0xda, 0xd7, 0xa7, 0xea, 0x08, 0x5f, 0xe5, 0xc5, 0x22, 0x24, 0x74, 0xe2,
+	0xc5, 0x12, 0xb1, 0x57, 0xed, 0x8d, 0x29, 0x1a, 0xca, 0x16, 0xc9, 0xf2, 0x68, 0xe2, 0x16, 0x45,
+	0x84, 0xd6, 0x2c, 0x5b, 0x24, 0x95, 0x27, 0x6f, 0x51, 0xcc, 0x60, 0xb5, 0x8d, 0x69, 0x2a, 0xa1,
+	0x8b, 0x51, 0xa3, 0x91, 0xed, 0xa2, 0xda, 0x4c, 0xe5, 0xbb, 0x68, 0xc2, 0x62, 0xa2, 0x69, 0xc9,
+	0xbe, 0x46, 0xd2, 0x7d, 0x4d, 0xbe, 0xcd, 0xfb, 0x50, 0x96, 0xb4, 0x1a, 0xca, 0x3c, 0x10, 0x11,
+	0xe5, 0xd6, 0xce, 0xab, 0x81, 0x8c, 0x85, 0xaf, 0x6b, 0x9d, 0xcf, 0x4b, 0x50, 0xe4, 0xd9, 0x04,
+	0x3d, 0x84, 0x4a, 0x50, 0x10, 0xa2, 0xd5, 0xdc, 0x6a, 0xf1, 0x59, 0x7b, 0x6d, 0x4a, 0x35, 0x19,
+	0x5c, 0x1a, 0x11, 0xab, 0x36, 0xe1, 0xd2, 0x50, 0x59, 0xb7, 0xfc, 0xe5, 0x3e, 0x80, 0x5a, 0x44,
+	0x98, 0x65, 0x6f, 0x87, 0xca, 0xa7, 0x4d, 0x3d, 0x2b, 0xea, 0x5f, 0x21, 0x32, 0xe3, 0x21, 0x49,
+	0xbd, 0xb4, 0xaf, 0x4f, 0xd5, 0xa1, 0x5e, 0x68, 0x79, 0x38, 0x9c, 0x62, 0x79, 0x38, 0x9c, 0x6e,
+	0x39, 0x51, 0x39, 0x1b, 0x0b, 0xe8, 0x87, 0x50, 0x57, 0x88, 0x8c, 0x6c, 0xcb, 0x49, 0xa6, 0x23,
+	0x1f, 0x03, 0x0b, 0x96, 0x92, 0x15, 0x34, 0xfa, 0xff, 0xcc, 0x8f, 0x5b, 0x69, 0xbe, 0xae, 0x7d,
+	0x73, 0x16, 0x35, 0xe1, 0xf2, 0x7d, 0xa8, 0x86, 0x7d, 0x33, 0xca, 0x8c, 0x17, 0xa5, 0xab, 0x9e,
+	0x96, 0x86, 0x97, 0x53, 0xdc, 0x49, 0x76, 0x5e, 0xbb, 0x48, 0xb0, 0xe4, 0xda, 0xed, 0xae, 0x7d,
+	0xfc, 0xd9, 0xea, 0xc2, 0x27, 0x9f, 0xad, 0x2e, 0x7c, 0x7c, 0xbe, 0xaa, 0x7d, 0x72, 0xbe, 0xaa,
+	0x7d, 0x7a, 0xbe, 0xaa, 0xfd, 0xe6, 0x5f, 0xab, 0x0b, 0x1f, 0x96, 0x36, 0xbf, 0x63, 0x79, 0xe4,
+	0xb8, 0x2c, 0xfe, 0x97, 0xf7, 0xee, 0xbf, 0x07, 0x00, 0xb8, 0x20, 0xa4, 0x03, 0xe7, 0x27, 0x00,
+	0x00,
 }
 
 func (m *Empty) Marshal() (dAtA []byte, err error) {
@@ -2443,6 +2988,16 @@ func (m *Client) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.MessageMaxCount != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.MessageMaxCount))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.MessageMaxAge != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.MessageMaxAge))
+		i--
+		dAtA[i] = 0x48
+	}
 	if m.GroupCount != 0 {
 		i = encodeVarintApi(dAtA, i, uint64(m.GroupCount))
 		i--
@@ -2727,6 +3282,23 @@ func (m *PushMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.PushedAt != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.PushedAt))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Sequence != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Topic) > 0 {
+		i -= len(m.Topic)
+		copy(dAtA[i:], m.Topic)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Topic)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.Data) > 0 {
 		i -= len(m.Data)
 		copy(dAtA[i:], m.Data)
@@ -2758,7 +3330,7 @@ func (m *PushMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *BroadcastMessage) Marshal() (dAtA []byte, err error) {
+func (m *PushUIDMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2768,12 +3340,12 @@ func (m *BroadcastMessage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *BroadcastMessage) MarshalTo(dAtA []byte) (int, error) {
+func (m *PushUIDMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *BroadcastMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *PushUIDMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -2782,43 +3354,55 @@ func (m *BroadcastMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.PushedAt != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.PushedAt))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Sequence != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Topic) > 0 {
+		i -= len(m.Topic)
+		copy(dAtA[i:], m.Topic)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Topic)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.Data) > 0 {
 		i -= len(m.Data)
 		copy(dAtA[i:], m.Data)
 		i = encodeVarintApi(dAtA, i, uint64(len(m.Data)))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x22
 	}
-	if len(m.Servers) > 0 {
-		for k := range m.Servers {
-			v := m.Servers[k]
-			baseI := i
-			if v != nil {
-				{
-					size, err := v.MarshalToSizedBuffer(dAtA[:i])
-					if err != nil {
-						return 0, err
-					}
-					i -= size
-					i = encodeVarintApi(dAtA, i, uint64(size))
-				}
-				i--
-				dAtA[i] = 0x12
-			}
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintApi(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintApi(dAtA, i, uint64(baseI-i))
+	if len(m.SkipSID) > 0 {
+		i -= len(m.SkipSID)
+		copy(dAtA[i:], m.SkipSID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.SkipSID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.UIDs) > 0 {
+		for iNdEx := len(m.UIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.UIDs[iNdEx])
+			copy(dAtA[i:], m.UIDs[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.UIDs[iNdEx])))
 			i--
-			dAtA[i] = 0xa
+			dAtA[i] = 0x12
 		}
 	}
+	if m.Operation != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.Operation))
+		i--
+		dAtA[i] = 0x8
+	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GetClientReq) Marshal() (dAtA []byte, err error) {
+func (m *PushTopicMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2828,12 +3412,12 @@ func (m *GetClientReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetClientReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *PushTopicMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetClientReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *PushTopicMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -2842,63 +3426,62 @@ func (m *GetClientReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Token) > 0 {
-		i -= len(m.Token)
-		copy(dAtA[i:], m.Token)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
+	if len(m.JoinUIDs) > 0 {
+		for iNdEx := len(m.JoinUIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.JoinUIDs[iNdEx])
+			copy(dAtA[i:], m.JoinUIDs[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.JoinUIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if m.PushedAt != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.PushedAt))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x38
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *CreateClientReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if m.Sequence != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x30
 	}
-	return dAtA[:n], nil
-}
-
-func (m *CreateClientReq) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *CreateClientReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x2a
 	}
-	if m.TokenExpire != 0 {
-		i = encodeVarintApi(dAtA, i, uint64(m.TokenExpire))
+	if len(m.SkipSID) > 0 {
+		i -= len(m.SkipSID)
+		copy(dAtA[i:], m.SkipSID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.SkipSID)))
 		i--
-		dAtA[i] = 0x18
+		dAtA[i] = 0x22
 	}
-	if len(m.TokenSecret) > 0 {
-		i -= len(m.TokenSecret)
-		copy(dAtA[i:], m.TokenSecret)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.TokenSecret)))
+	if len(m.Topic) > 0 {
+		i -= len(m.Topic)
+		copy(dAtA[i:], m.Topic)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Topic)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientID)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Name)))
+	if m.Operation != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.Operation))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *UpdateClientReq) Marshal() (dAtA []byte, err error) {
+func (m *BroadcastMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2908,12 +3491,12 @@ func (m *UpdateClientReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UpdateClientReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *BroadcastMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *UpdateClientReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *BroadcastMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -2922,53 +3505,84 @@ func (m *UpdateClientReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.TokenExpire != nil {
-		{
-			size, err := m.TokenExpire.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintApi(dAtA, i, uint64(size))
+	if len(m.ServerIDs) > 0 {
+		for iNdEx := len(m.ServerIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ServerIDs[iNdEx])
+			copy(dAtA[i:], m.ServerIDs[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.ServerIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.UIDs) > 0 {
+		for iNdEx := len(m.UIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.UIDs[iNdEx])
+			copy(dAtA[i:], m.UIDs[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.UIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x32
 		}
+	}
+	if len(m.MinVersion) > 0 {
+		i -= len(m.MinVersion)
+		copy(dAtA[i:], m.MinVersion)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.MinVersion)))
 		i--
-		dAtA[i] = 0x22
+		dAtA[i] = 0x2a
 	}
-	if m.TokenSecret != nil {
-		{
-			size, err := m.TokenSecret.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintApi(dAtA, i, uint64(size))
+	if len(m.Platforms) > 0 {
+		for iNdEx := len(m.Platforms) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Platforms[iNdEx])
+			copy(dAtA[i:], m.Platforms[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.Platforms[iNdEx])))
+			i--
+			dAtA[i] = 0x22
 		}
+	}
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientID)))
 		i--
 		dAtA[i] = 0x1a
 	}
-	if m.Name != nil {
-		{
-			size, err := m.Name.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintApi(dAtA, i, uint64(size))
-		}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Data)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Token) > 0 {
-		i -= len(m.Token)
-		copy(dAtA[i:], m.Token)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
-		i--
-		dAtA[i] = 0xa
+	if len(m.Servers) > 0 {
+		for k := range m.Servers {
+			v := m.Servers[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintApi(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintApi(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintApi(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0xa
+		}
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *DeleteClientReq) Marshal() (dAtA []byte, err error) {
+func (m *BroadcastRoomMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2978,12 +3592,12 @@ func (m *DeleteClientReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DeleteClientReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *BroadcastRoomMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DeleteClientReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *BroadcastRoomMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -2992,17 +3606,30 @@ func (m *DeleteClientReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Token) > 0 {
-		i -= len(m.Token)
-		copy(dAtA[i:], m.Token)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
+	if m.SampleRate != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.SampleRate))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Room) > 0 {
+		i -= len(m.Room)
+		copy(dAtA[i:], m.Room)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Room)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GenerateTokenReq) Marshal() (dAtA []byte, err error) {
+func (m *InvalidateLocalMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3012,12 +3639,12 @@ func (m *GenerateTokenReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GenerateTokenReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *InvalidateLocalMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GenerateTokenReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *InvalidateLocalMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3026,12 +3653,19 @@ func (m *GenerateTokenReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.ClientSecret) > 0 {
-		i -= len(m.ClientSecret)
-		copy(dAtA[i:], m.ClientSecret)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientSecret)))
+	if len(m.UIDs) > 0 {
+		for iNdEx := len(m.UIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.UIDs[iNdEx])
+			copy(dAtA[i:], m.UIDs[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.UIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.GroupID))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x10
 	}
 	if len(m.ClientID) > 0 {
 		i -= len(m.ClientID)
@@ -3043,7 +3677,7 @@ func (m *GenerateTokenReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *CreateUserReq) Marshal() (dAtA []byte, err error) {
+func (m *DeadLetter) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3053,12 +3687,12 @@ func (m *CreateUserReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CreateUserReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *DeadLetter) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CreateUserReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *DeadLetter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3067,24 +3701,43 @@ func (m *CreateUserReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Name)))
+	if m.CreatedAt != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.CreatedAt))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Request) > 0 {
+		i -= len(m.Request)
+		copy(dAtA[i:], m.Request)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Request)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Endpoint) > 0 {
+		i -= len(m.Endpoint)
+		copy(dAtA[i:], m.Endpoint)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Endpoint)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Token) > 0 {
-		i -= len(m.Token)
-		copy(dAtA[i:], m.Token)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
+	if len(m.ServerID) > 0 {
+		i -= len(m.ServerID)
+		copy(dAtA[i:], m.ServerID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ServerID)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *UpdateActivatedReq) Marshal() (dAtA []byte, err error) {
+func (m *GetClientReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3094,12 +3747,12 @@ func (m *UpdateActivatedReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UpdateActivatedReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetClientReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *UpdateActivatedReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GetClientReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3108,23 +3761,6 @@ func (m *UpdateActivatedReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Activated {
-		i--
-		if m.Activated {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.UID) > 0 {
-		i -= len(m.UID)
-		copy(dAtA[i:], m.UID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
-		i--
-		dAtA[i] = 0x12
-	}
 	if len(m.Token) > 0 {
 		i -= len(m.Token)
 		copy(dAtA[i:], m.Token)
@@ -3135,7 +3771,7 @@ func (m *UpdateActivatedReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *DeleteUserReq) Marshal() (dAtA []byte, err error) {
+func (m *CreateClientReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3145,12 +3781,12 @@ func (m *DeleteUserReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DeleteUserReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *CreateClientReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DeleteUserReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CreateClientReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3159,24 +3795,39 @@ func (m *DeleteUserReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.UID) > 0 {
-		i -= len(m.UID)
-		copy(dAtA[i:], m.UID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
+	if m.MessageMaxCount != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.MessageMaxCount))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.MessageMaxAge != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.MessageMaxAge))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.TokenExpire != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.TokenExpire))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.TokenSecret) > 0 {
+		i -= len(m.TokenSecret)
+		copy(dAtA[i:], m.TokenSecret)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.TokenSecret)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Token) > 0 {
-		i -= len(m.Token)
-		copy(dAtA[i:], m.Token)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Name)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GenerateUserTokenReq) Marshal() (dAtA []byte, err error) {
+func (m *UpdateClientReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3186,12 +3837,12 @@ func (m *GenerateUserTokenReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GenerateUserTokenReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateClientReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GenerateUserTokenReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *UpdateClientReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3200,13 +3851,66 @@ func (m *GenerateUserTokenReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.UID) > 0 {
-		i -= len(m.UID)
-		copy(dAtA[i:], m.UID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
-		i--
-		dAtA[i] = 0x12
-	}
+	if m.MessageMaxCount != nil {
+		{
+			size, err := m.MessageMaxCount.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.MessageMaxAge != nil {
+		{
+			size, err := m.MessageMaxAge.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.TokenExpire != nil {
+		{
+			size, err := m.TokenExpire.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.TokenSecret != nil {
+		{
+			size, err := m.TokenSecret.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Name != nil {
+		{
+			size, err := m.Name.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.Token) > 0 {
 		i -= len(m.Token)
 		copy(dAtA[i:], m.Token)
@@ -3217,7 +3921,7 @@ func (m *GenerateUserTokenReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *AddFriendReq) Marshal() (dAtA []byte, err error) {
+func (m *DeleteClientReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3227,12 +3931,12 @@ func (m *AddFriendReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AddFriendReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *DeleteClientReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AddFriendReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *DeleteClientReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3241,20 +3945,6 @@ func (m *AddFriendReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.FriendUID) > 0 {
-		i -= len(m.FriendUID)
-		copy(dAtA[i:], m.FriendUID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.FriendUID)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.UID) > 0 {
-		i -= len(m.UID)
-		copy(dAtA[i:], m.UID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
-		i--
-		dAtA[i] = 0x12
-	}
 	if len(m.Token) > 0 {
 		i -= len(m.Token)
 		copy(dAtA[i:], m.Token)
@@ -3265,7 +3955,7 @@ func (m *AddFriendReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *GetFriendsReq) Marshal() (dAtA []byte, err error) {
+func (m *GenerateTokenReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3275,12 +3965,12 @@ func (m *GetFriendsReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetFriendsReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *GenerateTokenReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetFriendsReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GenerateTokenReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3289,24 +3979,24 @@ func (m *GetFriendsReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.UID) > 0 {
-		i -= len(m.UID)
-		copy(dAtA[i:], m.UID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
+	if len(m.ClientSecret) > 0 {
+		i -= len(m.ClientSecret)
+		copy(dAtA[i:], m.ClientSecret)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientSecret)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Token) > 0 {
-		i -= len(m.Token)
-		copy(dAtA[i:], m.Token)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientID)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *DeleteFriendReq) Marshal() (dAtA []byte, err error) {
+func (m *CreateUserReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3316,12 +4006,12 @@ func (m *DeleteFriendReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DeleteFriendReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *CreateUserReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DeleteFriendReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CreateUserReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3330,17 +4020,10 @@ func (m *DeleteFriendReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.FriendUID) > 0 {
-		i -= len(m.FriendUID)
-		copy(dAtA[i:], m.FriendUID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.FriendUID)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.UID) > 0 {
-		i -= len(m.UID)
-		copy(dAtA[i:], m.UID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Name)))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -3354,7 +4037,7 @@ func (m *DeleteFriendReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *CreateGroupReq) Marshal() (dAtA []byte, err error) {
+func (m *UpdateActivatedReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3364,12 +4047,12 @@ func (m *CreateGroupReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CreateGroupReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateActivatedReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CreateGroupReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *UpdateActivatedReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3378,24 +4061,20 @@ func (m *CreateGroupReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Owner) > 0 {
-		i -= len(m.Owner)
-		copy(dAtA[i:], m.Owner)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Owner)))
+	if m.Activated {
 		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.Introduction) > 0 {
-		i -= len(m.Introduction)
-		copy(dAtA[i:], m.Introduction)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Introduction)))
+		if m.Activated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x18
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Name)))
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -3409,7 +4088,7 @@ func (m *CreateGroupReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *GetGroupsReq) Marshal() (dAtA []byte, err error) {
+func (m *DeleteUserReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3419,12 +4098,12 @@ func (m *GetGroupsReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetGroupsReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *DeleteUserReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetGroupsReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *DeleteUserReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3450,7 +4129,7 @@ func (m *GetGroupsReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *AddMemberReq) Marshal() (dAtA []byte, err error) {
+func (m *GenerateUserTokenReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3460,12 +4139,12 @@ func (m *AddMemberReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AddMemberReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *GenerateUserTokenReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AddMemberReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GenerateUserTokenReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3479,13 +4158,6 @@ func (m *AddMemberReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		copy(dAtA[i:], m.UID)
 		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
 		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.GID) > 0 {
-		i -= len(m.GID)
-		copy(dAtA[i:], m.GID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.GID)))
-		i--
 		dAtA[i] = 0x12
 	}
 	if len(m.Token) > 0 {
@@ -3498,7 +4170,7 @@ func (m *AddMemberReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *GetMembersReq) Marshal() (dAtA []byte, err error) {
+func (m *AddFriendReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3508,12 +4180,12 @@ func (m *GetMembersReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetMembersReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *AddFriendReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetMembersReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *AddFriendReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3522,10 +4194,17 @@ func (m *GetMembersReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.GID) > 0 {
-		i -= len(m.GID)
-		copy(dAtA[i:], m.GID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.GID)))
+	if len(m.FriendUID) > 0 {
+		i -= len(m.FriendUID)
+		copy(dAtA[i:], m.FriendUID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.FriendUID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -3539,7 +4218,7 @@ func (m *GetMembersReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *ListenReq) Marshal() (dAtA []byte, err error) {
+func (m *GetFriendsReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3549,12 +4228,12 @@ func (m *ListenReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ListenReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetFriendsReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListenReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GetFriendsReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3563,6 +4242,13 @@ func (m *ListenReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.Token) > 0 {
 		i -= len(m.Token)
 		copy(dAtA[i:], m.Token)
@@ -3573,7 +4259,7 @@ func (m *ListenReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *ConnectReq) Marshal() (dAtA []byte, err error) {
+func (m *DeleteFriendReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3583,12 +4269,12 @@ func (m *ConnectReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ConnectReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *DeleteFriendReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ConnectReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *DeleteFriendReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3597,31 +4283,31 @@ func (m *ConnectReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.ServerID) > 0 {
-		i -= len(m.ServerID)
-		copy(dAtA[i:], m.ServerID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.ServerID)))
+	if len(m.FriendUID) > 0 {
+		i -= len(m.FriendUID)
+		copy(dAtA[i:], m.FriendUID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.FriendUID)))
 		i--
 		dAtA[i] = 0x1a
 	}
-	if len(m.SID) > 0 {
-		i -= len(m.SID)
-		copy(dAtA[i:], m.SID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.SID)))
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.JWTToken) > 0 {
-		i -= len(m.JWTToken)
-		copy(dAtA[i:], m.JWTToken)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.JWTToken)))
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *DisconnectReq) Marshal() (dAtA []byte, err error) {
+func (m *CreateGroupReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3631,12 +4317,12 @@ func (m *DisconnectReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DisconnectReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *CreateGroupReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DisconnectReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CreateGroupReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3645,24 +4331,38 @@ func (m *DisconnectReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.SID) > 0 {
-		i -= len(m.SID)
-		copy(dAtA[i:], m.SID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.SID)))
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Owner)))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x22
 	}
-	if len(m.UID) > 0 {
-		i -= len(m.UID)
-		copy(dAtA[i:], m.UID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
-		i--
-		dAtA[i] = 0xa
+	if len(m.Introduction) > 0 {
+		i -= len(m.Introduction)
+		copy(dAtA[i:], m.Introduction)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Introduction)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *HeartbeatReq) Marshal() (dAtA []byte, err error) {
+func (m *GetGroupsReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3672,12 +4372,12 @@ func (m *HeartbeatReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HeartbeatReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetGroupsReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *HeartbeatReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GetGroupsReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3686,31 +4386,24 @@ func (m *HeartbeatReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.ServerID) > 0 {
-		i -= len(m.ServerID)
-		copy(dAtA[i:], m.ServerID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.ServerID)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.SID) > 0 {
-		i -= len(m.SID)
-		copy(dAtA[i:], m.SID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.SID)))
-		i--
-		dAtA[i] = 0x12
-	}
 	if len(m.UID) > 0 {
 		i -= len(m.UID)
 		copy(dAtA[i:], m.UID)
 		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
 		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
+		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *PullMessageReq) Marshal() (dAtA []byte, err error) {
+func (m *AddMemberReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3720,12 +4413,12 @@ func (m *PullMessageReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PullMessageReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *AddMemberReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *PullMessageReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *AddMemberReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3739,12 +4432,26 @@ func (m *PullMessageReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		copy(dAtA[i:], m.UID)
 		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
 		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.GID) > 0 {
+		i -= len(m.GID)
+		copy(dAtA[i:], m.GID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.GID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
+		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *PushMessageReq) Marshal() (dAtA []byte, err error) {
+func (m *GetMembersReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3754,12 +4461,12 @@ func (m *PushMessageReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PushMessageReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetMembersReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *PushMessageReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GetMembersReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3768,64 +4475,24 @@ func (m *PushMessageReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Mentions) > 0 {
-		for iNdEx := len(m.Mentions) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Mentions[iNdEx])
-			copy(dAtA[i:], m.Mentions[iNdEx])
-			i = encodeVarintApi(dAtA, i, uint64(len(m.Mentions[iNdEx])))
-			i--
-			dAtA[i] = 0x42
-		}
-	}
-	if len(m.Body) > 0 {
-		i -= len(m.Body)
-		copy(dAtA[i:], m.Body)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Body)))
-		i--
-		dAtA[i] = 0x3a
-	}
-	if m.ContentType != 0 {
-		i = encodeVarintApi(dAtA, i, uint64(m.ContentType))
-		i--
-		dAtA[i] = 0x30
-	}
-	if len(m.Receiver) > 0 {
-		i -= len(m.Receiver)
-		copy(dAtA[i:], m.Receiver)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Receiver)))
-		i--
-		dAtA[i] = 0x2a
-	}
-	if len(m.Sender) > 0 {
-		i -= len(m.Sender)
-		copy(dAtA[i:], m.Sender)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Sender)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.MessageType != 0 {
-		i = encodeVarintApi(dAtA, i, uint64(m.MessageType))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.SID) > 0 {
-		i -= len(m.SID)
-		copy(dAtA[i:], m.SID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.SID)))
+	if len(m.GID) > 0 {
+		i -= len(m.GID)
+		copy(dAtA[i:], m.GID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.GID)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.ClientID) > 0 {
-		i -= len(m.ClientID)
-		copy(dAtA[i:], m.ClientID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientID)))
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ReadMessageReq) Marshal() (dAtA []byte, err error) {
+func (m *ListenReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3835,12 +4502,12 @@ func (m *ReadMessageReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ReadMessageReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *ListenReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ReadMessageReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ListenReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3849,29 +4516,17 @@ func (m *ReadMessageReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Sequence != 0 {
-		i = encodeVarintApi(dAtA, i, uint64(m.Sequence))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Topic) > 0 {
-		i -= len(m.Topic)
-		copy(dAtA[i:], m.Topic)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Topic)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.UID) > 0 {
-		i -= len(m.UID)
-		copy(dAtA[i:], m.UID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *KeypressReq) Marshal() (dAtA []byte, err error) {
+func (m *ConnectReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3881,12 +4536,12 @@ func (m *KeypressReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KeypressReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *ConnectReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *KeypressReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ConnectReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3895,24 +4550,31 @@ func (m *KeypressReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Topic) > 0 {
-		i -= len(m.Topic)
-		copy(dAtA[i:], m.Topic)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Topic)))
+	if len(m.ServerID) > 0 {
+		i -= len(m.ServerID)
+		copy(dAtA[i:], m.ServerID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ServerID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.SID) > 0 {
+		i -= len(m.SID)
+		copy(dAtA[i:], m.SID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.SID)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.UID) > 0 {
-		i -= len(m.UID)
-		copy(dAtA[i:], m.UID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
+	if len(m.JWTToken) > 0 {
+		i -= len(m.JWTToken)
+		copy(dAtA[i:], m.JWTToken)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.JWTToken)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GetClientResp) Marshal() (dAtA []byte, err error) {
+func (m *DisconnectReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3922,12 +4584,12 @@ func (m *GetClientResp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetClientResp) MarshalTo(dAtA []byte) (int, error) {
+func (m *DisconnectReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetClientResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *DisconnectReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3936,22 +4598,31 @@ func (m *GetClientResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Client != nil {
-		{
-			size, err := m.Client.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintApi(dAtA, i, uint64(size))
-		}
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.SID) > 0 {
+		i -= len(m.SID)
+		copy(dAtA[i:], m.SID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.SID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *CreateClientResp) Marshal() (dAtA []byte, err error) {
+func (m *HeartbeatReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3961,12 +4632,12 @@ func (m *CreateClientResp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CreateClientResp) MarshalTo(dAtA []byte) (int, error) {
+func (m *HeartbeatReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CreateClientResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *HeartbeatReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3975,24 +4646,38 @@ func (m *CreateClientResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.ClientSecret) > 0 {
-		i -= len(m.ClientSecret)
-		copy(dAtA[i:], m.ClientSecret)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientSecret)))
-		i--
-		dAtA[i] = 0x12
-	}
 	if len(m.ClientID) > 0 {
 		i -= len(m.ClientID)
 		copy(dAtA[i:], m.ClientID)
 		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientID)))
 		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ServerID) > 0 {
+		i -= len(m.ServerID)
+		copy(dAtA[i:], m.ServerID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ServerID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.SID) > 0 {
+		i -= len(m.SID)
+		copy(dAtA[i:], m.SID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.SID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
+		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *TokenResp) Marshal() (dAtA []byte, err error) {
+func (m *PullMessageReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4002,12 +4687,12 @@ func (m *TokenResp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TokenResp) MarshalTo(dAtA []byte) (int, error) {
+func (m *PullMessageReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *TokenResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *PullMessageReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4016,24 +4701,24 @@ func (m *TokenResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Lifetime) > 0 {
-		i -= len(m.Lifetime)
-		copy(dAtA[i:], m.Lifetime)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Lifetime)))
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientID)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Token) > 0 {
-		i -= len(m.Token)
-		copy(dAtA[i:], m.Token)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *CreateUserResp) Marshal() (dAtA []byte, err error) {
+func (m *SearchMessagesReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4043,12 +4728,12 @@ func (m *CreateUserResp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CreateUserResp) MarshalTo(dAtA []byte) (int, error) {
+func (m *SearchMessagesReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CreateUserResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SearchMessagesReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4057,17 +4742,78 @@ func (m *CreateUserResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Limit != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x48
+	}
+	if len(m.ContentTypes) > 0 {
+		dAtA8 := make([]byte, len(m.ContentTypes)*10)
+		var j7 int
+		for _, num := range m.ContentTypes {
+			for num >= 1<<7 {
+				dAtA8[j7] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j7++
+			}
+			dAtA8[j7] = uint8(num)
+			j7++
+		}
+		i -= j7
+		copy(dAtA[i:], dAtA8[:j7])
+		i = encodeVarintApi(dAtA, i, uint64(j7))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.EndAt != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.EndAt))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.StartAt != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.StartAt))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Topic) > 0 {
+		i -= len(m.Topic)
+		copy(dAtA[i:], m.Topic)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Topic)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Query) > 0 {
+		i -= len(m.Query)
+		copy(dAtA[i:], m.Query)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Query)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.UID) > 0 {
 		i -= len(m.UID)
 		copy(dAtA[i:], m.UID)
 		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
 		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientID)))
+		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GetFriendsResp) Marshal() (dAtA []byte, err error) {
+func (m *PushMessageReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4077,12 +4823,12 @@ func (m *GetFriendsResp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetFriendsResp) MarshalTo(dAtA []byte) (int, error) {
+func (m *PushMessageReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetFriendsResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *PushMessageReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4091,19 +4837,64 @@ func (m *GetFriendsResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Friends) > 0 {
-		for iNdEx := len(m.Friends) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Friends[iNdEx])
-			copy(dAtA[i:], m.Friends[iNdEx])
-			i = encodeVarintApi(dAtA, i, uint64(len(m.Friends[iNdEx])))
+	if len(m.Mentions) > 0 {
+		for iNdEx := len(m.Mentions) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Mentions[iNdEx])
+			copy(dAtA[i:], m.Mentions[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.Mentions[iNdEx])))
 			i--
-			dAtA[i] = 0xa
+			dAtA[i] = 0x42
 		}
 	}
+	if len(m.Body) > 0 {
+		i -= len(m.Body)
+		copy(dAtA[i:], m.Body)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Body)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.ContentType != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.ContentType))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Receiver) > 0 {
+		i -= len(m.Receiver)
+		copy(dAtA[i:], m.Receiver)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Receiver)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.MessageType != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.MessageType))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.SID) > 0 {
+		i -= len(m.SID)
+		copy(dAtA[i:], m.SID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.SID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0xa
+	}
 	return len(dAtA) - i, nil
 }
 
-func (m *CreateGroupResp) Marshal() (dAtA []byte, err error) {
+func (m *ReadMessageReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4113,12 +4904,12 @@ func (m *CreateGroupResp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CreateGroupResp) MarshalTo(dAtA []byte) (int, error) {
+func (m *ReadMessageReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CreateGroupResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ReadMessageReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4127,22 +4918,36 @@ func (m *CreateGroupResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Group != nil {
-		{
-			size, err := m.Group.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintApi(dAtA, i, uint64(size))
-		}
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Sequence != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Topic) > 0 {
+		i -= len(m.Topic)
+		copy(dAtA[i:], m.Topic)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Topic)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GetGroupsResp) Marshal() (dAtA []byte, err error) {
+func (m *PushRoomMessageReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4152,12 +4957,12 @@ func (m *GetGroupsResp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetGroupsResp) MarshalTo(dAtA []byte) (int, error) {
+func (m *PushRoomMessageReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetGroupsResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *PushRoomMessageReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4166,24 +4971,38 @@ func (m *GetGroupsResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Groups) > 0 {
-		for iNdEx := len(m.Groups) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Groups[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintApi(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	if len(m.Body) > 0 {
+		i -= len(m.Body)
+		copy(dAtA[i:], m.Body)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Body)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Room) > 0 {
+		i -= len(m.Room)
+		copy(dAtA[i:], m.Room)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Room)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GetMembersResp) Marshal() (dAtA []byte, err error) {
+func (m *BroadcastReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4193,12 +5012,12 @@ func (m *GetMembersResp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetMembersResp) MarshalTo(dAtA []byte) (int, error) {
+func (m *BroadcastReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetMembersResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *BroadcastReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4207,19 +5026,58 @@ func (m *GetMembersResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Members) > 0 {
-		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Members[iNdEx])
-			copy(dAtA[i:], m.Members[iNdEx])
-			i = encodeVarintApi(dAtA, i, uint64(len(m.Members[iNdEx])))
+	if len(m.ServerIDs) > 0 {
+		for iNdEx := len(m.ServerIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ServerIDs[iNdEx])
+			copy(dAtA[i:], m.ServerIDs[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.ServerIDs[iNdEx])))
 			i--
-			dAtA[i] = 0xa
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.UIDs) > 0 {
+		for iNdEx := len(m.UIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.UIDs[iNdEx])
+			copy(dAtA[i:], m.UIDs[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.UIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.MinVersion) > 0 {
+		i -= len(m.MinVersion)
+		copy(dAtA[i:], m.MinVersion)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.MinVersion)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Platforms) > 0 {
+		for iNdEx := len(m.Platforms) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Platforms[iNdEx])
+			copy(dAtA[i:], m.Platforms[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.Platforms[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
 		}
 	}
+	if len(m.Body) > 0 {
+		i -= len(m.Body)
+		copy(dAtA[i:], m.Body)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Body)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0xa
+	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ConnectResp) Marshal() (dAtA []byte, err error) {
+func (m *BroadcastRoomReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4229,12 +5087,12 @@ func (m *ConnectResp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ConnectResp) MarshalTo(dAtA []byte) (int, error) {
+func (m *BroadcastRoomReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ConnectResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *BroadcastRoomReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4243,24 +5101,37 @@ func (m *ConnectResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.UID) > 0 {
-		i -= len(m.UID)
-		copy(dAtA[i:], m.UID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
+	if m.SampleRate != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.SampleRate))))
+		i--
+		dAtA[i] = 0x21
+	}
+	if len(m.Body) > 0 {
+		i -= len(m.Body)
+		copy(dAtA[i:], m.Body)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Body)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Room) > 0 {
+		i -= len(m.Room)
+		copy(dAtA[i:], m.Room)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Room)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.ClientID) > 0 {
-		i -= len(m.ClientID)
-		copy(dAtA[i:], m.ClientID)
-		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientID)))
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *PullMessageResp) Marshal() (dAtA []byte, err error) {
+func (m *KeypressReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4270,12 +5141,12 @@ func (m *PullMessageResp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PullMessageResp) MarshalTo(dAtA []byte) (int, error) {
+func (m *KeypressReq) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *PullMessageResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *KeypressReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4284,24 +5155,31 @@ func (m *PullMessageResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.TopicMessages) > 0 {
-		for iNdEx := len(m.TopicMessages) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.TopicMessages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintApi(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Topic) > 0 {
+		i -= len(m.Topic)
+		copy(dAtA[i:], m.Topic)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Topic)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *PushMessageResp) Marshal() (dAtA []byte, err error) {
+func (m *GetClientResp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4311,12 +5189,12 @@ func (m *PushMessageResp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PushMessageResp) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetClientResp) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *PushMessageResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GetClientResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4325,320 +5203,503 @@ func (m *PushMessageResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Sequence != 0 {
-		i = encodeVarintApi(dAtA, i, uint64(m.Sequence))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.MessageId != 0 {
-		i = encodeVarintApi(dAtA, i, uint64(m.MessageId))
+	if m.Client != nil {
+		{
+			size, err := m.Client.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintApi(dAtA, i, uint64(size))
+		}
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintApi(dAtA []byte, offset int, v uint64) int {
-	offset -= sovApi(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *CreateClientResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *Empty) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+
+func (m *CreateClientResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *StringValue) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *CreateClientResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *Int64Value) Size() (n int) {
-	if m == nil {
-		return 0
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	var l int
-	_ = l
-	if m.Value != 0 {
-		n += 1 + sovApi(uint64(m.Value))
+	if len(m.ClientSecret) > 0 {
+		i -= len(m.ClientSecret)
+		copy(dAtA[i:], m.ClientSecret)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientSecret)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *BoolValue) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *TokenResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TokenResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Value {
-		n += 2
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.Lifetime) > 0 {
+		i -= len(m.Lifetime)
+		copy(dAtA[i:], m.Lifetime)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Lifetime)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *BytesValue) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CreateUserResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateUserResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateUserResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *StringSliceValue) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetFriendsResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetFriendsResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetFriendsResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Value) > 0 {
-		for _, s := range m.Value {
-			l = len(s)
-			n += 1 + l + sovApi(uint64(l))
-		}
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.Friends) > 0 {
+		for iNdEx := len(m.Friends) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Friends[iNdEx])
+			copy(dAtA[i:], m.Friends[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.Friends[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *Client) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CreateGroupResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateGroupResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateGroupResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.CreatedAt != 0 {
-		n += 1 + sovApi(uint64(m.CreatedAt))
-	}
-	if m.UpdatedAt != 0 {
-		n += 1 + sovApi(uint64(m.UpdatedAt))
-	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
-	}
-	l = len(m.TokenSecret)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.TokenExpire != 0 {
-		n += 1 + sovApi(uint64(m.TokenExpire))
-	}
-	if m.UserCount != 0 {
-		n += 1 + sovApi(uint64(m.UserCount))
-	}
-	if m.GroupCount != 0 {
-		n += 1 + sovApi(uint64(m.GroupCount))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if m.Group != nil {
+		{
+			size, err := m.Group.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *Group) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetGroupsResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetGroupsResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetGroupsResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.CreatedAt != 0 {
-		n += 1 + sovApi(uint64(m.CreatedAt))
-	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
-	}
-	l = len(m.GID)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
-	}
-	l = len(m.Introduction)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Owner)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	if len(m.Groups) > 0 {
+		for iNdEx := len(m.Groups) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Groups[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintApi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	if m.MemberCount != 0 {
-		n += 1 + sovApi(uint64(m.MemberCount))
+	return len(dAtA) - i, nil
+}
+
+func (m *GetMembersResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetMembersResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetMembersResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.Members) > 0 {
+		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Members[iNdEx])
+			copy(dAtA[i:], m.Members[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.Members[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *TopicMessages) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ConnectResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Topic)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Messages) > 0 {
-		for _, e := range m.Messages {
-			l = e.Size()
-			n += 1 + l + sovApi(uint64(l))
+	if len(m.Topics) > 0 {
+		for k := range m.Topics {
+			v := m.Topics[k]
+			baseI := i
+			i = encodeVarintApi(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintApi(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintApi(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
 		}
 	}
-	if m.Count != 0 {
-		n += 1 + sovApi(uint64(m.Count))
+	if len(m.UID) > 0 {
+		i -= len(m.UID)
+		copy(dAtA[i:], m.UID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.UID)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *Message) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PullMessageResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *PullMessageResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PullMessageResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		n += 1 + sovApi(uint64(m.ID))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.CreatedAt != 0 {
-		n += 1 + sovApi(uint64(m.CreatedAt))
+	if len(m.TopicMessages) > 0 {
+		for iNdEx := len(m.TopicMessages) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TopicMessages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintApi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	l = len(m.MessageType)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	return len(dAtA) - i, nil
+}
+
+func (m *SearchMessagesResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Sender)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
-	}
-	l = len(m.Receiver)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *SearchMessagesResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SearchMessagesResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Topic)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	if len(m.Messages) > 0 {
+		for iNdEx := len(m.Messages) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Messages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintApi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	if m.Sequence != 0 {
-		n += 1 + sovApi(uint64(m.Sequence))
+	return len(dAtA) - i, nil
+}
+
+func (m *PushMessageResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.ContentType)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *PushMessageResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushMessageResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Body)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	if len(m.Topic) > 0 {
+		i -= len(m.Topic)
+		copy(dAtA[i:], m.Topic)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Topic)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if len(m.Mentions) > 0 {
-		for _, s := range m.Mentions {
-			l = len(s)
-			n += 1 + l + sovApi(uint64(l))
-		}
+	if m.Sequence != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.MessageId != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.MessageId))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *PushMessage) Size() (n int) {
+func encodeVarintApi(dAtA []byte, offset int, v uint64) int {
+	offset -= sovApi(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Empty) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Operation != 0 {
-		n += 1 + sovApi(uint64(m.Operation))
-	}
-	l = len(m.ServerID)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if len(m.SIDs) > 0 {
-		for _, s := range m.SIDs {
-			l = len(s)
-			n += 1 + l + sovApi(uint64(l))
-		}
-	}
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *BroadcastMessage) Size() (n int) {
+func (m *StringValue) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Servers) > 0 {
-		for k, v := range m.Servers {
-			_ = k
-			_ = v
-			l = 0
-			if v != nil {
-				l = v.Size()
-				l += 1 + sovApi(uint64(l))
-			}
-			mapEntrySize := 1 + len(k) + sovApi(uint64(len(k))) + l
-			n += mapEntrySize + 1 + sovApi(uint64(mapEntrySize))
-		}
-	}
-	l = len(m.Data)
+	l = len(m.Value)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
@@ -4648,15 +5709,14 @@ func (m *BroadcastMessage) Size() (n int) {
 	return n
 }
 
-func (m *GetClientReq) Size() (n int) {
+func (m *Int64Value) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	if m.Value != 0 {
+		n += 1 + sovApi(uint64(m.Value))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -4664,22 +5724,14 @@ func (m *GetClientReq) Size() (n int) {
 	return n
 }
 
-func (m *CreateClientReq) Size() (n int) {
+func (m *BoolValue) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
-	}
-	l = len(m.TokenSecret)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.TokenExpire != 0 {
-		n += 1 + sovApi(uint64(m.TokenExpire))
+	if m.Value {
+		n += 2
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -4687,43 +5739,33 @@ func (m *CreateClientReq) Size() (n int) {
 	return n
 }
 
-func (m *UpdateClientReq) Size() (n int) {
+func (m *BytesValue) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Token)
+	l = len(m.Value)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	if m.Name != nil {
-		l = m.Name.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.TokenSecret != nil {
-		l = m.TokenSecret.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.TokenExpire != nil {
-		l = m.TokenExpire.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *DeleteClientReq) Size() (n int) {
+func (m *StringSliceValue) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	if len(m.Value) > 0 {
+		for _, s := range m.Value {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -4731,62 +5773,103 @@ func (m *DeleteClientReq) Size() (n int) {
 	return n
 }
 
-func (m *GenerateTokenReq) Size() (n int) {
+func (m *Client) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.ClientID)
+	l = len(m.ID)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.ClientSecret)
+	if m.CreatedAt != 0 {
+		n += 1 + sovApi(uint64(m.CreatedAt))
+	}
+	if m.UpdatedAt != 0 {
+		n += 1 + sovApi(uint64(m.UpdatedAt))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.TokenSecret)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
+	if m.TokenExpire != 0 {
+		n += 1 + sovApi(uint64(m.TokenExpire))
+	}
+	if m.UserCount != 0 {
+		n += 1 + sovApi(uint64(m.UserCount))
+	}
+	if m.GroupCount != 0 {
+		n += 1 + sovApi(uint64(m.GroupCount))
+	}
+	if m.MessageMaxAge != 0 {
+		n += 1 + sovApi(uint64(m.MessageMaxAge))
+	}
+	if m.MessageMaxCount != 0 {
+		n += 1 + sovApi(uint64(m.MessageMaxCount))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *CreateUserReq) Size() (n int) {
+func (m *Group) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Token)
+	if m.CreatedAt != 0 {
+		n += 1 + sovApi(uint64(m.CreatedAt))
+	}
+	l = len(m.Name)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.Name)
+	l = len(m.GID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Introduction)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Owner)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
+	if m.MemberCount != 0 {
+		n += 1 + sovApi(uint64(m.MemberCount))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *UpdateActivatedReq) Size() (n int) {
+func (m *TopicMessages) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Token)
+	l = len(m.Topic)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.UID)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	if len(m.Messages) > 0 {
+		for _, e := range m.Messages {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
 	}
-	if m.Activated {
-		n += 2
+	if m.Count != 0 {
+		n += 1 + sovApi(uint64(m.Count))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -4794,179 +5877,274 @@ func (m *UpdateActivatedReq) Size() (n int) {
 	return n
 }
 
-func (m *DeleteUserReq) Size() (n int) {
+func (m *Message) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	if m.ID != 0 {
+		n += 1 + sovApi(uint64(m.ID))
 	}
-	l = len(m.UID)
+	if m.CreatedAt != 0 {
+		n += 1 + sovApi(uint64(m.CreatedAt))
+	}
+	l = len(m.MessageType)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
 	}
-	return n
-}
-
-func (m *GenerateUserTokenReq) Size() (n int) {
-	if m == nil {
-		return 0
+	l = len(m.Receiver)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
 	}
-	var l int
-	_ = l
-	l = len(m.Token)
+	l = len(m.Topic)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.UID)
+	if m.Sequence != 0 {
+		n += 1 + sovApi(uint64(m.Sequence))
+	}
+	l = len(m.ContentType)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Body)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
+	if len(m.Mentions) > 0 {
+		for _, s := range m.Mentions {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *AddFriendReq) Size() (n int) {
+func (m *PushMessage) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Token)
+	if m.Operation != 0 {
+		n += 1 + sovApi(uint64(m.Operation))
+	}
+	l = len(m.ServerID)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.UID)
+	if len(m.SIDs) > 0 {
+		for _, s := range m.SIDs {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	l = len(m.Data)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.FriendUID)
+	l = len(m.Topic)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
+	if m.Sequence != 0 {
+		n += 1 + sovApi(uint64(m.Sequence))
+	}
+	if m.PushedAt != 0 {
+		n += 1 + sovApi(uint64(m.PushedAt))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *GetFriendsReq) Size() (n int) {
+func (m *PushUIDMessage) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Token)
+	if m.Operation != 0 {
+		n += 1 + sovApi(uint64(m.Operation))
+	}
+	if len(m.UIDs) > 0 {
+		for _, s := range m.UIDs {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	l = len(m.SkipSID)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.UID)
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Topic)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
+	if m.Sequence != 0 {
+		n += 1 + sovApi(uint64(m.Sequence))
+	}
+	if m.PushedAt != 0 {
+		n += 1 + sovApi(uint64(m.PushedAt))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *DeleteFriendReq) Size() (n int) {
+func (m *PushTopicMessage) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Token)
+	if m.Operation != 0 {
+		n += 1 + sovApi(uint64(m.Operation))
+	}
+	l = len(m.ClientID)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.UID)
+	l = len(m.Topic)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.FriendUID)
+	l = len(m.SkipSID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Data)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
+	if m.Sequence != 0 {
+		n += 1 + sovApi(uint64(m.Sequence))
+	}
+	if m.PushedAt != 0 {
+		n += 1 + sovApi(uint64(m.PushedAt))
+	}
+	if len(m.JoinUIDs) > 0 {
+		for _, s := range m.JoinUIDs {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *CreateGroupReq) Size() (n int) {
+func (m *BroadcastMessage) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	if len(m.Servers) > 0 {
+		for k, v := range m.Servers {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovApi(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovApi(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovApi(uint64(mapEntrySize))
+		}
 	}
-	l = len(m.Name)
+	l = len(m.Data)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.Introduction)
+	l = len(m.ClientID)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.Owner)
+	if len(m.Platforms) > 0 {
+		for _, s := range m.Platforms {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	l = len(m.MinVersion)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
+	if len(m.UIDs) > 0 {
+		for _, s := range m.UIDs {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if len(m.ServerIDs) > 0 {
+		for _, s := range m.ServerIDs {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *GetGroupsReq) Size() (n int) {
+func (m *BroadcastRoomMessage) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Token)
+	l = len(m.Room)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.UID)
+	l = len(m.Data)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
+	if m.SampleRate != 0 {
+		n += 9
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *AddMemberReq) Size() (n int) {
+func (m *InvalidateLocalMessage) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Token)
+	l = len(m.ClientID)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.GID)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	if m.GroupID != 0 {
+		n += 1 + sovApi(uint64(m.GroupID))
 	}
-	l = len(m.UID)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	if len(m.UIDs) > 0 {
+		for _, s := range m.UIDs {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -4974,27 +6152,38 @@ func (m *AddMemberReq) Size() (n int) {
 	return n
 }
 
-func (m *GetMembersReq) Size() (n int) {
+func (m *DeadLetter) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Token)
+	l = len(m.ServerID)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.GID)
+	l = len(m.Endpoint)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Request)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Error)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
+	if m.CreatedAt != 0 {
+		n += 1 + sovApi(uint64(m.CreatedAt))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *ListenReq) Size() (n int) {
+func (m *GetClientReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -5010,23 +6199,28 @@ func (m *ListenReq) Size() (n int) {
 	return n
 }
 
-func (m *ConnectReq) Size() (n int) {
+func (m *CreateClientReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.JWTToken)
+	l = len(m.Name)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.SID)
+	l = len(m.TokenSecret)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.ServerID)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	if m.TokenExpire != 0 {
+		n += 1 + sovApi(uint64(m.TokenExpire))
+	}
+	if m.MessageMaxAge != 0 {
+		n += 1 + sovApi(uint64(m.MessageMaxAge))
+	}
+	if m.MessageMaxCount != 0 {
+		n += 1 + sovApi(uint64(m.MessageMaxCount))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -5034,42 +6228,34 @@ func (m *ConnectReq) Size() (n int) {
 	return n
 }
 
-func (m *DisconnectReq) Size() (n int) {
+func (m *UpdateClientReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.UID)
+	l = len(m.Token)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.SID)
-	if l > 0 {
+	if m.Name != nil {
+		l = m.Name.Size()
 		n += 1 + l + sovApi(uint64(l))
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *HeartbeatReq) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.TokenSecret != nil {
+		l = m.TokenSecret.Size()
+		n += 1 + l + sovApi(uint64(l))
 	}
-	var l int
-	_ = l
-	l = len(m.UID)
-	if l > 0 {
+	if m.TokenExpire != nil {
+		l = m.TokenExpire.Size()
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.SID)
-	if l > 0 {
+	if m.MessageMaxAge != nil {
+		l = m.MessageMaxAge.Size()
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.ServerID)
-	if l > 0 {
+	if m.MessageMaxCount != nil {
+		l = m.MessageMaxCount.Size()
 		n += 1 + l + sovApi(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
@@ -5078,13 +6264,13 @@ func (m *HeartbeatReq) Size() (n int) {
 	return n
 }
 
-func (m *PullMessageReq) Size() (n int) {
+func (m *DeleteClientReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.UID)
+	l = len(m.Token)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
@@ -5094,7 +6280,7 @@ func (m *PullMessageReq) Size() (n int) {
 	return n
 }
 
-func (m *PushMessageReq) Size() (n int) {
+func (m *GenerateTokenReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -5104,56 +6290,52 @@ func (m *PushMessageReq) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.SID)
+	l = len(m.ClientSecret)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	if m.MessageType != 0 {
-		n += 1 + sovApi(uint64(m.MessageType))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	l = len(m.Sender)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	return n
+}
+
+func (m *CreateUserReq) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	l = len(m.Receiver)
+	var l int
+	_ = l
+	l = len(m.Token)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	if m.ContentType != 0 {
-		n += 1 + sovApi(uint64(m.ContentType))
-	}
-	l = len(m.Body)
+	l = len(m.Name)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	if len(m.Mentions) > 0 {
-		for _, s := range m.Mentions {
-			l = len(s)
-			n += 1 + l + sovApi(uint64(l))
-		}
-	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *ReadMessageReq) Size() (n int) {
+func (m *UpdateActivatedReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.UID)
+	l = len(m.Token)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.Topic)
+	l = len(m.UID)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	if m.Sequence != 0 {
-		n += 1 + sovApi(uint64(m.Sequence))
+	if m.Activated {
+		n += 2
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -5161,17 +6343,37 @@ func (m *ReadMessageReq) Size() (n int) {
 	return n
 }
 
-func (m *KeypressReq) Size() (n int) {
+func (m *DeleteUserReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
 	l = len(m.UID)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.Topic)
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GenerateUserTokenReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.UID)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
@@ -5181,14 +6383,22 @@ func (m *KeypressReq) Size() (n int) {
 	return n
 }
 
-func (m *GetClientResp) Size() (n int) {
+func (m *AddFriendReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Client != nil {
-		l = m.Client.Size()
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.FriendUID)
+	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
@@ -5197,17 +6407,17 @@ func (m *GetClientResp) Size() (n int) {
 	return n
 }
 
-func (m *CreateClientResp) Size() (n int) {
+func (m *GetFriendsReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.ClientID)
+	l = len(m.Token)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.ClientSecret)
+	l = len(m.UID)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
@@ -5217,7 +6427,7 @@ func (m *CreateClientResp) Size() (n int) {
 	return n
 }
 
-func (m *TokenResp) Size() (n int) {
+func (m *DeleteFriendReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -5227,7 +6437,11 @@ func (m *TokenResp) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
-	l = len(m.Lifetime)
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.FriendUID)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
@@ -5237,12 +6451,44 @@ func (m *TokenResp) Size() (n int) {
 	return n
 }
 
-func (m *CreateUserResp) Size() (n int) {
+func (m *CreateGroupReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Introduction)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetGroupsReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
 	l = len(m.UID)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
@@ -5253,17 +6499,23 @@ func (m *CreateUserResp) Size() (n int) {
 	return n
 }
 
-func (m *GetFriendsResp) Size() (n int) {
+func (m *AddMemberReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Friends) > 0 {
-		for _, s := range m.Friends {
-			l = len(s)
-			n += 1 + l + sovApi(uint64(l))
-		}
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.GID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -5271,14 +6523,18 @@ func (m *GetFriendsResp) Size() (n int) {
 	return n
 }
 
-func (m *CreateGroupResp) Size() (n int) {
+func (m *GetMembersReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Group != nil {
-		l = m.Group.Size()
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.GID)
+	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
@@ -5287,17 +6543,15 @@ func (m *CreateGroupResp) Size() (n int) {
 	return n
 }
 
-func (m *GetGroupsResp) Size() (n int) {
+func (m *ListenReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Groups) > 0 {
-		for _, e := range m.Groups {
-			l = e.Size()
-			n += 1 + l + sovApi(uint64(l))
-		}
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -5305,17 +6559,23 @@ func (m *GetGroupsResp) Size() (n int) {
 	return n
 }
 
-func (m *GetMembersResp) Size() (n int) {
+func (m *ConnectReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Members) > 0 {
-		for _, s := range m.Members {
-			l = len(s)
-			n += 1 + l + sovApi(uint64(l))
-		}
+	l = len(m.JWTToken)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.SID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.ServerID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -5323,37 +6583,71 @@ func (m *GetMembersResp) Size() (n int) {
 	return n
 }
 
-func (m *ConnectResp) Size() (n int) {
+func (m *DisconnectReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.SID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
 	l = len(m.ClientID)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *HeartbeatReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
 	l = len(m.UID)
 	if l > 0 {
 		n += 1 + l + sovApi(uint64(l))
 	}
+	l = len(m.SID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.ServerID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *PullMessageResp) Size() (n int) {
+func (m *PullMessageReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.TopicMessages) > 0 {
-		for _, e := range m.TopicMessages {
-			l = e.Size()
-			n += 1 + l + sovApi(uint64(l))
-		}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -5361,17 +6655,47 @@ func (m *PullMessageResp) Size() (n int) {
 	return n
 }
 
-func (m *PushMessageResp) Size() (n int) {
+func (m *SearchMessagesReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.MessageId != 0 {
-		n += 1 + sovApi(uint64(m.MessageId))
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
 	}
-	if m.Sequence != 0 {
-		n += 1 + sovApi(uint64(m.Sequence))
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Query)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.StartAt != 0 {
+		n += 1 + sovApi(uint64(m.StartAt))
+	}
+	if m.EndAt != 0 {
+		n += 1 + sovApi(uint64(m.EndAt))
+	}
+	if len(m.ContentTypes) > 0 {
+		l = 0
+		for _, e := range m.ContentTypes {
+			l += sovApi(uint64(e))
+		}
+		n += 1 + sovApi(uint64(l)) + l
+	}
+	if m.Limit != 0 {
+		n += 1 + sovApi(uint64(m.Limit))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -5379,41 +6703,2604 @@ func (m *PushMessageResp) Size() (n int) {
 	return n
 }
 
-func sovApi(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozApi(x uint64) (n int) {
-	return sovApi(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (m *Empty) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowApi
+func (m *PushMessageReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.SID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.MessageType != 0 {
+		n += 1 + sovApi(uint64(m.MessageType))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Receiver)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.ContentType != 0 {
+		n += 1 + sovApi(uint64(m.ContentType))
+	}
+	l = len(m.Body)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if len(m.Mentions) > 0 {
+		for _, s := range m.Mentions {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReadMessageReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.Sequence != 0 {
+		n += 1 + sovApi(uint64(m.Sequence))
+	}
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PushRoomMessageReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Room)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Body)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BroadcastReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Body)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if len(m.Platforms) > 0 {
+		for _, s := range m.Platforms {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	l = len(m.MinVersion)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if len(m.UIDs) > 0 {
+		for _, s := range m.UIDs {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if len(m.ServerIDs) > 0 {
+		for _, s := range m.ServerIDs {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BroadcastRoomReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Room)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Body)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.SampleRate != 0 {
+		n += 9
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KeypressReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetClientResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Client != nil {
+		l = m.Client.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CreateClientResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.ClientSecret)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TokenResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.Lifetime)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CreateUserResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetFriendsResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Friends) > 0 {
+		for _, s := range m.Friends {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CreateGroupResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Group != nil {
+		l = m.Group.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetGroupsResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Groups) > 0 {
+		for _, e := range m.Groups {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetMembersResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Members) > 0 {
+		for _, s := range m.Members {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConnectResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.UID)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if len(m.Topics) > 0 {
+		for k, v := range m.Topics {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovApi(uint64(len(k))) + 1 + sovApi(uint64(v))
+			n += mapEntrySize + 1 + sovApi(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PullMessageResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.TopicMessages) > 0 {
+		for _, e := range m.TopicMessages {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SearchMessagesResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Messages) > 0 {
+		for _, e := range m.Messages {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PushMessageResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MessageId != 0 {
+		n += 1 + sovApi(uint64(m.MessageId))
+	}
+	if m.Sequence != 0 {
+		n += 1 + sovApi(uint64(m.Sequence))
+	}
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovApi(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozApi(x uint64) (n int) {
+	return sovApi(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Empty) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Empty: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Empty: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StringValue) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StringValue: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StringValue: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Int64Value) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Int64Value: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Int64Value: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			m.Value = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Value |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BoolValue) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BoolValue: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BoolValue: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Value = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BytesValue) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BytesValue: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BytesValue: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StringSliceValue) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StringSliceValue: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StringSliceValue: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Client) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Client: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Client: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			m.CreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+			}
+			m.UpdatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UpdatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenSecret", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenSecret = append(m.TokenSecret[:0], dAtA[iNdEx:postIndex]...)
+			if m.TokenSecret == nil {
+				m.TokenSecret = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenExpire", wireType)
+			}
+			m.TokenExpire = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TokenExpire |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserCount", wireType)
+			}
+			m.UserCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UserCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupCount", wireType)
+			}
+			m.GroupCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageMaxAge", wireType)
+			}
+			m.MessageMaxAge = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MessageMaxAge |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageMaxCount", wireType)
+			}
+			m.MessageMaxCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MessageMaxCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Group) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Group: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Group: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			m.CreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Introduction", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Introduction = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberCount", wireType)
+			}
+			m.MemberCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TopicMessages) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TopicMessages: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TopicMessages: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Messages = append(m.Messages, &Message{})
+			if err := m.Messages[len(m.Messages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Message) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Message: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Message: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			m.CreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MessageType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Receiver", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Receiver = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContentType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Body = append(m.Body[:0], dAtA[iNdEx:postIndex]...)
+			if m.Body == nil {
+				m.Body = []byte{}
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mentions", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Mentions = append(m.Mentions, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PushMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PushMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PushMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
+			}
+			m.Operation = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Operation |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServerID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SIDs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SIDs = append(m.SIDs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PushedAt", wireType)
+			}
+			m.PushedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PushedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PushUIDMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PushUIDMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PushUIDMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
+			}
+			m.Operation = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Operation |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UIDs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UIDs = append(m.UIDs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SkipSID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SkipSID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PushedAt", wireType)
+			}
+			m.PushedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PushedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PushTopicMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PushTopicMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PushTopicMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
+			}
+			m.Operation = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Operation |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SkipSID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SkipSID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
+			if byteLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Empty: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Empty: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PushedAt", wireType)
+			}
+			m.PushedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PushedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JoinUIDs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JoinUIDs = append(m.JoinUIDs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -5439,7 +9326,7 @@ func (m *Empty) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StringValue) Unmarshal(dAtA []byte) error {
+func (m *BroadcastMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5462,15 +9349,178 @@ func (m *StringValue) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StringValue: wiretype end group for non-group")
+			return fmt.Errorf("proto: BroadcastMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StringValue: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BroadcastMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Servers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Servers == nil {
+				m.Servers = make(map[string]*StringSliceValue)
+			}
+			var mapkey string
+			var mapvalue *StringSliceValue
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowApi
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowApi
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthApi
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthApi
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowApi
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthApi
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthApi
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &StringSliceValue{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipApi(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthApi
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Servers[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5498,67 +9548,45 @@ func (m *StringValue) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = string(dAtA[iNdEx:postIndex])
+			m.ClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipApi(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Platforms", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthApi
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthApi
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Int64Value) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowApi
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Int64Value: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Int64Value: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			m.Platforms = append(m.Platforms, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinVersion", wireType)
 			}
-			m.Value = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -5568,70 +9596,61 @@ func (m *Int64Value) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Value |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipApi(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthApi
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *BoolValue) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowApi
+			m.MinVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UIDs", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: BoolValue: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BoolValue: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
 			}
-			var v int
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UIDs = append(m.UIDs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerIDs", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -5641,12 +9660,24 @@ func (m *BoolValue) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Value = bool(v != 0)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServerIDs = append(m.ServerIDs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -5672,7 +9703,7 @@ func (m *BoolValue) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BytesValue) Unmarshal(dAtA []byte) error {
+func (m *BroadcastRoomMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5695,17 +9726,17 @@ func (m *BytesValue) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BytesValue: wiretype end group for non-group")
+			return fmt.Errorf("proto: BroadcastRoomMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BytesValue: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BroadcastRoomMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Room", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -5715,85 +9746,29 @@ func (m *BytesValue) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipApi(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthApi
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthApi
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *StringSliceValue) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowApi
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: StringSliceValue: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StringSliceValue: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Room = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -5803,24 +9778,37 @@ func (m *StringSliceValue) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value, string(dAtA[iNdEx:postIndex]))
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SampleRate", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.SampleRate = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -5846,7 +9834,7 @@ func (m *StringSliceValue) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Client) Unmarshal(dAtA []byte) error {
+func (m *InvalidateLocalMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5869,15 +9857,15 @@ func (m *Client) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Client: wiretype end group for non-group")
+			return fmt.Errorf("proto: InvalidateLocalMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Client: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InvalidateLocalMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5905,13 +9893,13 @@ func (m *Client) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
+			m.ClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
 			}
-			m.CreatedAt = 0
+			m.GroupID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -5921,33 +9909,14 @@ func (m *Client) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CreatedAt |= int64(b&0x7F) << shift
+				m.GroupID |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
-			}
-			m.UpdatedAt = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.UpdatedAt |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UIDs", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5975,99 +9944,8 @@ func (m *Client) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TokenSecret", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthApi
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.TokenSecret = append(m.TokenSecret[:0], dAtA[iNdEx:postIndex]...)
-			if m.TokenSecret == nil {
-				m.TokenSecret = []byte{}
-			}
+			m.UIDs = append(m.UIDs, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TokenExpire", wireType)
-			}
-			m.TokenExpire = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.TokenExpire |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserCount", wireType)
-			}
-			m.UserCount = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.UserCount |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupCount", wireType)
-			}
-			m.GroupCount = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.GroupCount |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -6093,7 +9971,7 @@ func (m *Client) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Group) Unmarshal(dAtA []byte) error {
+func (m *DeadLetter) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6116,17 +9994,17 @@ func (m *Group) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Group: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeadLetter: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Group: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeadLetter: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerID", wireType)
 			}
-			m.CreatedAt = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -6136,14 +10014,27 @@ func (m *Group) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CreatedAt |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServerID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6171,13 +10062,13 @@ func (m *Group) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Endpoint = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Request", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -6187,27 +10078,29 @@ func (m *Group) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GID = string(dAtA[iNdEx:postIndex])
+			m.Request = append(m.Request[:0], dAtA[iNdEx:postIndex]...)
+			if m.Request == nil {
+				m.Request = []byte{}
+			}
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Introduction", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6235,11 +10128,84 @@ func (m *Group) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Introduction = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			m.CreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetClientReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetClientReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetClientReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6267,27 +10233,8 @@ func (m *Group) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Owner = string(dAtA[iNdEx:postIndex])
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MemberCount", wireType)
-			}
-			m.MemberCount = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.MemberCount |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -6313,7 +10260,7 @@ func (m *Group) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TopicMessages) Unmarshal(dAtA []byte) error {
+func (m *CreateClientReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6336,15 +10283,15 @@ func (m *TopicMessages) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TopicMessages: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateClientReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TopicMessages: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateClientReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6372,13 +10319,13 @@ func (m *TopicMessages) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Topic = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenSecret", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -6388,31 +10335,29 @@ func (m *TopicMessages) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Messages = append(m.Messages, &Message{})
-			if err := m.Messages[len(m.Messages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.TokenSecret = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenExpire", wireType)
 			}
-			m.Count = 0
+			m.TokenExpire = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -6422,7 +10367,45 @@ func (m *TopicMessages) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Count |= int64(b&0x7F) << shift
+				m.TokenExpire |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageMaxAge", wireType)
+			}
+			m.MessageMaxAge = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MessageMaxAge |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageMaxCount", wireType)
+			}
+			m.MessageMaxCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MessageMaxCount |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6452,7 +10435,7 @@ func (m *TopicMessages) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Message) Unmarshal(dAtA []byte) error {
+func (m *UpdateClientReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6475,53 +10458,15 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Message: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateClientReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Message: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateClientReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			m.ID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ID |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
-			}
-			m.CreatedAt = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.CreatedAt |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MessageType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6549,13 +10494,13 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MessageType = string(dAtA[iNdEx:postIndex])
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -6565,61 +10510,33 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Sender = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Receiver", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthApi
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthApi
+			if m.Name == nil {
+				m.Name = &StringValue{}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if err := m.Name.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.Receiver = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenSecret", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -6629,48 +10546,33 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Topic = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			if m.TokenSecret == nil {
+				m.TokenSecret = &StringValue{}
 			}
-			m.Sequence = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Sequence |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if err := m.TokenSecret.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		case 8:
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContentType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenExpire", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -6680,29 +10582,33 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ContentType = string(dAtA[iNdEx:postIndex])
+			if m.TokenExpire == nil {
+				m.TokenExpire = &Int64Value{}
+			}
+			if err := m.TokenExpire.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 9:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageMaxAge", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -6712,31 +10618,33 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Body = append(m.Body[:0], dAtA[iNdEx:postIndex]...)
-			if m.Body == nil {
-				m.Body = []byte{}
+			if m.MessageMaxAge == nil {
+				m.MessageMaxAge = &Int64Value{}
+			}
+			if err := m.MessageMaxAge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 10:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Mentions", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageMaxCount", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -6746,23 +10654,27 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Mentions = append(m.Mentions, string(dAtA[iNdEx:postIndex]))
+			if m.MessageMaxCount == nil {
+				m.MessageMaxCount = &Int64Value{}
+			}
+			if err := m.MessageMaxCount.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -6789,7 +10701,7 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PushMessage) Unmarshal(dAtA []byte) error {
+func (m *DeleteClientReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6812,66 +10724,15 @@ func (m *PushMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PushMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteClientReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PushMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteClientReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
-			}
-			m.Operation = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Operation |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServerID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthApi
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ServerID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SIDs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6899,41 +10760,7 @@ func (m *PushMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SIDs = append(m.SIDs, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthApi
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -6960,7 +10787,7 @@ func (m *PushMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BroadcastMessage) Unmarshal(dAtA []byte) error {
+func (m *GenerateTokenReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6983,17 +10810,17 @@ func (m *BroadcastMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BroadcastMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: GenerateTokenReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BroadcastMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GenerateTokenReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Servers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -7003,126 +10830,29 @@ func (m *BroadcastMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Servers == nil {
-				m.Servers = make(map[string]*StringSliceValue)
-			}
-			var mapkey string
-			var mapvalue *StringSliceValue
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowApi
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowApi
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthApi
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthApi
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapmsglen int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowApi
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapmsglen |= int(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					if mapmsglen < 0 {
-						return ErrInvalidLengthApi
-					}
-					postmsgIndex := iNdEx + mapmsglen
-					if postmsgIndex < 0 {
-						return ErrInvalidLengthApi
-					}
-					if postmsgIndex > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = &StringSliceValue{}
-					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
-						return err
-					}
-					iNdEx = postmsgIndex
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipApi(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthApi
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.Servers[mapkey] = mapvalue
+			m.ClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientSecret", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -7132,25 +10862,23 @@ func (m *BroadcastMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
+			m.ClientSecret = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -7177,7 +10905,7 @@ func (m *BroadcastMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetClientReq) Unmarshal(dAtA []byte) error {
+func (m *CreateUserReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7200,10 +10928,10 @@ func (m *GetClientReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetClientReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateUserReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetClientReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateUserReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7238,6 +10966,38 @@ func (m *GetClientReq) Unmarshal(dAtA []byte) error {
 			}
 			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -7263,7 +11023,7 @@ func (m *GetClientReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateClientReq) Unmarshal(dAtA []byte) error {
+func (m *UpdateActivatedReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7286,15 +11046,15 @@ func (m *CreateClientReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateClientReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateActivatedReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateClientReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateActivatedReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7322,11 +11082,11 @@ func (m *CreateClientReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TokenSecret", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7354,13 +11114,13 @@ func (m *CreateClientReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TokenSecret = string(dAtA[iNdEx:postIndex])
+			m.UID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TokenExpire", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Activated", wireType)
 			}
-			m.TokenExpire = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -7370,11 +11130,12 @@ func (m *CreateClientReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TokenExpire |= int64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Activated = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -7400,7 +11161,7 @@ func (m *CreateClientReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateClientReq) Unmarshal(dAtA []byte) error {
+func (m *DeleteUserReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7423,10 +11184,10 @@ func (m *UpdateClientReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateClientReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteUserReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateClientReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteUserReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7463,9 +11224,9 @@ func (m *UpdateClientReq) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -7475,33 +11236,83 @@ func (m *UpdateClientReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Name == nil {
-				m.Name = &StringValue{}
-			}
-			if err := m.Name.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 3:
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GenerateUserTokenReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GenerateUserTokenReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GenerateUserTokenReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TokenSecret", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -7511,33 +11322,29 @@ func (m *UpdateClientReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TokenSecret == nil {
-				m.TokenSecret = &StringValue{}
-			}
-			if err := m.TokenSecret.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TokenExpire", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -7547,27 +11354,23 @@ func (m *UpdateClientReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TokenExpire == nil {
-				m.TokenExpire = &Int64Value{}
-			}
-			if err := m.TokenExpire.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.UID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -7594,7 +11397,7 @@ func (m *UpdateClientReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteClientReq) Unmarshal(dAtA []byte) error {
+func (m *AddFriendReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7617,10 +11420,10 @@ func (m *DeleteClientReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteClientReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: AddFriendReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteClientReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AddFriendReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7655,6 +11458,70 @@ func (m *DeleteClientReq) Unmarshal(dAtA []byte) error {
 			}
 			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FriendUID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FriendUID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -7680,7 +11547,7 @@ func (m *DeleteClientReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GenerateTokenReq) Unmarshal(dAtA []byte) error {
+func (m *GetFriendsReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7703,15 +11570,15 @@ func (m *GenerateTokenReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GenerateTokenReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetFriendsReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GenerateTokenReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetFriendsReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7739,11 +11606,11 @@ func (m *GenerateTokenReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClientID = string(dAtA[iNdEx:postIndex])
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientSecret", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7771,7 +11638,7 @@ func (m *GenerateTokenReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClientSecret = string(dAtA[iNdEx:postIndex])
+			m.UID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -7798,7 +11665,7 @@ func (m *GenerateTokenReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateUserReq) Unmarshal(dAtA []byte) error {
+func (m *DeleteFriendReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7821,10 +11688,10 @@ func (m *CreateUserReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateUserReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteFriendReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateUserReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteFriendReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7861,7 +11728,7 @@ func (m *CreateUserReq) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7889,7 +11756,39 @@ func (m *CreateUserReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FriendUID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FriendUID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -7916,7 +11815,7 @@ func (m *CreateUserReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateActivatedReq) Unmarshal(dAtA []byte) error {
+func (m *CreateGroupReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7939,10 +11838,10 @@ func (m *UpdateActivatedReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateActivatedReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateGroupReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateActivatedReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateGroupReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7979,7 +11878,7 @@ func (m *UpdateActivatedReq) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8007,13 +11906,13 @@ func (m *UpdateActivatedReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UID = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Activated", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Introduction", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -8023,12 +11922,56 @@ func (m *UpdateActivatedReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Activated = bool(v != 0)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Introduction = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -8054,7 +11997,7 @@ func (m *UpdateActivatedReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteUserReq) Unmarshal(dAtA []byte) error {
+func (m *GetGroupsReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8077,10 +12020,10 @@ func (m *DeleteUserReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteUserReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetGroupsReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteUserReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetGroupsReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8172,7 +12115,7 @@ func (m *DeleteUserReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GenerateUserTokenReq) Unmarshal(dAtA []byte) error {
+func (m *AddMemberReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8195,10 +12138,10 @@ func (m *GenerateUserTokenReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GenerateUserTokenReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: AddMemberReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GenerateUserTokenReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AddMemberReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8234,6 +12177,38 @@ func (m *GenerateUserTokenReq) Unmarshal(dAtA []byte) error {
 			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
 			}
@@ -8290,7 +12265,7 @@ func (m *GenerateUserTokenReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddFriendReq) Unmarshal(dAtA []byte) error {
+func (m *GetMembersReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8313,10 +12288,10 @@ func (m *AddFriendReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddFriendReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetMembersReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddFriendReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetMembersReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8353,7 +12328,7 @@ func (m *AddFriendReq) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8381,11 +12356,65 @@ func (m *AddFriendReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UID = string(dAtA[iNdEx:postIndex])
+			m.GID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListenReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListenReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListenReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FriendUID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8413,7 +12442,7 @@ func (m *AddFriendReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.FriendUID = string(dAtA[iNdEx:postIndex])
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -8440,7 +12469,7 @@ func (m *AddFriendReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetFriendsReq) Unmarshal(dAtA []byte) error {
+func (m *ConnectReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8463,15 +12492,47 @@ func (m *GetFriendsReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetFriendsReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConnectReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetFriendsReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConnectReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field JWTToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JWTToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8499,11 +12560,11 @@ func (m *GetFriendsReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.SID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8531,7 +12592,7 @@ func (m *GetFriendsReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UID = string(dAtA[iNdEx:postIndex])
+			m.ServerID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -8558,7 +12619,7 @@ func (m *GetFriendsReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteFriendReq) Unmarshal(dAtA []byte) error {
+func (m *DisconnectReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8581,15 +12642,15 @@ func (m *DeleteFriendReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteFriendReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: DisconnectReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteFriendReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DisconnectReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8617,11 +12678,11 @@ func (m *DeleteFriendReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.UID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8649,11 +12710,11 @@ func (m *DeleteFriendReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UID = string(dAtA[iNdEx:postIndex])
+			m.SID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FriendUID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8681,7 +12742,7 @@ func (m *DeleteFriendReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.FriendUID = string(dAtA[iNdEx:postIndex])
+			m.ClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -8708,7 +12769,7 @@ func (m *DeleteFriendReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateGroupReq) Unmarshal(dAtA []byte) error {
+func (m *HeartbeatReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8731,15 +12792,15 @@ func (m *CreateGroupReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateGroupReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: HeartbeatReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateGroupReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HeartbeatReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8767,11 +12828,11 @@ func (m *CreateGroupReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.UID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8799,11 +12860,11 @@ func (m *CreateGroupReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.SID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Introduction", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8831,11 +12892,11 @@ func (m *CreateGroupReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Introduction = string(dAtA[iNdEx:postIndex])
+			m.ServerID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8863,7 +12924,7 @@ func (m *CreateGroupReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Owner = string(dAtA[iNdEx:postIndex])
+			m.ClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -8890,7 +12951,7 @@ func (m *CreateGroupReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetGroupsReq) Unmarshal(dAtA []byte) error {
+func (m *PullMessageReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8913,15 +12974,15 @@ func (m *GetGroupsReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetGroupsReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: PullMessageReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetGroupsReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PullMessageReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8949,11 +13010,11 @@ func (m *GetGroupsReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.UID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8981,7 +13042,7 @@ func (m *GetGroupsReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UID = string(dAtA[iNdEx:postIndex])
+			m.ClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -9008,7 +13069,7 @@ func (m *GetGroupsReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddMemberReq) Unmarshal(dAtA []byte) error {
+func (m *SearchMessagesReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9031,15 +13092,15 @@ func (m *AddMemberReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddMemberReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: SearchMessagesReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddMemberReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SearchMessagesReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9067,11 +13128,107 @@ func (m *AddMemberReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.ClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9099,13 +13256,120 @@ func (m *AddMemberReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartAt", wireType)
+			}
+			m.StartAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndAt", wireType)
+			}
+			m.EndAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType == 0 {
+				var v ContentType
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowApi
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= ContentType(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ContentTypes = append(m.ContentTypes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowApi
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthApi
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthApi
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				if elementCount != 0 && len(m.ContentTypes) == 0 {
+					m.ContentTypes = make([]ContentType, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v ContentType
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowApi
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= ContentType(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ContentTypes = append(m.ContentTypes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentTypes", wireType)
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
 			}
-			var stringLen uint64
+			m.Limit = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -9115,24 +13379,11 @@ func (m *AddMemberReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Limit |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthApi
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.UID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -9158,7 +13409,7 @@ func (m *AddMemberReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetMembersReq) Unmarshal(dAtA []byte) error {
+func (m *PushMessageReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9181,15 +13432,15 @@ func (m *GetMembersReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetMembersReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: PushMessageReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetMembersReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PushMessageReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9217,11 +13468,11 @@ func (m *GetMembersReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.ClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9249,65 +13500,30 @@ func (m *GetMembersReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GID = string(dAtA[iNdEx:postIndex])
+			m.SID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipApi(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthApi
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthApi
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ListenReq) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowApi
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageType", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.MessageType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MessageType |= MessageType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ListenReq: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListenReq: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9335,65 +13551,11 @@ func (m *ListenReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.Sender = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipApi(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthApi
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthApi
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ConnectReq) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowApi
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ConnectReq: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConnectReq: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JWTToken", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Receiver", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9421,13 +13583,32 @@ func (m *ConnectReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.JWTToken = string(dAtA[iNdEx:postIndex])
+			m.Receiver = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentType", wireType)
+			}
+			m.ContentType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ContentType |= ContentType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -9437,27 +13618,29 @@ func (m *ConnectReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SID = string(dAtA[iNdEx:postIndex])
+			m.Body = append(m.Body[:0], dAtA[iNdEx:postIndex]...)
+			if m.Body == nil {
+				m.Body = []byte{}
+			}
 			iNdEx = postIndex
-		case 3:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServerID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Mentions", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9485,7 +13668,7 @@ func (m *ConnectReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ServerID = string(dAtA[iNdEx:postIndex])
+			m.Mentions = append(m.Mentions, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -9512,7 +13695,7 @@ func (m *ConnectReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DisconnectReq) Unmarshal(dAtA []byte) error {
+func (m *ReadMessageReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9531,19 +13714,51 @@ func (m *DisconnectReq) Unmarshal(dAtA []byte) error {
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: DisconnectReq: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DisconnectReq: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReadMessageReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReadMessageReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9571,11 +13786,30 @@ func (m *DisconnectReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UID = string(dAtA[iNdEx:postIndex])
+			m.Topic = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9603,7 +13837,7 @@ func (m *DisconnectReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SID = string(dAtA[iNdEx:postIndex])
+			m.ClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -9630,7 +13864,7 @@ func (m *DisconnectReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HeartbeatReq) Unmarshal(dAtA []byte) error {
+func (m *PushRoomMessageReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9653,15 +13887,15 @@ func (m *HeartbeatReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HeartbeatReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: PushRoomMessageReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HeartbeatReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PushRoomMessageReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9689,11 +13923,11 @@ func (m *HeartbeatReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UID = string(dAtA[iNdEx:postIndex])
+			m.ClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9721,11 +13955,11 @@ func (m *HeartbeatReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SID = string(dAtA[iNdEx:postIndex])
+			m.Sender = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServerID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Room", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9753,67 +13987,13 @@ func (m *HeartbeatReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ServerID = string(dAtA[iNdEx:postIndex])
+			m.Room = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipApi(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthApi
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthApi
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *PullMessageReq) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowApi
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PullMessageReq: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PullMessageReq: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -9823,23 +14003,25 @@ func (m *PullMessageReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UID = string(dAtA[iNdEx:postIndex])
+			m.Body = append(m.Body[:0], dAtA[iNdEx:postIndex]...)
+			if m.Body == nil {
+				m.Body = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -9866,7 +14048,7 @@ func (m *PullMessageReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PushMessageReq) Unmarshal(dAtA []byte) error {
+func (m *BroadcastReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9889,15 +14071,15 @@ func (m *PushMessageReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PushMessageReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: BroadcastReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PushMessageReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BroadcastReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9925,13 +14107,13 @@ func (m *PushMessageReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClientID = string(dAtA[iNdEx:postIndex])
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -9941,46 +14123,29 @@ func (m *PushMessageReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SID = string(dAtA[iNdEx:postIndex])
+			m.Body = append(m.Body[:0], dAtA[iNdEx:postIndex]...)
+			if m.Body == nil {
+				m.Body = []byte{}
+			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MessageType", wireType)
-			}
-			m.MessageType = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.MessageType |= MessageType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Platforms", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10008,11 +14173,11 @@ func (m *PushMessageReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Sender = string(dAtA[iNdEx:postIndex])
+			m.Platforms = append(m.Platforms, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 5:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Receiver", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MinVersion", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10040,32 +14205,13 @@ func (m *PushMessageReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Receiver = string(dAtA[iNdEx:postIndex])
+			m.MinVersion = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContentType", wireType)
-			}
-			m.ContentType = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ContentType |= ContentType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 7:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UIDs", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -10075,29 +14221,27 @@ func (m *PushMessageReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthApi
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Body = append(m.Body[:0], dAtA[iNdEx:postIndex]...)
-			if m.Body == nil {
-				m.Body = []byte{}
-			}
+			m.UIDs = append(m.UIDs, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 8:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Mentions", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerIDs", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10125,7 +14269,7 @@ func (m *PushMessageReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Mentions = append(m.Mentions, string(dAtA[iNdEx:postIndex]))
+			m.ServerIDs = append(m.ServerIDs, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10152,7 +14296,7 @@ func (m *PushMessageReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReadMessageReq) Unmarshal(dAtA []byte) error {
+func (m *BroadcastRoomReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10175,15 +14319,15 @@ func (m *ReadMessageReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReadMessageReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: BroadcastRoomReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReadMessageReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BroadcastRoomReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10211,11 +14355,11 @@ func (m *ReadMessageReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UID = string(dAtA[iNdEx:postIndex])
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Room", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10243,13 +14387,13 @@ func (m *ReadMessageReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Topic = string(dAtA[iNdEx:postIndex])
+			m.Room = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
 			}
-			m.Sequence = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -10259,11 +14403,37 @@ func (m *ReadMessageReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Sequence |= int64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Body = append(m.Body[:0], dAtA[iNdEx:postIndex]...)
+			if m.Body == nil {
+				m.Body = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SampleRate", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.SampleRate = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -10382,6 +14552,38 @@ func (m *KeypressReq) Unmarshal(dAtA []byte) error {
 			}
 			m.Topic = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -11262,6 +15464,119 @@ func (m *ConnectResp) Unmarshal(dAtA []byte) error {
 			}
 			m.UID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topics", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Topics == nil {
+				m.Topics = make(map[string]int64)
+			}
+			var mapkey string
+			var mapvalue int64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowApi
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowApi
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthApi
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthApi
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowApi
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipApi(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthApi
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Topics[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -11375,6 +15690,94 @@ func (m *PullMessageResp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SearchMessagesResp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SearchMessagesResp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SearchMessagesResp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Messages = append(m.Messages, &Message{})
+			if err := m.Messages[len(m.Messages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *PushMessageResp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -11442,6 +15845,38 @@ func (m *PushMessageResp) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -193,6 +193,10 @@ type ChatClientAdminService interface {
 	AddMember(ctx context.Context, in *AddMemberReq, opts ...client.CallOption) (*Empty, error)
 	// Get users of the group
 	GetMembers(ctx context.Context, in *GetMembersReq, opts ...client.CallOption) (*GetMembersResp, error)
+	// Broadcast a message to the sessions of the client matching the filters
+	Broadcast(ctx context.Context, in *BroadcastReq, opts ...client.CallOption) (*Empty, error)
+	// Broadcast a message to the sessions in the room
+	BroadcastRoom(ctx context.Context, in *BroadcastRoomReq, opts ...client.CallOption) (*Empty, error)
 	// Listening all real-time messages under the client to which the current token belongs
 	Listen(ctx context.Context, in *ListenReq, opts ...client.CallOption) (ChatClientAdmin_ListenService, error)
 }
@@ -369,6 +373,26 @@ func (c *chatClientAdminService) GetMembers(ctx context.Context, in *GetMembersR
 	return out, nil
 }
 
+func (c *chatClientAdminService) Broadcast(ctx context.Context, in *BroadcastReq, opts ...client.CallOption) (*Empty, error) {
+	req := c.c.NewRequest(c.name, "ChatClientAdmin.Broadcast", in)
+	out := new(Empty)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatClientAdminService) BroadcastRoom(ctx context.Context, in *BroadcastRoomReq, opts ...client.CallOption) (*Empty, error) {
+	req := c.c.NewRequest(c.name, "ChatClientAdmin.BroadcastRoom", in)
+	out := new(Empty)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chatClientAdminService) Listen(ctx context.Context, in *ListenReq, opts ...client.CallOption) (ChatClientAdmin_ListenService, error) {
 	req := c.c.NewRequest(c.name, "ChatClientAdmin.Listen", &ListenReq{})
 	stream, err := c.c.Stream(ctx, req, opts...)
@@ -453,6 +477,10 @@ type ChatClientAdminHandler interface {
 	AddMember(context.Context, *AddMemberReq, *Empty) error
 	// Get users of the group
 	GetMembers(context.Context, *GetMembersReq, *GetMembersResp) error
+	// Broadcast a message to the sessions of the client matching the filters
+	Broadcast(context.Context, *BroadcastReq, *Empty) error
+	// Broadcast a message to the sessions in the room
+	BroadcastRoom(context.Context, *BroadcastRoomReq, *Empty) error
 	// Listening all real-time messages under the client to which the current token belongs
 	Listen(context.Context, *ListenReq, ChatClientAdmin_ListenStream) error
 }
@@ -475,6 +503,8 @@ func RegisterChatClientAdminHandler(s server.Server, hdlr ChatClientAdminHandler
 		GetGroups(ctx context.Context, in *GetGroupsReq, out *GetGroupsResp) error
 		AddMember(ctx context.Context, in *AddMemberReq, out *Empty) error
 		GetMembers(ctx context.Context, in *GetMembersReq, out *GetMembersResp) error
+		Broadcast(ctx context.Context, in *BroadcastReq, out *Empty) error
+		BroadcastRoom(ctx context.Context, in *BroadcastRoomReq, out *Empty) error
 		Listen(ctx context.Context, stream server.Stream) error
 	}
 	type ChatClientAdmin struct {
@@ -552,6 +582,14 @@ func (h *chatClientAdminHandler) GetMembers(ctx context.Context, in *GetMembersR
 	return h.ChatClientAdminHandler.GetMembers(ctx, in, out)
 }
 
+func (h *chatClientAdminHandler) Broadcast(ctx context.Context, in *BroadcastReq, out *Empty) error {
+	return h.ChatClientAdminHandler.Broadcast(ctx, in, out)
+}
+
+func (h *chatClientAdminHandler) BroadcastRoom(ctx context.Context, in *BroadcastRoomReq, out *Empty) error {
+	return h.ChatClientAdminHandler.BroadcastRoom(ctx, in, out)
+}
+
 func (h *chatClientAdminHandler) Listen(ctx context.Context, stream server.Stream) error {
 	m := new(ListenReq)
 	if err := stream.Recv(m); err != nil {
@@ -613,8 +651,12 @@ type ChatService interface {
 	PullMessage(ctx context.Context, in *PullMessageReq, opts ...client.CallOption) (*PullMessageResp, error)
 	// Read message
 	ReadMessage(ctx context.Context, in *ReadMessageReq, opts ...client.CallOption) (*Empty, error)
+	// Search the messages of the topics of the user
+	SearchMessages(ctx context.Context, in *SearchMessagesReq, opts ...client.CallOption) (*SearchMessagesResp, error)
 	// Keypress
 	Keypress(ctx context.Context, in *KeypressReq, opts ...client.CallOption) (*Empty, error)
+	// Push message to a room
+	PushRoomMessage(ctx context.Context, in *PushRoomMessageReq, opts ...client.CallOption) (*Empty, error)
 }
 
 type chatService struct {
@@ -689,6 +731,16 @@ func (c *chatService) ReadMessage(ctx context.Context, in *ReadMessageReq, opts
 	return out, nil
 }
 
+func (c *chatService) SearchMessages(ctx context.Context, in *SearchMessagesReq, opts ...client.CallOption) (*SearchMessagesResp, error) {
+	req := c.c.NewRequest(c.name, "Chat.SearchMessages", in)
+	out := new(SearchMessagesResp)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chatService) Keypress(ctx context.Context, in *KeypressReq, opts ...client.CallOption) (*Empty, error) {
 	req := c.c.NewRequest(c.name, "Chat.Keypress", in)
 	out := new(Empty)
@@ -699,6 +751,16 @@ func (c *chatService) Keypress(ctx context.Context, in *KeypressReq, opts ...cli
 	return out, nil
 }
 
+func (c *chatService) PushRoomMessage(ctx context.Context, in *PushRoomMessageReq, opts ...client.CallOption) (*Empty, error) {
+	req := c.c.NewRequest(c.name, "Chat.PushRoomMessage", in)
+	out := new(Empty)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Chat service
 
 type ChatHandler interface {
@@ -714,8 +776,12 @@ type ChatHandler interface {
 	PullMessage(context.Context, *PullMessageReq, *PullMessageResp) error
 	// Read message
 	ReadMessage(context.Context, *ReadMessageReq, *Empty) error
+	// Search the messages of the topics of the user
+	SearchMessages(context.Context, *SearchMessagesReq, *SearchMessagesResp) error
 	// Keypress
 	Keypress(context.Context, *KeypressReq, *Empty) error
+	// Push message to a room
+	PushRoomMessage(context.Context, *PushRoomMessageReq, *Empty) error
 }
 
 func RegisterChatHandler(s server.Server, hdlr ChatHandler, opts ...server.HandlerOption) error {
@@ -726,7 +792,9 @@ func RegisterChatHandler(s server.Server, hdlr ChatHandler, opts ...server.Handl
 		PushMessage(ctx context.Context, in *PushMessageReq, out *PushMessageResp) error
 		PullMessage(ctx context.Context, in *PullMessageReq, out *PullMessageResp) error
 		ReadMessage(ctx context.Context, in *ReadMessageReq, out *Empty) error
+		SearchMessages(ctx context.Context, in *SearchMessagesReq, out *SearchMessagesResp) error
 		Keypress(ctx context.Context, in *KeypressReq, out *Empty) error
+		PushRoomMessage(ctx context.Context, in *PushRoomMessageReq, out *Empty) error
 	}
 	type Chat struct {
 		chat
@@ -763,6 +831,14 @@ func (h *chatHandler) ReadMessage(ctx context.Context, in *ReadMessageReq, out *
 	return h.ChatHandler.ReadMessage(ctx, in, out)
 }
 
+func (h *chatHandler) SearchMessages(ctx context.Context, in *SearchMessagesReq, out *SearchMessagesResp) error {
+	return h.ChatHandler.SearchMessages(ctx, in, out)
+}
+
 func (h *chatHandler) Keypress(ctx context.Context, in *KeypressReq, out *Empty) error {
 	return h.ChatHandler.Keypress(ctx, in, out)
 }
+
+func (h *chatHandler) PushRoomMessage(ctx context.Context, in *PushRoomMessageReq, out *Empty) error {
+	return h.ChatHandler.PushRoomMessage(ctx, in, out)
+}
@@ -14,6 +14,9 @@ type Client struct {
 	Credential  string `gorm:"type:VARCHAR;column:credential"`
 	UserCount   int32  `gorm:"column:user_count"`
 	GroupCount  int32  `gorm:"column:group_count"`
+	// In seconds
+	MessageMaxAge   int64 `gorm:"column:message_max_age"`
+	MessageMaxCount int64 `gorm:"column:message_max_count"`
 }
 
 type User struct {
@@ -63,6 +66,8 @@ type Message struct {
 	ID        int64 `gorm:"primary_key;column:id"`
 	CreatedAt int64 `gorm:"column:created_at"`
 	UpdatedAt int64 `gorm:"column:updated_at"`
+	// The client the message belongs to
+	ClientID string `gorm:"type:VARCHAR;column:client_id"`
 	// The topic of the message
 	Topic string `gorm:"type:VARCHAR;column:topic"`
 	// The sequence of the topic
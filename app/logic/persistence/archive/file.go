@@ -0,0 +1,142 @@
+// Package archive implements persistence.Archiver on cold storage.
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+	"mercury/app/logic/persistence"
+	"mercury/x/ecode"
+)
+
+// FileArchiver appends the messages of a topic to a file of JSON lines in its directory,
+// the files can be moved to cheaper storage as they are only read by the history. A
+// message archived again replaces the one with its sequence.
+type FileArchiver struct {
+	dir string
+	mu  sync.RWMutex
+}
+
+// NewFileArchiver returns the archiver of dir, which is created if it does not exist.
+func NewFileArchiver(dir string) (*FileArchiver, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	return &FileArchiver{dir: dir}, nil
+}
+
+func (a *FileArchiver) path(topic string) string {
+	return filepath.Join(a.dir, url.PathEscape(topic)+".jsonl")
+}
+
+func (a *FileArchiver) Archive(_ context.Context, messages []*persistence.Message) error {
+	byTopic := make(map[string][]byte)
+	for _, message := range messages {
+		line, err := jsoniter.Marshal(message)
+		if err != nil {
+			return err
+		}
+		byTopic[message.Topic] = append(append(byTopic[message.Topic], line...), '\n')
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for topic, lines := range byTopic {
+		if err := appendFile(a.path(topic), lines); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appendFile(name string, data []byte) error {
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// read returns the archived messages of the topic, in the order of their sequences.
+func (a *FileArchiver) read(topic string) ([]*persistence.Message, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	f, err := os.Open(a.path(topic))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	bySequence := make(map[int64]*persistence.Message)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 16<<20)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var message persistence.Message
+		if err := jsoniter.Unmarshal(line, &message); err != nil {
+			return nil, err
+		}
+		bySequence[message.Sequence] = &message
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	messages := make([]*persistence.Message, 0, len(bySequence))
+	for _, message := range bySequence {
+		messages = append(messages, message)
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].Sequence < messages[j].Sequence
+	})
+	return messages, nil
+}
+
+func (a *FileArchiver) GetTopicMessageBySequence(_ context.Context, topic string, sequence int64) (*persistence.Message, error) {
+	archived, err := a.read(topic)
+	if err != nil {
+		return nil, err
+	}
+	for _, message := range archived {
+		if message.Sequence == sequence {
+			return message, nil
+		}
+	}
+	return nil, ecode.ErrDataDoesNotExist
+}
+
+func (a *FileArchiver) GetTopicMessages(_ context.Context, topic string, after, before int64) ([]*persistence.Message, error) {
+	archived, err := a.read(topic)
+	if err != nil {
+		return nil, err
+	}
+	var messages []*persistence.Message
+	for i := len(archived) - 1; i >= 0; i-- {
+		if s := archived[i].Sequence; s > after && (before <= 0 || s < before) {
+			messages = append(messages, archived[i])
+		}
+	}
+	return messages, nil
+}
+
+func (a *FileArchiver) Close() error {
+	return nil
+}
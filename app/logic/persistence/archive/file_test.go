@@ -0,0 +1,17 @@
+package archive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"mercury/app/logic/persistence"
+	"mercury/app/logic/persistence/persistencetest"
+)
+
+func TestFileArchiver(t *testing.T) {
+	persistencetest.RunArchiver(t, func(t *testing.T) persistence.Archiver {
+		a, err := NewFileArchiver(t.TempDir())
+		require.NoError(t, err)
+		return a
+	})
+}
@@ -2,49 +2,44 @@ package cache
 
 import (
 	"mercury/x"
+	"mercury/x/database/redis"
 	"time"
 
-	"github.com/go-redis/redis/v7"
+	goredis "github.com/go-redis/redis/v7"
 )
 
 const (
-	// keys
-	userSessionServerKey = "userSessionServer:%s"
-	sessionServerKey     = "sessionServer:%s"
-
-	// scripts
-	addMappingLUA = `
-		if redis.call("HEXISTS", KEYS[1], ARGV[1]) == 1 then
-            return 0
-        end
-		redis.call("HSET", KEYS[1], ARGV[1], ARGV[2])
-		redis.call("EXPIRE", KEYS[1], ARGV[3])
-		redis.call("SET", KEYS[2], ARGV[2]);
-        redis.call("EXPIRE", KEYS[2], ARGV[3])
-        return 1
-    `
+	// keys, scoped by the client
+	userSessionServerKey = "userSessionServer:%s:%s"
+	sessionServerKey     = "sessionServer:%s:%s"
 )
 
 // Mapping expiration time
 var mappingExpire = 1800 * time.Second
 
-// key: uid; field: sid; value: serverID
-func (c *Cache) AddMapping(uid, sid, serverID string) error {
-	keys := []string{
-		x.Sprintf(userSessionServerKey, uid),
-		x.Sprintf(sessionServerKey, sid),
+// key: uid; field: sid; value: serverID. The keys of the user and the session are in
+// different slots of a cluster, they are not set by a script.
+func (c *Cache) AddMapping(clientID, uid, sid, serverID string) error {
+	userKey := x.Sprintf(userSessionServerKey, clientID, uid)
+	added, err := c.client.HSetNX(userKey, sid, serverID).Result()
+	if err != nil || !added {
+		return err
 	}
-	args := []interface{}{sid, serverID, mappingExpire.Seconds()}
-	err := redis.NewScript(addMappingLUA).Run(c.client, keys, args...).Err()
+
+	_, err = c.client.Pipelined(func(pipe redis.Pipeliner) error {
+		pipe.Expire(userKey, mappingExpire)
+		pipe.Set(x.Sprintf(sessionServerKey, clientID, sid), serverID, mappingExpire)
+		return nil
+	})
 	return err
 }
 
-func (c *Cache) ExpireMapping(uid, sid string) (bool, error) {
+func (c *Cache) ExpireMapping(clientID, uid, sid string) (bool, error) {
 	var (
 		expired bool
 		err     error
 	)
-	expired, err = c.client.Expire(x.Sprintf(userSessionServerKey, uid), mappingExpire).Result()
+	expired, err = c.client.Expire(x.Sprintf(userSessionServerKey, clientID, uid), mappingExpire).Result()
 	if err != nil {
 		return false, err
 	}
@@ -52,7 +47,7 @@ func (c *Cache) ExpireMapping(uid, sid string) (bool, error) {
 		return expired, err
 	}
 
-	expired, err = c.client.Expire(x.Sprintf(sessionServerKey, sid), mappingExpire).Result()
+	expired, err = c.client.Expire(x.Sprintf(sessionServerKey, clientID, sid), mappingExpire).Result()
 	if err != nil {
 		return false, err
 	}
@@ -61,31 +56,29 @@ func (c *Cache) ExpireMapping(uid, sid string) (bool, error) {
 }
 
 // Delete the mapping
-func (c *Cache) DeleteMapping(uid, sid string) error {
-	var err error
-	if err = c.client.HDel(x.Sprintf(userSessionServerKey, uid), sid).Err(); err != nil {
-		return err
-	}
+func (c *Cache) DeleteMapping(clientID, uid, sid string) error {
+	_, err := c.client.Pipelined(func(pipe redis.Pipeliner) error {
+		pipe.HDel(x.Sprintf(userSessionServerKey, clientID, uid), sid)
+		pipe.Del(x.Sprintf(sessionServerKey, clientID, sid))
+		return nil
+	})
+	return err
+}
 
-	if err = c.client.Del(x.Sprintf(sessionServerKey, sid)).Err(); err != nil {
-		return err
+func (c *Cache) GetSessions(clientID string, uids ...string) (map[string]string, []string, error) {
+	users, err := c.GetUsersSessions(clientID, uids...)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return nil
-}
-
-func (c *Cache) GetSessions(uids ...string) (map[string]string, []string, error) {
 	sessions := make(map[string]string)
 	var onlineUIDs []string
 	for _, uid := range uids {
-		result, err := c.client.HGetAll(x.Sprintf(userSessionServerKey, uid)).Result()
-		if err != nil {
-			return nil, nil, err
-		}
-
-		if len(result) > 0 {
-			onlineUIDs = append(onlineUIDs, uid)
+		result, ok := users[uid]
+		if !ok {
+			continue
 		}
+		onlineUIDs = append(onlineUIDs, uid)
 
 		for k, v := range result {
 			sessions[k] = v
@@ -95,23 +88,53 @@ func (c *Cache) GetSessions(uids ...string) (map[string]string, []string, error)
 	return sessions, onlineUIDs, nil
 }
 
-func (c *Cache) GetServerIDs(sids ...string) ([]string, error) {
+// GetUsersSessions gets the sessions of the users in a pipeline, a cluster sends it to
+// the nodes of their slots.
+func (c *Cache) GetUsersSessions(clientID string, uids ...string) (map[string]map[string]string, error) {
+	users := make(map[string]map[string]string)
+	if len(uids) == 0 {
+		return users, nil
+	}
+
+	cmds := make([]*goredis.StringStringMapCmd, len(uids))
+	_, err := c.client.Pipelined(func(pipe redis.Pipeliner) error {
+		for i, uid := range uids {
+			cmds[i] = pipe.HGetAll(x.Sprintf(userSessionServerKey, clientID, uid))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, cmd := range cmds {
+		if result := cmd.Val(); len(result) > 0 {
+			users[uids[i]] = result
+		}
+	}
+
+	return users, nil
+}
+
+// GetServerIDs gets the servers of the sessions in a pipeline, MGET fails for keys in
+// different slots of a cluster.
+func (c *Cache) GetServerIDs(clientID string, sids ...string) ([]string, error) {
 	var servers []string
 	if len(sids) > 0 {
-		var keys []string
-		for _, sid := range sids {
-			keys = append(keys, x.Sprintf(sessionServerKey, sid))
-		}
-		result, err := c.client.MGet(keys...).Result()
-		if err != nil {
+		cmds := make([]*goredis.StringCmd, len(sids))
+		_, err := c.client.Pipelined(func(pipe redis.Pipeliner) error {
+			for i, sid := range sids {
+				cmds[i] = pipe.Get(x.Sprintf(sessionServerKey, clientID, sid))
+			}
+			return nil
+		})
+		if err != nil && err != redis.RedisNil {
 			return nil, err
 		}
 
-		for _, v := range result {
-			var serverID string
-			if v != nil {
-				serverID = v.(string)
-			}
+		for _, cmd := range cmds {
+			// A missing session is an empty server ID
+			serverID, _ := cmd.Result()
 			servers = append(servers, serverID)
 		}
 	}
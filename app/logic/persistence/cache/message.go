@@ -2,21 +2,23 @@ package cache
 
 import (
 	"mercury/x"
+	"mercury/x/database/redis"
 	"strconv"
 )
 
 const (
-	// keys
-	userTopicSequenceKey = "userTopicLastSequence:%s"
-	userTopicsKey        = "userTopics:%s"
+	// keys scoped by the client, the ones of a user have the same hash tag to be in the
+	// same slot of a cluster
+	userTopicSequenceKey = "userTopicLastSequence:{%s:%s}"
+	userTopicsKey        = "userTopics:{%s:%s}"
 )
 
-func (c *Cache) SetUserTopicLastSequence(uid, topic string, sequence int64) error {
-	return c.client.HSet(x.Sprintf(userTopicSequenceKey, uid), topic, sequence).Err()
+func (c *Cache) SetUserTopicLastSequence(clientID, uid, topic string, sequence int64) error {
+	return c.client.HSet(x.Sprintf(userTopicSequenceKey, clientID, uid), topic, sequence).Err()
 }
 
-func (c *Cache) GetUserTopicLastSequence(uid, topic string) (int64, error) {
-	result, err := c.client.HGet(x.Sprintf(userTopicSequenceKey, uid), topic).Int64()
+func (c *Cache) GetUserTopicLastSequence(clientID, uid, topic string) (int64, error) {
+	result, err := c.client.HGet(x.Sprintf(userTopicSequenceKey, clientID, uid), topic).Int64()
 	if err != nil {
 		return 0, err
 	}
@@ -24,9 +26,9 @@ func (c *Cache) GetUserTopicLastSequence(uid, topic string) (int64, error) {
 	return result, nil
 }
 
-func (c *Cache) GetUserTopicsLastSequence(uid string) (map[string]int64, error) {
+func (c *Cache) GetUserTopicsLastSequence(clientID, uid string) (map[string]int64, error) {
 	topics := make(map[string]int64)
-	result, err := c.client.HGetAll(x.Sprintf(userTopicSequenceKey, uid)).Result()
+	result, err := c.client.HGetAll(x.Sprintf(userTopicSequenceKey, clientID, uid)).Result()
 	if err != nil {
 		return nil, err
 	}
@@ -39,19 +41,46 @@ func (c *Cache) GetUserTopicsLastSequence(uid string) (map[string]int64, error)
 	return topics, nil
 }
 
-func (c *Cache) SetUsersTopic(uids []string, topic string) error {
-	for _, uid := range uids {
-		key := x.Sprintf(userTopicsKey, uid)
-		if !c.client.HExists(key, topic).Val() {
-			if err := c.client.SAdd(key, topic).Err(); err != nil {
-				return err
-			}
-		}
+// SetUsersTopic adds the topic to the users in a pipeline.
+func (c *Cache) SetUsersTopic(clientID string, uids []string, topic string) error {
+	if len(uids) == 0 {
+		return nil
 	}
 
-	return nil
+	_, err := c.client.Pipelined(func(pipe redis.Pipeliner) error {
+		for _, uid := range uids {
+			pipe.SAdd(x.Sprintf(userTopicsKey, clientID, uid), topic)
+		}
+		return nil
+	})
+	return err
 }
 
-func (c *Cache) GetUserTopics(uid string) ([]string, error) {
-	return c.client.SMembers(x.Sprintf(userTopicsKey, uid)).Result()
+func (c *Cache) GetUserTopics(clientID, uid string) ([]string, error) {
+	return c.client.SMembers(x.Sprintf(userTopicsKey, clientID, uid)).Result()
+}
+
+// SetUserTopics sets the topics of the user and their last sequences in a transaction,
+// the keys of the user are in the same slot.
+func (c *Cache) SetUserTopics(clientID, uid string, topics map[string]int64) error {
+	if len(topics) == 0 {
+		return nil
+	}
+
+	members := make([]interface{}, 0, len(topics))
+	sequences := make(map[string]interface{})
+	for topic, sequence := range topics {
+		members = append(members, topic)
+		if sequence > 0 {
+			sequences[topic] = sequence
+		}
+	}
+	_, err := c.client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.SAdd(x.Sprintf(userTopicsKey, clientID, uid), members...)
+		if len(sequences) > 0 {
+			pipe.HSet(x.Sprintf(userTopicSequenceKey, clientID, uid), sequences)
+		}
+		return nil
+	})
+	return err
 }
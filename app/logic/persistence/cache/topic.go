@@ -8,23 +8,23 @@ import (
 )
 
 const (
-	topicSequenceKey     = "topicSequence:%v"
+	topicSequenceKey     = "topicSequence:%s:%s"
 	defaultTopicLifetime = 3600 * time.Second
 )
 
-func (c *Cache) GetTopicSequence(topic string) (int64, error) {
-	key := x.Sprintf(topicSequenceKey, topic)
+func (c *Cache) GetTopicSequence(clientID, topic string) (int64, error) {
+	key := x.Sprintf(topicSequenceKey, clientID, topic)
 	return c.client.Get(key).Int64()
 }
 
-func (c *Cache) SetTopicSequence(topic string, sequence int64, lifetime time.Duration) error {
+func (c *Cache) SetTopicSequence(clientID, topic string, sequence int64, lifetime time.Duration) error {
 	if topic == "" {
 		return ecode.NewError("topic is missing")
 	}
 	if lifetime == 0 {
 		lifetime = defaultTopicLifetime
 	}
-	key := x.Sprintf(topicSequenceKey, topic)
+	key := x.Sprintf(topicSequenceKey, clientID, topic)
 	if err := c.client.Set(key, sequence, lifetime).Err(); err != nil {
 		return err
 	}
@@ -32,15 +32,38 @@ func (c *Cache) SetTopicSequence(topic string, sequence int64, lifetime time.Dur
 	return nil
 }
 
-func (c *Cache) IncrTopicSequence(topic string) (int64, error) {
-	key := x.Sprintf(topicSequenceKey, topic)
-	// Make sure the key already exists
-	if c.client.Exists(key).Val() != 1 {
-		return 0, redis.RedisNil
+var (
+	// Increments the sequence only if it exists, INCR would start a missing one from 0
+	incrTopicSequenceScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return false
+end
+return redis.call('INCR', KEYS[1])
+`)
+
+	// Raises the sequence to ARGV[1] with the lifetime ARGV[2] in seconds if it is missing
+	// or lower, a sequence behind the database after a failover is caught up
+	seedTopicSequenceScript = redis.NewScript(`
+local sequence = redis.call('GET', KEYS[1])
+if not sequence or tonumber(sequence) < tonumber(ARGV[1]) then
+	redis.call('SET', KEYS[1], ARGV[1], 'EX', ARGV[2])
+end
+return redis.call('INCR', KEYS[1])
+`)
+)
+
+func (c *Cache) IncrTopicSequence(clientID, topic string) (int64, error) {
+	key := x.Sprintf(topicSequenceKey, clientID, topic)
+	return incrTopicSequenceScript.Run(c.client, []string{key}).Int64()
+}
+
+func (c *Cache) SeedTopicSequence(clientID, topic string, last int64, lifetime time.Duration) (int64, error) {
+	if topic == "" {
+		return 0, ecode.NewError("topic is missing")
 	}
-	sequence, err := c.client.Incr(key).Result()
-	if err != nil {
-		return 0, err
+	if lifetime == 0 {
+		lifetime = defaultTopicLifetime
 	}
-	return sequence, nil
+	key := x.Sprintf(topicSequenceKey, clientID, topic)
+	return seedTopicSequenceScript.Run(c.client, []string{key}, last, int64(lifetime/time.Second)).Int64()
 }
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mercury/config"
+	"mercury/x/database/redis"
+)
+
+type testConfig struct {
+	redis *config.Redis
+}
+
+func (c testConfig) Redis() *config.Redis {
+	return c.redis
+}
+
+// newTestCache returns a cache of a miniredis server, which runs the Lua scripts.
+func newTestCache(t *testing.T) (*Cache, *miniredis.Miniredis) {
+	m := miniredis.RunT(t)
+	client, err := redis.NewClient(testConfig{&config.Redis{Mode: config.RedisModeSingle, Address: m.Addr()}})
+	require.NoError(t, err)
+	c := NewCache(client)
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	return c, m
+}
+
+func TestIncrTopicSequence(t *testing.T) {
+	c, m := newTestCache(t)
+
+	// A missing sequence is left missing
+	_, err := c.IncrTopicSequence("c1", "grp1")
+	require.Equal(t, redis.RedisNil, err)
+	require.False(t, m.Exists("topicSequence:c1:grp1"))
+
+	require.NoError(t, c.SetTopicSequence("c1", "grp1", 5, 0))
+	sequence, err := c.IncrTopicSequence("c1", "grp1")
+	require.NoError(t, err)
+	require.Equal(t, int64(6), sequence)
+	// The sequences are scoped by the client
+	_, err = c.IncrTopicSequence("c2", "grp1")
+	require.Equal(t, redis.RedisNil, err)
+}
+
+func TestSeedTopicSequence(t *testing.T) {
+	c, m := newTestCache(t)
+
+	// A missing sequence starts after the last one, with the lifetime
+	sequence, err := c.SeedTopicSequence("c1", "grp1", 10, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, int64(11), sequence)
+	require.Equal(t, time.Minute, m.TTL("topicSequence:c1:grp1"))
+
+	// A lower sequence is raised, e.g. after a failover lost the latest increments
+	require.NoError(t, c.SetTopicSequence("c1", "grp1", 3, 0))
+	sequence, err = c.SeedTopicSequence("c1", "grp1", 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(11), sequence)
+	require.Equal(t, defaultTopicLifetime, m.TTL("topicSequence:c1:grp1"))
+
+	// A higher one is only incremented
+	sequence, err = c.SeedTopicSequence("c1", "grp1", 5, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(12), sequence)
+
+	_, err = c.SeedTopicSequence("c1", "", 5, 0)
+	require.Error(t, err)
+}
+
+func TestTopicSequenceConcurrently(t *testing.T) {
+	c, _ := newTestCache(t)
+
+	// The servers seeding and incrementing a missing sequence at once never allocate the
+	// same sequence twice
+	const n = 50
+	var (
+		wg        sync.WaitGroup
+		mux       sync.Mutex
+		sequences = make(map[int64]bool)
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(seed bool) {
+			defer wg.Done()
+			sequence, err := c.IncrTopicSequence("c1", "grp1")
+			if seed || err == redis.RedisNil {
+				sequence, err = c.SeedTopicSequence("c1", "grp1", 100, 0)
+			}
+			assert.NoError(t, err)
+			mux.Lock()
+			defer mux.Unlock()
+			assert.False(t, sequences[sequence], "sequence %d is allocated twice", sequence)
+			sequences[sequence] = true
+		}(i%5 == 0)
+	}
+	wg.Wait()
+
+	require.Len(t, sequences, n)
+	for sequence := int64(101); sequence <= 100+n; sequence++ {
+		require.True(t, sequences[sequence], "sequence %d is missing", sequence)
+	}
+}
@@ -13,6 +13,10 @@ type Client struct {
 	TokenExpire time.Duration
 	UserCount   int64
 	GroupCount  int64
+	// Messages older than MessageMaxAge and the ones beyond the latest MessageMaxCount
+	// of a topic are purged, 0 keeps them
+	MessageMaxAge   time.Duration
+	MessageMaxCount int64
 }
 
 type ClientCreate struct {
@@ -21,6 +25,9 @@ type ClientCreate struct {
 	TokenSecret string
 	Credential  string
 	TokenExpire int64
+	// In seconds
+	MessageMaxAge   int64
+	MessageMaxCount int64
 }
 
 type ClientUpdate struct {
@@ -28,4 +35,7 @@ type ClientUpdate struct {
 	Name        *string
 	TokenSecret *string
 	TokenExpire *int64
+	// In seconds
+	MessageMaxAge   *int64
+	MessageMaxCount *int64
 }
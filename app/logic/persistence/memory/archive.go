@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"mercury/app/logic/persistence"
+	"mercury/x/ecode"
+)
+
+// Archive implements persistence.Archiver, a message archived again replaces the one
+// with its sequence.
+type Archive struct {
+	mu sync.RWMutex
+	// Messages by topic, in the order of their sequences
+	messages map[string][]*persistence.Message
+}
+
+func NewArchive() *Archive {
+	return &Archive{
+		messages: make(map[string][]*persistence.Message),
+	}
+}
+
+func (a *Archive) Archive(_ context.Context, messages []*persistence.Message) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, message := range messages {
+		archived := a.messages[message.Topic]
+		i := searchSequence(archived, message.Sequence)
+		if i < len(archived) && archived[i].Sequence == message.Sequence {
+			archived[i] = copyMessage(message)
+			continue
+		}
+		archived = append(archived, nil)
+		copy(archived[i+1:], archived[i:])
+		archived[i] = copyMessage(message)
+		a.messages[message.Topic] = archived
+	}
+	return nil
+}
+
+func (a *Archive) GetTopicMessageBySequence(_ context.Context, topic string, sequence int64) (*persistence.Message, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	archived := a.messages[topic]
+	i := searchSequence(archived, sequence)
+	if i == len(archived) || archived[i].Sequence != sequence {
+		return nil, ecode.ErrDataDoesNotExist
+	}
+	return copyMessage(archived[i]), nil
+}
+
+func (a *Archive) GetTopicMessages(_ context.Context, topic string, after, before int64) ([]*persistence.Message, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	archived := a.messages[topic]
+	from, to := searchSequence(archived, after+1), len(archived)
+	if before > 0 {
+		to = searchSequence(archived, before)
+	}
+	var messages []*persistence.Message
+	for i := to - 1; i >= from; i-- {
+		messages = append(messages, copyMessage(archived[i]))
+	}
+	return messages, nil
+}
+
+func (a *Archive) Close() error {
+	return nil
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"mercury/app/logic/persistence"
+	"mercury/app/logic/persistence/persistencetest"
+)
+
+func TestArchive(t *testing.T) {
+	persistencetest.RunArchiver(t, func(t *testing.T) persistence.Archiver {
+		return NewArchive()
+	})
+}
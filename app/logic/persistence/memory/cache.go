@@ -0,0 +1,447 @@
+// Package memory keeps the cache and the data of the logic in the memory of the
+// process, for the tests and for mercury running in a single process. Nothing is
+// persisted, the data is lost when the process exits.
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"mercury/app/logic/persistence"
+	"mercury/x/database/redis"
+	"mercury/x/ecode"
+)
+
+// ErrClosed is returned by Ping once the cache or the persister is closed.
+var ErrClosed = ecode.NewError("memory storage is closed")
+
+// Lifetimes the same as the ones of the redis cache
+const (
+	mappingExpire        = 1800 * time.Second
+	defaultTopicLifetime = 3600 * time.Second
+)
+
+// expiry is the expiration time of a key, the zero time never expires.
+type expiry time.Time
+
+func (e expiry) expired(now time.Time) bool {
+	t := time.Time(e)
+	return !t.IsZero() && !now.Before(t)
+}
+
+type sessionsEntry struct {
+	expiry
+	// Server IDs by session ID
+	servers map[string]string
+}
+
+type stringEntry struct {
+	expiry
+	value string
+}
+
+type sequenceEntry struct {
+	expiry
+	value int64
+}
+
+// Cache implements persistence.Cacher with the semantics of the redis cache, a missing
+// key is reported with redis.RedisNil where the redis cache does so.
+type Cache struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	closed bool
+
+	userSessions       map[string]*sessionsEntry
+	sessionServers     map[string]*stringEntry
+	clients            map[string]*persistence.Client
+	tokens             map[string]*stringEntry
+	topicSequences     map[string]*sequenceEntry
+	userTopicSequences map[string]map[string]int64
+	userTopics         map[string]map[string]struct{}
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		now:                time.Now,
+		userSessions:       make(map[string]*sessionsEntry),
+		sessionServers:     make(map[string]*stringEntry),
+		clients:            make(map[string]*persistence.Client),
+		tokens:             make(map[string]*stringEntry),
+		topicSequences:     make(map[string]*sequenceEntry),
+		userTopicSequences: make(map[string]map[string]int64),
+		userTopics:         make(map[string]map[string]struct{}),
+	}
+}
+
+func (c *Cache) Ping() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+	return nil
+}
+
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	return nil
+}
+
+// scoped returns the key of the client, like the keys of the redis cache the users,
+// sessions and topics of the clients do not collide.
+func scoped(clientID, key string) string {
+	return clientID + ":" + key
+}
+
+func (c *Cache) expireAt(lifetime time.Duration) expiry {
+	return expiry(c.now().Add(lifetime))
+}
+
+// sessions returns the live sessions of the user, expired keys are removed as they
+// are found.
+func (c *Cache) sessions(uid string) *sessionsEntry {
+	e, ok := c.userSessions[uid]
+	if ok && e.expired(c.now()) {
+		delete(c.userSessions, uid)
+		return nil
+	}
+	return e
+}
+
+func (c *Cache) sessionServer(sid string) *stringEntry {
+	e, ok := c.sessionServers[sid]
+	if ok && e.expired(c.now()) {
+		delete(c.sessionServers, sid)
+		return nil
+	}
+	return e
+}
+
+func (c *Cache) AddMapping(clientID, uid, sid, serverID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	userKey := scoped(clientID, uid)
+	e := c.sessions(userKey)
+	if e == nil {
+		e = &sessionsEntry{servers: make(map[string]string)}
+		c.userSessions[userKey] = e
+	}
+	if _, ok := e.servers[sid]; ok {
+		return nil
+	}
+	e.servers[sid] = serverID
+	e.expiry = c.expireAt(mappingExpire)
+	c.sessionServers[scoped(clientID, sid)] = &stringEntry{expiry: c.expireAt(mappingExpire), value: serverID}
+	return nil
+}
+
+func (c *Cache) ExpireMapping(clientID, uid, sid string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.sessions(scoped(clientID, uid))
+	if e == nil {
+		return false, nil
+	}
+	e.expiry = c.expireAt(mappingExpire)
+
+	s := c.sessionServer(scoped(clientID, sid))
+	if s == nil {
+		return false, nil
+	}
+	s.expiry = c.expireAt(mappingExpire)
+	return true, nil
+}
+
+func (c *Cache) DeleteMapping(clientID, uid, sid string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	userKey := scoped(clientID, uid)
+	if e := c.sessions(userKey); e != nil {
+		delete(e.servers, sid)
+		if len(e.servers) == 0 {
+			delete(c.userSessions, userKey)
+		}
+	}
+	delete(c.sessionServers, scoped(clientID, sid))
+	return nil
+}
+
+func (c *Cache) GetSessions(clientID string, uids ...string) (map[string]string, []string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sessions := make(map[string]string)
+	var onlineUIDs []string
+	for _, uid := range uids {
+		e := c.sessions(scoped(clientID, uid))
+		if e == nil || len(e.servers) == 0 {
+			continue
+		}
+		onlineUIDs = append(onlineUIDs, uid)
+		for sid, serverID := range e.servers {
+			sessions[sid] = serverID
+		}
+	}
+
+	return sessions, onlineUIDs, nil
+}
+
+func (c *Cache) GetUsersSessions(clientID string, uids ...string) (map[string]map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	users := make(map[string]map[string]string)
+	for _, uid := range uids {
+		e := c.sessions(scoped(clientID, uid))
+		if e == nil || len(e.servers) == 0 {
+			continue
+		}
+		servers := make(map[string]string, len(e.servers))
+		for sid, serverID := range e.servers {
+			servers[sid] = serverID
+		}
+		users[uid] = servers
+	}
+	return users, nil
+}
+
+func (c *Cache) GetServerIDs(clientID string, sids ...string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var servers []string
+	for _, sid := range sids {
+		var serverID string
+		if s := c.sessionServer(scoped(clientID, sid)); s != nil {
+			serverID = s.value
+		}
+		servers = append(servers, serverID)
+	}
+
+	return servers, nil
+}
+
+func (c *Cache) GetClient(clientID string) (*persistence.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	client, ok := c.clients[clientID]
+	if !ok {
+		return nil, redis.RedisNil
+	}
+	return copyClient(client), nil
+}
+
+func (c *Cache) SetClient(clientID string, client *persistence.Client) error {
+	if clientID == "" {
+		return ecode.NewError("client ID is missing")
+	} else if client == nil {
+		return ecode.NewError("client can not be nil")
+	}
+
+	c.mu.Lock()
+	c.clients[clientID] = copyClient(client)
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *Cache) DeleteClient(clientID string) error {
+	c.mu.Lock()
+	delete(c.clients, clientID)
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *Cache) GetClientID(token string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.tokens[token]
+	if !ok {
+		return ""
+	}
+	if e.expired(c.now()) {
+		delete(c.tokens, token)
+		return ""
+	}
+	return e.value
+}
+
+func (c *Cache) SetClientID(token, clientID string, lifetime time.Duration) error {
+	if token == "" {
+		return ecode.NewError("token is missing")
+	} else if clientID == "" {
+		return ecode.NewError("client ID is missing")
+	} else if lifetime <= 0 {
+		return ecode.NewError("invalid lifetime")
+	}
+
+	c.mu.Lock()
+	c.tokens[token] = &stringEntry{expiry: c.expireAt(lifetime), value: clientID}
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *Cache) topicSequence(topic string) *sequenceEntry {
+	e, ok := c.topicSequences[topic]
+	if ok && e.expired(c.now()) {
+		delete(c.topicSequences, topic)
+		return nil
+	}
+	return e
+}
+
+func (c *Cache) GetTopicSequence(clientID, topic string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.topicSequence(scoped(clientID, topic))
+	if e == nil {
+		return 0, redis.RedisNil
+	}
+	return e.value, nil
+}
+
+func (c *Cache) SetTopicSequence(clientID, topic string, sequence int64, lifetime time.Duration) error {
+	if topic == "" {
+		return ecode.NewError("topic is missing")
+	}
+	if lifetime == 0 {
+		lifetime = defaultTopicLifetime
+	}
+
+	c.mu.Lock()
+	c.topicSequences[scoped(clientID, topic)] = &sequenceEntry{expiry: c.expireAt(lifetime), value: sequence}
+	c.mu.Unlock()
+	return nil
+}
+
+// IncrTopicSequence increments the sequence of the topic, the sequence keeps its
+// expiration time like INCR does.
+func (c *Cache) IncrTopicSequence(clientID, topic string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Make sure the key already exists
+	e := c.topicSequence(scoped(clientID, topic))
+	if e == nil {
+		return 0, redis.RedisNil
+	}
+	e.value++
+	return e.value, nil
+}
+
+// SeedTopicSequence raises the sequence like the script of the redis cache does, an
+// existing sequence not raised keeps its expiration time.
+func (c *Cache) SeedTopicSequence(clientID, topic string, last int64, lifetime time.Duration) (int64, error) {
+	if topic == "" {
+		return 0, ecode.NewError("topic is missing")
+	}
+	if lifetime == 0 {
+		lifetime = defaultTopicLifetime
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := scoped(clientID, topic)
+	e := c.topicSequence(key)
+	if e == nil || e.value < last {
+		e = &sequenceEntry{expiry: c.expireAt(lifetime), value: last}
+		c.topicSequences[key] = e
+	}
+	e.value++
+	return e.value, nil
+}
+
+func (c *Cache) SetUserTopicLastSequence(clientID, uid, topic string, sequence int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	userKey := scoped(clientID, uid)
+	topics, ok := c.userTopicSequences[userKey]
+	if !ok {
+		topics = make(map[string]int64)
+		c.userTopicSequences[userKey] = topics
+	}
+	topics[topic] = sequence
+	return nil
+}
+
+func (c *Cache) GetUserTopicsLastSequence(clientID, uid string) (map[string]int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	topics := make(map[string]int64)
+	for topic, sequence := range c.userTopicSequences[scoped(clientID, uid)] {
+		topics[topic] = sequence
+	}
+	return topics, nil
+}
+
+func (c *Cache) SetUsersTopic(clientID string, uids []string, topic string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, uid := range uids {
+		userKey := scoped(clientID, uid)
+		topics, ok := c.userTopics[userKey]
+		if !ok {
+			topics = make(map[string]struct{})
+			c.userTopics[userKey] = topics
+		}
+		topics[topic] = struct{}{}
+	}
+	return nil
+}
+
+// GetUserTopics returns the topics of the user in lexical order.
+func (c *Cache) GetUserTopics(clientID, uid string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	members := c.userTopics[scoped(clientID, uid)]
+	topics := make([]string, 0, len(members))
+	for topic := range members {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics, nil
+}
+
+func (c *Cache) SetUserTopics(clientID, uid string, topics map[string]int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	userKey := scoped(clientID, uid)
+	members, ok := c.userTopics[userKey]
+	if !ok {
+		members = make(map[string]struct{})
+		c.userTopics[userKey] = members
+	}
+	sequences, ok := c.userTopicSequences[userKey]
+	if !ok {
+		sequences = make(map[string]int64)
+		c.userTopicSequences[userKey] = sequences
+	}
+	for topic, sequence := range topics {
+		members[topic] = struct{}{}
+		if sequence > 0 {
+			sequences[topic] = sequence
+		}
+	}
+	return nil
+}
+
+func copyClient(client *persistence.Client) *persistence.Client {
+	c := *client
+	c.TokenSecret = append([]byte(nil), client.TokenSecret...)
+	return &c
+}
@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"mercury/app/logic/persistence"
+	"mercury/x/database/redis"
+)
+
+// newTestCache returns a cache whose clock is moved with the returned function.
+func newTestCache() (*Cache, func(d time.Duration)) {
+	now := time.Unix(1600000000, 0)
+	c := NewCache()
+	c.now = func() time.Time { return now }
+	return c, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestCacheMapping(t *testing.T) {
+	c, advance := newTestCache()
+
+	require.NoError(t, c.AddMapping("client", "uid", "sid1", "server1"))
+	require.NoError(t, c.AddMapping("client", "uid", "sid2", "server2"))
+	// An existing session keeps its server
+	require.NoError(t, c.AddMapping("client", "uid", "sid1", "server3"))
+
+	sessions, online, err := c.GetSessions("client", "uid", "offline")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"sid1": "server1", "sid2": "server2"}, sessions)
+	require.Equal(t, []string{"uid"}, online)
+	users, err := c.GetUsersSessions("client", "uid", "offline")
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[string]string{"uid": {"sid1": "server1", "sid2": "server2"}}, users)
+
+	servers, err := c.GetServerIDs("client", "sid1", "missing", "sid2")
+	require.NoError(t, err)
+	require.Equal(t, []string{"server1", "", "server2"}, servers)
+	// The sessions of a client are not the ones of another
+	_, online, err = c.GetSessions("other", "uid")
+	require.NoError(t, err)
+	require.Empty(t, online)
+	servers, err = c.GetServerIDs("other", "sid1")
+	require.NoError(t, err)
+	require.Equal(t, []string{""}, servers)
+
+	advance(mappingExpire - time.Second)
+	ok, err := c.ExpireMapping("client", "uid", "sid1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	advance(2 * time.Second)
+	servers, err = c.GetServerIDs("client", "sid1", "sid2")
+	require.NoError(t, err)
+	require.Equal(t, []string{"server1", ""}, servers)
+	ok, err = c.ExpireMapping("client", "uid", "sid2")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, c.DeleteMapping("client", "uid", "sid1"))
+	require.NoError(t, c.DeleteMapping("client", "uid", "sid2"))
+	_, online, err = c.GetSessions("client", "uid")
+	require.NoError(t, err)
+	require.Empty(t, online)
+	ok, err = c.ExpireMapping("client", "uid", "sid1")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestCacheClient(t *testing.T) {
+	c, advance := newTestCache()
+
+	_, err := c.GetClient("client")
+	require.Equal(t, redis.RedisNil, err)
+	require.Error(t, c.SetClient("", &persistence.Client{}))
+	require.Error(t, c.SetClient("client", nil))
+
+	client := &persistence.Client{ID: "client", Name: "name", TokenSecret: []byte("secret")}
+	require.NoError(t, c.SetClient("client", client))
+	client.TokenSecret[0] = 'S'
+	got, err := c.GetClient("client")
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret"), got.TokenSecret)
+	require.NoError(t, c.DeleteClient("client"))
+	_, err = c.GetClient("client")
+	require.Equal(t, redis.RedisNil, err)
+
+	require.Error(t, c.SetClientID("token", "client", 0))
+	require.NoError(t, c.SetClientID("token", "client", time.Minute))
+	require.Equal(t, "client", c.GetClientID("token"))
+	advance(time.Minute)
+	require.Equal(t, "", c.GetClientID("token"))
+}
+
+func TestCacheTopic(t *testing.T) {
+	c, advance := newTestCache()
+
+	_, err := c.IncrTopicSequence("client", "topic")
+	require.Equal(t, redis.RedisNil, err)
+	_, err = c.GetTopicSequence("client", "topic")
+	require.Equal(t, redis.RedisNil, err)
+
+	require.NoError(t, c.SetTopicSequence("client", "topic", 5, 0))
+	sequence, err := c.IncrTopicSequence("client", "topic")
+	require.NoError(t, err)
+	require.Equal(t, int64(6), sequence)
+	advance(defaultTopicLifetime)
+	_, err = c.IncrTopicSequence("client", "topic")
+	require.Equal(t, redis.RedisNil, err)
+
+	// A missing or lower sequence is raised, a greater one is kept
+	sequence, err = c.SeedTopicSequence("client", "topic", 3, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), sequence)
+	sequence, err = c.SeedTopicSequence("client", "topic", 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), sequence)
+	sequence, err = c.SeedTopicSequence("client", "topic", 8, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(9), sequence)
+	sequence, err = c.IncrTopicSequence("client", "topic")
+	require.NoError(t, err)
+	require.Equal(t, int64(10), sequence)
+
+	require.NoError(t, c.SetUsersTopic("client", []string{"alice", "bob"}, "topic2"))
+	require.NoError(t, c.SetUsersTopic("client", []string{"alice"}, "topic1"))
+	topics, err := c.GetUserTopics("client", "alice")
+	require.NoError(t, err)
+	require.Equal(t, []string{"topic1", "topic2"}, topics)
+
+	require.NoError(t, c.SetUserTopicLastSequence("client", "alice", "topic1", 3))
+	require.NoError(t, c.SetUserTopicLastSequence("client", "alice", "topic1", 4))
+	last, err := c.GetUserTopicsLastSequence("client", "alice")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"topic1": 4}, last)
+
+	require.NoError(t, c.SetUserTopics("client", "alice", map[string]int64{"topic2": 2, "topic3": 0}))
+	topics, err = c.GetUserTopics("client", "alice")
+	require.NoError(t, err)
+	require.Equal(t, []string{"topic1", "topic2", "topic3"}, topics)
+	last, err = c.GetUserTopicsLastSequence("client", "alice")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"topic1": 4, "topic2": 2}, last)
+
+	// The topics and the sequences of a client are not the ones of another
+	topics, err = c.GetUserTopics("other", "alice")
+	require.NoError(t, err)
+	require.Empty(t, topics)
+	last, err = c.GetUserTopicsLastSequence("other", "alice")
+	require.NoError(t, err)
+	require.Empty(t, last)
+	_, err = c.IncrTopicSequence("other", "topic")
+	require.Equal(t, redis.RedisNil, err)
+}
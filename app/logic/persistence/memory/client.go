@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"mercury/app/logic/persistence"
+	"mercury/x/ecode"
+)
+
+type clientRow struct {
+	id          string
+	createdAt   int64
+	updatedAt   int64
+	name        string
+	tokenSecret string
+	tokenExpire int64
+	credential  string
+	userCount   int64
+	groupCount  int64
+	// In seconds
+	messageMaxAge   int64
+	messageMaxCount int64
+}
+
+type clientPersister struct {
+	s *store
+}
+
+func (p *clientPersister) GetClientCredential(_ context.Context, id string) (string, error) {
+	p.s.mu.RLock()
+	defer p.s.mu.RUnlock()
+
+	c, ok := p.s.clients[id]
+	if !ok {
+		return "", ecode.ErrDataDoesNotExist
+	}
+	return c.credential, nil
+}
+
+func (p *clientPersister) GetClient(_ context.Context, id string) (*persistence.Client, error) {
+	p.s.mu.RLock()
+	defer p.s.mu.RUnlock()
+
+	c, ok := p.s.clients[id]
+	if !ok {
+		return nil, ecode.ErrDataDoesNotExist
+	}
+
+	return c.client(), nil
+}
+
+func (c *clientRow) client() *persistence.Client {
+	return &persistence.Client{
+		ID:              c.id,
+		CreatedAt:       c.createdAt,
+		UpdatedAt:       c.updatedAt,
+		Name:            c.name,
+		TokenSecret:     []byte(c.tokenSecret),
+		TokenExpire:     time.Duration(c.tokenExpire) * time.Second,
+		UserCount:       c.userCount,
+		GroupCount:      c.groupCount,
+		MessageMaxAge:   time.Duration(c.messageMaxAge) * time.Second,
+		MessageMaxCount: c.messageMaxCount,
+	}
+}
+
+// GetRetainedClients returns the clients with a retention, the earliest created first.
+func (p *clientPersister) GetRetainedClients(_ context.Context) ([]*persistence.Client, error) {
+	p.s.mu.RLock()
+	defer p.s.mu.RUnlock()
+
+	var rows []*clientRow
+	for _, c := range p.s.clients {
+		if c.messageMaxAge > 0 || c.messageMaxCount > 0 {
+			rows = append(rows, c)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].createdAt != rows[j].createdAt {
+			return rows[i].createdAt < rows[j].createdAt
+		}
+		return rows[i].id < rows[j].id
+	})
+
+	var clients []*persistence.Client
+	for _, c := range rows {
+		clients = append(clients, c.client())
+	}
+	return clients, nil
+}
+
+func (p *clientPersister) Create(_ context.Context, in *persistence.ClientCreate) error {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+
+	if _, ok := p.s.clients[in.ID]; ok {
+		return ecode.ErrDataAlreadyExists
+	}
+	for _, c := range p.s.clients {
+		if c.name == in.Name {
+			return ecode.ErrDataAlreadyExists
+		}
+	}
+
+	now := time.Now().Unix()
+	p.s.clients[in.ID] = &clientRow{
+		id:              in.ID,
+		createdAt:       now,
+		updatedAt:       now,
+		name:            in.Name,
+		tokenSecret:     in.TokenSecret,
+		tokenExpire:     in.TokenExpire,
+		credential:      in.Credential,
+		messageMaxAge:   in.MessageMaxAge,
+		messageMaxCount: in.MessageMaxCount,
+	}
+	return nil
+}
+
+func (p *clientPersister) Update(_ context.Context, in *persistence.ClientUpdate) error {
+	if in.Name == nil && in.TokenSecret == nil && in.TokenExpire == nil && in.MessageMaxAge == nil && in.MessageMaxCount == nil {
+		return nil
+	}
+
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+
+	c, ok := p.s.clients[in.ID]
+	if !ok {
+		return ecode.ErrDataDoesNotExist
+	}
+	c.updatedAt = time.Now().Unix()
+	if in.Name != nil {
+		c.name = *in.Name
+	}
+	if in.TokenSecret != nil {
+		c.tokenSecret = *in.TokenSecret
+	}
+	if in.TokenExpire != nil {
+		c.tokenExpire = *in.TokenExpire
+	}
+	if in.MessageMaxAge != nil {
+		c.messageMaxAge = *in.MessageMaxAge
+	}
+	if in.MessageMaxCount != nil {
+		c.messageMaxCount = *in.MessageMaxCount
+	}
+	return nil
+}
+
+func (p *clientPersister) Delete(_ context.Context, id string) error {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+
+	if _, ok := p.s.clients[id]; !ok {
+		return ecode.ErrDataDoesNotExist
+	}
+	delete(p.s.clients, id)
+	return nil
+}
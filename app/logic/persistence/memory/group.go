@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"mercury/app/logic/persistence"
+	"mercury/x/ecode"
+)
+
+type groupRow struct {
+	id           int64
+	createdAt    int64
+	clientID     string
+	name         string
+	gid          string
+	introduction string
+	owner        int64
+	activated    bool
+	memberCount  int64
+}
+
+type memberRow struct {
+	createdAt int64
+	userID    int64
+}
+
+type groupPersister struct {
+	s *store
+}
+
+func (p *groupPersister) Create(_ context.Context, in *persistence.GroupCreate) (*persistence.Group, error) {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+
+	if _, ok := p.s.groups[in.GroupID]; ok {
+		return nil, ecode.ErrDataAlreadyExists
+	}
+	c, ok := p.s.clients[in.ClientID]
+	if !ok || !p.s.clientUsers(in.ClientID, in.Owner) {
+		return nil, ecode.ErrDataDoesNotExist
+	}
+
+	now := time.Now().Unix()
+	p.s.groups[in.GroupID] = &groupRow{
+		id:           in.GroupID,
+		createdAt:    now,
+		clientID:     in.ClientID,
+		name:         in.Name,
+		gid:          in.GID,
+		introduction: in.Introduction,
+		owner:        in.Owner,
+		activated:    true,
+		memberCount:  1,
+	}
+	c.groupCount++
+	p.s.members[in.GroupID] = []*memberRow{{createdAt: now, userID: in.Owner}}
+
+	return &persistence.Group{
+		CreatedAt:    now,
+		Name:         in.Name,
+		GID:          in.GID,
+		Introduction: in.Introduction,
+		Owner:        in.Owner,
+		MemberCount:  1,
+	}, nil
+}
+
+// GetGroups returns the activated groups of the client the user is a member of, the
+// latest created first.
+func (p *groupPersister) GetGroups(_ context.Context, clientID string, userID int64) ([]*persistence.Group, error) {
+	p.s.mu.RLock()
+	defer p.s.mu.RUnlock()
+
+	var rows []*groupRow
+	for id, g := range p.s.groups {
+		if g.clientID == clientID && g.activated && p.s.isMember(id, userID) {
+			rows = append(rows, g)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].createdAt != rows[j].createdAt {
+			return rows[i].createdAt > rows[j].createdAt
+		}
+		return rows[i].id > rows[j].id
+	})
+
+	var groups []*persistence.Group
+	for _, g := range rows {
+		groups = append(groups, &persistence.Group{
+			CreatedAt:    g.createdAt,
+			Name:         g.name,
+			GID:          g.gid,
+			Introduction: g.introduction,
+			Owner:        g.owner,
+			MemberCount:  g.memberCount,
+		})
+	}
+	return groups, nil
+}
+
+func (s *store) isMember(groupID, userID int64) bool {
+	for _, m := range s.members[groupID] {
+		if m.userID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// group returns the group of the client.
+func (s *store) group(clientID string, groupID int64) (*groupRow, bool) {
+	g, ok := s.groups[groupID]
+	if !ok || g.clientID != clientID {
+		return nil, false
+	}
+	return g, true
+}
+
+func (p *groupPersister) AddMember(_ context.Context, in *persistence.GroupMember) error {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+
+	g, ok := p.s.group(in.ClientID, in.GroupID)
+	if !ok || !p.s.clientUsers(in.ClientID, in.UserID) {
+		return ecode.ErrDataDoesNotExist
+	}
+	if p.s.isMember(in.GroupID, in.UserID) {
+		return ecode.ErrDataAlreadyExists
+	}
+
+	p.s.members[in.GroupID] = append(p.s.members[in.GroupID], &memberRow{createdAt: time.Now().Unix(), userID: in.UserID})
+	g.memberCount++
+	return nil
+}
+
+func (p *groupPersister) CheckMember(_ context.Context, clientID string, groupID int64, userID int64) (bool, error) {
+	p.s.mu.RLock()
+	defer p.s.mu.RUnlock()
+
+	if _, ok := p.s.group(clientID, groupID); !ok {
+		return false, nil
+	}
+	return p.s.isMember(groupID, userID), nil
+}
+
+// GetMembers returns the members of the group, the latest added first.
+func (p *groupPersister) GetMembers(_ context.Context, clientID string, groupID int64) ([]int64, error) {
+	p.s.mu.RLock()
+	defer p.s.mu.RUnlock()
+
+	if _, ok := p.s.group(clientID, groupID); !ok {
+		return nil, ecode.ErrDataDoesNotExist
+	}
+
+	rows := p.s.members[groupID]
+	var memberIDs []int64
+	for i := len(rows) - 1; i >= 0; i-- {
+		memberIDs = append(memberIDs, rows[i].userID)
+	}
+	return memberIDs, nil
+}
@@ -0,0 +1,181 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"mercury/app/logic/persistence"
+	"mercury/x/ecode"
+	"mercury/x/types"
+)
+
+type messagePersister struct {
+	s *store
+}
+
+// search returns the index of the first message of the topic with a sequence not less
+// than sequence.
+func (s *store) search(topic string, sequence int64) int {
+	return searchSequence(s.messages[topic], sequence)
+}
+
+// searchSequence returns the index of the first of the messages, sorted by sequence,
+// with a sequence not less than sequence.
+func searchSequence(messages []*persistence.Message, sequence int64) int {
+	return sort.Search(len(messages), func(i int) bool {
+		return messages[i].Sequence >= sequence
+	})
+}
+
+func (p *messagePersister) Add(_ context.Context, message *persistence.Message) error {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+
+	messages := p.s.messages[message.Topic]
+	i := p.s.search(message.Topic, message.Sequence)
+	if i < len(messages) && messages[i].Sequence == message.Sequence {
+		return ecode.ErrDataAlreadyExists
+	}
+
+	p.s.messageID++
+	message.ID = p.s.messageID
+	message.CreatedAt = time.Now().Unix()
+
+	m := copyMessage(message)
+	m.Status = uint8(types.MessageStatusNormal)
+	messages = append(messages, nil)
+	copy(messages[i+1:], messages[i:])
+	messages[i] = m
+	p.s.messages[message.Topic] = messages
+	return nil
+}
+
+func (p *messagePersister) GetTopicLastSequence(_ context.Context, clientID, topic string) (int64, error) {
+	p.s.mu.RLock()
+	defer p.s.mu.RUnlock()
+
+	messages := p.s.messages[topic]
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].ClientID == clientID {
+			return messages[i].Sequence, nil
+		}
+	}
+	return 0, nil
+}
+
+func (p *messagePersister) GetTopicMessageBySequence(_ context.Context, clientID, topic string, sequence int64) (*persistence.Message, error) {
+	p.s.mu.RLock()
+	defer p.s.mu.RUnlock()
+
+	messages := p.s.messages[topic]
+	i := p.s.search(topic, sequence)
+	if i == len(messages) || messages[i].Sequence != sequence || messages[i].ClientID != clientID {
+		return nil, ecode.ErrDataDoesNotExist
+	}
+	return copyMessage(messages[i]), nil
+}
+
+// GetTopicMessagesByLastSequence returns the messages of the topic after the sequence,
+// the latest first, and their count.
+func (p *messagePersister) GetTopicMessagesByLastSequence(_ context.Context, clientID, topic string, sequence int64) ([]*persistence.Message, int64, error) {
+	p.s.mu.RLock()
+	defer p.s.mu.RUnlock()
+
+	after := p.s.messages[topic][p.s.search(topic, sequence+1):]
+	var messages []*persistence.Message
+	for i := len(after) - 1; i >= 0; i-- {
+		if after[i].ClientID == clientID {
+			messages = append(messages, copyMessage(after[i]))
+		}
+	}
+	return messages, int64(len(messages)), nil
+}
+
+func (p *messagePersister) GetExpiredMessages(_ context.Context, clientID string, before, maxCount int64, limit int) ([]*persistence.Message, error) {
+	if (before <= 0 && maxCount <= 0) || limit <= 0 {
+		return nil, nil
+	}
+
+	p.s.mu.RLock()
+	defer p.s.mu.RUnlock()
+
+	var expired []*persistence.Message
+	for _, messages := range p.s.messages {
+		if len(messages) == 0 {
+			continue
+		}
+		last := messages[len(messages)-1].Sequence
+		for _, m := range messages {
+			if m.ClientID != clientID {
+				continue
+			}
+			if (before > 0 && m.CreatedAt < before && m.Sequence < last) || (maxCount > 0 && m.Sequence <= last-maxCount) {
+				expired = append(expired, m)
+			}
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].ID < expired[j].ID
+	})
+	if len(expired) > limit {
+		expired = expired[:limit]
+	}
+
+	for i, m := range expired {
+		expired[i] = copyMessage(m)
+	}
+	return expired, nil
+}
+
+func (p *messagePersister) Update(_ context.Context, message *persistence.Message) error {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+
+	for _, messages := range p.s.messages {
+		for _, m := range messages {
+			if m.ID == message.ID && m.ClientID == message.ClientID {
+				m.ContentType = message.ContentType
+				m.Body = append([]byte(nil), message.Body...)
+				m.Status = message.Status
+				return nil
+			}
+		}
+	}
+	return ecode.ErrDataDoesNotExist
+}
+
+func (p *messagePersister) Delete(_ context.Context, clientID string, ids ...int64) error {
+	deleted := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		deleted[id] = true
+	}
+
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+
+	for topic, messages := range p.s.messages {
+		kept := messages[:0]
+		for _, m := range messages {
+			if !deleted[m.ID] || m.ClientID != clientID {
+				kept = append(kept, m)
+			}
+		}
+		if len(kept) == 0 {
+			delete(p.s.messages, topic)
+			continue
+		}
+		p.s.messages[topic] = kept
+	}
+	return nil
+}
+
+func copyMessage(message *persistence.Message) *persistence.Message {
+	m := *message
+	m.Body = append([]byte(nil), message.Body...)
+	m.Mentions = nil
+	if len(message.Mentions) > 0 {
+		m.Mentions = append([]int64(nil), message.Mentions...)
+	}
+	return &m
+}
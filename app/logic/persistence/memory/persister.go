@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"sync"
+
+	"mercury/app/logic/persistence"
+)
+
+// store holds the tables of the persister, the persisters of the tables share its lock.
+type store struct {
+	mu     sync.RWMutex
+	closed bool
+
+	clients map[string]*clientRow
+	users   map[int64]*userRow
+	// Friend rows by user ID, in the order they were added
+	friends map[int64][]*friendRow
+	groups  map[int64]*groupRow
+	// Member rows by group ID, in the order they were added
+	members map[int64][]*memberRow
+	// Messages by topic, in the order of their sequences
+	messages  map[string][]*persistence.Message
+	messageID int64
+	// Topics of the users by user ID and topic
+	userTopics map[int64]map[string]*persistence.UserTopic
+}
+
+// Persister implements persistence.Persister with the semantics of the sql persister.
+// The data returned is a copy of the stored one.
+type Persister struct {
+	store   *store
+	client  *clientPersister
+	user    *userPersister
+	message *messagePersister
+	group   *groupPersister
+	topic   *topicPersister
+}
+
+func NewPersister() *Persister {
+	s := &store{
+		clients:    make(map[string]*clientRow),
+		users:      make(map[int64]*userRow),
+		friends:    make(map[int64][]*friendRow),
+		groups:     make(map[int64]*groupRow),
+		members:    make(map[int64][]*memberRow),
+		messages:   make(map[string][]*persistence.Message),
+		userTopics: make(map[int64]map[string]*persistence.UserTopic),
+	}
+	return &Persister{
+		store: s,
+		client: &clientPersister{
+			s: s,
+		},
+		user: &userPersister{
+			s: s,
+		},
+		message: &messagePersister{
+			s: s,
+		},
+		group: &groupPersister{
+			s: s,
+		},
+		topic: &topicPersister{
+			s: s,
+		},
+	}
+}
+
+func (p *Persister) Ping() error {
+	p.store.mu.RLock()
+	defer p.store.mu.RUnlock()
+	if p.store.closed {
+		return ErrClosed
+	}
+	return nil
+}
+
+func (p *Persister) Close() error {
+	p.store.mu.Lock()
+	p.store.closed = true
+	p.store.mu.Unlock()
+	return nil
+}
+
+func (p *Persister) Client() persistence.ClientPersister {
+	return p.client
+}
+
+func (p *Persister) User() persistence.UserPersister {
+	return p.user
+}
+
+func (p *Persister) Message() persistence.MessagePersister {
+	return p.message
+}
+
+func (p *Persister) Group() persistence.GroupPersister {
+	return p.group
+}
+
+func (p *Persister) Topic() persistence.TopicPersister {
+	return p.topic
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"mercury/app/logic/persistence"
+	"mercury/app/logic/persistence/persistencetest"
+)
+
+func TestPersister(t *testing.T) {
+	persistencetest.Run(t, func(t *testing.T) persistence.Persister {
+		return NewPersister()
+	})
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"mercury/app/logic/persistence"
+)
+
+type topicPersister struct {
+	s *store
+}
+
+func (p *topicPersister) Save(_ context.Context, topics ...*persistence.UserTopic) error {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+
+	for _, topic := range topics {
+		rows, ok := p.s.userTopics[topic.UserID]
+		if !ok {
+			rows = make(map[string]*persistence.UserTopic)
+			p.s.userTopics[topic.UserID] = rows
+		}
+		row, ok := rows[topic.Topic]
+		if !ok {
+			c := *topic
+			rows[topic.Topic] = &c
+			continue
+		}
+		if topic.LastSequence > row.LastSequence {
+			row.LastSequence = topic.LastSequence
+		}
+	}
+	return nil
+}
+
+// GetUserTopics returns the topics of the user in lexical order like the sql persister.
+func (p *topicPersister) GetUserTopics(_ context.Context, clientID string, userID int64) ([]*persistence.UserTopic, error) {
+	p.s.mu.RLock()
+	defer p.s.mu.RUnlock()
+
+	var topics []*persistence.UserTopic
+	for _, row := range p.s.userTopics[userID] {
+		if row.ClientID != clientID {
+			continue
+		}
+		c := *row
+		topics = append(topics, &c)
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Topic < topics[j].Topic })
+	return topics, nil
+}
+
+func (p *topicPersister) GetUsers(_ context.Context, after int64, limit int) ([]*persistence.TopicUser, error) {
+	p.s.mu.RLock()
+	defer p.s.mu.RUnlock()
+
+	var users []*persistence.TopicUser
+	for id, rows := range p.s.userTopics {
+		if id <= after {
+			continue
+		}
+		// The topics of a user are all of its client
+		for _, row := range rows {
+			users = append(users, &persistence.TopicUser{ClientID: row.ClientID, UserID: id})
+			break
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].UserID < users[j].UserID })
+	if len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
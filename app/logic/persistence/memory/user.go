@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"mercury/app/logic/persistence"
+	"mercury/x/ecode"
+)
+
+type userRow struct {
+	id        int64
+	createdAt int64
+	clientID  string
+	name      string
+	uid       string
+	activated bool
+}
+
+type friendRow struct {
+	createdAt    int64
+	friendUserID int64
+}
+
+type userPersister struct {
+	s *store
+}
+
+func (p *userPersister) CheckActivated(_ context.Context, clientID, uid string) (bool, error) {
+	p.s.mu.RLock()
+	defer p.s.mu.RUnlock()
+
+	for _, u := range p.s.users {
+		if u.clientID == clientID && u.uid == uid && u.activated {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p *userPersister) Create(_ context.Context, in *persistence.UserCreate) error {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+
+	if _, ok := p.s.users[in.UserID]; ok {
+		return ecode.ErrDataAlreadyExists
+	}
+	for _, u := range p.s.users {
+		if u.clientID == in.ClientID && u.name == in.Name {
+			return ecode.ErrDataAlreadyExists
+		}
+	}
+	c, ok := p.s.clients[in.ClientID]
+	if !ok {
+		return ecode.ErrDataDoesNotExist
+	}
+
+	p.s.users[in.UserID] = &userRow{
+		id:        in.UserID,
+		createdAt: time.Now().Unix(),
+		clientID:  in.ClientID,
+		name:      in.Name,
+		uid:       in.UID,
+		activated: true,
+	}
+	c.userCount++
+	return nil
+}
+
+// clientUser returns the user if it is one of the client.
+func (s *store) clientUser(clientID string, id int64) (*userRow, bool) {
+	u, ok := s.users[id]
+	if !ok || u.clientID != clientID {
+		return nil, false
+	}
+	return u, true
+}
+
+func (p *userPersister) UpdateActivated(_ context.Context, clientID string, id int64, activated bool) error {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+
+	u, ok := p.s.clientUser(clientID, id)
+	if !ok {
+		return ecode.ErrDataDoesNotExist
+	}
+	u.activated = activated
+	return nil
+}
+
+func (p *userPersister) Delete(_ context.Context, clientID string, id int64) error {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+
+	if _, ok := p.s.clientUser(clientID, id); !ok {
+		return ecode.ErrDataDoesNotExist
+	}
+	delete(p.s.users, id)
+	return nil
+}
+
+// clientUsers reports whether all the users are ones of the client.
+func (s *store) clientUsers(clientID string, ids ...int64) bool {
+	for _, id := range ids {
+		if _, ok := s.clientUser(clientID, id); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *store) friendIndex(userID, friendUserID int64) int {
+	for i, f := range s.friends[userID] {
+		if f.friendUserID == friendUserID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *userPersister) AddFriend(_ context.Context, in *persistence.UserFriend) error {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+
+	if !p.s.clientUsers(in.ClientID, in.UserID, in.FriendUserID) {
+		return ecode.ErrDataDoesNotExist
+	}
+	if p.s.friendIndex(in.UserID, in.FriendUserID) >= 0 {
+		return ecode.ErrDataAlreadyExists
+	}
+
+	now := time.Now().Unix()
+	p.s.friends[in.UserID] = append(p.s.friends[in.UserID], &friendRow{createdAt: now, friendUserID: in.FriendUserID})
+	p.s.friends[in.FriendUserID] = append(p.s.friends[in.FriendUserID], &friendRow{createdAt: now, friendUserID: in.UserID})
+	return nil
+}
+
+// GetFriends returns the friends of the user, the latest first.
+func (p *userPersister) GetFriends(_ context.Context, clientID string, userID int64) ([]int64, error) {
+	p.s.mu.RLock()
+	defer p.s.mu.RUnlock()
+
+	if _, ok := p.s.clientUser(clientID, userID); !ok {
+		return nil, nil
+	}
+	rows := p.s.friends[userID]
+	var friendIDs []int64
+	for i := len(rows) - 1; i >= 0; i-- {
+		friendIDs = append(friendIDs, rows[i].friendUserID)
+	}
+	return friendIDs, nil
+}
+
+func (p *userPersister) DeleteFriend(_ context.Context, in *persistence.UserFriend) error {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+
+	if !p.s.clientUsers(in.ClientID, in.UserID, in.FriendUserID) {
+		return ecode.ErrDataDoesNotExist
+	}
+	i, j := p.s.friendIndex(in.UserID, in.FriendUserID), p.s.friendIndex(in.FriendUserID, in.UserID)
+	if i < 0 || j < 0 {
+		return ecode.ErrDataDoesNotExist
+	}
+	p.s.friends[in.UserID] = removeFriend(p.s.friends[in.UserID], i)
+	p.s.friends[in.FriendUserID] = removeFriend(p.s.friends[in.FriendUserID], j)
+	return nil
+}
+
+func removeFriend(rows []*friendRow, i int) []*friendRow {
+	return append(rows[:i:i], rows[i+1:]...)
+}
@@ -5,6 +5,7 @@ import "mercury/x/types"
 type Message struct {
 	ID          int64
 	CreatedAt   int64
+	ClientID    string
 	Topic       string
 	Sequence    int64
 	MessageType types.MessageType
@@ -15,3 +16,27 @@ type Message struct {
 	Status      uint8
 	Mentions    []int64
 }
+
+// MessageQuery selects the messages of the client matching all the words of Text.
+type MessageQuery struct {
+	ClientID string
+	Text     string
+	// Topics the messages belong to, there is no message to match if it is empty
+	Topics []string
+	// The sender, 0 matches any
+	Sender int64
+	// Range of the creation time, 0 does not bound it
+	StartAt int64
+	EndAt   int64
+	// Content types, empty matches any
+	ContentTypes []types.ContentType
+	// The count of messages, 0 does not limit it
+	Limit int
+}
+
+// MessageHit identifies a message matching a query.
+type MessageHit struct {
+	ID       int64
+	Topic    string
+	Sequence int64
+}
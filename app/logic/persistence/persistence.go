@@ -10,15 +10,19 @@ type Cacher interface {
 
 	Close() error
 
-	AddMapping(uid, sid, serverID string) error
+	AddMapping(clientID, uid, sid, serverID string) error
 
-	ExpireMapping(uid, sid string) (bool, error)
+	ExpireMapping(clientID, uid, sid string) (bool, error)
 
-	DeleteMapping(uid, sid string) error
+	DeleteMapping(clientID, uid, sid string) error
 
-	GetSessions(uids ...string) (map[string]string, []string, error)
+	GetSessions(clientID string, uids ...string) (map[string]string, []string, error)
 
-	GetServerIDs(sids ...string) ([]string, error)
+	// GetUsersSessions returns the servers of the sessions of each user, the users without
+	// sessions are left out.
+	GetUsersSessions(clientID string, uids ...string) (map[string]map[string]string, error)
+
+	GetServerIDs(clientID string, sids ...string) ([]string, error)
 
 	GetClient(clientID string) (*Client, error)
 
@@ -30,19 +34,29 @@ type Cacher interface {
 
 	SetClientID(token, clientID string, lifetime time.Duration) error
 
-	GetTopicSequence(topic string) (int64, error)
+	GetTopicSequence(clientID, topic string) (int64, error)
+
+	SetTopicSequence(clientID, topic string, sequence int64, lifetime time.Duration) error
+
+	// IncrTopicSequence increments the sequence of the topic and returns it, a missing
+	// sequence is reported with redis.RedisNil and left missing.
+	IncrTopicSequence(clientID, topic string) (int64, error)
 
-	SetTopicSequence(topic string, sequence int64, lifetime time.Duration) error
+	// SeedTopicSequence raises the sequence of the topic to last if it is missing or lower,
+	// with the lifetime, then increments it and returns it, atomically.
+	SeedTopicSequence(clientID, topic string, last int64, lifetime time.Duration) (int64, error)
 
-	IncrTopicSequence(topic string) (int64, error)
+	SetUserTopicLastSequence(clientID, uid, topic string, sequence int64) error
 
-	SetUserTopicLastSequence(uid, topic string, sequence int64) error
+	GetUserTopicsLastSequence(clientID, uid string) (map[string]int64, error)
 
-	GetUserTopicsLastSequence(uid string) (map[string]int64, error)
+	SetUsersTopic(clientID string, uids []string, topic string) error
 
-	SetUsersTopic(uids []string, topic string) error
+	GetUserTopics(clientID, uid string) ([]string, error)
 
-	GetUserTopics(uid string) ([]string, error)
+	// SetUserTopics adds the topics to the user and sets the last sequences read greater
+	// than 0, at once.
+	SetUserTopics(clientID, uid string, topics map[string]int64) error
 }
 
 type Persister interface {
@@ -52,6 +66,7 @@ type Persister interface {
 	User() UserPersister
 	Message() MessagePersister
 	Group() GroupPersister
+	Topic() TopicPersister
 }
 
 type ClientPersister interface {
@@ -64,6 +79,9 @@ type ClientPersister interface {
 	Update(ctx context.Context, in *ClientUpdate) error
 
 	Delete(ctx context.Context, id string) error
+
+	// GetRetainedClients returns the clients with a retention of their messages.
+	GetRetainedClients(ctx context.Context) ([]*Client, error)
 }
 
 type UserPersister interface {
@@ -71,13 +89,15 @@ type UserPersister interface {
 
 	Create(ctx context.Context, in *UserCreate) error
 
-	UpdateActivated(ctx context.Context, id int64, activated bool) error
+	// UpdateActivated returns ErrDataDoesNotExist if the user is not one of the client.
+	UpdateActivated(ctx context.Context, clientID string, id int64, activated bool) error
 
-	Delete(ctx context.Context, id int64) error
+	// Delete returns ErrDataDoesNotExist if the user is not one of the client.
+	Delete(ctx context.Context, clientID string, id int64) error
 
 	AddFriend(ctx context.Context, in *UserFriend) error
 
-	GetFriends(ctx context.Context, userID int64) ([]int64, error)
+	GetFriends(ctx context.Context, clientID string, userID int64) ([]int64, error)
 
 	DeleteFriend(ctx context.Context, in *UserFriend) error
 }
@@ -85,11 +105,35 @@ type UserPersister interface {
 type MessagePersister interface {
 	Add(ctx context.Context, message *Message) error
 
-	GetTopicLastSequence(ctx context.Context, topic string) (int64, error)
+	GetTopicLastSequence(ctx context.Context, clientID, topic string) (int64, error)
+
+	GetTopicMessageBySequence(ctx context.Context, clientID, topic string, sequence int64) (*Message, error)
+
+	GetTopicMessagesByLastSequence(ctx context.Context, clientID, topic string, sequence int64) ([]*Message, int64, error)
+
+	// GetExpiredMessages returns up to limit messages of the client created before the
+	// time, 0 skips it, or beyond the latest maxCount of their topic, 0 skips it. The
+	// latest message of a topic is never expired, the earliest added come first.
+	GetExpiredMessages(ctx context.Context, clientID string, before, maxCount int64, limit int) ([]*Message, error)
+
+	// Update replaces the content type, the body and the status of the message of the
+	// client with the ID of message, it returns ErrDataDoesNotExist if there is none.
+	Update(ctx context.Context, message *Message) error
+
+	Delete(ctx context.Context, clientID string, ids ...int64) error
+}
+
+// Archiver keeps the messages purged from the persister, so that they can still be read.
+type Archiver interface {
+	Archive(ctx context.Context, messages []*Message) error
 
 	GetTopicMessageBySequence(ctx context.Context, topic string, sequence int64) (*Message, error)
 
-	GetTopicMessagesByLastSequence(ctx context.Context, topic string, sequence int64) ([]*Message, int64, error)
+	// GetTopicMessages returns the messages of the topic with a sequence after the first
+	// one and before the second one, 0 does not bound it, the latest first.
+	GetTopicMessages(ctx context.Context, topic string, after, before int64) ([]*Message, error)
+
+	Close() error
 }
 
 type GroupPersister interface {
@@ -97,9 +141,36 @@ type GroupPersister interface {
 
 	AddMember(ctx context.Context, in *GroupMember) error
 
-	CheckMember(ctx context.Context, groupID int64, userID int64) (bool, error)
+	CheckMember(ctx context.Context, clientID string, groupID int64, userID int64) (bool, error)
 
 	GetMembers(ctx context.Context, clientID string, groupID int64) ([]int64, error)
 
-	GetGroups(ctx context.Context, userID int64) ([]*Group, error)
+	GetGroups(ctx context.Context, clientID string, userID int64) ([]*Group, error)
+}
+
+// TopicPersister keeps the topics of the users and their read cursors, the cache of
+// them is rebuilt from it.
+type TopicPersister interface {
+	// Save adds the topics to the users, the last sequence of a topic already added only
+	// moves forward.
+	Save(ctx context.Context, topics ...*UserTopic) error
+
+	GetUserTopics(ctx context.Context, clientID string, userID int64) ([]*UserTopic, error)
+
+	// GetUsers returns up to limit users with topics, with an ID greater than the one
+	// and in ascending order of it.
+	GetUsers(ctx context.Context, after int64, limit int) ([]*TopicUser, error)
+}
+
+// Indexer indexes the text of the messages, see types.Text, for the search.
+type Indexer interface {
+	// Index adds the messages, a message indexed again replaces the previous one.
+	Index(ctx context.Context, messages ...*Message) error
+
+	Remove(ctx context.Context, ids ...int64) error
+
+	// Search returns the messages matching the query, the latest created first.
+	Search(ctx context.Context, q *MessageQuery) ([]*MessageHit, error)
+
+	Close() error
 }
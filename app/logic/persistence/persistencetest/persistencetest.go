@@ -0,0 +1,582 @@
+// Package persistencetest is a conformance suite of the implementations of
+// persistence.Persister.
+package persistencetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"mercury/app/logic/persistence"
+	"mercury/x/ecode"
+	"mercury/x/types"
+)
+
+// NewPersister returns a persister of an empty database.
+type NewPersister func(t *testing.T) persistence.Persister
+
+// Run runs the suite against the persisters of newPersister.
+func Run(t *testing.T, newPersister NewPersister) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, p persistence.Persister)
+	}{
+		{"Client", testClient},
+		{"User", testUser},
+		{"Friend", testFriend},
+		{"Group", testGroup},
+		{"Message", testMessage},
+		{"Retention", testRetention},
+		{"Topic", testTopic},
+		{"Isolation", testIsolation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPersister(t)
+			defer p.Close()
+			require.NoError(t, p.Ping())
+			tt.test(t, p)
+		})
+	}
+}
+
+var ctx = context.Background()
+
+func createClient(t *testing.T, p persistence.Persister, name string) string {
+	id := uuid.New().String()
+	require.NoError(t, p.Client().Create(ctx, &persistence.ClientCreate{
+		ID:          id,
+		Name:        name,
+		TokenSecret: "secret-" + name,
+		Credential:  "credential-" + name,
+		TokenExpire: 3600,
+	}))
+	return id
+}
+
+func createUsers(t *testing.T, p persistence.Persister, clientID string, names map[int64]string) {
+	for id, name := range names {
+		require.NoError(t, p.User().Create(ctx, &persistence.UserCreate{ClientID: clientID, UserID: id, Name: name, UID: "uid-" + name}))
+	}
+}
+
+func testClient(t *testing.T, p persistence.Persister) {
+	id := createClient(t, p, "client")
+
+	c, err := p.Client().GetClient(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "client", c.Name)
+	require.Equal(t, []byte("secret-client"), c.TokenSecret)
+	require.Equal(t, time.Hour, c.TokenExpire)
+	require.NotZero(t, c.CreatedAt)
+
+	credential, err := p.Client().GetClientCredential(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "credential-client", credential)
+
+	err = p.Client().Create(ctx, &persistence.ClientCreate{ID: uuid.New().String(), Name: "client", TokenSecret: "s"})
+	require.Equal(t, ecode.ErrDataAlreadyExists, err)
+
+	name, expire := "renamed", int64(60)
+	require.NoError(t, p.Client().Update(ctx, &persistence.ClientUpdate{ID: id, Name: &name, TokenExpire: &expire}))
+	c, err = p.Client().GetClient(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "renamed", c.Name)
+	require.Equal(t, time.Minute, c.TokenExpire)
+	require.Equal(t, []byte("secret-client"), c.TokenSecret)
+	require.Zero(t, c.MessageMaxAge)
+	require.Zero(t, c.MessageMaxCount)
+
+	maxAge, maxCount := int64(3600), int64(100)
+	require.NoError(t, p.Client().Update(ctx, &persistence.ClientUpdate{ID: id, MessageMaxAge: &maxAge, MessageMaxCount: &maxCount}))
+	c, err = p.Client().GetClient(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, c.MessageMaxAge)
+	require.Equal(t, int64(100), c.MessageMaxCount)
+
+	require.NoError(t, p.Client().Delete(ctx, id))
+	_, err = p.Client().GetClient(ctx, id)
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+	_, err = p.Client().GetClientCredential(ctx, id)
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+}
+
+func testUser(t *testing.T, p persistence.Persister) {
+	clientID := createClient(t, p, "client")
+
+	require.NoError(t, p.User().Create(ctx, &persistence.UserCreate{ClientID: clientID, UserID: 1, Name: "alice", UID: "uid-alice"}))
+	err := p.User().Create(ctx, &persistence.UserCreate{ClientID: clientID, UserID: 2, Name: "alice", UID: "uid-other"})
+	require.Equal(t, ecode.ErrDataAlreadyExists, err)
+
+	c, err := p.Client().GetClient(ctx, clientID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), c.UserCount)
+
+	activated, err := p.User().CheckActivated(ctx, clientID, "uid-alice")
+	require.NoError(t, err)
+	require.True(t, activated)
+	activated, err = p.User().CheckActivated(ctx, "other-client", "uid-alice")
+	require.NoError(t, err)
+	require.False(t, activated)
+
+	require.NoError(t, p.User().UpdateActivated(ctx, clientID, 1, false))
+	activated, err = p.User().CheckActivated(ctx, clientID, "uid-alice")
+	require.NoError(t, err)
+	require.False(t, activated)
+	require.NoError(t, p.User().UpdateActivated(ctx, clientID, 1, false))
+	err = p.User().UpdateActivated(ctx, clientID, 2, true)
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+
+	require.NoError(t, p.User().Delete(ctx, clientID, 1))
+	err = p.User().Delete(ctx, clientID, 1)
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+}
+
+func testFriend(t *testing.T, p persistence.Persister) {
+	clientID := createClient(t, p, "client")
+	createUsers(t, p, clientID, map[int64]string{1: "alice", 2: "bob", 3: "carol"})
+
+	require.NoError(t, p.User().AddFriend(ctx, &persistence.UserFriend{ClientID: clientID, UserID: 1, FriendUserID: 2}))
+	require.NoError(t, p.User().AddFriend(ctx, &persistence.UserFriend{ClientID: clientID, UserID: 1, FriendUserID: 3}))
+	err := p.User().AddFriend(ctx, &persistence.UserFriend{ClientID: clientID, UserID: 2, FriendUserID: 1})
+	require.Equal(t, ecode.ErrDataAlreadyExists, err)
+	err = p.User().AddFriend(ctx, &persistence.UserFriend{ClientID: clientID, UserID: 1, FriendUserID: 4})
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+
+	friends, err := p.User().GetFriends(ctx, clientID, 1)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []int64{2, 3}, friends)
+	friends, err = p.User().GetFriends(ctx, clientID, 2)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, friends)
+
+	require.NoError(t, p.User().DeleteFriend(ctx, &persistence.UserFriend{ClientID: clientID, UserID: 2, FriendUserID: 1}))
+	friends, err = p.User().GetFriends(ctx, clientID, 1)
+	require.NoError(t, err)
+	require.Equal(t, []int64{3}, friends)
+	friends, err = p.User().GetFriends(ctx, clientID, 2)
+	require.NoError(t, err)
+	require.Empty(t, friends)
+}
+
+func testGroup(t *testing.T, p persistence.Persister) {
+	clientID := createClient(t, p, "client")
+	createUsers(t, p, clientID, map[int64]string{1: "alice", 2: "bob", 3: "carol"})
+
+	g, err := p.Group().Create(ctx, &persistence.GroupCreate{
+		ClientID: clientID, GroupID: 10, Name: "group", GID: "gid-group", Introduction: "hello", Owner: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), g.MemberCount)
+
+	c, err := p.Client().GetClient(ctx, clientID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), c.GroupCount)
+
+	require.NoError(t, p.Group().AddMember(ctx, &persistence.GroupMember{ClientID: clientID, GroupID: 10, UserID: 2}))
+	err = p.Group().AddMember(ctx, &persistence.GroupMember{ClientID: clientID, GroupID: 10, UserID: 2})
+	require.Equal(t, ecode.ErrDataAlreadyExists, err)
+	err = p.Group().AddMember(ctx, &persistence.GroupMember{ClientID: "other-client", GroupID: 10, UserID: 3})
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+
+	ok, err := p.Group().CheckMember(ctx, clientID, 10, 2)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = p.Group().CheckMember(ctx, clientID, 10, 3)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	members, err := p.Group().GetMembers(ctx, clientID, 10)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []int64{1, 2}, members)
+	_, err = p.Group().GetMembers(ctx, "other-client", 10)
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+
+	groups, err := p.Group().GetGroups(ctx, clientID, 2)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Equal(t, "gid-group", groups[0].GID)
+	require.Equal(t, "hello", groups[0].Introduction)
+	require.Equal(t, int64(2), groups[0].MemberCount)
+}
+
+func testMessage(t *testing.T, p persistence.Persister) {
+	last, err := p.Message().GetTopicLastSequence(ctx, "client", "topic")
+	require.NoError(t, err)
+	require.Zero(t, last)
+
+	for seq := int64(1); seq <= 3; seq++ {
+		m := &persistence.Message{
+			ClientID:    "client",
+			Topic:       "topic",
+			Sequence:    seq,
+			MessageType: types.MessageTypeSingle,
+			Sender:      1,
+			Receiver:    2,
+			ContentType: types.ContentTypeText,
+			Body:        []byte(`{"content":"hello"}`),
+			Mentions:    []int64{3, 4},
+		}
+		require.NoError(t, p.Message().Add(ctx, m))
+		require.NotZero(t, m.ID)
+	}
+	err = p.Message().Add(ctx, &persistence.Message{ClientID: "client", Topic: "topic", Sequence: 2, Body: []byte(`{}`)})
+	require.Equal(t, ecode.ErrDataAlreadyExists, err)
+
+	last, err = p.Message().GetTopicLastSequence(ctx, "client", "topic")
+	require.NoError(t, err)
+	require.Equal(t, int64(3), last)
+
+	m, err := p.Message().GetTopicMessageBySequence(ctx, "client", "topic", 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), m.Sequence)
+	require.Equal(t, "client", m.ClientID)
+	require.Equal(t, types.MessageTypeSingle, m.MessageType)
+	require.Equal(t, types.ContentTypeText, m.ContentType)
+	require.JSONEq(t, `{"content":"hello"}`, string(m.Body))
+	require.Equal(t, []int64{3, 4}, m.Mentions)
+	_, err = p.Message().GetTopicMessageBySequence(ctx, "client", "topic", 4)
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+
+	messages, count, err := p.Message().GetTopicMessagesByLastSequence(ctx, "client", "topic", 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+	require.Len(t, messages, 2)
+	require.Equal(t, int64(3), messages[0].Sequence)
+	require.Equal(t, int64(2), messages[1].Sequence)
+
+	m.ContentType = types.ContentTypeQuote
+	m.Body = []byte(`{"content":"edited"}`)
+	m.Status = uint8(types.MessageStatusRecalled)
+	require.NoError(t, p.Message().Update(ctx, m))
+	updated, err := p.Message().GetTopicMessageBySequence(ctx, "client", "topic", 2)
+	require.NoError(t, err)
+	require.Equal(t, types.ContentTypeQuote, updated.ContentType)
+	require.JSONEq(t, `{"content":"edited"}`, string(updated.Body))
+	require.Equal(t, uint8(types.MessageStatusRecalled), updated.Status)
+	require.Equal(t, []int64{3, 4}, updated.Mentions)
+
+	// The messages of the other clients are not updated
+	err = p.Message().Update(ctx, &persistence.Message{ID: m.ID, ClientID: "other", Body: []byte(`{}`)})
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+}
+
+func addMessages(t *testing.T, p persistence.Persister, clientID, topic string, count int64) {
+	for seq := int64(1); seq <= count; seq++ {
+		require.NoError(t, p.Message().Add(ctx, &persistence.Message{
+			ClientID:    clientID,
+			Topic:       topic,
+			Sequence:    seq,
+			MessageType: types.MessageTypeSingle,
+			ContentType: types.ContentTypeText,
+			Body:        []byte(`{}`),
+		}))
+	}
+}
+
+func testRetention(t *testing.T, p persistence.Persister) {
+	retained := uuid.New().String()
+	require.NoError(t, p.Client().Create(ctx, &persistence.ClientCreate{
+		ID:              retained,
+		Name:            "retained",
+		TokenSecret:     "secret",
+		MessageMaxCount: 2,
+	}))
+	other := createClient(t, p, "other")
+
+	clients, err := p.Client().GetRetainedClients(ctx)
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+	require.Equal(t, retained, clients[0].ID)
+	require.Equal(t, int64(2), clients[0].MessageMaxCount)
+
+	addMessages(t, p, retained, "topic1", 4)
+	addMessages(t, p, retained, "topic2", 2)
+	addMessages(t, p, other, "topic3", 3)
+
+	// Beyond the latest two of each topic
+	expired, err := p.Message().GetExpiredMessages(ctx, retained, 0, 2, 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 2)
+	require.Equal(t, "topic1", expired[0].Topic)
+	require.Equal(t, int64(1), expired[0].Sequence)
+	require.Equal(t, int64(2), expired[1].Sequence)
+	require.Equal(t, retained, expired[0].ClientID)
+
+	expired, err = p.Message().GetExpiredMessages(ctx, retained, 0, 2, 1)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+
+	// Created before the time, but the latest of each topic
+	all, err := p.Message().GetExpiredMessages(ctx, retained, time.Now().Unix()+1, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, all, 4)
+	require.Equal(t, "topic2", all[3].Topic)
+	require.Equal(t, int64(1), all[3].Sequence)
+
+	expired, err = p.Message().GetExpiredMessages(ctx, retained, 0, 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, expired)
+
+	require.NoError(t, p.Message().Delete(ctx, retained))
+	require.NoError(t, p.Message().Delete(ctx, retained, all[0].ID, all[1].ID))
+	messages, count, err := p.Message().GetTopicMessagesByLastSequence(ctx, retained, "topic1", 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+	require.Equal(t, int64(4), messages[0].Sequence)
+	require.Equal(t, int64(3), messages[1].Sequence)
+	last, err := p.Message().GetTopicLastSequence(ctx, retained, "topic1")
+	require.NoError(t, err)
+	require.Equal(t, int64(4), last)
+
+	expired, err = p.Message().GetExpiredMessages(ctx, retained, 0, 2, 10)
+	require.NoError(t, err)
+	require.Empty(t, expired)
+	_, count, err = p.Message().GetTopicMessagesByLastSequence(ctx, other, "topic3", 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+}
+
+func testTopic(t *testing.T, p persistence.Persister) {
+	topics, err := p.Topic().GetUserTopics(ctx, "client", 1)
+	require.NoError(t, err)
+	require.Empty(t, topics)
+
+	require.NoError(t, p.Topic().Save(ctx,
+		&persistence.UserTopic{ClientID: "client", UserID: 1, Topic: "b"},
+		&persistence.UserTopic{ClientID: "client", UserID: 1, Topic: "a", LastSequence: 3},
+		&persistence.UserTopic{ClientID: "client", UserID: 1, Topic: "a", LastSequence: 5},
+		&persistence.UserTopic{ClientID: "client", UserID: 2, Topic: "a"},
+		&persistence.UserTopic{ClientID: "other", UserID: 4, Topic: "c", LastSequence: 1},
+	))
+	// The last sequences only move forward
+	require.NoError(t, p.Topic().Save(ctx,
+		&persistence.UserTopic{ClientID: "client", UserID: 1, Topic: "a", LastSequence: 4},
+		&persistence.UserTopic{ClientID: "client", UserID: 1, Topic: "b", LastSequence: 2},
+		&persistence.UserTopic{ClientID: "client", UserID: 2, Topic: "a"},
+	))
+
+	topics, err = p.Topic().GetUserTopics(ctx, "client", 1)
+	require.NoError(t, err)
+	require.Equal(t, []*persistence.UserTopic{
+		{ClientID: "client", UserID: 1, Topic: "a", LastSequence: 5},
+		{ClientID: "client", UserID: 1, Topic: "b", LastSequence: 2},
+	}, topics)
+	topics, err = p.Topic().GetUserTopics(ctx, "client", 2)
+	require.NoError(t, err)
+	require.Equal(t, []*persistence.UserTopic{{ClientID: "client", UserID: 2, Topic: "a"}}, topics)
+	topics, err = p.Topic().GetUserTopics(ctx, "client", 4)
+	require.NoError(t, err)
+	require.Empty(t, topics)
+
+	users, err := p.Topic().GetUsers(ctx, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, []*persistence.TopicUser{{ClientID: "client", UserID: 1}, {ClientID: "client", UserID: 2}}, users)
+	users, err = p.Topic().GetUsers(ctx, 2, 2)
+	require.NoError(t, err)
+	require.Equal(t, []*persistence.TopicUser{{ClientID: "other", UserID: 4}}, users)
+	users, err = p.Topic().GetUsers(ctx, 4, 2)
+	require.NoError(t, err)
+	require.Empty(t, users)
+
+	// More topics than a statement saves
+	var many []*persistence.UserTopic
+	for i := 0; i < 250; i++ {
+		many = append(many, &persistence.UserTopic{ClientID: "client", UserID: 5, Topic: fmt.Sprintf("topic-%03d", i), LastSequence: int64(i)})
+	}
+	require.NoError(t, p.Topic().Save(ctx, many...))
+	topics, err = p.Topic().GetUserTopics(ctx, "client", 5)
+	require.NoError(t, err)
+	require.Equal(t, many, topics)
+}
+
+// testIsolation checks a client can neither read nor change the users, the groups and
+// the messages of another client, even with their IDs.
+func testIsolation(t *testing.T, p persistence.Persister) {
+	clientID := createClient(t, p, "client")
+	other := createClient(t, p, "other")
+	createUsers(t, p, clientID, map[int64]string{1: "alice", 2: "bob"})
+	createUsers(t, p, other, map[int64]string{3: "mallory"})
+
+	_, err := p.Group().Create(ctx, &persistence.GroupCreate{ClientID: clientID, GroupID: 10, Name: "group", GID: "gid-group", Owner: 1})
+	require.NoError(t, err)
+	require.NoError(t, p.Group().AddMember(ctx, &persistence.GroupMember{ClientID: clientID, GroupID: 10, UserID: 2}))
+	require.NoError(t, p.User().AddFriend(ctx, &persistence.UserFriend{ClientID: clientID, UserID: 1, FriendUserID: 2}))
+	addMessages(t, p, clientID, "topic", 2)
+
+	// The users
+	err = p.User().UpdateActivated(ctx, other, 1, false)
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+	err = p.User().Delete(ctx, other, 1)
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+	activated, err := p.User().CheckActivated(ctx, clientID, "uid-alice")
+	require.NoError(t, err)
+	require.True(t, activated)
+
+	err = p.User().AddFriend(ctx, &persistence.UserFriend{ClientID: other, UserID: 3, FriendUserID: 1})
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+	err = p.User().AddFriend(ctx, &persistence.UserFriend{ClientID: other, UserID: 1, FriendUserID: 3})
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+	err = p.User().DeleteFriend(ctx, &persistence.UserFriend{ClientID: other, UserID: 1, FriendUserID: 2})
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+	friends, err := p.User().GetFriends(ctx, other, 1)
+	require.NoError(t, err)
+	require.Empty(t, friends)
+	friends, err = p.User().GetFriends(ctx, clientID, 1)
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, friends)
+
+	// The groups
+	_, err = p.Group().Create(ctx, &persistence.GroupCreate{ClientID: other, GroupID: 11, Name: "group", GID: "gid-other", Owner: 1})
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+	err = p.Group().AddMember(ctx, &persistence.GroupMember{ClientID: other, GroupID: 10, UserID: 3})
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+	err = p.Group().AddMember(ctx, &persistence.GroupMember{ClientID: clientID, GroupID: 10, UserID: 3})
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+	ok, err := p.Group().CheckMember(ctx, other, 10, 1)
+	require.NoError(t, err)
+	require.False(t, ok)
+	_, err = p.Group().GetMembers(ctx, other, 10)
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+	groups, err := p.Group().GetGroups(ctx, other, 1)
+	require.NoError(t, err)
+	require.Empty(t, groups)
+	members, err := p.Group().GetMembers(ctx, clientID, 10)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []int64{1, 2}, members)
+
+	// The messages
+	last, err := p.Message().GetTopicLastSequence(ctx, other, "topic")
+	require.NoError(t, err)
+	require.Zero(t, last)
+	_, err = p.Message().GetTopicMessageBySequence(ctx, other, "topic", 1)
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+	messages, count, err := p.Message().GetTopicMessagesByLastSequence(ctx, other, "topic", 0)
+	require.NoError(t, err)
+	require.Zero(t, count)
+	require.Empty(t, messages)
+
+	messages, _, err = p.Message().GetTopicMessagesByLastSequence(ctx, clientID, "topic", 0)
+	require.NoError(t, err)
+	require.NoError(t, p.Message().Delete(ctx, other, messages[0].ID, messages[1].ID))
+	_, count, err = p.Message().GetTopicMessagesByLastSequence(ctx, clientID, "topic", 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
+// NewArchiver returns an empty archiver.
+type NewArchiver func(t *testing.T) persistence.Archiver
+
+// RunArchiver runs the suite against the archivers of newArchiver.
+func RunArchiver(t *testing.T, newArchiver NewArchiver) {
+	a := newArchiver(t)
+	defer a.Close()
+
+	var messages []*persistence.Message
+	for seq := int64(1); seq <= 4; seq++ {
+		messages = append(messages, &persistence.Message{
+			ID:          seq,
+			ClientID:    "client",
+			Topic:       "topic",
+			Sequence:    seq,
+			MessageType: types.MessageTypeSingle,
+			ContentType: types.ContentTypeText,
+			Body:        []byte(`{"content":"hello"}`),
+			Mentions:    []int64{3},
+		})
+	}
+	require.NoError(t, a.Archive(ctx, messages[2:]))
+	require.NoError(t, a.Archive(ctx, messages[:3]))
+
+	m, err := a.GetTopicMessageBySequence(ctx, "topic", 3)
+	require.NoError(t, err)
+	require.Equal(t, messages[2], m)
+	_, err = a.GetTopicMessageBySequence(ctx, "topic", 5)
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+	_, err = a.GetTopicMessageBySequence(ctx, "other", 1)
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+
+	archived, err := a.GetTopicMessages(ctx, "topic", 1, 4)
+	require.NoError(t, err)
+	require.Equal(t, []*persistence.Message{messages[2], messages[1]}, archived)
+	archived, err = a.GetTopicMessages(ctx, "topic", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, archived, 4)
+	require.Equal(t, int64(4), archived[0].Sequence)
+	archived, err = a.GetTopicMessages(ctx, "other", 0, 0)
+	require.NoError(t, err)
+	require.Empty(t, archived)
+}
+
+// NewIndexer returns an empty indexer.
+type NewIndexer func(t *testing.T) persistence.Indexer
+
+// RunIndexer runs the suite against the indexers of newIndexer.
+func RunIndexer(t *testing.T, newIndexer NewIndexer) {
+	x := newIndexer(t)
+	defer x.Close()
+
+	message := func(id int64, clientID, topic string, sender int64, contentType types.ContentType, body string) *persistence.Message {
+		return &persistence.Message{
+			ID:          id,
+			CreatedAt:   id * 100,
+			ClientID:    clientID,
+			Topic:       topic,
+			Sequence:    id,
+			MessageType: types.MessageTypeSingle,
+			Sender:      sender,
+			ContentType: contentType,
+			Body:        []byte(body),
+		}
+	}
+	require.NoError(t, x.Index(ctx,
+		message(1, "client", "topic1", 1, types.ContentTypeText, `{"content":"Hello, World!"}`),
+		message(2, "client", "topic1", 2, types.ContentTypeQuote, `{"quoted_message_id":1,"content":"hello again"}`),
+		message(3, "client", "topic2", 1, types.ContentTypeFile, `{"file_stat":{"filename":"Report-2020.pdf"},"hash":"h"}`),
+		message(4, "other", "topic1", 1, types.ContentTypeText, `{"content":"hello"}`),
+		message(5, "client", "topic1", 1, types.ContentTypeText, `{"content":"你好世界"}`),
+	))
+
+	search := func(q persistence.MessageQuery) []int64 {
+		if q.ClientID == "" {
+			q.ClientID = "client"
+		}
+		if q.Topics == nil {
+			q.Topics = []string{"topic1", "topic2"}
+		}
+		hits, err := x.Search(ctx, &q)
+		require.NoError(t, err)
+		var ids []int64
+		for _, hit := range hits {
+			ids = append(ids, hit.ID)
+			require.Equal(t, hit.ID, hit.Sequence)
+		}
+		return ids
+	}
+	require.Equal(t, []int64{2, 1}, search(persistence.MessageQuery{Text: "hello"}))
+	require.Equal(t, []int64{1}, search(persistence.MessageQuery{Text: "HELLO world"}))
+	require.Equal(t, []int64{3}, search(persistence.MessageQuery{Text: "report"}))
+	require.Equal(t, []int64{5}, search(persistence.MessageQuery{Text: "世界"}))
+	require.Empty(t, search(persistence.MessageQuery{Text: "hello", Topics: []string{"topic2"}}))
+	require.Empty(t, search(persistence.MessageQuery{Text: "hello", Topics: []string{}}))
+	require.Empty(t, search(persistence.MessageQuery{Text: "   "}))
+	require.Equal(t, []int64{2}, search(persistence.MessageQuery{Text: "hello", Sender: 2}))
+	require.Equal(t, []int64{2}, search(persistence.MessageQuery{Text: "hello", StartAt: 150}))
+	require.Equal(t, []int64{1}, search(persistence.MessageQuery{Text: "hello", EndAt: 150}))
+	require.Equal(t, []int64{2}, search(persistence.MessageQuery{Text: "hello", ContentTypes: []types.ContentType{types.ContentTypeQuote}}))
+	require.Equal(t, []int64{2}, search(persistence.MessageQuery{Text: "hello", Limit: 1}))
+	require.Equal(t, []int64{4}, search(persistence.MessageQuery{ClientID: "other", Text: "hello"}))
+
+	// Indexing a message again replaces it
+	require.NoError(t, x.Index(ctx, message(1, "client", "topic1", 1, types.ContentTypeText, `{"content":"goodbye"}`)))
+	require.Equal(t, []int64{2}, search(persistence.MessageQuery{Text: "hello"}))
+	require.Equal(t, []int64{1}, search(persistence.MessageQuery{Text: "goodbye"}))
+
+	require.NoError(t, x.Remove(ctx))
+	require.NoError(t, x.Remove(ctx, 2, 3))
+	require.Empty(t, search(persistence.MessageQuery{Text: "hello"}))
+	require.Empty(t, search(persistence.MessageQuery{Text: "report"}))
+}
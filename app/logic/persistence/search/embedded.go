@@ -0,0 +1,290 @@
+// Package search implements persistence.Indexer without a search engine.
+package search
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+	"mercury/app/logic/persistence"
+	"mercury/x/types"
+)
+
+// The file of the log of the embedded index in its directory
+const logFile = "index.jsonl"
+
+type document struct {
+	ID          int64             `json:"id"`
+	CreatedAt   int64             `json:"created_at"`
+	ClientID    string            `json:"client_id"`
+	Topic       string            `json:"topic"`
+	Sequence    int64             `json:"sequence"`
+	Sender      int64             `json:"sender"`
+	ContentType types.ContentType `json:"content_type"`
+	Tokens      []string          `json:"tokens"`
+}
+
+// entry of the log, either a document indexed or the IDs of the ones removed
+type entry struct {
+	Document *document `json:"document,omitempty"`
+	Removed  []int64   `json:"removed,omitempty"`
+}
+
+// EmbeddedIndexer is an inverted index of the words of the messages held in memory,
+// for the deployments of a single logic. The changes are appended to a log in its
+// directory, which is replayed and compacted when the index is opened.
+type EmbeddedIndexer struct {
+	mu        sync.RWMutex
+	documents map[int64]*document
+	postings  map[string]map[int64]struct{}
+	log       *os.File
+}
+
+// NewEmbeddedIndexer opens the index of dir, which is created if it does not exist. The
+// index is only kept in memory if dir is empty.
+func NewEmbeddedIndexer(dir string) (*EmbeddedIndexer, error) {
+	idx := &EmbeddedIndexer{
+		documents: make(map[int64]*document),
+		postings:  make(map[string]map[int64]struct{}),
+	}
+	if dir == "" {
+		return idx, nil
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	name := filepath.Join(dir, logFile)
+	if err := idx.replay(name); err != nil {
+		return nil, err
+	}
+	if err := idx.compact(name); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return nil, err
+	}
+	idx.log = f
+	return idx, nil
+}
+
+func (idx *EmbeddedIndexer) replay(name string) error {
+	f, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 16<<20)
+	for scanner.Scan() {
+		var e entry
+		if err := jsoniter.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// The last entry is torn if the process stopped while appending it
+			break
+		}
+		idx.apply(&e)
+	}
+	return scanner.Err()
+}
+
+// compact rewrites the log with the documents indexed.
+func (idx *EmbeddedIndexer) compact(name string) error {
+	tmp := name + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, d := range idx.documents {
+		line, err := jsoniter.Marshal(&entry{Document: d})
+		if err != nil {
+			_ = f.Close()
+			return err
+		}
+		_, _ = w.Write(line)
+		_ = w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+func (idx *EmbeddedIndexer) apply(e *entry) {
+	if d := e.Document; d != nil {
+		idx.remove(d.ID)
+		idx.documents[d.ID] = d
+		for _, token := range d.Tokens {
+			ids, ok := idx.postings[token]
+			if !ok {
+				ids = make(map[int64]struct{})
+				idx.postings[token] = ids
+			}
+			ids[d.ID] = struct{}{}
+		}
+	}
+	for _, id := range e.Removed {
+		idx.remove(id)
+	}
+}
+
+func (idx *EmbeddedIndexer) remove(id int64) {
+	d, ok := idx.documents[id]
+	if !ok {
+		return
+	}
+	delete(idx.documents, id)
+	for _, token := range d.Tokens {
+		delete(idx.postings[token], id)
+		if len(idx.postings[token]) == 0 {
+			delete(idx.postings, token)
+		}
+	}
+}
+
+// write applies the entries and appends them to the log, it must be called with the
+// lock held.
+func (idx *EmbeddedIndexer) write(entries ...*entry) error {
+	var buf []byte
+	for _, e := range entries {
+		idx.apply(e)
+		if idx.log == nil {
+			continue
+		}
+		line, err := jsoniter.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+	if idx.log == nil {
+		return nil
+	}
+	_, err := idx.log.Write(buf)
+	return err
+}
+
+func (idx *EmbeddedIndexer) Index(_ context.Context, messages ...*persistence.Message) error {
+	entries := make([]*entry, 0, len(messages))
+	for _, m := range messages {
+		entries = append(entries, &entry{Document: &document{
+			ID:          m.ID,
+			CreatedAt:   m.CreatedAt,
+			ClientID:    m.ClientID,
+			Topic:       m.Topic,
+			Sequence:    m.Sequence,
+			Sender:      m.Sender,
+			ContentType: m.ContentType,
+			Tokens:      uniq(types.Tokenize(types.Text(m.ContentType, m.Body))),
+		}})
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	return idx.write(entries...)
+}
+
+func (idx *EmbeddedIndexer) Remove(_ context.Context, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	return idx.write(&entry{Removed: ids})
+}
+
+func (idx *EmbeddedIndexer) Search(_ context.Context, q *persistence.MessageQuery) ([]*persistence.MessageHit, error) {
+	tokens := uniq(types.Tokenize(q.Text))
+	if len(tokens) == 0 || len(q.Topics) == 0 {
+		return nil, nil
+	}
+	topics := make(map[string]bool, len(q.Topics))
+	for _, topic := range q.Topics {
+		topics[topic] = true
+	}
+	contentTypes := make(map[types.ContentType]bool, len(q.ContentTypes))
+	for _, t := range q.ContentTypes {
+		contentTypes[t] = true
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	// The documents with every token, starting from the rarest one
+	sort.Slice(tokens, func(i, j int) bool {
+		return len(idx.postings[tokens[i]]) < len(idx.postings[tokens[j]])
+	})
+	var matched []*document
+	for id := range idx.postings[tokens[0]] {
+		d := idx.documents[id]
+		if d.ClientID != q.ClientID || !topics[d.Topic] ||
+			(q.Sender != 0 && d.Sender != q.Sender) ||
+			(q.StartAt > 0 && d.CreatedAt < q.StartAt) ||
+			(q.EndAt > 0 && d.CreatedAt > q.EndAt) ||
+			(len(contentTypes) > 0 && !contentTypes[d.ContentType]) {
+			continue
+		}
+		all := true
+		for _, token := range tokens[1:] {
+			if _, ok := idx.postings[token][id]; !ok {
+				all = false
+				break
+			}
+		}
+		if all {
+			matched = append(matched, d)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].CreatedAt != matched[j].CreatedAt {
+			return matched[i].CreatedAt > matched[j].CreatedAt
+		}
+		return matched[i].ID > matched[j].ID
+	})
+	if q.Limit > 0 && len(matched) > q.Limit {
+		matched = matched[:q.Limit]
+	}
+
+	hits := make([]*persistence.MessageHit, len(matched))
+	for i, d := range matched {
+		hits[i] = &persistence.MessageHit{ID: d.ID, Topic: d.Topic, Sequence: d.Sequence}
+	}
+	return hits, nil
+}
+
+func (idx *EmbeddedIndexer) Close() error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.log == nil {
+		return nil
+	}
+	err := idx.log.Close()
+	idx.log = nil
+	return err
+}
+
+func uniq(tokens []string) []string {
+	seen := make(map[string]bool, len(tokens))
+	out := tokens[:0]
+	for _, token := range tokens {
+		if !seen[token] {
+			seen[token] = true
+			out = append(out, token)
+		}
+	}
+	return out
+}
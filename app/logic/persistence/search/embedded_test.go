@@ -0,0 +1,60 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"mercury/app/logic/persistence"
+	"mercury/app/logic/persistence/persistencetest"
+	"mercury/x/types"
+)
+
+func TestEmbeddedIndexer(t *testing.T) {
+	t.Run("Memory", func(t *testing.T) {
+		persistencetest.RunIndexer(t, func(t *testing.T) persistence.Indexer {
+			idx, err := NewEmbeddedIndexer("")
+			require.NoError(t, err)
+			return idx
+		})
+	})
+	t.Run("Dir", func(t *testing.T) {
+		persistencetest.RunIndexer(t, func(t *testing.T) persistence.Indexer {
+			idx, err := NewEmbeddedIndexer(t.TempDir())
+			require.NoError(t, err)
+			return idx
+		})
+	})
+}
+
+func TestEmbeddedIndexerReopen(t *testing.T) {
+	ctx, dir := context.Background(), t.TempDir()
+	q := &persistence.MessageQuery{ClientID: "client", Text: "hello", Topics: []string{"topic"}}
+
+	idx, err := NewEmbeddedIndexer(dir)
+	require.NoError(t, err)
+	for id := int64(1); id <= 3; id++ {
+		require.NoError(t, idx.Index(ctx, &persistence.Message{
+			ID:          id,
+			ClientID:    "client",
+			Topic:       "topic",
+			Sequence:    id,
+			ContentType: types.ContentTypeText,
+			Body:        []byte(`{"content":"hello"}`),
+		}))
+	}
+	require.NoError(t, idx.Remove(ctx, 2))
+	require.NoError(t, idx.Close())
+
+	for i := 0; i < 2; i++ {
+		idx, err = NewEmbeddedIndexer(dir)
+		require.NoError(t, err)
+		hits, err := idx.Search(ctx, q)
+		require.NoError(t, err)
+		require.Equal(t, []*persistence.MessageHit{
+			{ID: 3, Topic: "topic", Sequence: 3},
+			{ID: 1, Topic: "topic", Sequence: 1},
+		}, hits)
+		require.NoError(t, idx.Close())
+	}
+}
@@ -25,10 +25,32 @@ INSERT INTO
 		token_secret,
 		token_expire,
         credential,
-		user_count
+		user_count,
+		message_max_age,
+		message_max_count
     )
 VALUES
-    ($1, $2, $2, $3, $4, $5, $6, 0);
+    ($1, $2, $2, $3, $4, $5, $6, 0, $7, $8);
+`
+
+	getRetainedClientsSQL = `
+SELECT
+	id,
+	created_at,
+	updated_at,
+	name,
+	token_expire,
+	token_secret,
+	user_count,
+	group_count,
+	message_max_age,
+	message_max_count
+FROM
+    client
+WHERE
+    message_max_age > 0
+OR
+	message_max_count > 0;
 `
 )
 
@@ -55,26 +77,58 @@ func (p *clientPersister) GetClient(_ context.Context, id string) (*persistence.
 	var (
 		name, tokenSecret                                        string
 		createdAt, updatedAt, tokenExpire, userCount, groupCount int64
+		messageMaxAge, messageMaxCount                           int64
 	)
-	if err := p.db.QueryRow("SELECT created_at, updated_at, name, token_expire, token_secret, user_count, group_count FROM client WHERE id = $1;", id).
-		Scan(&createdAt, &updatedAt, &name, &tokenExpire, &tokenSecret, &userCount, &groupCount); sqlx.IsErrNoRows(err) {
+	if err := p.db.QueryRow("SELECT created_at, updated_at, name, token_expire, token_secret, user_count, group_count, message_max_age, message_max_count FROM client WHERE id = $1;", id).
+		Scan(&createdAt, &updatedAt, &name, &tokenExpire, &tokenSecret, &userCount, &groupCount, &messageMaxAge, &messageMaxCount); sqlx.IsErrNoRows(err) {
 		return nil, ecode.ErrDataDoesNotExist
 	} else if err != nil {
 		return nil, err
 	}
 
 	return &persistence.Client{
-		ID:          id,
-		CreatedAt:   createdAt,
-		UpdatedAt:   updatedAt,
-		Name:        name,
-		TokenSecret: []byte(tokenSecret),
-		TokenExpire: time.Duration(tokenExpire) * time.Second,
-		UserCount:   userCount,
-		GroupCount:  groupCount,
+		ID:              id,
+		CreatedAt:       createdAt,
+		UpdatedAt:       updatedAt,
+		Name:            name,
+		TokenSecret:     []byte(tokenSecret),
+		TokenExpire:     time.Duration(tokenExpire) * time.Second,
+		UserCount:       userCount,
+		GroupCount:      groupCount,
+		MessageMaxAge:   time.Duration(messageMaxAge) * time.Second,
+		MessageMaxCount: messageMaxCount,
 	}, nil
 }
 
+func (p *clientPersister) GetRetainedClients(_ context.Context) ([]*persistence.Client, error) {
+	rows, err := p.db.Query(getRetainedClientsSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var clients []*persistence.Client
+	for rows.Next() {
+		var (
+			client                                      persistence.Client
+			tokenSecret                                 string
+			tokenExpire, messageMaxAge, messageMaxCount int64
+		)
+		if err := rows.Scan(&client.ID, &client.CreatedAt, &client.UpdatedAt, &client.Name, &tokenExpire, &tokenSecret,
+			&client.UserCount, &client.GroupCount, &messageMaxAge, &messageMaxCount); err != nil {
+			return nil, err
+		}
+
+		client.TokenSecret = []byte(tokenSecret)
+		client.TokenExpire = time.Duration(tokenExpire) * time.Second
+		client.MessageMaxAge = time.Duration(messageMaxAge) * time.Second
+		client.MessageMaxCount = messageMaxCount
+		clients = append(clients, &client)
+	}
+
+	return clients, nil
+}
+
 func (p *clientPersister) Create(_ context.Context, in *persistence.ClientCreate) error {
 	var isExist int
 	if err := p.db.QueryRow("SELECT 1 FROM client WHERE name = $1 limit 1;", in.Name).
@@ -87,7 +141,8 @@ func (p *clientPersister) Create(_ context.Context, in *persistence.ClientCreate
 	}
 
 	now := time.Now().Unix()
-	if err := p.db.Exec(insertClientSQL, 1, in.ID, now, in.Name, in.TokenSecret, in.TokenExpire, in.Credential); err != nil {
+	if err := p.db.Exec(insertClientSQL, 1, in.ID, now, in.Name, in.TokenSecret, in.TokenExpire, in.Credential,
+		in.MessageMaxAge, in.MessageMaxCount); err != nil {
 		return err
 	}
 
@@ -117,6 +172,16 @@ func (p *clientPersister) Update(_ context.Context, in *persistence.ClientUpdate
 		updateValues = append(updateValues, x.Sprintf(updateValuesTemplate, "token_expire", start))
 		args = append(args, *in.TokenExpire)
 	}
+	if in.MessageMaxAge != nil {
+		start++
+		updateValues = append(updateValues, x.Sprintf(updateValuesTemplate, "message_max_age", start))
+		args = append(args, *in.MessageMaxAge)
+	}
+	if in.MessageMaxCount != nil {
+		start++
+		updateValues = append(updateValues, x.Sprintf(updateValuesTemplate, "message_max_count", start))
+		args = append(args, *in.MessageMaxCount)
+	}
 
 	if start > 1 {
 		start++
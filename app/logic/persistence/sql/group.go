@@ -17,7 +17,7 @@ const (
 SELECT
     1
 FROM
-    public.group
+    "group"
 WHERE
     client_id = $1
 AND
@@ -28,7 +28,7 @@ limit
 
 	insertGroupSQL = `
 INSERT INTO
-    public.group (
+    "group" (
 		id,
         created_at,
         updated_at,
@@ -56,21 +56,23 @@ SELECT
 FROM
 	group_member gm
 JOIN 
-	public.group g
+	"group" g
 ON 
 	g.ID = gm.group_id
 AND
 	g.activated = true
+AND
+	g.client_id = $1
 WHERE
-	gm.user_id = $1
+	gm.user_id = $2
 ORDER BY
-	created_at DESC;`
+	g.created_at DESC;`
 
 	isGroupMemberExistSQL = `
 SELECT
     1
 FROM
-    public.group_member
+    group_member
 WHERE
     group_id = $1
 AND
@@ -79,9 +81,28 @@ limit
     1;
 `
 
+	isClientGroupMemberExistSQL = `
+SELECT
+    1
+FROM
+    group_member gm
+JOIN
+	"group" g
+ON
+	g.id = gm.group_id
+WHERE
+	g.client_id = $1
+AND
+    gm.group_id = $2
+AND
+	gm.user_id = $3
+limit
+    1;
+`
+
 	insertGroupMemberSQL = `
 INSERT INTO
-    public.group_member (
+    group_member (
         created_at,
         updated_at,
         group_id,
@@ -103,6 +124,16 @@ func (p *groupPersister) Create(_ context.Context, in *persistence.GroupCreate)
 		}
 	}()
 
+	var isExist int
+	if err = tx.QueryRow(isClientUserExistSQL, in.ClientID, in.Owner).Scan(&isExist); err != nil && !sqlx.IsErrNoRows(err) {
+		return nil, err
+	}
+
+	if isExist == 0 {
+		err = ecode.ErrDataDoesNotExist
+		return nil, err
+	}
+
 	now := time.Now().Unix()
 	if err = tx.Exec(insertGroupSQL, 1, in.GroupID, now, in.ClientID, in.Name, in.GID, in.Introduction, in.Owner, 0, true); err != nil {
 		return nil, err
@@ -130,11 +161,12 @@ func (p *groupPersister) Create(_ context.Context, in *persistence.GroupCreate)
 	}, nil
 }
 
-func (p *groupPersister) GetGroups(_ context.Context, userID int64) ([]*persistence.Group, error) {
-	rows, err := p.db.Query(getGroupsSQL, userID)
+func (p *groupPersister) GetGroups(_ context.Context, clientID string, userID int64) ([]*persistence.Group, error) {
+	rows, err := p.db.Query(getGroupsSQL, clientID, userID)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	var groups []*persistence.Group
 	for rows.Next() {
@@ -150,7 +182,7 @@ func (p *groupPersister) GetGroups(_ context.Context, userID int64) ([]*persiste
 }
 
 func increaseGroupMemberCount(tx *sqlx.Tx, id int64, count int64) error {
-	return tx.Exec("UPDATE public.group SET member_count = member_count + $1 WHERE id = $2;", 1, count, id)
+	return tx.Exec(`UPDATE "group" SET member_count = member_count + $1 WHERE id = $2;`, 1, count, id)
 }
 
 func (p *groupPersister) AddMember(_ context.Context, in *persistence.GroupMember) error {
@@ -173,13 +205,23 @@ func (p *groupPersister) AddMember(_ context.Context, in *persistence.GroupMembe
 		return ecode.ErrDataDoesNotExist
 	}
 
+	isExist = 0
+	if err = tx.QueryRow(isClientUserExistSQL, in.ClientID, in.UserID).Scan(&isExist); err != nil && !sqlx.IsErrNoRows(err) {
+		return err
+	}
+
+	if isExist == 0 {
+		return ecode.ErrDataDoesNotExist
+	}
+
 	isExist = 0
 	if err = tx.QueryRow(isGroupMemberExistSQL, in.GroupID, in.UserID).Scan(&isExist); err != nil && !sqlx.IsErrNoRows(err) {
 		return err
 	}
 
 	if isExist == 1 {
-		return ecode.ErrDataAlreadyExists
+		err = ecode.ErrDataAlreadyExists
+		return err
 	}
 
 	now := time.Now().Unix()
@@ -198,9 +240,9 @@ func (p *groupPersister) AddMember(_ context.Context, in *persistence.GroupMembe
 	return nil
 }
 
-func (p *groupPersister) CheckMember(_ context.Context, groupID int64, userID int64) (bool, error) {
+func (p *groupPersister) CheckMember(_ context.Context, clientID string, groupID int64, userID int64) (bool, error) {
 	var isExist int
-	if err := p.db.QueryRow(isGroupMemberExistSQL, groupID, userID).Scan(&isExist); err != nil {
+	if err := p.db.QueryRow(isClientGroupMemberExistSQL, clientID, groupID, userID).Scan(&isExist); err != nil {
 		if sqlx.IsErrNoRows(err) {
 			return false, nil
 		}
@@ -224,6 +266,7 @@ func (p *groupPersister) GetMembers(_ context.Context, clientID string, groupID
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	var memberIDs []int64
 	for rows.Next() {
@@ -7,6 +7,7 @@ import (
 	"mercury/x/database/sqlx"
 	"mercury/x/ecode"
 	"mercury/x/types"
+	"strings"
 	"time"
 )
 
@@ -20,6 +21,7 @@ INSERT INTO
     message (
         created_at,
         updated_at,
+		client_id,
 		topic,
 		sequence,
 		message_type,
@@ -31,14 +33,14 @@ INSERT INTO
 		mentions
     )
 VALUES
-    ($1, $1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-RETURNING id;
+    ($1, $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
 `
 
 	getMessagesBySequenceSQL = `
 SELECT
 	id,
     created_at,
+	client_id,
 	topic,
 	sequence,
 	message_type,
@@ -51,9 +53,11 @@ SELECT
 FROM
     message
 WHERE
-    topic = $1
+    client_id = $1
 AND
-	sequence = $2
+    topic = $2
+AND
+	sequence = $3
 LIMIT 1;
 `
 
@@ -61,6 +65,7 @@ LIMIT 1;
 SELECT
 	id,
     created_at,
+	client_id,
 	topic,
 	sequence,
 	message_type,
@@ -73,12 +78,44 @@ SELECT
 FROM
     message
 WHERE
-    topic = $1
+    client_id = $1
+AND
+    topic = $2
 AND
-	sequence > $2
+	sequence > $3
 ORDER BY
     sequence DESC;
 `
+
+	// Takes the conditions a message is expired by, it is expired if it meets any
+	getExpiredMessagesSQL = `
+SELECT
+	id,
+    created_at,
+	client_id,
+	topic,
+	sequence,
+	message_type,
+	sender,
+	receiver,
+	content_type,
+	body,
+	status,
+	mentions
+FROM
+    message
+WHERE
+    client_id = $1
+AND
+	(%s)
+ORDER BY
+    id
+LIMIT %d;
+`
+	// The latest message of a topic is kept whatever its age, so that its sequence carries on
+	expiredByAgeSQL   = "(created_at < $%d AND sequence < " + latestSequenceSQL + ")"
+	expiredByCountSQL = "sequence <= " + latestSequenceSQL + " - $%d"
+	latestSequenceSQL = "(SELECT MAX(latest.sequence) FROM message latest WHERE latest.topic = message.topic)"
 )
 
 func (p *messagePersister) Add(_ context.Context, message *persistence.Message) error {
@@ -93,21 +130,23 @@ func (p *messagePersister) Add(_ context.Context, message *persistence.Message)
 	}
 
 	message.CreatedAt = time.Now().Unix()
-	// use the QueryRow instead of ExecX, because LastInsertId is not supported by this driver
-	err := p.db.QueryRow(insertMessageSQL, message.CreatedAt, message.Topic, message.Sequence, message.MessageType,
-		message.Sender, message.Receiver, message.ContentType, message.Body, types.MessageStatusNormal,
-		x.Join(message.Mentions, ",")).
-		Scan(&message.ID)
-	if err != nil {
+	id, err := p.db.InsertID(insertMessageSQL, message.CreatedAt, message.ClientID, message.Topic, message.Sequence, message.MessageType,
+		message.Sender, message.Receiver, message.ContentType, string(message.Body), types.MessageStatusNormal,
+		x.Join(message.Mentions, ","))
+	if sqlx.IsErrUniqueViolation(err) {
+		// The sequence was added concurrently, see the message_topic_sequence_idx index
+		return ecode.ErrDataAlreadyExists
+	} else if err != nil {
 		return err
 	}
+	message.ID = id
 
 	return nil
 }
 
-func (p *messagePersister) GetTopicLastSequence(_ context.Context, topic string) (int64, error) {
+func (p *messagePersister) GetTopicLastSequence(_ context.Context, clientID, topic string) (int64, error) {
 	var sequence int64
-	if err := p.db.QueryRow("SELECT sequence FROM message WHERE topic = $1 ORDER BY sequence DESC LIMIT 1;", topic).
+	if err := p.db.QueryRow("SELECT sequence FROM message WHERE client_id = $1 AND topic = $2 ORDER BY sequence DESC LIMIT 1;", clientID, topic).
 		Scan(&sequence); sqlx.IsErrNoRows(err) {
 		return 0, nil
 	} else if err != nil {
@@ -117,14 +156,14 @@ func (p *messagePersister) GetTopicLastSequence(_ context.Context, topic string)
 	return sequence, nil
 }
 
-func (p *messagePersister) GetTopicMessageBySequence(_ context.Context, topic string, sequence int64) (*persistence.Message, error) {
+func (p *messagePersister) GetTopicMessageBySequence(_ context.Context, clientID, topic string, sequence int64) (*persistence.Message, error) {
 	var (
 		message                  persistence.Message
 		messageType, contentType uint8
 		body, mentions           string
 	)
-	if err := p.db.QueryRow(getMessagesBySequenceSQL, topic, sequence).Scan(&message.ID, &message.CreatedAt,
-		&message.Topic, &message.Sequence, &messageType, &message.Sender, &message.Receiver,
+	if err := p.db.QueryRow(getMessagesBySequenceSQL, clientID, topic, sequence).Scan(&message.ID, &message.CreatedAt,
+		&message.ClientID, &message.Topic, &message.Sequence, &messageType, &message.Sender, &message.Receiver,
 		&contentType, &body, &message.Status, &mentions); err != nil {
 		if sqlx.IsErrNoRows(err) {
 			return nil, ecode.ErrDataDoesNotExist
@@ -142,18 +181,80 @@ func (p *messagePersister) GetTopicMessageBySequence(_ context.Context, topic st
 	return &message, nil
 }
 
-func (p *messagePersister) GetTopicMessagesByLastSequence(_ context.Context, topic string, sequence int64) ([]*persistence.Message, int64, error) {
+func (p *messagePersister) GetTopicMessagesByLastSequence(_ context.Context, clientID, topic string, sequence int64) ([]*persistence.Message, int64, error) {
 	var count int64
-	if err := p.db.QueryRow("SELECT count(*) FROM message WHERE topic = $1 AND sequence > $2", topic, sequence).
+	if err := p.db.QueryRow("SELECT count(*) FROM message WHERE client_id = $1 AND topic = $2 AND sequence > $3", clientID, topic, sequence).
 		Scan(&count); err != nil {
 		return nil, 0, err
 	}
 
-	rows, err := p.db.Query(getMessagesByLastSequenceSQL, topic, sequence)
+	rows, err := p.db.Query(getMessagesByLastSequenceSQL, clientID, topic, sequence)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	messages, err := scanMessages(rows)
 	if err != nil {
 		return nil, 0, err
 	}
 
+	return messages, count, nil
+}
+
+func (p *messagePersister) GetExpiredMessages(_ context.Context, clientID string, before, maxCount int64, limit int) ([]*persistence.Message, error) {
+	var conditions []string
+	args := []interface{}{clientID}
+	if before > 0 {
+		args = append(args, before)
+		conditions = append(conditions, x.Sprintf(expiredByAgeSQL, len(args)))
+	}
+	if maxCount > 0 {
+		args = append(args, maxCount)
+		conditions = append(conditions, x.Sprintf(expiredByCountSQL, len(args)))
+	}
+	if len(conditions) == 0 || limit <= 0 {
+		return nil, nil
+	}
+
+	rows, err := p.db.Query(x.Sprintf(getExpiredMessagesSQL, strings.Join(conditions, " OR "), limit), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMessages(rows)
+}
+
+func (p *messagePersister) Update(_ context.Context, message *persistence.Message) error {
+	var isExist int
+	if err := p.db.QueryRow("SELECT 1 FROM message WHERE client_id = $1 AND id = $2 LIMIT 1;", message.ClientID, message.ID).
+		Scan(&isExist); sqlx.IsErrNoRows(err) {
+		return ecode.ErrDataDoesNotExist
+	} else if err != nil {
+		return err
+	}
+
+	// MySQL does not count the row as affected if it is unchanged
+	return p.db.Exec("UPDATE message SET updated_at = $1, content_type = $2, body = $3, status = $4 WHERE client_id = $5 AND id = $6;", 0,
+		time.Now().Unix(), message.ContentType, string(message.Body), message.Status, message.ClientID, message.ID)
+}
+
+func (p *messagePersister) Delete(_ context.Context, clientID string, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := []interface{}{clientID}
+	for i, id := range ids {
+		placeholders[i] = x.Sprintf("$%d", i+2)
+		args = append(args, id)
+	}
+	return p.db.Exec(x.Sprintf("DELETE FROM message WHERE client_id = $1 AND id IN (%s);", strings.Join(placeholders, ", ")), 0, args...)
+}
+
+func scanMessages(rows *sqlx.Rows) ([]*persistence.Message, error) {
 	var messages []*persistence.Message
 	for rows.Next() {
 		var (
@@ -161,9 +262,9 @@ func (p *messagePersister) GetTopicMessagesByLastSequence(_ context.Context, top
 			messageType, contentType uint8
 			body, mentions           string
 		)
-		if err := rows.Scan(&message.ID, &message.CreatedAt, &message.Topic, &message.Sequence, &messageType,
-			&message.Sender, &message.Receiver, &contentType, &body, &message.Status, &mentions); err != nil {
-			return nil, 0, err
+		if err := rows.Scan(&message.ID, &message.CreatedAt, &message.ClientID, &message.Topic, &message.Sequence,
+			&messageType, &message.Sender, &message.Receiver, &contentType, &body, &message.Status, &mentions); err != nil {
+			return nil, err
 		}
 
 		message.MessageType = types.MessageType(messageType)
@@ -176,5 +277,5 @@ func (p *messagePersister) GetTopicMessagesByLastSequence(_ context.Context, top
 		messages = append(messages, &message)
 	}
 
-	return messages, count, nil
+	return messages, rows.Err()
 }
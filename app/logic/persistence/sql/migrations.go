@@ -0,0 +1,468 @@
+package sql
+
+import (
+	"mercury/x/database/migrate"
+	"mercury/x/database/sqlx"
+)
+
+// Migrations of the schema the persisters rely on, by dialect. The PostgreSQL tables are
+// created only if they do not exist, so that databases created before the migrations
+// can be adopted.
+var Migrations = map[string][]migrate.Migration{
+	sqlx.DialectPostgres: postgresMigrations,
+	sqlx.DialectMySQL:    mysqlMigrations,
+	sqlx.DialectSQLite:   sqliteMigrations,
+}
+
+var postgresMigrations = []migrate.Migration{
+	{
+		Version: 1,
+		Name:    "create_tables",
+		Up: `
+CREATE TABLE IF NOT EXISTS client (
+    id UUID NOT NULL PRIMARY KEY,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    name VARCHAR NOT NULL,
+    token_secret VARCHAR NOT NULL,
+    token_expire BIGINT NOT NULL DEFAULT 0,
+    credential VARCHAR NOT NULL DEFAULT '',
+    user_count BIGINT NOT NULL DEFAULT 0,
+    group_count BIGINT NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX IF NOT EXISTS client_name_idx ON client (name);
+
+CREATE TABLE IF NOT EXISTS public.user (
+    id BIGINT NOT NULL PRIMARY KEY,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    client_id VARCHAR NOT NULL,
+    name VARCHAR NOT NULL,
+    uid VARCHAR NOT NULL,
+    activated BOOLEAN NOT NULL DEFAULT true
+);
+CREATE UNIQUE INDEX IF NOT EXISTS user_client_id_uid_idx ON public.user (client_id, uid);
+CREATE UNIQUE INDEX IF NOT EXISTS user_client_id_name_idx ON public.user (client_id, name);
+
+CREATE TABLE IF NOT EXISTS friend (
+    id BIGSERIAL NOT NULL PRIMARY KEY,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    friend_user_id BIGINT NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS friend_user_id_friend_user_id_idx ON friend (user_id, friend_user_id);
+
+CREATE TABLE IF NOT EXISTS public.group (
+    id BIGINT NOT NULL PRIMARY KEY,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    client_id VARCHAR NOT NULL,
+    name VARCHAR NOT NULL,
+    gid VARCHAR NOT NULL,
+    introduction VARCHAR NOT NULL DEFAULT '',
+    owner BIGINT NOT NULL,
+    type SMALLINT NOT NULL,
+    activated BOOLEAN NOT NULL DEFAULT true,
+    member_count BIGINT NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX IF NOT EXISTS group_gid_idx ON public.group (gid);
+
+CREATE TABLE IF NOT EXISTS group_member (
+    id BIGSERIAL NOT NULL PRIMARY KEY,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    group_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS group_member_group_id_user_id_idx ON group_member (group_id, user_id);
+CREATE INDEX IF NOT EXISTS group_member_user_id_idx ON group_member (user_id);
+
+CREATE TABLE IF NOT EXISTS message (
+    id BIGSERIAL NOT NULL PRIMARY KEY,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    topic VARCHAR NOT NULL,
+    sequence BIGINT NOT NULL,
+    message_type SMALLINT NOT NULL,
+    sender BIGINT NOT NULL,
+    receiver BIGINT NOT NULL,
+    content_type SMALLINT NOT NULL,
+    body JSONB,
+    status SMALLINT NOT NULL DEFAULT 0,
+    mentions VARCHAR NOT NULL DEFAULT ''
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS message;
+DROP TABLE IF EXISTS group_member;
+DROP TABLE IF EXISTS public.group;
+DROP TABLE IF EXISTS friend;
+DROP TABLE IF EXISTS public.user;
+DROP TABLE IF EXISTS client;
+`,
+	},
+	{
+		Version: 2,
+		Name:    "message_topic_sequence_index",
+		// messagePersister.Add relies on the index to reject a sequence allocated twice
+		Up:   `CREATE UNIQUE INDEX IF NOT EXISTS message_topic_sequence_idx ON message (topic, sequence);`,
+		Down: `DROP INDEX IF EXISTS message_topic_sequence_idx;`,
+	},
+	{
+		Version: 3,
+		Name:    "message_retention",
+		// The messages added before have no client, they are never purged
+		Up: `
+ALTER TABLE client ADD COLUMN IF NOT EXISTS message_max_age BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE client ADD COLUMN IF NOT EXISTS message_max_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE message ADD COLUMN IF NOT EXISTS client_id VARCHAR NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS message_client_id_created_at_idx ON message (client_id, created_at);
+`,
+		Down: `
+DROP INDEX IF EXISTS message_client_id_created_at_idx;
+ALTER TABLE message DROP COLUMN IF EXISTS client_id;
+ALTER TABLE client DROP COLUMN IF EXISTS message_max_count;
+ALTER TABLE client DROP COLUMN IF EXISTS message_max_age;
+`,
+	},
+	{
+		Version: 4,
+		Name:    "message_search",
+		// The full-text index of PostgresIndexer, the other dialects use the embedded one
+		Up: `
+CREATE TABLE IF NOT EXISTS message_search (
+    message_id BIGINT NOT NULL PRIMARY KEY,
+    created_at BIGINT NOT NULL,
+    client_id VARCHAR NOT NULL,
+    topic VARCHAR NOT NULL,
+    sequence BIGINT NOT NULL,
+    sender BIGINT NOT NULL,
+    content_type SMALLINT NOT NULL,
+    document TSVECTOR NOT NULL
+);
+CREATE INDEX IF NOT EXISTS message_search_document_idx ON message_search USING GIN (document);
+CREATE INDEX IF NOT EXISTS message_search_client_id_topic_idx ON message_search (client_id, topic);
+`,
+		Down: `DROP TABLE IF EXISTS message_search;`,
+	},
+	{
+		Version: 5,
+		Name:    "user_topic",
+		// The topics of the users and the last sequences they read, the cache is rebuilt from it
+		Up: `
+CREATE TABLE IF NOT EXISTS user_topic (
+    user_id BIGINT NOT NULL,
+    topic VARCHAR NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    last_sequence BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, topic)
+);
+`,
+		Down: `DROP TABLE IF EXISTS user_topic;`,
+	},
+	{
+		Version: 6,
+		Name:    "client_scope",
+		// The messages added before version 3 and the topics of the users get the client of
+		// their user, the queries are scoped by it
+		Up: `
+ALTER TABLE user_topic ADD COLUMN IF NOT EXISTS client_id VARCHAR NOT NULL DEFAULT '';
+UPDATE user_topic SET client_id = u.client_id FROM "user" u WHERE u.id = user_topic.user_id AND user_topic.client_id = '';
+UPDATE message SET client_id = u.client_id FROM "user" u WHERE u.id = message.sender AND message.client_id = '';
+`,
+		Down: `ALTER TABLE user_topic DROP COLUMN IF EXISTS client_id;`,
+	},
+}
+
+// The MySQL driver does not execute several statements at once, so the statements of its
+// migrations are executed one by one, split at the semicolons ending a line. A statement
+// holding one, e.g. the body of a procedure or a trigger, can not be added here, see
+// migrate.SplitStatements.
+var mysqlMigrations = []migrate.Migration{
+	{
+		Version: 1,
+		Name:    "create_tables",
+		Up: `
+CREATE TABLE IF NOT EXISTS client (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    token_secret VARCHAR(255) NOT NULL,
+    token_expire BIGINT NOT NULL DEFAULT 0,
+    credential VARCHAR(255) NOT NULL DEFAULT '',
+    user_count BIGINT NOT NULL DEFAULT 0,
+    group_count BIGINT NOT NULL DEFAULT 0,
+    UNIQUE KEY client_name_idx (name)
+);
+
+CREATE TABLE IF NOT EXISTS ` + "`user`" + ` (
+    id BIGINT NOT NULL PRIMARY KEY,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    uid VARCHAR(255) NOT NULL,
+    activated BOOLEAN NOT NULL DEFAULT true,
+    UNIQUE KEY user_client_id_uid_idx (client_id, uid),
+    UNIQUE KEY user_client_id_name_idx (client_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS friend (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    friend_user_id BIGINT NOT NULL,
+    UNIQUE KEY friend_user_id_friend_user_id_idx (user_id, friend_user_id)
+);
+
+CREATE TABLE IF NOT EXISTS ` + "`group`" + ` (
+    id BIGINT NOT NULL PRIMARY KEY,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    gid VARCHAR(255) NOT NULL,
+    introduction VARCHAR(1024) NOT NULL DEFAULT '',
+    owner BIGINT NOT NULL,
+    type SMALLINT NOT NULL,
+    activated BOOLEAN NOT NULL DEFAULT true,
+    member_count BIGINT NOT NULL DEFAULT 0,
+    UNIQUE KEY group_gid_idx (gid)
+);
+
+CREATE TABLE IF NOT EXISTS group_member (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    group_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    UNIQUE KEY group_member_group_id_user_id_idx (group_id, user_id),
+    KEY group_member_user_id_idx (user_id)
+);
+
+CREATE TABLE IF NOT EXISTS message (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    topic VARCHAR(255) NOT NULL,
+    sequence BIGINT NOT NULL,
+    message_type SMALLINT NOT NULL,
+    sender BIGINT NOT NULL,
+    receiver BIGINT NOT NULL,
+    content_type SMALLINT NOT NULL,
+    body LONGTEXT,
+    status SMALLINT NOT NULL DEFAULT 0,
+    mentions VARCHAR(1024) NOT NULL DEFAULT ''
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS message;
+DROP TABLE IF EXISTS group_member;
+DROP TABLE IF EXISTS ` + "`group`" + `;
+DROP TABLE IF EXISTS friend;
+DROP TABLE IF EXISTS ` + "`user`" + `;
+DROP TABLE IF EXISTS client;
+`,
+	},
+	{
+		Version: 2,
+		Name:    "message_topic_sequence_index",
+		Up:      `CREATE UNIQUE INDEX message_topic_sequence_idx ON message (topic, sequence);`,
+		Down:    `DROP INDEX message_topic_sequence_idx ON message;`,
+	},
+	{
+		Version: 3,
+		Name:    "message_retention",
+		Up: `
+ALTER TABLE client ADD COLUMN message_max_age BIGINT NOT NULL DEFAULT 0, ADD COLUMN message_max_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE message ADD COLUMN client_id VARCHAR(255) NOT NULL DEFAULT '';
+CREATE INDEX message_client_id_created_at_idx ON message (client_id, created_at);
+`,
+		Down: `
+DROP INDEX message_client_id_created_at_idx ON message;
+ALTER TABLE message DROP COLUMN client_id;
+ALTER TABLE client DROP COLUMN message_max_age, DROP COLUMN message_max_count;
+`,
+	},
+	{
+		// Version 4 is the full-text index of postgres
+		Version: 5,
+		Name:    "user_topic",
+		Up: `
+CREATE TABLE IF NOT EXISTS user_topic (
+    user_id BIGINT NOT NULL,
+    topic VARCHAR(255) NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    last_sequence BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, topic)
+);
+`,
+		Down: `DROP TABLE IF EXISTS user_topic;`,
+	},
+	{
+		Version: 6,
+		Name:    "client_scope",
+		Up: `
+ALTER TABLE user_topic ADD COLUMN client_id VARCHAR(255) NOT NULL DEFAULT '';
+UPDATE user_topic t JOIN ` + "`user`" + ` u ON u.id = t.user_id SET t.client_id = u.client_id WHERE t.client_id = '';
+UPDATE message m JOIN ` + "`user`" + ` u ON u.id = m.sender SET m.client_id = u.client_id WHERE m.client_id = '';
+`,
+		Down: `ALTER TABLE user_topic DROP COLUMN client_id;`,
+	},
+}
+
+var sqliteMigrations = []migrate.Migration{
+	{
+		Version: 1,
+		Name:    "create_tables",
+		Up: `
+CREATE TABLE IF NOT EXISTS client (
+    id TEXT NOT NULL PRIMARY KEY,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    token_secret TEXT NOT NULL,
+    token_expire INTEGER NOT NULL DEFAULT 0,
+    credential TEXT NOT NULL DEFAULT '',
+    user_count INTEGER NOT NULL DEFAULT 0,
+    group_count INTEGER NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX IF NOT EXISTS client_name_idx ON client (name);
+
+CREATE TABLE IF NOT EXISTS "user" (
+    id INTEGER NOT NULL PRIMARY KEY,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    client_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    uid TEXT NOT NULL,
+    activated BOOLEAN NOT NULL DEFAULT true
+);
+CREATE UNIQUE INDEX IF NOT EXISTS user_client_id_uid_idx ON "user" (client_id, uid);
+CREATE UNIQUE INDEX IF NOT EXISTS user_client_id_name_idx ON "user" (client_id, name);
+
+CREATE TABLE IF NOT EXISTS friend (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    friend_user_id INTEGER NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS friend_user_id_friend_user_id_idx ON friend (user_id, friend_user_id);
+
+CREATE TABLE IF NOT EXISTS "group" (
+    id INTEGER NOT NULL PRIMARY KEY,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    client_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    gid TEXT NOT NULL,
+    introduction TEXT NOT NULL DEFAULT '',
+    owner INTEGER NOT NULL,
+    type INTEGER NOT NULL,
+    activated BOOLEAN NOT NULL DEFAULT true,
+    member_count INTEGER NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX IF NOT EXISTS group_gid_idx ON "group" (gid);
+
+CREATE TABLE IF NOT EXISTS group_member (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    group_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS group_member_group_id_user_id_idx ON group_member (group_id, user_id);
+CREATE INDEX IF NOT EXISTS group_member_user_id_idx ON group_member (user_id);
+
+CREATE TABLE IF NOT EXISTS message (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    topic TEXT NOT NULL,
+    sequence INTEGER NOT NULL,
+    message_type INTEGER NOT NULL,
+    sender INTEGER NOT NULL,
+    receiver INTEGER NOT NULL,
+    content_type INTEGER NOT NULL,
+    body TEXT,
+    status INTEGER NOT NULL DEFAULT 0,
+    mentions TEXT NOT NULL DEFAULT ''
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS message;
+DROP TABLE IF EXISTS group_member;
+DROP TABLE IF EXISTS "group";
+DROP TABLE IF EXISTS friend;
+DROP TABLE IF EXISTS "user";
+DROP TABLE IF EXISTS client;
+`,
+	},
+	{
+		Version: 2,
+		Name:    "message_topic_sequence_index",
+		Up:      `CREATE UNIQUE INDEX IF NOT EXISTS message_topic_sequence_idx ON message (topic, sequence);`,
+		Down:    `DROP INDEX IF EXISTS message_topic_sequence_idx;`,
+	},
+	{
+		Version: 3,
+		Name:    "message_retention",
+		Up: `
+ALTER TABLE client ADD COLUMN message_max_age INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE client ADD COLUMN message_max_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE message ADD COLUMN client_id TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS message_client_id_created_at_idx ON message (client_id, created_at);
+`,
+		Down: `
+DROP INDEX IF EXISTS message_client_id_created_at_idx;
+ALTER TABLE message DROP COLUMN client_id;
+ALTER TABLE client DROP COLUMN message_max_count;
+ALTER TABLE client DROP COLUMN message_max_age;
+`,
+	},
+	{
+		// Version 4 is the full-text index of postgres
+		Version: 5,
+		Name:    "user_topic",
+		Up: `
+CREATE TABLE IF NOT EXISTS user_topic (
+    user_id INTEGER NOT NULL,
+    topic TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    last_sequence INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, topic)
+);
+`,
+		Down: `DROP TABLE IF EXISTS user_topic;`,
+	},
+	{
+		Version: 6,
+		Name:    "client_scope",
+		Up: `
+ALTER TABLE user_topic ADD COLUMN client_id TEXT NOT NULL DEFAULT '';
+UPDATE user_topic SET client_id = COALESCE((SELECT u.client_id FROM "user" u WHERE u.id = user_topic.user_id), '') WHERE client_id = '';
+UPDATE message SET client_id = COALESCE((SELECT u.client_id FROM "user" u WHERE u.id = message.sender), '') WHERE client_id = '';
+`,
+		Down: `ALTER TABLE user_topic DROP COLUMN client_id;`,
+	},
+}
+
+// NewMigrator returns the migrator of the schema of db. The servers migrating a
+// PostgreSQL or MySQL database run the migrations one at a time, SQLite locks the file.
+func NewMigrator(db *sqlx.DB) (*migrate.Migrator, error) {
+	opts := []migrate.Option{migrate.Placeholder(db.Dialect().Placeholder)}
+	switch db.Dialect().Name() {
+	case sqlx.DialectPostgres:
+		opts = append(opts, migrate.Lock(migrate.PostgresLocker{}))
+	case sqlx.DialectMySQL:
+		opts = append(opts, migrate.Lock(migrate.MySQLLocker{}), migrate.SplitStatements())
+	}
+	return migrate.New(db.DB, Migrations[db.Dialect().Name()], opts...)
+}
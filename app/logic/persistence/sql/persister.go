@@ -11,6 +11,7 @@ type Persister struct {
 	user    *userPersister
 	message *messagePersister
 	group   *groupPersister
+	topic   *topicPersister
 }
 
 func NewPersister(db *sqlx.DB) *Persister {
@@ -28,6 +29,9 @@ func NewPersister(db *sqlx.DB) *Persister {
 		group: &groupPersister{
 			db: db,
 		},
+		topic: &topicPersister{
+			db: db,
+		},
 	}
 }
 
@@ -54,3 +58,7 @@ func (p *Persister) Message() persistence.MessagePersister {
 func (p *Persister) Group() persistence.GroupPersister {
 	return p.group
 }
+
+func (p *Persister) Topic() persistence.TopicPersister {
+	return p.topic
+}
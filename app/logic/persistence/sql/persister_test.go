@@ -0,0 +1,139 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mercury/app/logic/persistence"
+	"mercury/app/logic/persistence/persistencetest"
+	"mercury/config"
+	"mercury/x/database/sqlx"
+)
+
+// The PostgreSQL and MySQL backends are tested against the databases of these variables,
+// the tables of the databases are dropped.
+const (
+	postgresDSNEnv = "MERCURY_TEST_POSTGRES_DSN"
+	mysqlDSNEnv    = "MERCURY_TEST_MYSQL_DSN"
+)
+
+func TestSQLitePersister(t *testing.T) {
+	persistencetest.Run(t, func(t *testing.T) persistence.Persister {
+		return newTestPersister(t, sqlx.DialectSQLite, filepath.Join(t.TempDir(), "mercury.db"))
+	})
+}
+
+func TestPostgresPersister(t *testing.T) {
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skip(postgresDSNEnv + " is not set")
+	}
+	persistencetest.Run(t, func(t *testing.T) persistence.Persister {
+		return newTestPersister(t, sqlx.DialectPostgres, dsn)
+	})
+}
+
+func TestPostgresIndexer(t *testing.T) {
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skip(postgresDSNEnv + " is not set")
+	}
+	persistencetest.RunIndexer(t, func(t *testing.T) persistence.Indexer {
+		p := newTestPersister(t, sqlx.DialectPostgres, dsn).(*Persister)
+		x, err := NewPostgresIndexer(p.db)
+		require.NoError(t, err)
+		return x
+	})
+}
+
+func TestMySQLPersister(t *testing.T) {
+	dsn := os.Getenv(mysqlDSNEnv)
+	if dsn == "" {
+		t.Skip(mysqlDSNEnv + " is not set")
+	}
+	persistencetest.Run(t, func(t *testing.T) persistence.Persister {
+		return newTestPersister(t, sqlx.DialectMySQL, dsn)
+	})
+}
+
+func TestPostgresMigrateConcurrently(t *testing.T) {
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skip(postgresDSNEnv + " is not set")
+	}
+	testMigrateConcurrently(t, sqlx.DialectPostgres, dsn)
+}
+
+func TestMySQLMigrateConcurrently(t *testing.T) {
+	dsn := os.Getenv(mysqlDSNEnv)
+	if dsn == "" {
+		t.Skip(mysqlDSNEnv + " is not set")
+	}
+	testMigrateConcurrently(t, sqlx.DialectMySQL, dsn)
+}
+
+// testMigrateConcurrently migrates an empty schema from several servers at once, every
+// migration is applied by one of them.
+func testMigrateConcurrently(t *testing.T, driver, dsn string) {
+	cfg := config.DefaultConfig()
+	cfg.Database = &config.Database{Driver: driver, DSN: dsn, Active: 4, Idle: 4}
+	db, err := sqlx.Open(config.NewProviderConfig(cfg))
+	require.NoError(t, err)
+	defer db.Close()
+	m, err := NewMigrator(db)
+	require.NoError(t, err)
+	_, err = m.Down(context.Background(), len(Migrations[driver]))
+	require.NoError(t, err)
+
+	var (
+		wg      sync.WaitGroup
+		mux     sync.Mutex
+		applied int
+	)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			db, err := sqlx.Open(config.NewProviderConfig(cfg))
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer db.Close()
+			m, err := NewMigrator(db)
+			if !assert.NoError(t, err) {
+				return
+			}
+			done, err := m.Up(context.Background())
+			assert.NoError(t, err)
+			mux.Lock()
+			applied += len(done)
+			mux.Unlock()
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, len(Migrations[driver]), applied)
+}
+
+// newTestPersister migrates an empty schema of the database.
+func newTestPersister(t *testing.T, driver, dsn string) persistence.Persister {
+	cfg := config.DefaultConfig()
+	cfg.Database = &config.Database{Driver: driver, DSN: dsn, Active: 4, Idle: 4}
+	db, err := sqlx.Open(config.NewProviderConfig(cfg))
+	require.NoError(t, err)
+
+	m, err := NewMigrator(db)
+	require.NoError(t, err)
+	_, err = m.Down(context.Background(), len(Migrations[driver]))
+	require.NoError(t, err)
+	applied, err := m.Up(context.Background())
+	require.NoError(t, err)
+	require.Len(t, applied, len(Migrations[driver]), fmt.Sprintf("migrations of %s", driver))
+
+	return NewPersister(db)
+}
@@ -0,0 +1,160 @@
+package sql
+
+import (
+	"context"
+	"mercury/app/logic/persistence"
+	"mercury/x"
+	"mercury/x/database/sqlx"
+	"mercury/x/ecode"
+	"mercury/x/types"
+	"strings"
+)
+
+const (
+	indexMessageSQL = `
+INSERT INTO
+    message_search (
+		message_id,
+		created_at,
+		client_id,
+		topic,
+		sequence,
+		sender,
+		content_type,
+		document
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, to_tsvector('simple', $8))
+ON CONFLICT (message_id) DO UPDATE SET
+	created_at = excluded.created_at,
+	client_id = excluded.client_id,
+	topic = excluded.topic,
+	sequence = excluded.sequence,
+	sender = excluded.sender,
+	content_type = excluded.content_type,
+	document = excluded.document;
+`
+
+	// Takes the conditions of the query and the limit
+	searchMessagesSQL = `
+SELECT
+	message_id,
+	topic,
+	sequence
+FROM
+    message_search
+WHERE
+    client_id = $1
+AND
+	document @@ plainto_tsquery('simple', $2)
+AND
+	%s
+ORDER BY
+    created_at DESC, message_id DESC
+LIMIT %s;
+`
+)
+
+// PostgresIndexer implements persistence.Indexer with the full-text search of PostgreSQL
+// on the message_search table. The text is split with types.Tokenize, so that the words
+// of the languages written without spaces are found as well.
+type PostgresIndexer struct {
+	db *sqlx.DB
+}
+
+// NewPostgresIndexer returns the indexer of db, which it closes when it is closed.
+func NewPostgresIndexer(db *sqlx.DB) (*PostgresIndexer, error) {
+	if name := db.Dialect().Name(); name != sqlx.DialectPostgres {
+		return nil, ecode.NewError(x.Sprintf("full-text search is not supported by %s", name))
+	}
+	return &PostgresIndexer{db: db}, nil
+}
+
+func (p *PostgresIndexer) Index(_ context.Context, messages ...*persistence.Message) error {
+	for _, m := range messages {
+		document := strings.Join(types.Tokenize(types.Text(m.ContentType, m.Body)), " ")
+		if err := p.db.Exec(indexMessageSQL, 0, m.ID, m.CreatedAt, m.ClientID, m.Topic, m.Sequence, m.Sender,
+			m.ContentType, document); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PostgresIndexer) Remove(_ context.Context, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = x.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+	return p.db.Exec(x.Sprintf("DELETE FROM message_search WHERE message_id IN (%s);", strings.Join(placeholders, ", ")), 0, args...)
+}
+
+func (p *PostgresIndexer) Search(_ context.Context, q *persistence.MessageQuery) ([]*persistence.MessageHit, error) {
+	text := strings.Join(types.Tokenize(q.Text), " ")
+	if text == "" || len(q.Topics) == 0 {
+		return nil, nil
+	}
+
+	args := []interface{}{q.ClientID, text}
+	in := func(values ...interface{}) string {
+		placeholders := make([]string, len(values))
+		for i, v := range values {
+			args = append(args, v)
+			placeholders[i] = x.Sprintf("$%d", len(args))
+		}
+		return strings.Join(placeholders, ", ")
+	}
+
+	topics := make([]interface{}, len(q.Topics))
+	for i, topic := range q.Topics {
+		topics[i] = topic
+	}
+	conditions := []string{x.Sprintf("topic IN (%s)", in(topics...))}
+	if q.Sender != 0 {
+		conditions = append(conditions, x.Sprintf("sender = %s", in(q.Sender)))
+	}
+	if q.StartAt > 0 {
+		conditions = append(conditions, x.Sprintf("created_at >= %s", in(q.StartAt)))
+	}
+	if q.EndAt > 0 {
+		conditions = append(conditions, x.Sprintf("created_at <= %s", in(q.EndAt)))
+	}
+	if len(q.ContentTypes) > 0 {
+		contentTypes := make([]interface{}, len(q.ContentTypes))
+		for i, t := range q.ContentTypes {
+			contentTypes[i] = t
+		}
+		conditions = append(conditions, x.Sprintf("content_type IN (%s)", in(contentTypes...)))
+	}
+	limit := "ALL"
+	if q.Limit > 0 {
+		limit = x.Sprintf("%d", q.Limit)
+	}
+
+	rows, err := p.db.Query(x.Sprintf(searchMessagesSQL, strings.Join(conditions, " AND "), limit), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []*persistence.MessageHit
+	for rows.Next() {
+		var hit persistence.MessageHit
+		if err := rows.Scan(&hit.ID, &hit.Topic, &hit.Sequence); err != nil {
+			return nil, err
+		}
+		hits = append(hits, &hit)
+	}
+
+	return hits, rows.Err()
+}
+
+func (p *PostgresIndexer) Close() error {
+	return p.db.Close()
+}
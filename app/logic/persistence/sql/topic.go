@@ -0,0 +1,159 @@
+package sql
+
+import (
+	"context"
+	"mercury/app/logic/persistence"
+	"mercury/x"
+	"mercury/x/database/sqlx"
+	"strings"
+	"time"
+)
+
+type topicPersister struct {
+	db *sqlx.DB
+}
+
+// The count of topics saved by a statement, it keeps the placeholders of a statement
+// below the limit of SQLite
+const saveTopicsBatchSize = 100
+
+const (
+	insertUserTopicSQL = `
+INSERT INTO
+    user_topic (
+        created_at,
+        updated_at,
+        client_id,
+        user_id,
+        topic,
+        last_sequence
+    )
+VALUES
+    %s
+`
+
+	// The upserts keeping the greatest last sequence by dialect
+	upsertUserTopicPostgresSQL = `ON CONFLICT (user_id, topic) DO UPDATE SET updated_at = EXCLUDED.updated_at, last_sequence = GREATEST(user_topic.last_sequence, EXCLUDED.last_sequence);`
+	upsertUserTopicSQLiteSQL   = `ON CONFLICT (user_id, topic) DO UPDATE SET updated_at = excluded.updated_at, last_sequence = MAX(user_topic.last_sequence, excluded.last_sequence);`
+	upsertUserTopicMySQLSQL    = `ON DUPLICATE KEY UPDATE updated_at = VALUES(updated_at), last_sequence = GREATEST(last_sequence, VALUES(last_sequence));`
+
+	getUserTopicsSQL = `
+SELECT
+    client_id,
+    user_id,
+    topic,
+    last_sequence
+FROM
+    user_topic
+WHERE
+    client_id = $1
+AND
+    user_id = $2
+ORDER BY
+    topic;
+`
+
+	getTopicUsersSQL = `
+SELECT DISTINCT
+    user_id,
+    client_id
+FROM
+    user_topic
+WHERE
+    user_id > $1
+ORDER BY
+    user_id
+LIMIT
+    $2;
+`
+)
+
+func (p *topicPersister) upsertSQL() string {
+	switch p.db.Dialect().Name() {
+	case sqlx.DialectMySQL:
+		return upsertUserTopicMySQLSQL
+	case sqlx.DialectSQLite:
+		return upsertUserTopicSQLiteSQL
+	default:
+		return upsertUserTopicPostgresSQL
+	}
+}
+
+func (p *topicPersister) Save(_ context.Context, topics ...*persistence.UserTopic) error {
+	// A statement must not update a row twice, the topics of a user are merged first
+	type key struct {
+		userID int64
+		topic  string
+	}
+	merged := make(map[key]int, len(topics))
+	var rows []*persistence.UserTopic
+	for _, topic := range topics {
+		k := key{topic.UserID, topic.Topic}
+		if i, ok := merged[k]; ok {
+			if topic.LastSequence > rows[i].LastSequence {
+				rows[i] = topic
+			}
+			continue
+		}
+		merged[k] = len(rows)
+		rows = append(rows, topic)
+	}
+
+	now := time.Now().Unix()
+	for len(rows) > 0 {
+		batch := rows
+		if len(batch) > saveTopicsBatchSize {
+			batch = batch[:saveTopicsBatchSize]
+		}
+		rows = rows[len(batch):]
+
+		values := make([]string, len(batch))
+		args := []interface{}{now}
+		for i, topic := range batch {
+			n := len(args)
+			values[i] = x.Sprintf("($1, $1, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4)
+			args = append(args, topic.ClientID, topic.UserID, topic.Topic, topic.LastSequence)
+		}
+		query := x.Sprintf(insertUserTopicSQL, strings.Join(values, ", ")) + p.upsertSQL()
+		if err := p.db.Exec(query, 0, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *topicPersister) GetUserTopics(_ context.Context, clientID string, userID int64) ([]*persistence.UserTopic, error) {
+	rows, err := p.db.Query(getUserTopicsSQL, clientID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var topics []*persistence.UserTopic
+	for rows.Next() {
+		var topic persistence.UserTopic
+		if err := rows.Scan(&topic.ClientID, &topic.UserID, &topic.Topic, &topic.LastSequence); err != nil {
+			return nil, err
+		}
+		topics = append(topics, &topic)
+	}
+	return topics, rows.Err()
+}
+
+func (p *topicPersister) GetUsers(_ context.Context, after int64, limit int) ([]*persistence.TopicUser, error) {
+	rows, err := p.db.Query(getTopicUsersSQL, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*persistence.TopicUser
+	for rows.Next() {
+		var user persistence.TopicUser
+		if err := rows.Scan(&user.UserID, &user.ClientID); err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+	return users, rows.Err()
+}
@@ -15,7 +15,7 @@ type userPersister struct {
 const (
 	insertUserSQL = `
 INSERT INTO
-    public.user (
+    "user" (
 		id,
         created_at,
         updated_at,
@@ -54,15 +54,34 @@ VALUES
     ($1, $1, $3, $2);
 `
 
+	isClientUserExistSQL = `
+SELECT
+    1
+FROM
+    "user"
+WHERE
+    client_id = $1
+AND
+	id = $2
+limit
+    1;
+`
+
 	getFriendsSQL = `
 SELECT
-	friend_user_id
+	f.friend_user_id
 FROM
-	friend
+	friend f
+JOIN
+	"user" u
+ON
+	u.id = f.user_id
 WHERE
-	user_id = $1
+	u.client_id = $1
+AND
+	f.user_id = $2
 ORDER BY
-	created_at DESC;
+	f.created_at DESC;
 `
 
 	deleteFriendSQL = `
@@ -77,7 +96,7 @@ AND
 
 func (p *userPersister) CheckActivated(_ context.Context, clientID, uid string) (bool, error) {
 	var isExist int
-	err := p.db.QueryRow("SELECT 1 FROM public.user WHERE client_id = $1 AND uid = $2 AND activated = $3 limit 1;", clientID, uid, true).
+	err := p.db.QueryRow(`SELECT 1 FROM "user" WHERE client_id = $1 AND uid = $2 AND activated = $3 limit 1;`, clientID, uid, true).
 		Scan(&isExist)
 	if err != nil {
 		if sqlx.IsErrNoRows(err) {
@@ -101,7 +120,7 @@ func (p *userPersister) Create(_ context.Context, in *persistence.UserCreate) er
 	}()
 
 	var isExist int
-	if err = tx.QueryRow("SELECT 1 FROM public.user WHERE client_id = $1 AND name = $2 limit 1;", in.ClientID, in.Name).
+	if err = tx.QueryRow(`SELECT 1 FROM "user" WHERE client_id = $1 AND name = $2 limit 1;`, in.ClientID, in.Name).
 		Scan(&isExist); err != nil && !sqlx.IsErrNoRows(err) {
 		return err
 	}
@@ -127,30 +146,52 @@ func (p *userPersister) Create(_ context.Context, in *persistence.UserCreate) er
 	return nil
 }
 
-func (p *userPersister) UpdateActivated(_ context.Context, id int64, activated bool) error {
-	return p.db.Exec("UPDATE public.user SET activated = $1 WHERE id = $2;", 1, activated, id)
+// checkClientUsers returns ErrDataDoesNotExist if any of the users is not one of the
+// client.
+func (p *userPersister) checkClientUsers(clientID string, ids ...int64) error {
+	for _, id := range ids {
+		var isExist int
+		if err := p.db.QueryRow(isClientUserExistSQL, clientID, id).Scan(&isExist); err != nil && !sqlx.IsErrNoRows(err) {
+			return err
+		}
+
+		if isExist == 0 {
+			return ecode.ErrDataDoesNotExist
+		}
+	}
+
+	return nil
 }
 
-func (p *userPersister) Delete(_ context.Context, id int64) error {
-	return p.db.Exec("DELETE FROM public.user WHERE id = $1;", 1, id)
+func (p *userPersister) UpdateActivated(_ context.Context, clientID string, id int64, activated bool) error {
+	if err := p.checkClientUsers(clientID, id); err != nil {
+		return err
+	}
+
+	// MySQL does not count the row as affected if activated is unchanged
+	return p.db.Exec(`UPDATE "user" SET activated = $1 WHERE client_id = $2 AND id = $3;`, 0, activated, clientID, id)
 }
 
-func (p *userPersister) AddFriend(_ context.Context, in *persistence.UserFriend) error {
-	var isExist int
-	if err := p.db.QueryRow(isFriendExistSQL, in.UserID, in.FriendUserID).Scan(&isExist); err != nil && !sqlx.IsErrNoRows(err) {
+func (p *userPersister) Delete(_ context.Context, clientID string, id int64) error {
+	if err := p.checkClientUsers(clientID, id); err != nil {
 		return err
 	}
 
-	if isExist == 1 {
-		return ecode.ErrDataAlreadyExists
+	return p.db.Exec(`DELETE FROM "user" WHERE client_id = $1 AND id = $2;`, 1, clientID, id)
+}
+
+func (p *userPersister) AddFriend(_ context.Context, in *persistence.UserFriend) error {
+	if err := p.checkClientUsers(in.ClientID, in.UserID, in.FriendUserID); err != nil {
+		return err
 	}
 
-	if err := p.db.QueryRow("SELECT 1 FROM public.user WHERE client_id = $1 AND id = $2 limit 1;", in.ClientID, in.FriendUserID).Scan(&isExist); err != nil && err != sqlx.ErrNoRows {
+	var isExist int
+	if err := p.db.QueryRow(isFriendExistSQL, in.UserID, in.FriendUserID).Scan(&isExist); err != nil && !sqlx.IsErrNoRows(err) {
 		return err
 	}
 
-	if isExist == 0 {
-		return ecode.ErrDataDoesNotExist
+	if isExist == 1 {
+		return ecode.ErrDataAlreadyExists
 	}
 
 	now := time.Now().Unix()
@@ -161,11 +202,12 @@ func (p *userPersister) AddFriend(_ context.Context, in *persistence.UserFriend)
 	return nil
 }
 
-func (p *userPersister) GetFriends(_ context.Context, userID int64) ([]int64, error) {
-	rows, err := p.db.Query(getFriendsSQL, userID)
+func (p *userPersister) GetFriends(_ context.Context, clientID string, userID int64) ([]int64, error) {
+	rows, err := p.db.Query(getFriendsSQL, clientID, userID)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	var friendIDs []int64
 	for rows.Next() {
@@ -181,6 +223,10 @@ func (p *userPersister) GetFriends(_ context.Context, userID int64) ([]int64, er
 }
 
 func (p *userPersister) DeleteFriend(_ context.Context, in *persistence.UserFriend) error {
+	if err := p.checkClientUsers(in.ClientID, in.UserID, in.FriendUserID); err != nil {
+		return err
+	}
+
 	tx, err := p.db.Begin()
 	if err != nil {
 		return err
@@ -191,11 +237,6 @@ func (p *userPersister) DeleteFriend(_ context.Context, in *persistence.UserFrie
 		}
 	}()
 
-	var isExist int
-	if err = tx.QueryRow("SELECT 1 FROM public.user WHERE client_id = $1 AND id = $2 limit 1;", in.ClientID, in.FriendUserID).Scan(&isExist); err != nil && !sqlx.IsErrNoRows(err) {
-		return err
-	}
-
 	if err = tx.Exec(deleteFriendSQL, 1, in.UserID, in.FriendUserID); err != nil {
 		return err
 	}
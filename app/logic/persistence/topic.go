@@ -0,0 +1,16 @@
+package persistence
+
+// UserTopic is a topic the user is a member of, with the sequence of the last message of
+// the topic the user read, 0 if none.
+type UserTopic struct {
+	ClientID     string
+	UserID       int64
+	Topic        string
+	LastSequence int64
+}
+
+// TopicUser identifies a user with topics.
+type TopicUser struct {
+	ClientID string
+	UserID   int64
+}
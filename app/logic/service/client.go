@@ -27,43 +27,47 @@ func (s *Service) GetClient(ctx context.Context) (*api.Client, error) {
 	id := MustClientIDFromContext(ctx)
 	client, err := s.getClient(ctx, id)
 	if err != nil {
-		s.log.Error("[GetClient] failed to get client", "error", err)
+		s.logger(ctx).Error("[GetClient] failed to get client", "error", err)
 		return nil, err
 	}
 
 	return &api.Client{
-		ID:          client.ID,
-		CreatedAt:   client.CreatedAt,
-		UpdatedAt:   client.UpdatedAt,
-		Name:        client.Name,
-		TokenSecret: client.TokenSecret,
-		TokenExpire: int64(client.TokenExpire.Seconds()),
-		UserCount:   client.UserCount,
-		GroupCount:  client.GroupCount,
+		ID:              client.ID,
+		CreatedAt:       client.CreatedAt,
+		UpdatedAt:       client.UpdatedAt,
+		Name:            client.Name,
+		TokenSecret:     client.TokenSecret,
+		TokenExpire:     int64(client.TokenExpire.Seconds()),
+		UserCount:       client.UserCount,
+		GroupCount:      client.GroupCount,
+		MessageMaxAge:   int64(client.MessageMaxAge.Seconds()),
+		MessageMaxCount: client.MessageMaxCount,
 	}, nil
 }
 
 func (s *Service) CreateClient(ctx context.Context, req *api.CreateClientReq) (string, string, error) {
 	secret, err := x.GenerateSecret(26)
 	if err != nil {
-		s.log.Error("[CreateClient] failed to generate secret", "error", err)
+		s.logger(ctx).Error("[CreateClient] failed to generate secret", "error", err)
 		return "", "", err
 	}
 	credential, err := s.hash.Hash(secret)
 	if err != nil {
-		s.log.Error("[CreateClient] failed to create a hash from secret", "error", err)
+		s.logger(ctx).Error("[CreateClient] failed to create a hash from secret", "error", err)
 		return "", "", err
 	}
 	id := uuid.New().String()
 	in := &persistence.ClientCreate{
-		ID:          id,
-		Name:        req.Name,
-		TokenSecret: req.TokenSecret,
-		Credential:  string(credential),
-		TokenExpire: req.TokenExpire,
+		ID:              id,
+		Name:            req.Name,
+		TokenSecret:     req.TokenSecret,
+		Credential:      string(credential),
+		TokenExpire:     req.TokenExpire,
+		MessageMaxAge:   req.MessageMaxAge,
+		MessageMaxCount: req.MessageMaxCount,
 	}
 	if err := s.persister.Client().Create(ctx, in); err != nil {
-		s.log.Error("[CreateClient] failed to create client", "client_name", req.Name, "error", err)
+		s.logger(ctx).Error("[CreateClient] failed to create client", "client_name", req.Name, "error", err)
 		return "", "", err
 	}
 
@@ -84,21 +88,27 @@ func (s *Service) UpdateClient(ctx context.Context, req *api.UpdateClientReq) er
 	if req.TokenExpire != nil {
 		in.TokenExpire = &req.TokenExpire.Value
 	}
+	if req.MessageMaxAge != nil {
+		in.MessageMaxAge = &req.MessageMaxAge.Value
+	}
+	if req.MessageMaxCount != nil {
+		in.MessageMaxCount = &req.MessageMaxCount.Value
+	}
 	if err := s.persister.Client().Update(ctx, in); err != nil {
-		s.log.Error("[UpdateClient] failed to update client", "client_id", id, "error", err)
+		s.logger(ctx).Error("[UpdateClient] failed to update client", "client_id", id, "error", err)
 		return err
 	}
 
 	go func() {
 		client, err := s.persister.Client().GetClient(ctx, id)
 		if err != nil {
-			s.log.Error("[UpdateClient] failed to get client", "client_id", id, "error", err)
+			s.logger(ctx).Error("[UpdateClient] failed to get client", "client_id", id, "error", err)
 			return
 		}
 
 		err = s.cache.SetClient(id, client)
 		if err != nil {
-			s.log.Error("[UpdateClient] failed to set client to cache", "client_id", id, "error", err)
+			s.logger(ctx).Error("[UpdateClient] failed to set client to cache", "client_id", id, "error", err)
 		}
 	}()
 
@@ -108,14 +118,14 @@ func (s *Service) UpdateClient(ctx context.Context, req *api.UpdateClientReq) er
 func (s *Service) DeleteClient(ctx context.Context) error {
 	id := MustClientIDFromContext(ctx)
 	if err := s.persister.Client().Delete(ctx, id); err != nil {
-		s.log.Error("[DeleteClient] failed to delete client", "client_id", id, "error", err)
+		s.logger(ctx).Error("[DeleteClient] failed to delete client", "client_id", id, "error", err)
 		return err
 	}
 
 	go func() {
 		err := s.cache.DeleteClient(id)
 		if err != nil {
-			s.log.Error("[DeleteClient] failed to delete client from cache", "client_id", id, "error", err)
+			s.logger(ctx).Error("[DeleteClient] failed to delete client from cache", "client_id", id, "error", err)
 		}
 	}()
 
@@ -125,18 +135,18 @@ func (s *Service) DeleteClient(ctx context.Context) error {
 func (s *Service) GenerateToken(ctx context.Context, req *api.GenerateTokenReq) (string, string, error) {
 	credential, err := s.persister.Client().GetClientCredential(ctx, req.ClientID)
 	if err != nil {
-		s.log.Error("[GenerateToken] failed to get client credential", "client_id", req.ClientID, "error", err)
+		s.logger(ctx).Error("[GenerateToken] failed to get client credential", "client_id", req.ClientID, "error", err)
 		return "", "", err
 	}
 
 	if err = s.hash.Compare([]byte(credential), []byte(req.ClientSecret)); err != nil {
-		s.log.Error("[GenerateToken] failed to compare", "error", err)
+		s.logger(ctx).Error("[GenerateToken] failed to compare", "error", err)
 		return "", "", err
 	}
 
 	token, lifetime, err := s.token.GenerateToken(req.ClientID)
 	if err != nil {
-		s.log.Error("[GenerateToken] failed to generate token", "client_id", req.ClientID, "error", err)
+		s.logger(ctx).Error("[GenerateToken] failed to generate token", "client_id", req.ClientID, "error", err)
 		return "", "", err
 	}
 
@@ -147,7 +157,7 @@ func (s *Service) Listen(ctx context.Context, token string, stream api.ChatClien
 	var clientID string
 	_, err := s.token.Authenticate(token, &clientID)
 	if err != nil {
-		s.log.Error("[Listen] failed to authenticating the token", "error", err)
+		s.logger(ctx).Error("[Listen] failed to authenticating the token", "error", err)
 		return ecode.ErrInvalidToken
 	}
 
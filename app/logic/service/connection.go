@@ -6,52 +6,73 @@ import (
 	"mercury/x/ecode"
 )
 
-func (s *Service) Connect(ctx context.Context, req *api.ConnectReq) (string, string, error) {
+// Connect authenticates the session and maps it to its server. In the topic push mode it
+// returns the topics of the user, the session joins them on its comet.
+func (s *Service) Connect(ctx context.Context, req *api.ConnectReq) (*api.ConnectResp, error) {
 	clientID := s.cache.GetClientID(req.JWTToken)
 	if clientID == "" {
-		return "", "", ecode.ErrInvalidToken
+		return nil, ecode.ErrInvalidToken
 	}
 	client, err := s.getClient(ctx, clientID)
 	if err != nil {
-		s.log.Error("[Connect] failed to get client", "client_id", clientID, "error", err)
-		return "", "", err
+		s.logger(ctx).Error("[Connect] failed to get client", "client_id", clientID, "error", err)
+		return nil, err
 	}
 
 	var uid string
 	_, err = s.jwt.Authenticate(req.JWTToken, client.Name, client.TokenSecret, &uid)
 	if err != nil {
-		s.log.Error("[Connect] failed to authenticating the jwt token", "uid", uid, "error", err)
-		return "", "", err
+		s.logger(ctx).Error("[Connect] failed to authenticating the jwt token", "uid", uid, "error", err)
+		return nil, err
 	}
 
-	if err := s.cache.AddMapping(uid, req.SID, req.ServerID); err != nil {
-		s.log.Error("[Connect] failed to add mapping", "uid", uid, "error", err)
-		return "", "", err
+	topics, err := s.userTopicsLastSequence(ctx, clientID, uid)
+	if err != nil {
+		s.logger(ctx).Error("[Connect] failed to get topics", "uid", uid, "error", err)
+		return nil, err
+	}
+	resp := &api.ConnectResp{ClientID: clientID, UID: uid, Topics: make(map[string]int64, len(topics))}
+	for topic := range topics {
+		resp.Topics[topic] = s.topicSequence(clientID, topic)
+	}
+
+	if err := s.cache.AddMapping(clientID, uid, req.SID, req.ServerID); err != nil {
+		s.logger(ctx).Error("[Connect] failed to add mapping", "uid", uid, "error", err)
+		return nil, err
 	}
+	s.removeUserSessions(ctx, clientID, uid)
 
-	return clientID, uid, nil
+	return resp, nil
 }
 
 func (s *Service) Disconnect(ctx context.Context, req *api.DisconnectReq) error {
-	if err := s.cache.DeleteMapping(req.UID, req.SID); err != nil {
-		s.log.Error("[Disconnect] failed to delete mapping", "uid", req.UID, "error", err)
+	if req.ClientID == "" {
+		return errEmptyClientID
+	}
+	if err := s.cache.DeleteMapping(req.ClientID, req.UID, req.SID); err != nil {
+		s.logger(ctx).Error("[Disconnect] failed to delete mapping", "uid", req.UID, "error", err)
 		return err
 	}
+	s.removeUserSessions(ctx, req.ClientID, req.UID)
 
 	return nil
 }
 
 func (s *Service) Heartbeat(ctx context.Context, req *api.HeartbeatReq) error {
-	expired, err := s.cache.ExpireMapping(req.UID, req.SID)
+	if req.ClientID == "" {
+		return errEmptyClientID
+	}
+	expired, err := s.cache.ExpireMapping(req.ClientID, req.UID, req.SID)
 	if err != nil {
-		s.log.Error("[Heartbeat] failed to expire mapping", "uid", req.UID, "error", err)
+		s.logger(ctx).Error("[Heartbeat] failed to expire mapping", "uid", req.UID, "error", err)
 		return err
 	}
 	if !expired {
-		if err := s.cache.AddMapping(req.UID, req.SID, req.ServerID); err != nil {
-			s.log.Error("[Heartbeat] failed to add mapping", "uid", req.UID, "error", err)
+		if err := s.cache.AddMapping(req.ClientID, req.UID, req.SID, req.ServerID); err != nil {
+			s.logger(ctx).Error("[Heartbeat] failed to add mapping", "uid", req.UID, "error", err)
 			return err
 		}
+		s.removeUserSessions(ctx, req.ClientID, req.UID)
 	}
 
 	return nil
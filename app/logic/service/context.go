@@ -2,8 +2,14 @@ package service
 
 import (
 	"context"
+
+	"mercury/x/ecode"
 )
 
+// errEmptyClientID is returned for the requests of no client, the users, their sessions
+// and their topics are all scoped by the client.
+var errEmptyClientID = ecode.ErrBadRequest.ResetMessage("client id can not be empty")
+
 type clientKey struct{}
 
 func ClientIDFromContext(ctx context.Context) (string, bool) {
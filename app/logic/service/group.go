@@ -20,11 +20,14 @@ func (s *Service) CreateGroup(ctx context.Context, req *api.CreateGroupReq) (*ap
 	}
 	group, err := s.persister.Group().Create(ctx, in)
 	if err != nil {
-		s.log.Error("[CreateGroup] failed to create group", "client_id", clientID, "name", req.Name, "owner", req.Owner, "error", err)
+		s.logger(ctx).Error("[CreateGroup] failed to create group", "name", req.Name, "owner", req.Owner, "error", err)
 		return nil, err
 	}
 
-	go s.cache.SetUsersTopic([]string{req.Owner}, group.GID)
+	// A push to the group before it existed may have cached it without members
+	s.removeGroupMembers(ctx, clientID, in.GroupID)
+	go s.addUsersTopic(ctx, clientID, []string{req.Owner}, group.GID)
+	s.joinTopic(ctx, clientID, group.GID, req.Owner)
 
 	return &api.Group{
 		CreatedAt:    group.CreatedAt,
@@ -38,9 +41,9 @@ func (s *Service) CreateGroup(ctx context.Context, req *api.CreateGroupReq) (*ap
 
 func (s *Service) GetGroups(ctx context.Context, uid string) ([]*api.Group, error) {
 	clientID := MustClientIDFromContext(ctx)
-	groups, err := s.persister.Group().GetGroups(ctx, s.DecodeID(types.ParseUID(uid)))
+	groups, err := s.persister.Group().GetGroups(ctx, clientID, s.DecodeID(types.ParseUID(uid)))
 	if err != nil {
-		s.log.Error("[GetGroups] failed to get groups", "client_id", clientID, "uid", uid, "error", err)
+		s.logger(ctx).Error("[GetGroups] failed to get groups", "client_id", clientID, "uid", uid, "error", err)
 		return nil, err
 	}
 
@@ -68,11 +71,13 @@ func (s *Service) AddMember(ctx context.Context, req *api.AddMemberReq) error {
 		UserID:   s.idGen.DecodeID(types.ParseUID(req.UID)),
 	}
 	if err := s.persister.Group().AddMember(ctx, in); err != nil {
-		s.log.Error("[AddMember] failed to add member to group", "client_id", clientID, "gid", req.GID, "uid", req.UID, "error", err)
+		s.logger(ctx).Error("[AddMember] failed to add member to group", "gid", req.GID, "uid", req.UID, "error", err)
 		return err
 	}
 
-	go s.cache.SetUsersTopic([]string{req.UID}, req.GID)
+	s.removeGroupMembers(ctx, clientID, in.GroupID)
+	go s.addUsersTopic(ctx, clientID, []string{req.UID}, req.GID)
+	s.joinTopic(ctx, clientID, req.GID, req.UID)
 
 	// TODO send the notification to other group members.
 
@@ -83,7 +88,7 @@ func (s *Service) GetMembers(ctx context.Context, gid string) ([]string, error)
 	clientID := MustClientIDFromContext(ctx)
 	memberIDs, err := s.persister.Group().GetMembers(ctx, clientID, s.DecodeID(types.ParseGID(gid)))
 	if err != nil {
-		s.log.Error("[GetMembers] failed to get group members", "gid", gid, "error", err)
+		s.logger(ctx).Error("[GetMembers] failed to get group members", "gid", gid, "error", err)
 		return nil, err
 	}
 
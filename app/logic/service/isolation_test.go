@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"mercury/app/logic/api"
+	"mercury/app/logic/persistence"
+	"mercury/x/ecode"
+	"mercury/x/types"
+)
+
+// TestClientIsolation checks a client can neither read nor change the users, the groups
+// and the topics of another client, even with their IDs.
+func TestClientIsolation(t *testing.T) {
+	s := newTestService(t)
+
+	ctx := context.Background()
+	clientID, _, err := s.CreateClient(ctx, &api.CreateClientReq{Name: "client", TokenSecret: "secret"})
+	require.NoError(t, err)
+	otherID, _, err := s.CreateClient(ctx, &api.CreateClientReq{Name: "other", TokenSecret: "secret"})
+	require.NoError(t, err)
+	cctx, octx := ContextWithClientID(ctx, clientID), ContextWithClientID(ctx, otherID)
+
+	alice, err := s.CreateUser(cctx, &api.CreateUserReq{Name: "alice"})
+	require.NoError(t, err)
+	bob, err := s.CreateUser(cctx, &api.CreateUserReq{Name: "bob"})
+	require.NoError(t, err)
+	mallory, err := s.CreateUser(octx, &api.CreateUserReq{Name: "mallory"})
+	require.NoError(t, err)
+	require.NoError(t, s.AddFriend(cctx, alice, bob))
+	group, err := s.CreateGroup(cctx, &api.CreateGroupReq{Name: "group", Owner: alice})
+	require.NoError(t, err)
+
+	topic := types.ParseUID(alice).P2PName(types.ParseUID(bob))
+	require.NoError(t, s.persister.Message().Add(ctx, &persistence.Message{
+		ClientID:    clientID,
+		Topic:       topic,
+		Sequence:    1,
+		MessageType: types.MessageTypeSingle,
+		Sender:      s.DecodeID(types.ParseUID(alice)),
+		Receiver:    s.DecodeID(types.ParseUID(bob)),
+		ContentType: types.ContentTypeText,
+		Body:        []byte(`{}`),
+	}))
+	s.addUsersTopic(ctx, clientID, []string{alice, bob}, topic)
+
+	// The users
+	require.Error(t, s.DeleteUser(octx, alice))
+	require.Error(t, s.UpdateActivated(octx, alice, false))
+	activated, err := s.persister.User().CheckActivated(ctx, clientID, alice)
+	require.NoError(t, err)
+	require.True(t, activated)
+	require.Error(t, s.AddFriend(octx, mallory, alice))
+	require.Error(t, s.DeleteFriend(octx, alice, bob))
+	friends, err := s.GetFriends(octx, alice)
+	require.NoError(t, err)
+	require.Empty(t, friends)
+
+	// The groups
+	groups, err := s.GetGroups(octx, alice)
+	require.NoError(t, err)
+	require.Empty(t, groups)
+	_, err = s.GetMembers(octx, group.GID)
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+	require.Error(t, s.AddMember(octx, &api.AddMemberReq{GID: group.GID, UID: mallory}))
+	require.Error(t, s.AddMember(cctx, &api.AddMemberReq{GID: group.GID, UID: mallory}))
+	_, err = s.CreateGroup(octx, &api.CreateGroupReq{Name: "group", Owner: alice})
+	require.Error(t, err)
+	members, err := s.GetMembers(cctx, group.GID)
+	require.NoError(t, err)
+	require.Equal(t, []string{alice}, members)
+
+	// The topics
+	_, err = s.PushMessage(ctx, &api.PushMessageReq{
+		ClientID:    otherID,
+		Sender:      mallory,
+		Receiver:    alice,
+		MessageType: api.MessageTypeSingle,
+		Body:        []byte(`{}`),
+	})
+	require.Equal(t, ecode.ErrUserNotActivated, err)
+	_, err = s.PushMessage(ctx, &api.PushMessageReq{
+		ClientID:    otherID,
+		Sender:      mallory,
+		Receiver:    group.GID,
+		MessageType: api.MessageTypeGroup,
+		Body:        []byte(`{}`),
+	})
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+
+	pulled, err := s.PullMessage(ctx, &api.PullMessageReq{ClientID: otherID, UID: alice})
+	require.NoError(t, err)
+	require.Empty(t, pulled)
+	err = s.ReadMessage(ctx, &api.ReadMessageReq{ClientID: otherID, UID: mallory, Topic: topic, Sequence: 1})
+	require.Equal(t, ecode.ErrDataDoesNotExist, err)
+	require.Error(t, s.Keypress(ctx, &api.KeypressReq{ClientID: otherID, UID: alice, Topic: topic}))
+
+	pulled, err = s.PullMessage(ctx, &api.PullMessageReq{ClientID: clientID, UID: bob})
+	require.NoError(t, err)
+	require.Len(t, pulled, 1)
+	require.Equal(t, int64(1), pulled[0].Count)
+
+	// A request of no client is rejected
+	_, err = s.PullMessage(ctx, &api.PullMessageReq{UID: alice})
+	require.Equal(t, errEmptyClientID, err)
+	require.Equal(t, errEmptyClientID, s.Heartbeat(ctx, &api.HeartbeatReq{UID: alice, SID: "sid"}))
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"github.com/micro/go-micro/v2/broker"
+	"mercury/app/logic/api"
+	"sync"
+	"time"
+)
+
+// Bounds of the entries of the local cache, the expired ones are swept once reached and
+// all of them are dropped if it is still full
+const (
+	localGroupsSize   = 10000
+	localSessionsSize = 100000
+)
+
+type localGroupKey struct {
+	clientID string
+	groupID  int64
+}
+
+type localUserKey struct {
+	clientID string
+	uid      string
+}
+
+type localMembers struct {
+	expireAt time.Time
+	members  []int64
+}
+
+type localSessions struct {
+	expireAt time.Time
+	// Server IDs by session ID, empty if the user is offline
+	servers map[string]string
+}
+
+// localCache keeps the members of the groups and the sessions of the users in the memory
+// of logic for a short time, a push to a group then skips the database and the cache.
+type localCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu       sync.RWMutex
+	members  map[localGroupKey]localMembers
+	sessions map[localUserKey]localSessions
+}
+
+func newLocalCache(ttl time.Duration) *localCache {
+	return &localCache{
+		ttl:      ttl,
+		now:      time.Now,
+		members:  make(map[localGroupKey]localMembers),
+		sessions: make(map[localUserKey]localSessions),
+	}
+}
+
+func (c *localCache) getMembers(key localGroupKey, now time.Time) ([]int64, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.members[key]
+	if !ok || !now.Before(e.expireAt) {
+		return nil, false
+	}
+	return e.members, true
+}
+
+func (c *localCache) addMembers(key localGroupKey, members []int64, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.members[key]; !ok && len(c.members) >= localGroupsSize {
+		for k, e := range c.members {
+			if !now.Before(e.expireAt) {
+				delete(c.members, k)
+			}
+		}
+		if len(c.members) >= localGroupsSize {
+			c.members = make(map[localGroupKey]localMembers)
+		}
+	}
+	c.members[key] = localMembers{expireAt: now.Add(c.ttl), members: members}
+}
+
+// getSessions merges the servers of the sessions of the users into sessions and returns
+// the users missing.
+func (c *localCache) getSessions(clientID string, uids []string, sessions map[string]string, now time.Time) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var missing []string
+	for _, uid := range uids {
+		e, ok := c.sessions[localUserKey{clientID: clientID, uid: uid}]
+		if !ok || !now.Before(e.expireAt) {
+			missing = append(missing, uid)
+			continue
+		}
+		for sid, serverID := range e.servers {
+			sessions[sid] = serverID
+		}
+	}
+	return missing
+}
+
+func (c *localCache) addSessions(clientID string, users map[string]map[string]string, uids []string, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.sessions)+len(uids) > localSessionsSize {
+		for k, e := range c.sessions {
+			if !now.Before(e.expireAt) {
+				delete(c.sessions, k)
+			}
+		}
+		if len(c.sessions)+len(uids) > localSessionsSize {
+			c.sessions = make(map[localUserKey]localSessions)
+		}
+	}
+	expireAt := now.Add(c.ttl)
+	for _, uid := range uids {
+		c.sessions[localUserKey{clientID: clientID, uid: uid}] = localSessions{expireAt: expireAt, servers: users[uid]}
+	}
+}
+
+func (c *localCache) deleteMembers(clientID string, groupID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.members, localGroupKey{clientID: clientID, groupID: groupID})
+}
+
+func (c *localCache) deleteSessions(clientID, uid string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.sessions, localUserKey{clientID: clientID, uid: uid})
+}
+
+// groupMembers returns the members of the group, they must not be modified.
+func (s *Service) groupMembers(ctx context.Context, clientID string, groupID int64) ([]int64, error) {
+	if s.local == nil {
+		return s.persister.Group().GetMembers(ctx, clientID, groupID)
+	}
+
+	key := localGroupKey{clientID: clientID, groupID: groupID}
+	now := s.local.now()
+	if members, ok := s.local.getMembers(key, now); ok {
+		return members, nil
+	}
+	members, err := s.persister.Group().GetMembers(ctx, clientID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	s.local.addMembers(key, members, now)
+	return members, nil
+}
+
+// removeGroupMembers drops the members of the group from the local cache of every logic.
+func (s *Service) removeGroupMembers(ctx context.Context, clientID string, groupID int64) {
+	if s.local != nil {
+		s.local.deleteMembers(clientID, groupID)
+		s.publishInvalidation(ctx, &api.InvalidateLocalMessage{ClientID: clientID, GroupID: groupID})
+	}
+}
+
+// userSessions returns the servers of the sessions of the users, the ones missing from
+// the local cache are got from the cache at once.
+func (s *Service) userSessions(clientID string, uids ...string) (map[string]string, error) {
+	if s.local == nil {
+		sessions, _, err := s.cache.GetSessions(clientID, uids...)
+		return sessions, err
+	}
+
+	sessions := make(map[string]string)
+	now := s.local.now()
+	missing := s.local.getSessions(clientID, uids, sessions, now)
+	if len(missing) == 0 {
+		return sessions, nil
+	}
+
+	users, err := s.cache.GetUsersSessions(clientID, missing...)
+	if err != nil {
+		return nil, err
+	}
+	s.local.addSessions(clientID, users, missing, now)
+	for _, uid := range missing {
+		for sid, serverID := range users[uid] {
+			sessions[sid] = serverID
+		}
+	}
+	return sessions, nil
+}
+
+// removeUserSessions drops the sessions of the user from the local cache of every logic.
+func (s *Service) removeUserSessions(ctx context.Context, clientID, uid string) {
+	if s.local != nil {
+		s.local.deleteSessions(clientID, uid)
+		s.publishInvalidation(ctx, &api.InvalidateLocalMessage{ClientID: clientID, UIDs: []string{uid}})
+	}
+}
+
+// subscribeInvalidations subscribes every logic to the invalidations of the local cache
+// published by the others. Without a broker or the topic invalidate_local_cache, the
+// changes made through another logic are seen after the TTL, like the changes made
+// outside of logic always are.
+func (s *Service) subscribeInvalidations() error {
+	topics := s.config.Topic()
+	topic, ok := topics.Get("invalidate_local_cache")
+	if !ok || s.broker == nil {
+		return nil
+	}
+	sub, err := s.broker.Subscribe(topic, s.handleInvalidation)
+	if err != nil {
+		return err
+	}
+	s.invalidations = sub
+	return nil
+}
+
+// publishInvalidation publishes the entries dropped from the local cache to the other logics.
+func (s *Service) publishInvalidation(ctx context.Context, m *api.InvalidateLocalMessage) {
+	if s.invalidations == nil {
+		return
+	}
+	if err := s.invoke(ctx, s.invalidations.Topic(), m); err != nil {
+		s.logger(ctx).Error("[Invalidate] failed to publish invalidation", "error", err)
+	}
+}
+
+// handleInvalidation drops the entries invalidated by a logic, this one included.
+func (s *Service) handleInvalidation(e broker.Event) error {
+	m := new(api.InvalidateLocalMessage)
+	if err := m.Unmarshal(e.Message().Body); err != nil {
+		s.log.Error("[Invalidate] failed to unmarshal invalidation", "error", err)
+		return nil
+	}
+	if m.GroupID != 0 {
+		s.local.deleteMembers(m.ClientID, m.GroupID)
+	}
+	for _, uid := range m.UIDs {
+		s.local.deleteSessions(m.ClientID, uid)
+	}
+	return nil
+}
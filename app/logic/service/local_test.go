@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"mercury/app/logic/api"
+	"mercury/app/logic/persistence"
+	"mercury/app/logic/persistence/memory"
+	"mercury/config"
+	"mercury/x/brokerx"
+	"mercury/x/types"
+)
+
+func TestLocalCache(t *testing.T) {
+	s := newTestService(t)
+	require.NotNil(t, s.local)
+	now := time.Now()
+	s.local.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	clientID, _, err := s.CreateClient(ctx, &api.CreateClientReq{Name: "client", TokenSecret: "secret"})
+	require.NoError(t, err)
+	cctx := ContextWithClientID(ctx, clientID)
+	alice, err := s.CreateUser(cctx, &api.CreateUserReq{Name: "alice"})
+	require.NoError(t, err)
+	bob, err := s.CreateUser(cctx, &api.CreateUserReq{Name: "bob"})
+	require.NoError(t, err)
+	carol, err := s.CreateUser(cctx, &api.CreateUserReq{Name: "carol"})
+	require.NoError(t, err)
+	group, err := s.CreateGroup(cctx, &api.CreateGroupReq{Name: "group", Owner: alice})
+	require.NoError(t, err)
+	groupID := s.DecodeID(types.ParseGID(group.GID))
+
+	members, err := s.groupMembers(ctx, clientID, groupID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	_, err = s.groupMembers(ctx, "other-client", groupID)
+	require.Error(t, err)
+
+	// A member added through the service drops the members cached
+	require.NoError(t, s.AddMember(cctx, &api.AddMemberReq{GID: group.GID, UID: bob}))
+	members, err = s.groupMembers(ctx, clientID, groupID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	// the ones added elsewhere are seen after the TTL
+	require.NoError(t, s.persister.Group().AddMember(ctx, &persistence.GroupMember{ClientID: clientID, GroupID: groupID, UserID: s.DecodeID(types.ParseUID(carol))}))
+	members, err = s.groupMembers(ctx, clientID, groupID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	now = now.Add(s.local.ttl)
+	members, err = s.groupMembers(ctx, clientID, groupID)
+	require.NoError(t, err)
+	require.Len(t, members, 3)
+
+	// The users offline are cached too
+	require.NoError(t, s.cache.AddMapping(clientID, alice, "sid1", "server1"))
+	sessions, err := s.userSessions(clientID, alice, bob)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"sid1": "server1"}, sessions)
+	require.NoError(t, s.cache.AddMapping(clientID, bob, "sid2", "server2"))
+	sessions, err = s.userSessions(clientID, alice, bob)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"sid1": "server1"}, sessions)
+
+	// A heartbeat adding the mapping again and a disconnect drop the sessions cached
+	require.NoError(t, s.Heartbeat(ctx, &api.HeartbeatReq{ClientID: clientID, UID: bob, SID: "sid3", ServerID: "server2"}))
+	sessions, err = s.userSessions(clientID, alice, bob)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"sid1": "server1", "sid2": "server2", "sid3": "server2"}, sessions)
+	require.NoError(t, s.Disconnect(ctx, &api.DisconnectReq{ClientID: clientID, UID: alice, SID: "sid1"}))
+	sessions, err = s.userSessions(clientID, alice, bob)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"sid2": "server2", "sid3": "server2"}, sessions)
+	sessions, err = s.userSessions("other-client", alice, bob)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+}
+
+func TestLocalCacheInvalidation(t *testing.T) {
+	b := brokerx.NewMemoryBroker()
+	require.NoError(t, b.Connect())
+	c, p := memory.NewCache(), memory.NewPersister()
+	// Two logics sharing the cache, the database and the broker
+	s1 := newTestService(t, WithCacher(c), WithPersister(p), WithBroker(b))
+	s2 := newTestService(t, WithCacher(c), WithPersister(p), WithBroker(b))
+	require.NotNil(t, s2.invalidations)
+
+	ctx := context.Background()
+	clientID, _, err := s1.CreateClient(ctx, &api.CreateClientReq{Name: "client", TokenSecret: "secret"})
+	require.NoError(t, err)
+	cctx := ContextWithClientID(ctx, clientID)
+	alice, err := s1.CreateUser(cctx, &api.CreateUserReq{Name: "alice"})
+	require.NoError(t, err)
+	bob, err := s1.CreateUser(cctx, &api.CreateUserReq{Name: "bob"})
+	require.NoError(t, err)
+	group, err := s1.CreateGroup(cctx, &api.CreateGroupReq{Name: "group", Owner: alice})
+	require.NoError(t, err)
+	groupID := s1.DecodeID(types.ParseGID(group.GID))
+
+	// A member added through one logic drops the members cached by the other
+	members, err := s2.groupMembers(ctx, clientID, groupID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	require.NoError(t, s1.AddMember(cctx, &api.AddMemberReq{GID: group.GID, UID: bob}))
+	require.Eventually(t, func() bool {
+		members, err := s2.groupMembers(ctx, clientID, groupID)
+		return err == nil && len(members) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// So does a disconnect for the sessions
+	require.NoError(t, c.AddMapping(clientID, bob, "sid1", "server1"))
+	sessions, err := s2.userSessions(clientID, bob)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.NoError(t, s1.Disconnect(ctx, &api.DisconnectReq{ClientID: clientID, UID: bob, SID: "sid1"}))
+	require.Eventually(t, func() bool {
+		sessions, err := s2.userSessions(clientID, bob)
+		return err == nil && len(sessions) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+// roundTripCache delays the calls of the cache on the path of a push by a round trip to
+// redis, the cache of the service is pipelined so a call is one round trip.
+type roundTripCache struct {
+	persistence.Cacher
+}
+
+const benchmarkRoundTrip = 200 * time.Microsecond
+
+func (c roundTripCache) IncrTopicSequence(clientID, topic string) (int64, error) {
+	time.Sleep(benchmarkRoundTrip)
+	return c.Cacher.IncrTopicSequence(clientID, topic)
+}
+
+func (c roundTripCache) GetSessions(clientID string, uids ...string) (map[string]string, []string, error) {
+	time.Sleep(benchmarkRoundTrip)
+	return c.Cacher.GetSessions(clientID, uids...)
+}
+
+func (c roundTripCache) GetUsersSessions(clientID string, uids ...string) (map[string]map[string]string, error) {
+	time.Sleep(benchmarkRoundTrip)
+	return c.Cacher.GetUsersSessions(clientID, uids...)
+}
+
+// benchmarkPushGroupMessage pushes messages to a group of the members, a tenth of them
+// online. The messages are not published, the fan-out up to it is sent at once to be
+// measured along.
+func benchmarkPushGroupMessage(b *testing.B, members int, ttl string) {
+	cfg := newTestConfig()
+	cfg.Topic = config.Topic{}
+	srvCfg, _ := cfg.GetService("mercury.logic")
+	srvCfg.Config["local_cache_ttl"] = ttl
+	s := newTestServiceConfig(b, cfg, WithCacher(roundTripCache{memory.NewCache()}))
+
+	ctx := context.Background()
+	clientID, _, err := s.CreateClient(ctx, &api.CreateClientReq{Name: "client", TokenSecret: "secret"})
+	require.NoError(b, err)
+	cctx := ContextWithClientID(ctx, clientID)
+	owner, err := s.CreateUser(cctx, &api.CreateUserReq{Name: "owner"})
+	require.NoError(b, err)
+	group, err := s.CreateGroup(cctx, &api.CreateGroupReq{Name: "group", Owner: owner})
+	require.NoError(b, err)
+	groupID := s.DecodeID(types.ParseGID(group.GID))
+	for i := 1; i < members; i++ {
+		uid, err := s.CreateUser(cctx, &api.CreateUserReq{Name: fmt.Sprintf("member%d", i)})
+		require.NoError(b, err)
+		require.NoError(b, s.persister.Group().AddMember(ctx, &persistence.GroupMember{
+			ClientID: clientID, GroupID: groupID, UserID: s.DecodeID(types.ParseUID(uid)),
+		}))
+		if i%10 == 0 {
+			require.NoError(b, s.cache.AddMapping(clientID, uid, fmt.Sprintf("sid%d", i), "server"))
+		}
+	}
+
+	req := &api.PushMessageReq{
+		ClientID:    clientID,
+		Sender:      owner,
+		Receiver:    group.GID,
+		MessageType: api.MessageTypeGroup,
+		ContentType: api.ContentType(types.ContentTypeText),
+		Body:        []byte(`{"content":"hello"}`),
+	}
+	uids := make([]string, 0, members)
+	memberIDs, err := s.groupMembers(ctx, clientID, groupID)
+	require.NoError(b, err)
+	for _, id := range memberIDs {
+		uids = append(uids, s.EncodeID(id).UID())
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pushed, err := s.PushMessage(ctx, req)
+		if err != nil {
+			b.Fatal(err)
+		}
+		s.send(ctx, clientID, types.OperationPush, &types.Message{Topic: group.GID, Sequence: pushed.Sequence}, "", uids...)
+	}
+}
+
+func BenchmarkPushGroupMessage(b *testing.B) {
+	for _, members := range []int{1000, 10000} {
+		for _, ttl := range []string{"0s", "2s"} {
+			b.Run(fmt.Sprintf("members=%d/local_cache_ttl=%s", members, ttl), func(b *testing.B) {
+				benchmarkPushGroupMessage(b, members, ttl)
+			})
+		}
+	}
+}
@@ -5,39 +5,42 @@ import (
 	jsoniter "github.com/json-iterator/go"
 	"mercury/app/logic/api"
 	"mercury/app/logic/persistence"
+	"mercury/config"
 	"mercury/x"
 	"mercury/x/database/redis"
 	"mercury/x/ecode"
 	"mercury/x/types"
+	"time"
 )
 
-func (s *Service) nextSequence(ctx context.Context, topic string) (int64, error) {
-	sequence, err := s.cache.IncrTopicSequence(topic)
-	switch err {
-	case redis.RedisNil:
-		sequence, err = s.persister.Message().GetTopicLastSequence(ctx, topic)
-		if err != nil {
-			return 0, err
+// nextSequence allocates the next sequence of the topic. The sequence of the cache is
+// seeded from the last one of the persister when it is missing, or when reseed is set
+// since the one of the cache is behind, e.g. after a failover of redis lost its latest
+// increments.
+func (s *Service) nextSequence(ctx context.Context, clientID, topic string, reseed bool) (int64, error) {
+	if !reseed {
+		sequence, err := s.cache.IncrTopicSequence(clientID, topic)
+		if err != redis.RedisNil {
+			return sequence, err
 		}
-		sequence++
-		go s.cache.SetTopicSequence(topic, sequence, 0)
-		return sequence, nil
-	case nil:
-		return sequence, nil
-	default:
+	}
+
+	last, err := s.persister.Message().GetTopicLastSequence(ctx, clientID, topic)
+	if err != nil {
 		return 0, err
 	}
+	return s.cache.SeedTopicSequence(clientID, topic, last, 0)
 }
 
 const addMessageRetries = 3
 
-func (s *Service) PushMessage(ctx context.Context, req *api.PushMessageReq) (int64, int64, error) {
+func (s *Service) PushMessage(ctx context.Context, req *api.PushMessageReq) (*api.PushMessageResp, error) {
 	sender := types.ParseUID(req.Sender)
 
 	check, _ := s.persister.User().CheckActivated(ctx, req.ClientID, req.Sender)
 	if !check {
-		s.log.Error("[SendMessage] sender not activated", "uid", req.Sender)
-		return 0, 0, ecode.ErrUserNotActivated
+		s.logger(ctx).Error("[SendMessage] sender not activated", "uid", req.Sender)
+		return nil, ecode.ErrUserNotActivated
 	}
 
 	uids := []string{req.Sender}
@@ -51,27 +54,27 @@ func (s *Service) PushMessage(ctx context.Context, req *api.PushMessageReq) (int
 		receiver = types.ParseUID(req.Receiver)
 		check, _ = s.persister.User().CheckActivated(ctx, req.ClientID, req.Receiver)
 		if !check {
-			s.log.Error("[PushMessage] receiver not activated", "uid", req.Receiver)
-			return 0, 0, ecode.ErrUserNotActivated
+			s.logger(ctx).Error("[PushMessage] receiver not activated", "uid", req.Receiver)
+			return nil, ecode.ErrUserNotActivated
 		}
 
 		uids = append(uids, req.Receiver)
 		topic = sender.P2PName(receiver)
 
-		go s.cache.SetUsersTopic(uids, topic)
+		go s.addUsersTopic(ctx, req.ClientID, uids, topic)
 	case api.MessageTypeGroup:
 		gid := types.ParseGID(req.Receiver)
 		receiver = gid
 		// Get a list of all member IDs in the group
-		members, err := s.persister.Group().GetMembers(ctx, req.ClientID, s.DecodeID(gid))
+		members, err := s.groupMembers(ctx, req.ClientID, s.DecodeID(gid))
 		if err != nil {
-			s.log.Error("[PushMessage] failed to get group members", "gid", req.Receiver, "error", err)
-			return 0, 0, err
+			s.logger(ctx).Error("[PushMessage] failed to get group members", "gid", req.Receiver, "error", err)
+			return nil, err
 		}
 
 		// Check if the sender is in the group
 		if !x.IsInSlice(members, s.DecodeID(sender)) {
-			return 0, 0, ecode.NewError("the user is not join the group")
+			return nil, ecode.NewError("the user is not join the group")
 		}
 
 		for _, member := range members {
@@ -80,7 +83,7 @@ func (s *Service) PushMessage(ctx context.Context, req *api.PushMessageReq) (int
 		for _, mention := range req.Mentions {
 			id := s.DecodeID(types.ParseUID(mention))
 			if !x.IsInSlice(members, id) {
-				return 0, 0, ecode.ErrInternalServer.ResetMessage("the mentioned user is not join the group")
+				return nil, ecode.ErrInternalServer.ResetMessage("the mentioned user is not join the group")
 			}
 			mentions = append(mentions, id)
 		}
@@ -88,6 +91,7 @@ func (s *Service) PushMessage(ctx context.Context, req *api.PushMessageReq) (int
 	}
 
 	message := &persistence.Message{
+		ClientID:    req.ClientID,
 		Topic:       topic,
 		MessageType: types.MessageType(req.MessageType),
 		Sender:      s.DecodeID(sender),
@@ -96,95 +100,105 @@ func (s *Service) PushMessage(ctx context.Context, req *api.PushMessageReq) (int
 		Body:        req.Body,
 		Mentions:    mentions,
 	}
-	add := func() error {
-		var err error
-		// Get the next sequence of the topic
-		message.Sequence, err = s.nextSequence(ctx, topic)
+	ch := make(chan error, 1)
+	go func() {
+		ch <- s.addMessage(ctx, message)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case err := <-ch:
 		if err != nil {
-			s.log.Error("[PushMessage] failed to get sequence", "topic", topic, "error", err)
-			return err
+			return nil, err
 		}
+	}
 
-		if err = s.persister.Message().Add(ctx, message); err != nil {
-			s.log.Error("[PushMessage] failed to add message", "sequence", message.Sequence, "topic", topic, "error", err)
-			return err
+	m := &types.Message{
+		ID:          message.ID,
+		CreatedAt:   message.CreatedAt,
+		MessageType: types.MessageType(req.MessageType),
+		Sender:      sender.UID(),
+		Receiver:    req.Receiver,
+		Topic:       topic,
+		Sequence:    message.Sequence,
+		ContentType: types.ContentType(req.ContentType),
+		Body:        req.Body,
+		Mentions:    req.Mentions,
+	}
+	go s.send(ctx, req.ClientID, types.OperationPush, m, req.SID, uids...)
+	if len(req.Mentions) > 0 {
+		n := &types.Notification{
+			Topic: topic,
+			What:  types.WhatTypeMentioned,
 		}
+		go s.send(ctx, req.ClientID, types.OperationNotification, n, "", req.Mentions...)
+	}
+	go s.setLastSequence(ctx, req.ClientID, sender.UID(), message.Topic, message.Sequence)
+	go s.indexMessages(ctx, message)
+
+	go s.InvokeMessageListener(req.ClientID, m)
+	return &api.PushMessageResp{MessageId: message.ID, Sequence: message.Sequence, Topic: message.Topic}, nil
+}
 
-		return nil
+// updateMessage saves the content type, the body and the status of the message, then
+// updates its text in the search index, or removes it from the index once it is recalled
+// or deleted. The edit, recall and delete of a message go through it.
+func (s *Service) updateMessage(ctx context.Context, message *persistence.Message) error {
+	if err := s.persister.Message().Update(ctx, message); err != nil {
+		s.logger(ctx).Error("[UpdateMessage] failed to update message", "id", message.ID, "error", err)
+		return err
 	}
+	go s.indexMessages(ctx, message)
+	return nil
+}
 
-	ch := make(chan error, addMessageRetries)
+// addMessage allocates the next sequence of the topic of the message and adds it. A
+// sequence allocated for a message which could not be added is not given back, since
+// the next one may have been allocated meanwhile, so the sequences of a topic may have
+// gaps. The comets skip a gap once their reorder timeout expired and pulls do not notice
+// it, as they return the messages after a sequence.
+func (s *Service) addMessage(ctx context.Context, message *persistence.Message) error {
+	var reseed bool
 	for i := 0; i < addMessageRetries; i++ {
-		go func() {
-			ch <- add()
-		}()
-
-		select {
-		case <-ctx.Done():
-			return 0, 0, ctx.Err()
-		case err := <-ch:
-			if err == nil {
-				m := &types.Message{
-					ID:          message.ID,
-					CreatedAt:   message.CreatedAt,
-					MessageType: types.MessageType(req.MessageType),
-					Sender:      sender.UID(),
-					Receiver:    req.Receiver,
-					Topic:       topic,
-					Sequence:    message.Sequence,
-					ContentType: types.ContentType(req.ContentType),
-					Body:        req.Body,
-					Mentions:    req.Mentions,
-				}
-				go s.send(types.OperationPush, m, req.SID, uids...)
-				if len(req.Mentions) > 0 {
-					n := &types.Notification{
-						Topic: topic,
-						What:  types.WhatTypeMentioned,
-					}
-					go s.send(types.OperationNotification, n, "", req.Mentions...)
-				}
-				go s.cache.SetUserTopicLastSequence(sender.UID(), message.Topic, message.Sequence)
+		var err error
+		// Get the next sequence of the topic
+		message.Sequence, err = s.nextSequence(ctx, message.ClientID, message.Topic, reseed)
+		if err != nil {
+			s.logger(ctx).Error("[PushMessage] failed to get sequence", "topic", message.Topic, "error", err)
+			return err
+		}
 
-				go s.InvokeMessageListener(req.ClientID, m)
-				return message.ID, message.Sequence, nil
-			}
+		err = s.persister.Message().Add(ctx, message)
+		if err == nil {
+			return nil
+		}
+		s.logger(ctx).Error("[PushMessage] failed to add message", "sequence", message.Sequence, "topic", message.Topic, "error", err)
 
-			// If the error is ErrDataAlreadyExists, it means that the sequence already exists,
-			// try to get the next sequence again,
-			// otherwise return this error
-			if !ecode.EqualError(ecode.ErrDataAlreadyExists, err) {
-				return 0, 0, err
-			}
+		// If the error is ErrDataAlreadyExists, it means that the sequence already exists
+		// and the one of the cache is behind, try to get the next sequence again from the
+		// last one added, otherwise return this error
+		if !ecode.EqualError(ecode.ErrDataAlreadyExists, err) {
+			return err
 		}
+		reseed = true
 	}
 
-	return 0, 0, ecode.ErrInternalServer
+	return ecode.ErrInternalServer
 }
 
 func (s *Service) PullMessage(ctx context.Context, req *api.PullMessageReq) ([]*api.TopicMessages, error) {
-	topicsLastSequence, err := s.cache.GetUserTopicsLastSequence(req.UID)
+	if req.ClientID == "" {
+		return nil, errEmptyClientID
+	}
+	topicsLastSequence, err := s.userTopicsLastSequence(ctx, req.ClientID, req.UID)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(topicsLastSequence) == 0 {
-		topics, err := s.cache.GetUserTopics(req.UID)
-		if err != nil {
-			return nil, err
-		}
-
-		if len(topics) > 0 {
-			topicsLastSequence = make(map[string]int64)
-			for _, topic := range topics {
-				topicsLastSequence[topic] = 0
-			}
-		}
-	}
-
 	var topicMessages []*api.TopicMessages
 	for topic, sequence := range topicsLastSequence {
-		messages, count, err := s.persister.Message().GetTopicMessagesByLastSequence(ctx, topic, sequence)
+		messages, count, err := s.topicMessages(ctx, req.ClientID, topic, sequence)
 		if err != nil {
 			return nil, err
 		}
@@ -195,22 +209,7 @@ func (s *Service) PullMessage(ctx context.Context, req *api.PullMessageReq) ([]*
 		}
 
 		for _, message := range messages {
-			mentions := make([]string, 0)
-			for _, mention := range message.Mentions {
-				mentions = append(mentions, s.EncodeID(mention).UID())
-			}
-			tm.Messages = append(tm.Messages, &api.Message{
-				ID:          message.ID,
-				CreatedAt:   message.CreatedAt,
-				MessageType: message.MessageType.String(),
-				Sender:      s.EncodeID(message.Sender).UID(),
-				Receiver:    s.EncodeID(message.Receiver).UID(),
-				Topic:       message.Topic,
-				Sequence:    message.Sequence,
-				ContentType: message.ContentType.String(),
-				Body:        message.Body,
-				Mentions:    mentions,
-			})
+			tm.Messages = append(tm.Messages, s.apiMessage(message))
 		}
 
 		topicMessages = append(topicMessages, tm)
@@ -219,13 +218,35 @@ func (s *Service) PullMessage(ctx context.Context, req *api.PullMessageReq) ([]*
 	return topicMessages, nil
 }
 
+func (s *Service) apiMessage(message *persistence.Message) *api.Message {
+	mentions := make([]string, 0)
+	for _, mention := range message.Mentions {
+		mentions = append(mentions, s.EncodeID(mention).UID())
+	}
+	return &api.Message{
+		ID:          message.ID,
+		CreatedAt:   message.CreatedAt,
+		MessageType: message.MessageType.String(),
+		Sender:      s.EncodeID(message.Sender).UID(),
+		Receiver:    s.EncodeID(message.Receiver).UID(),
+		Topic:       message.Topic,
+		Sequence:    message.Sequence,
+		ContentType: message.ContentType.String(),
+		Body:        message.Body,
+		Mentions:    mentions,
+	}
+}
+
 func (s *Service) ReadMessage(ctx context.Context, req *api.ReadMessageReq) error {
-	message, err := s.persister.Message().GetTopicMessageBySequence(ctx, req.Topic, req.Sequence)
+	if req.ClientID == "" {
+		return errEmptyClientID
+	}
+	message, err := s.topicMessage(ctx, req.ClientID, req.Topic, req.Sequence)
 	if err != nil {
 		return err
 	}
 
-	err = s.cache.SetUserTopicLastSequence(req.UID, message.Topic, message.Sequence)
+	err = s.setLastSequence(ctx, req.ClientID, req.UID, message.Topic, message.Sequence)
 	if err != nil {
 		return err
 	}
@@ -238,13 +259,16 @@ func (s *Service) ReadMessage(ctx context.Context, req *api.ReadMessageReq) erro
 			MessageID: message.ID,
 		}
 		sender := s.EncodeID(message.Sender).UID()
-		go s.send(types.OperationNotification, n, "", sender)
+		go s.send(ctx, req.ClientID, types.OperationNotification, n, "", sender)
 	}
 
 	return nil
 }
 
 func (s *Service) Keypress(ctx context.Context, req *api.KeypressReq) error {
+	if req.ClientID == "" {
+		return errEmptyClientID
+	}
 	from := types.ParseUID(req.UID)
 
 	u1, u2, err := types.ParseP2P(req.Topic)
@@ -259,19 +283,64 @@ func (s *Service) Keypress(ctx context.Context, req *api.KeypressReq) error {
 		to = u1
 	}
 
+	if to.IsZero() {
+		return ecode.ErrForbidden.ResetMessage("the user is not in the topic")
+	}
+	// The user of another client is never notified, even with its ID
+	check, _ := s.persister.User().CheckActivated(ctx, req.ClientID, to.UID())
+	if !check {
+		return ecode.ErrUserNotActivated
+	}
+
 	n := &types.Notification{
 		Topic: req.Topic,
 		What:  types.WhatTypeKeypress,
 	}
-	go s.send(types.OperationNotification, n, "", to.UID())
+	go s.send(ctx, req.ClientID, types.OperationNotification, n, "", to.UID())
 
 	return nil
 }
 
-func (s *Service) send(op types.Operation, v interface{}, skipSID string, uids ...string) {
-	sessions, _, err := s.cache.GetSessions(uids...)
+// Broadcast sends a message to the sessions of the client matching the filters of the request.
+func (s *Service) Broadcast(ctx context.Context, req *api.BroadcastReq) error {
+	clientID := MustClientIDFromContext(ctx)
+
+	topic := s.config.Topic()
+	broadcastMessageTopic, ok := topic.Get("broadcast_message")
+	if !ok {
+		return ecode.ErrInternalServer.ResetMessage("topic broadcast_message is not configured")
+	}
+	return s.invoke(ctx, broadcastMessageTopic, &api.BroadcastMessage{
+		Data:       req.Body,
+		ClientID:   clientID,
+		Platforms:  req.Platforms,
+		MinVersion: req.MinVersion,
+		UIDs:       req.UIDs,
+		ServerIDs:  req.ServerIDs,
+	})
+}
+
+// pushByTopic reports whether logic routes the pushes in the topic push mode.
+func (s *Service) pushByTopic() bool {
+	srvCfg, ok := s.config.GetService("mercury.logic")
+	return ok && srvCfg.PushMode() == config.PushModeTopic
+}
+
+func (s *Service) send(ctx context.Context, clientID string, op types.Operation, v interface{}, skipSID string, uids ...string) {
+	if s.pushByTopic() {
+		// The messages go to all the users in their topic, the notifications to a few of them
+		if m, ok := v.(*types.Message); ok {
+			s.sendByTopic(ctx, clientID, op, m, skipSID)
+		} else {
+			s.sendByUID(ctx, op, v, skipSID, uids...)
+		}
+		return
+	}
+
+	l := s.logger(ctx)
+	sessions, err := s.userSessions(clientID, uids...)
 	if err != nil {
-		s.log.Warn("[send] failed to get sessions", "error", err)
+		l.Warn("[send] failed to get sessions", "error", err)
 		return
 	}
 
@@ -279,7 +348,7 @@ func (s *Service) send(op types.Operation, v interface{}, skipSID string, uids .
 		servers := make(map[string][]string)
 		for sid, serverID := range sessions {
 			if sid == "" || serverID == "" {
-				s.log.Warn("[send] sid or serverID is empty", "sid", sid, "serverID", serverID, "error", err)
+				l.Warn("[send] sid or serverID is empty", "sid", sid, "serverID", serverID, "error", err)
 				continue
 			}
 			if sid != skipSID {
@@ -289,23 +358,119 @@ func (s *Service) send(op types.Operation, v interface{}, skipSID string, uids .
 
 		data, err := jsoniter.Marshal(v)
 		if err != nil {
-			s.log.Warn("[send] failed to marshal", "error", err)
+			l.Warn("[send] failed to marshal", "error", err)
 			return
 		}
 
-		topic := s.config.Topic()
-		pushMessageTopic, ok := topic.Get("push_message")
+		topic, sequence := orderOf(v)
+		pushedAt := x.UnixMilli(time.Now())
+		topics := s.config.Topic()
+		pushMessageTopic, ok := topics.Get("push_message")
 		if ok {
 			for serverID, sids := range servers {
-				if err := s.invoke(pushMessageTopic, &api.PushMessage{
+				if err := s.invokeOrdered(ctx, pushMessageTopic, topic, &api.PushMessage{
 					Operation: int32(op),
 					ServerID:  serverID,
 					SIDs:      sids,
 					Data:      data,
+					Topic:     topic,
+					Sequence:  sequence,
+					PushedAt:  pushedAt,
 				}); err != nil {
-					s.log.Warn("[send] failed to invoke", "serverID", serverID, "error", err)
+					l.Warn("[send] failed to invoke", "serverID", serverID, "error", err)
 				}
 			}
 		}
 	}
 }
+
+// sendByUID publishes the message once for all the users, comets deliver it to their local
+// sessions of the users, so there is no need to look up the sessions in the cache.
+func (s *Service) sendByUID(ctx context.Context, op types.Operation, v interface{}, skipSID string, uids ...string) {
+	if len(uids) == 0 {
+		return
+	}
+
+	l := s.logger(ctx)
+	data, err := jsoniter.Marshal(v)
+	if err != nil {
+		l.Warn("[sendByUID] failed to marshal", "error", err)
+		return
+	}
+
+	topics := s.config.Topic()
+	pushUIDMessageTopic, ok := topics.Get("push_uid_message")
+	if !ok {
+		l.Warn("[sendByUID] topic push_uid_message is not configured")
+		return
+	}
+	topic, sequence := orderOf(v)
+	if err := s.invokeOrdered(ctx, pushUIDMessageTopic, topic, &api.PushUIDMessage{
+		Operation: int32(op),
+		UIDs:      uids,
+		SkipSID:   skipSID,
+		Data:      data,
+		Topic:     topic,
+		Sequence:  sequence,
+		PushedAt:  x.UnixMilli(time.Now()),
+	}); err != nil {
+		l.Warn("[sendByUID] failed to invoke", "error", err)
+	}
+}
+
+// sendByTopic publishes the message once for its topic, comets deliver it to their local
+// sessions in the topic, so neither the members nor their sessions are looked up.
+func (s *Service) sendByTopic(ctx context.Context, clientID string, op types.Operation, m *types.Message, skipSID string) {
+	data, err := jsoniter.Marshal(m)
+	if err != nil {
+		s.logger(ctx).Warn("[sendByTopic] failed to marshal", "error", err)
+		return
+	}
+
+	s.publishTopic(ctx, &api.PushTopicMessage{
+		Operation: int32(op),
+		ClientID:  clientID,
+		Topic:     m.Topic,
+		SkipSID:   skipSID,
+		Data:      data,
+		Sequence:  m.Sequence,
+		PushedAt:  x.UnixMilli(time.Now()),
+	})
+}
+
+// joinTopic has the sessions of the users join the group topic on their comets, in the
+// topic push mode. The join is ordered with the messages of the topic, the messages
+// following it reach the users.
+func (s *Service) joinTopic(ctx context.Context, clientID, topic string, uids ...string) {
+	if s.pushByTopic() {
+		s.publishTopic(ctx, &api.PushTopicMessage{
+			ClientID: clientID,
+			Topic:    topic,
+			JoinUIDs: uids,
+		})
+	}
+}
+
+func (s *Service) publishTopic(ctx context.Context, pm *api.PushTopicMessage) {
+	topics := s.config.Topic()
+	pushTopicMessageTopic, ok := topics.Get("push_topic_message")
+	if !ok {
+		s.logger(ctx).Warn("[publishTopic] topic push_topic_message is not configured")
+		return
+	}
+	if err := s.invokeOrdered(ctx, pushTopicMessageTopic, pm.Topic, pm); err != nil {
+		s.logger(ctx).Warn("[publishTopic] failed to invoke", "topic", pm.Topic, "error", err)
+	}
+}
+
+// orderOf returns the topic and the sequence of a message, sequence is 0 for
+// notifications which are not ordered.
+func orderOf(v interface{}) (string, int64) {
+	switch m := v.(type) {
+	case *types.Message:
+		return m.Topic, m.Sequence
+	case *types.Notification:
+		return m.Topic, 0
+	}
+	return "", 0
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/stretchr/testify/require"
+	"mercury/app/logic/api"
+	"mercury/app/logic/persistence"
+	"mercury/app/logic/persistence/memory"
+	"mercury/config"
+	"mercury/x/brokerx"
+	"mercury/x/types"
+)
+
+// addMessagesConcurrently adds count messages to the topic from each of the workers at
+// once.
+func addMessagesConcurrently(t *testing.T, s *Service, topic string, workers, count int) {
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*count)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < count; i++ {
+				errs <- s.addMessage(ctx, &persistence.Message{
+					ClientID:    "client",
+					Topic:       topic,
+					MessageType: types.MessageTypeGroup,
+					ContentType: types.ContentTypeText,
+					Body:        []byte(`{}`),
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
+
+// requireSequences checks the messages of the topic have the sequences from 1 to last,
+// each once.
+func requireSequences(t *testing.T, p persistence.Persister, topic string, last int64) {
+	messages, count, err := p.Message().GetTopicMessagesByLastSequence(context.Background(), "client", topic, 0)
+	require.NoError(t, err)
+	require.Equal(t, last, count)
+	seen := make(map[int64]bool)
+	for _, m := range messages {
+		require.False(t, seen[m.Sequence], "duplicate sequence %d", m.Sequence)
+		seen[m.Sequence] = true
+	}
+	for seq := int64(1); seq <= last; seq++ {
+		require.True(t, seen[seq], "missing sequence %d", seq)
+	}
+}
+
+func TestAddMessageConcurrently(t *testing.T) {
+	const (
+		workers = 16
+		count   = 25
+	)
+	p := memory.NewPersister()
+	c := memory.NewCache()
+	s := newTestService(t, WithCacher(c), WithPersister(p))
+
+	// The sequence is missing from the cache, the workers seed it at once
+	addMessagesConcurrently(t, s, "topic", workers, count)
+	requireSequences(t, p, "topic", workers*count)
+
+	// The cache lost the sequence
+	s.cache = memory.NewCache()
+	addMessagesConcurrently(t, s, "topic", workers, count)
+	requireSequences(t, p, "topic", 2*workers*count)
+
+	// The cache is behind the persister, like after a failover losing the latest
+	// increments
+	require.NoError(t, s.cache.SetTopicSequence("client", "topic", 100, 0))
+	addMessagesConcurrently(t, s, "topic", workers, count)
+	requireSequences(t, p, "topic", 3*workers*count)
+
+	sequence, err := s.cache.GetTopicSequence("client", "topic")
+	require.NoError(t, err)
+	require.Equal(t, int64(3*workers*count), sequence)
+}
+
+// receivePushTopicMessages returns the topic pushes published within the wait.
+func receivePushTopicMessages(t *testing.T, ch <-chan *api.PushTopicMessage, wait time.Duration) []*api.PushTopicMessage {
+	var pms []*api.PushTopicMessage
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		select {
+		case pm := <-ch:
+			pms = append(pms, pm)
+		case <-timer.C:
+			return pms
+		}
+	}
+}
+
+func TestPushByTopic(t *testing.T) {
+	cfg := newTestConfig()
+	srvCfg, _ := cfg.GetService("mercury.logic")
+	srvCfg.Config["push_mode"] = config.PushModeTopic
+	b := brokerx.NewMemoryBroker()
+	require.NoError(t, b.Connect())
+	s := newTestServiceConfig(t, cfg, WithBroker(b))
+
+	pushTopicMessageTopic, _ := cfg.Topic.Get("push_topic_message")
+	ch := make(chan *api.PushTopicMessage, 16)
+	_, err := b.Subscribe(pushTopicMessageTopic, func(e broker.Event) error {
+		pm := new(api.PushTopicMessage)
+		require.NoError(t, pm.Unmarshal(e.Message().Body))
+		ch <- pm
+		return nil
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	clientID, _, err := s.CreateClient(ctx, &api.CreateClientReq{Name: "client", TokenSecret: "secret"})
+	require.NoError(t, err)
+	cctx := ContextWithClientID(ctx, clientID)
+	alice, err := s.CreateUser(cctx, &api.CreateUserReq{Name: "alice"})
+	require.NoError(t, err)
+	bob, err := s.CreateUser(cctx, &api.CreateUserReq{Name: "bob"})
+	require.NoError(t, err)
+	for i, uid := range []string{alice, bob} {
+		require.NoError(t, s.cache.AddMapping(clientID, uid, fmt.Sprintf("sid%d", i), "server"))
+	}
+
+	// The members join the topic of the group in order
+	group, err := s.CreateGroup(cctx, &api.CreateGroupReq{Name: "group", Owner: alice})
+	require.NoError(t, err)
+	require.NoError(t, s.AddMember(cctx, &api.AddMemberReq{GID: group.GID, UID: bob}))
+	pms := receivePushTopicMessages(t, ch, 100*time.Millisecond)
+	require.Len(t, pms, 2)
+	for i, uid := range []string{alice, bob} {
+		require.Equal(t, clientID, pms[i].ClientID)
+		require.Equal(t, group.GID, pms[i].Topic)
+		require.Equal(t, []string{uid}, pms[i].JoinUIDs)
+		require.Empty(t, pms[i].Data)
+	}
+
+	// A message is published once to its topic, whatever the number of members
+	pushed, err := s.PushMessage(ctx, &api.PushMessageReq{
+		ClientID:    clientID,
+		SID:         "sid0",
+		Sender:      alice,
+		Receiver:    group.GID,
+		MessageType: api.MessageTypeGroup,
+		ContentType: api.ContentType(types.ContentTypeText),
+		Body:        []byte(`{"content":"hello"}`),
+	})
+	require.NoError(t, err)
+	pms = receivePushTopicMessages(t, ch, 100*time.Millisecond)
+	require.Len(t, pms, 1)
+	require.Equal(t, group.GID, pms[0].Topic)
+	require.Equal(t, "sid0", pms[0].SkipSID)
+	require.Equal(t, group.GID, pushed.Topic)
+	require.Equal(t, pushed.Sequence, pms[0].Sequence)
+	require.Equal(t, int32(types.OperationPush), pms[0].Operation)
+	require.NotEmpty(t, pms[0].Data)
+	require.Empty(t, pms[0].JoinUIDs)
+}
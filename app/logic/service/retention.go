@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"mercury/app/logic/persistence"
+	"mercury/x/ecode"
+)
+
+// The count of messages purged at a time
+const purgeBatchSize = 500
+
+// purgeLoop purges the messages every interval until the service is closed.
+func (s *Service) purgeLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if purged, err := s.Purge(context.Background()); err != nil {
+				s.log.Error("[Purge] failed to purge messages", "purged", purged, "error", err)
+			} else if purged > 0 {
+				s.log.Info("[Purge] messages purged", "purged", purged)
+			}
+		case <-s.purgeDone:
+			return
+		}
+	}
+}
+
+// Purge deletes the messages beyond the retention of the clients and returns their
+// count, they are archived before if the service has an archiver. A client failing to
+// be purged does not stop the others.
+func (s *Service) Purge(ctx context.Context) (int64, error) {
+	clients, err := s.persister.Client().GetRetainedClients(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var purged int64
+	for _, client := range clients {
+		n, e := s.purgeClient(ctx, client)
+		purged += n
+		if e != nil {
+			s.logger(ctx).Error("[Purge] failed to purge messages of client", "client_id", client.ID, "error", e)
+			err = e
+		}
+	}
+	return purged, err
+}
+
+func (s *Service) purgeClient(ctx context.Context, client *persistence.Client) (int64, error) {
+	var before int64
+	if client.MessageMaxAge > 0 {
+		before = time.Now().Add(-client.MessageMaxAge).Unix()
+	}
+
+	var purged int64
+	for {
+		messages, err := s.persister.Message().GetExpiredMessages(ctx, client.ID, before, client.MessageMaxCount, purgeBatchSize)
+		if err != nil || len(messages) == 0 {
+			return purged, err
+		}
+		if s.archiver != nil {
+			if err := s.archiver.Archive(ctx, messages); err != nil {
+				return purged, err
+			}
+		}
+
+		ids := make([]int64, len(messages))
+		for i, message := range messages {
+			ids[i] = message.ID
+		}
+		if err := s.persister.Message().Delete(ctx, client.ID, ids...); err != nil {
+			return purged, err
+		}
+		// The archived messages can still be read, so they are still searched
+		if s.archiver == nil && s.indexer != nil {
+			if err := s.indexer.Remove(ctx, ids...); err != nil {
+				s.logger(ctx).Error("[Purge] failed to remove messages from the search index", "error", err)
+			}
+		}
+		purged += int64(len(messages))
+
+		if len(messages) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// topicMessages returns the messages of the topic after the sequence, the latest first,
+// and their count. The ones purged are read from the archive, the persister keeps the
+// latest message of a topic so there is nothing to read if it has none after sequence.
+func (s *Service) topicMessages(ctx context.Context, clientID, topic string, sequence int64) ([]*persistence.Message, int64, error) {
+	messages, count, err := s.persister.Message().GetTopicMessagesByLastSequence(ctx, clientID, topic, sequence)
+	if err != nil || s.archiver == nil || len(messages) == 0 {
+		return messages, count, err
+	}
+	earliest := messages[len(messages)-1].Sequence
+	if earliest == sequence+1 {
+		return messages, count, nil
+	}
+
+	archived, err := s.archiver.GetTopicMessages(ctx, topic, sequence, earliest)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, message := range archived {
+		if message.ClientID == clientID {
+			messages = append(messages, message)
+			count++
+		}
+	}
+	return messages, count, nil
+}
+
+// topicMessage returns the message of the topic with the sequence, from the archive if
+// it was purged.
+func (s *Service) topicMessage(ctx context.Context, clientID, topic string, sequence int64) (*persistence.Message, error) {
+	message, err := s.persister.Message().GetTopicMessageBySequence(ctx, clientID, topic, sequence)
+	if !ecode.EqualError(ecode.ErrDataDoesNotExist, err) || s.archiver == nil {
+		return message, err
+	}
+
+	message, err = s.archiver.GetTopicMessageBySequence(ctx, topic, sequence)
+	if err == nil && message.ClientID != clientID {
+		return nil, ecode.ErrDataDoesNotExist
+	}
+	return message, err
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"mercury/app/logic/api"
+	"mercury/app/logic/persistence"
+	"mercury/app/logic/persistence/memory"
+	"mercury/x/types"
+)
+
+func TestPurge(t *testing.T) {
+	p := memory.NewPersister()
+	s := newTestService(t, WithPersister(p), WithArchiver(memory.NewArchive()))
+
+	ctx := context.Background()
+	clientID, _, err := s.CreateClient(ctx, &api.CreateClientReq{Name: "client", TokenSecret: "secret", MessageMaxCount: 2})
+	require.NoError(t, err)
+	for seq := int64(1); seq <= 5; seq++ {
+		require.NoError(t, p.Message().Add(ctx, &persistence.Message{
+			ClientID:    clientID,
+			Topic:       "topic",
+			Sequence:    seq,
+			MessageType: types.MessageTypeSingle,
+			ContentType: types.ContentTypeText,
+			Body:        []byte(`{}`),
+		}))
+	}
+
+	purged, err := s.Purge(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), purged)
+	purged, err = s.Purge(ctx)
+	require.NoError(t, err)
+	require.Zero(t, purged)
+	_, count, err := p.Message().GetTopicMessagesByLastSequence(ctx, clientID, "topic", 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	// The history reads the purged messages from the archive
+	uid := s.EncodeID(1).UID()
+	require.NoError(t, s.cache.SetUserTopicLastSequence(clientID, uid, "topic", 1))
+	topics, err := s.PullMessage(ctx, &api.PullMessageReq{ClientID: clientID, UID: uid})
+	require.NoError(t, err)
+	require.Len(t, topics, 1)
+	require.Equal(t, int64(4), topics[0].Count)
+	for i, m := range topics[0].Messages {
+		require.Equal(t, int64(5-i), m.Sequence)
+	}
+
+	require.NoError(t, s.ReadMessage(ctx, &api.ReadMessageReq{ClientID: clientID, UID: uid, Topic: "topic", Sequence: 2}))
+	last, err := s.cache.GetUserTopicsLastSequence(clientID, uid)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"topic": 2}, last)
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	jsoniter "github.com/json-iterator/go"
+	"mercury/app/logic/api"
+	"mercury/x/ecode"
+	"mercury/x/types"
+	"time"
+)
+
+// PushRoomMessage sends a message of a user to a room. Room messages are ephemeral,
+// they are neither persisted nor sequenced.
+func (s *Service) PushRoomMessage(ctx context.Context, req *api.PushRoomMessageReq) error {
+	sampleRate := 1.0
+	if srvCfg, ok := s.config.GetService("mercury.logic"); ok {
+		sampleRate = srvCfg.RoomSampleRate()
+	}
+
+	return s.broadcastRoom(ctx, req.ClientID, req.Room, req.Sender, req.Body, sampleRate)
+}
+
+// BroadcastRoom sends a message of the client to a room.
+func (s *Service) BroadcastRoom(ctx context.Context, req *api.BroadcastRoomReq) error {
+	clientID := MustClientIDFromContext(ctx)
+	return s.broadcastRoom(ctx, clientID, req.Room, "", req.Body, req.SampleRate)
+}
+
+func (s *Service) broadcastRoom(ctx context.Context, clientID, room, sender string, body []byte, sampleRate float64) error {
+	if room == "" {
+		return ecode.ErrBadRequest.ResetMessage("room can not be empty")
+	}
+
+	data, err := jsoniter.Marshal(&types.RoomMessage{
+		CreatedAt: time.Now().Unix(),
+		Room:      room,
+		Sender:    sender,
+		Body:      body,
+	})
+	if err != nil {
+		s.logger(ctx).Error("[BroadcastRoom] failed to marshal", "error", err)
+		return err
+	}
+
+	topic := s.config.Topic()
+	broadcastRoomMessageTopic, ok := topic.Get("broadcast_room_message")
+	if !ok {
+		return ecode.ErrInternalServer.ResetMessage("topic broadcast_room_message is not configured")
+	}
+	return s.invoke(ctx, broadcastRoomMessageTopic, &api.BroadcastRoomMessage{
+		Room:       types.RoomKey(clientID, room),
+		Data:       data,
+		SampleRate: sampleRate,
+	})
+}
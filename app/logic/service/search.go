@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"mercury/app/logic/api"
+	"mercury/app/logic/persistence"
+	"mercury/app/logic/persistence/search"
+	"mercury/app/logic/persistence/sql"
+	"mercury/config"
+	"mercury/x"
+	"mercury/x/database/sqlx"
+	"mercury/x/ecode"
+	"mercury/x/types"
+	"time"
+)
+
+// The count of messages a search returns by default and at most
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// How long an update of the search index may take
+const indexTimeout = 10 * time.Second
+
+func (s *Service) withIndexer() error {
+	if s.indexer != nil {
+		return nil
+	}
+	c := s.config.Search()
+	if c == nil {
+		return nil
+	}
+
+	switch c.Type {
+	case "":
+	case config.SearchTypeEmbedded:
+		idx, err := search.NewEmbeddedIndexer(c.Dir)
+		if err != nil {
+			return err
+		}
+		s.indexer = idx
+	case config.SearchTypePostgres:
+		db, err := sqlx.Open(s.config)
+		if err != nil {
+			return err
+		}
+		idx, err := sql.NewPostgresIndexer(db)
+		if err != nil {
+			_ = db.Close()
+			return err
+		}
+		s.indexer = idx
+	default:
+		return ecode.NewError(x.Sprintf("unknown search type %s", c.Type))
+	}
+	return nil
+}
+
+// indexMessages updates the search index with the messages if there is one. The ones
+// with a normal status are indexed again, replacing their previous text, the recalled or
+// deleted ones are removed. The index is updated once the request returned, so it is not
+// bound to the context of the request but to indexTimeout.
+func (s *Service) indexMessages(ctx context.Context, messages ...*persistence.Message) {
+	if s.indexer == nil {
+		return
+	}
+	ictx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+	defer cancel()
+
+	var indexed []*persistence.Message
+	var removed []int64
+	for _, message := range messages {
+		if types.MessageStatus(message.Status) == types.MessageStatusNormal {
+			indexed = append(indexed, message)
+		} else {
+			removed = append(removed, message.ID)
+		}
+	}
+	if len(indexed) > 0 {
+		if err := s.indexer.Index(ictx, indexed...); err != nil {
+			s.logger(ctx).Error("[Search] failed to index messages", "error", err)
+		}
+	}
+	if len(removed) > 0 {
+		if err := s.indexer.Remove(ictx, removed...); err != nil {
+			s.logger(ctx).Error("[Search] failed to remove messages from the index", "error", err)
+		}
+	}
+}
+
+// userTopics returns the topics of the user, the single chats it took part in and its
+// groups.
+func (s *Service) userTopics(ctx context.Context, clientID, uid string) ([]string, error) {
+	sequences, err := s.userTopicsLastSequence(ctx, clientID, uid)
+	if err != nil {
+		return nil, err
+	}
+	topics := make([]string, 0, len(sequences))
+	for topic := range sequences {
+		topics = append(topics, topic)
+	}
+	groups, err := s.persister.Group().GetGroups(ctx, clientID, s.DecodeID(types.ParseUID(uid)))
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if !x.IsInSlice(topics, group.GID) {
+			topics = append(topics, group.GID)
+		}
+	}
+	return topics, nil
+}
+
+// SearchMessages returns the messages of the topics of the user matching the query, the
+// latest first.
+func (s *Service) SearchMessages(ctx context.Context, req *api.SearchMessagesReq) ([]*api.Message, error) {
+	if s.indexer == nil {
+		return nil, ecode.ErrServiceUnavailable.ResetMessage("search is not enabled")
+	}
+	check, _ := s.persister.User().CheckActivated(ctx, req.ClientID, req.UID)
+	if !check {
+		s.logger(ctx).Error("[SearchMessages] user not activated", "uid", req.UID)
+		return nil, ecode.ErrUserNotActivated
+	}
+
+	topics, err := s.userTopics(ctx, req.ClientID, req.UID)
+	if err != nil {
+		s.logger(ctx).Error("[SearchMessages] failed to get topics", "error", err)
+		return nil, err
+	}
+	if req.Topic != "" {
+		if !x.IsInSlice(topics, req.Topic) {
+			return nil, ecode.ErrForbidden.ResetMessage("the user is not in the topic")
+		}
+		topics = []string{req.Topic}
+	}
+
+	q := &persistence.MessageQuery{
+		ClientID: req.ClientID,
+		Text:     req.Query,
+		Topics:   topics,
+		StartAt:  req.StartAt,
+		EndAt:    req.EndAt,
+		Limit:    defaultSearchLimit,
+	}
+	if req.Sender != "" {
+		q.Sender = s.DecodeID(types.ParseUID(req.Sender))
+	}
+	for _, t := range req.ContentTypes {
+		q.ContentTypes = append(q.ContentTypes, types.ContentType(t))
+	}
+	if req.Limit > 0 {
+		q.Limit = int(req.Limit)
+		if q.Limit > maxSearchLimit {
+			q.Limit = maxSearchLimit
+		}
+	}
+
+	hits, err := s.indexer.Search(ctx, q)
+	if err != nil {
+		s.logger(ctx).Error("[SearchMessages] failed to search", "error", err)
+		return nil, err
+	}
+
+	var messages []*api.Message
+	for _, hit := range hits {
+		message, err := s.topicMessage(ctx, req.ClientID, hit.Topic, hit.Sequence)
+		if ecode.EqualError(ecode.ErrDataDoesNotExist, err) {
+			// Purged since it was indexed
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		// Recalled or deleted since it was indexed
+		if types.MessageStatus(message.Status) != types.MessageStatusNormal {
+			continue
+		}
+		messages = append(messages, s.apiMessage(message))
+	}
+	return messages, nil
+}
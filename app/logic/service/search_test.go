@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"mercury/app/logic/api"
+	"mercury/app/logic/persistence"
+	"mercury/app/logic/persistence/search"
+	"mercury/x/ecode"
+	"mercury/x/types"
+)
+
+func TestSearchMessages(t *testing.T) {
+	idx, err := search.NewEmbeddedIndexer("")
+	require.NoError(t, err)
+	s := newTestService(t, WithIndexer(idx))
+
+	ctx := context.Background()
+	clientID, _, err := s.CreateClient(ctx, &api.CreateClientReq{Name: "client", TokenSecret: "secret"})
+	require.NoError(t, err)
+	cctx := ContextWithClientID(ctx, clientID)
+	createUser := func(name string) string {
+		uid, err := s.CreateUser(cctx, &api.CreateUserReq{Name: name})
+		require.NoError(t, err)
+		return uid
+	}
+	alice, bob, carol := createUser("alice"), createUser("bob"), createUser("carol")
+
+	sequences := make(map[string]int64)
+	add := func(topic, sender string, contentType types.ContentType, body string) *persistence.Message {
+		sequences[topic]++
+		m := &persistence.Message{
+			ClientID:    clientID,
+			Topic:       topic,
+			Sequence:    sequences[topic],
+			Sender:      s.DecodeID(types.ParseUID(sender)),
+			ContentType: contentType,
+			Body:        []byte(body),
+		}
+		require.NoError(t, s.persister.Message().Add(ctx, m))
+		s.indexMessages(ctx, m)
+		return m
+	}
+	aliceBob := types.ParseUID(alice).P2PName(types.ParseUID(bob))
+	aliceCarol := types.ParseUID(alice).P2PName(types.ParseUID(carol))
+	require.NoError(t, s.cache.SetUsersTopic(clientID, []string{alice, bob}, aliceBob))
+	require.NoError(t, s.cache.SetUsersTopic(clientID, []string{alice, carol}, aliceCarol))
+	group, err := s.CreateGroup(cctx, &api.CreateGroupReq{Name: "group", Owner: bob})
+	require.NoError(t, err)
+
+	hello := add(aliceBob, alice, types.ContentTypeText, `{"content":"hello bob"}`)
+	add(aliceBob, bob, types.ContentTypeFile, `{"file_stat":{"filename":"hello.txt"},"hash":"h"}`)
+	add(aliceCarol, alice, types.ContentTypeText, `{"content":"hello carol"}`)
+	groupHello := add(group.GID, bob, types.ContentTypeText, `{"content":"hello group"}`)
+
+	searchMessages := func(req *api.SearchMessagesReq) []string {
+		req.ClientID = clientID
+		messages, err := s.SearchMessages(ctx, req)
+		require.NoError(t, err)
+		var bodies []string
+		for _, m := range messages {
+			bodies = append(bodies, string(m.Body))
+		}
+		return bodies
+	}
+
+	// Bob only finds the messages of his topics
+	require.Equal(t, []string{
+		`{"content":"hello group"}`,
+		`{"file_stat":{"filename":"hello.txt"},"hash":"h"}`,
+		`{"content":"hello bob"}`,
+	}, searchMessages(&api.SearchMessagesReq{UID: bob, Query: "hello"}))
+	require.Equal(t, []string{`{"content":"hello bob"}`},
+		searchMessages(&api.SearchMessagesReq{UID: bob, Query: "hello", Sender: alice}))
+	require.Equal(t, []string{`{"file_stat":{"filename":"hello.txt"},"hash":"h"}`},
+		searchMessages(&api.SearchMessagesReq{UID: bob, Query: "hello", ContentTypes: []api.ContentType{api.ContentType(types.ContentTypeFile)}}))
+	require.Len(t, searchMessages(&api.SearchMessagesReq{UID: bob, Query: "hello", Limit: 1}), 1)
+	require.Equal(t, []string{`{"content":"hello carol"}`},
+		searchMessages(&api.SearchMessagesReq{UID: carol, Query: "hello"}))
+	require.Len(t, searchMessages(&api.SearchMessagesReq{UID: alice, Query: "hello"}), 3)
+
+	_, err = s.SearchMessages(ctx, &api.SearchMessagesReq{ClientID: clientID, UID: carol, Query: "hello", Topic: aliceBob})
+	require.True(t, ecode.EqualError(ecode.ErrForbidden, err))
+
+	// The users of the other clients find nothing
+	otherID, _, err := s.CreateClient(ctx, &api.CreateClientReq{Name: "other", TokenSecret: "secret"})
+	require.NoError(t, err)
+	_, err = s.SearchMessages(ctx, &api.SearchMessagesReq{ClientID: otherID, UID: bob, Query: "hello"})
+	require.True(t, ecode.EqualError(ecode.ErrUserNotActivated, err))
+
+	// An edited message is found by its new text only, a recalled one is not found
+	hello.Body = []byte(`{"content":"goodbye bob"}`)
+	require.NoError(t, s.updateMessage(ctx, hello))
+	groupHello.Status = uint8(types.MessageStatusRecalled)
+	require.NoError(t, s.updateMessage(ctx, groupHello))
+	require.Eventually(t, func() bool {
+		return len(searchMessages(&api.SearchMessagesReq{UID: bob, Query: "hello"})) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{`{"content":"goodbye bob"}`}, searchMessages(&api.SearchMessagesReq{UID: bob, Query: "goodbye"}))
+
+	// A message recalled while it is still indexed is not returned
+	hits, err := idx.Search(ctx, &persistence.MessageQuery{ClientID: clientID, Text: "carol", Topics: []string{aliceCarol}})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	carolMessage, err := s.persister.Message().GetTopicMessageBySequence(ctx, clientID, aliceCarol, 1)
+	require.NoError(t, err)
+	carolMessage.Status = uint8(types.MessageStatusDeleted)
+	require.NoError(t, s.persister.Message().Update(ctx, carolMessage))
+	require.Empty(t, searchMessages(&api.SearchMessagesReq{UID: carol, Query: "hello"}))
+}
@@ -8,18 +8,23 @@ import (
 	"github.com/micro/go-micro/v2/metadata"
 	"github.com/micro/go-micro/v2/server"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 	"mercury/app/logic/api"
 	"mercury/app/logic/auth/jwt"
 	"mercury/app/logic/auth/token"
 	"mercury/app/logic/persistence"
+	"mercury/app/logic/persistence/archive"
 	"mercury/app/logic/persistence/cache"
 	"mercury/app/logic/persistence/sql"
 	"mercury/config"
+	"mercury/x/brokerx"
 	"mercury/x/database/redis"
 	"mercury/x/database/sqlx"
 	"mercury/x/ecode"
 	"mercury/x/hash"
 	"mercury/x/log"
+	"mercury/x/stat"
+	"mercury/x/tracing"
 	"mercury/x/types"
 	"reflect"
 	"strings"
@@ -27,6 +32,9 @@ import (
 )
 
 type Servicer interface {
+	PingCache() error
+	PingPersister() error
+
 	Authenticate(token string, out interface{}) (string, error)
 
 	GetClient(ctx context.Context) (*api.Client, error)
@@ -36,7 +44,7 @@ type Servicer interface {
 	GenerateToken(ctx context.Context, req *api.GenerateTokenReq) (string, string, error)
 	Listen(ctx context.Context, token string, stream api.ChatClientAdmin_ListenStream) error
 
-	Connect(ctx context.Context, req *api.ConnectReq) (string, string, error)
+	Connect(ctx context.Context, req *api.ConnectReq) (*api.ConnectResp, error)
 	Disconnect(ctx context.Context, req *api.DisconnectReq) error
 	Heartbeat(ctx context.Context, req *api.HeartbeatReq) error
 
@@ -45,10 +53,14 @@ type Servicer interface {
 	AddMember(ctx context.Context, req *api.AddMemberReq) error
 	GetMembers(ctx context.Context, gid string) ([]string, error)
 
-	PushMessage(ctx context.Context, req *api.PushMessageReq) (int64, int64, error)
+	PushMessage(ctx context.Context, req *api.PushMessageReq) (*api.PushMessageResp, error)
 	PullMessage(ctx context.Context, req *api.PullMessageReq) ([]*api.TopicMessages, error)
 	ReadMessage(ctx context.Context, req *api.ReadMessageReq) error
+	SearchMessages(ctx context.Context, req *api.SearchMessagesReq) ([]*api.Message, error)
 	Keypress(ctx context.Context, req *api.KeypressReq) error
+	PushRoomMessage(ctx context.Context, req *api.PushRoomMessageReq) error
+	BroadcastRoom(ctx context.Context, req *api.BroadcastRoomReq) error
+	Broadcast(ctx context.Context, req *api.BroadcastReq) error
 
 	CreateUser(ctx context.Context, req *api.CreateUserReq) (string, error)
 	UpdateActivated(ctx context.Context, uid string, activated bool) error
@@ -67,15 +79,64 @@ type Service struct {
 	hash      hash.Hasher
 	cache     persistence.Cacher
 	persister persistence.Persister
+	archiver  persistence.Archiver
+	indexer   persistence.Indexer
+	broker    broker.Broker
+	topics    *topicBuffer
+	local     *localCache
+	// Subscription to the invalidations of the local cache published by the logics, nil
+	// if they are not published
+	invalidations broker.Subscriber
 
 	idGen types.IDGenerator
 
 	messageChan       map[string]chan *types.Message
 	brokerMessageChan chan *PublishMessage
 	doneChan          chan struct{}
+	purgeDone         chan struct{}
+	topicsDone        chan struct{}
+}
+
+// Option configures the service created by NewService.
+type Option func(s *Service)
+
+// WithCacher makes the service use c instead of the redis cache of the config.
+func WithCacher(c persistence.Cacher) Option {
+	return func(s *Service) {
+		s.cache = c
+	}
+}
+
+// WithPersister makes the service use p instead of the database of the config, the
+// schema of p is not migrated.
+func WithPersister(p persistence.Persister) Option {
+	return func(s *Service) {
+		s.persister = p
+	}
+}
+
+// WithArchiver makes the service use a instead of the archive of the config.
+func WithArchiver(a persistence.Archiver) Option {
+	return func(s *Service) {
+		s.archiver = a
+	}
+}
+
+// WithIndexer makes the service use x instead of the search index of the config.
+func WithIndexer(x persistence.Indexer) Option {
+	return func(s *Service) {
+		s.indexer = x
+	}
+}
+
+// WithBroker makes the service publish to b instead of the default broker.
+func WithBroker(b broker.Broker) Option {
+	return func(s *Service) {
+		s.broker = b
+	}
 }
 
-func NewService(c config.Provider, l log.Logger) (*Service, error) {
+func NewService(c config.Provider, l log.Logger, opts ...Option) (*Service, error) {
 	s := &Service{
 		config:            c,
 		log:               l,
@@ -83,6 +144,12 @@ func NewService(c config.Provider, l log.Logger) (*Service, error) {
 		messageChan:       make(map[string]chan *types.Message),
 		brokerMessageChan: make(chan *PublishMessage, 4096),
 		doneChan:          make(chan struct{}),
+		purgeDone:         make(chan struct{}),
+		topics:            newTopicBuffer(),
+		topicsDone:        make(chan struct{}),
+	}
+	for _, o := range opts {
+		o(s)
 	}
 
 	err := s.withTokenAuthenticator()
@@ -101,16 +168,61 @@ func NewService(c config.Provider, l log.Logger) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = s.withArchiver()
+	if err != nil {
+		return nil, err
+	}
+	err = s.withIndexer()
+	if err != nil {
+		return nil, err
+	}
 	err = s.withIDGenerator()
 	if err != nil {
 		return nil, err
 	}
+	err = s.withLocalCache()
+	if err != nil {
+		return nil, err
+	}
 
 	go s.process()
+	if interval := c.Database().PurgeInterval; interval > 0 {
+		go s.purgeLoop(interval)
+	}
+	if interval := c.Database().TopicFlushInterval; interval > 0 {
+		go s.flushTopicsLoop(interval)
+	}
 	return s, nil
 }
 
+// PingCache checks the connection to the cache.
+func (s *Service) PingCache() error {
+	if s.cache == nil {
+		return ecode.NewError("cache is not initialized")
+	}
+	return s.cache.Ping()
+}
+
+// PingPersister checks the connection to the database.
+func (s *Service) PingPersister() error {
+	if s.persister == nil {
+		return ecode.NewError("persister is not initialized")
+	}
+	return s.persister.Ping()
+}
+
 func (s *Service) Close() error {
+	if s.invalidations != nil {
+		if err := s.invalidations.Unsubscribe(); err != nil {
+			return err
+		}
+	}
+	close(s.topicsDone)
+	if s.persister != nil {
+		if err := s.flushTopics(context.Background()); err != nil {
+			return err
+		}
+	}
 	if s.cache != nil {
 		if err := s.cache.Close(); err != nil {
 			return err
@@ -121,6 +233,17 @@ func (s *Service) Close() error {
 			return err
 		}
 	}
+	if s.archiver != nil {
+		if err := s.archiver.Close(); err != nil {
+			return err
+		}
+	}
+	if s.indexer != nil {
+		if err := s.indexer.Close(); err != nil {
+			return err
+		}
+	}
+	close(s.purgeDone)
 	s.doneChan <- struct{}{}
 	close(s.doneChan)
 	for _, c := range s.messageChan {
@@ -130,22 +253,36 @@ func (s *Service) Close() error {
 }
 
 func (s *Service) process() {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
 	for {
 		select {
+		case <-ticker.C:
+			stat.Queue.State("mercury.logic", int64(len(s.brokerMessageChan)), "broker_message")
 		case m := <-s.brokerMessageChan:
-			if err := broker.Publish(m.Topic, m.Message); err != nil {
+			if err := s.publisher().Publish(m.Topic, m.Message); err != nil {
 				s.log.Error("failed to publish message", "error", err)
 				return
 			}
 		case <-s.doneChan:
 			close(s.brokerMessageChan)
-			if err := broker.Disconnect(); err != nil {
+			if err := s.publisher().Disconnect(); err != nil {
 				s.log.Warn("failed to disconnecting broker", "error", err)
 			}
 		}
 	}
 }
 
+// publisher returns the broker of the service, the default broker of go-micro if it has
+// none, e.g. in the commands.
+func (s *Service) publisher() broker.Broker {
+	if s.broker != nil {
+		return s.broker
+	}
+	return broker.DefaultBroker
+}
+
 func (s *Service) withTokenAuthenticator() error {
 	var err error
 	if s.token, err = token.NewAuthenticator(s.config); err != nil {
@@ -163,6 +300,9 @@ func (s *Service) withJWTAuthenticator() error {
 }
 
 func (s *Service) withCache() error {
+	if s.cache != nil {
+		return nil
+	}
 	c, err := redis.NewClient(s.config)
 	if err != nil {
 		return err
@@ -180,18 +320,6 @@ func (s *Service) withPersister() error {
 	bc.Reset()
 	return errors.WithStack(
 		backoff.Retry(func() error {
-			//gormDB, err := orm.NewORM(s.config)
-			//if err != nil {
-			//	return err
-			//}
-			//gormDB.AutoMigrate(
-			//	new(entity.Client),
-			//	new(entity.User),
-			//	new(entity.Friend),
-			//	new(entity.Group),
-			//	new(entity.GroupMember),
-			//	new(entity.Message),
-			//)
 			db, err := sqlx.Open(s.config)
 			if err != nil {
 				return err
@@ -201,12 +329,58 @@ func (s *Service) withPersister() error {
 				s.log.Error("unable to ping the persister, retrying", "error", err)
 				return err
 			}
+			if s.config.Database().AutoMigrate {
+				if err := s.migrate(db); err != nil {
+					_ = p.Close()
+					return backoff.Permanent(err)
+				}
+			}
 			s.persister = p
 			return nil
 		}, bc),
 	)
 }
 
+func (s *Service) withArchiver() error {
+	if s.archiver != nil {
+		return nil
+	}
+	if dir := s.config.Database().ArchiveDir; dir != "" {
+		a, err := archive.NewFileArchiver(dir)
+		if err != nil {
+			return err
+		}
+		s.archiver = a
+	}
+	return nil
+}
+
+func (s *Service) withLocalCache() error {
+	if srvCfg, ok := s.config.GetService("mercury.logic"); ok {
+		if ttl := srvCfg.LocalCacheTTL(); ttl > 0 {
+			s.local = newLocalCache(ttl)
+			return s.subscribeInvalidations()
+		}
+	}
+	return nil
+}
+
+// migrate applies the pending migrations of the schema.
+func (s *Service) migrate(db *sqlx.DB) error {
+	m, err := sql.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	applied, err := m.Up(context.Background())
+	for _, mig := range applied {
+		s.log.Info("[Migrate] migration applied", "version", mig.Version, "name", mig.Name)
+	}
+	if err != nil {
+		s.log.Error("[Migrate] failed to migrate", "error", err)
+	}
+	return err
+}
+
 func (s *Service) withIDGenerator() error {
 	if err := s.idGen.Init(s.config.Generator().ID); err != nil {
 		return err
@@ -223,17 +397,35 @@ type marshaler interface {
 	Marshal() ([]byte, error)
 }
 
-func (s *Service) invoke(topic string, m marshaler) error {
+func (s *Service) invoke(ctx context.Context, topic string, m marshaler) error {
+	return s.invokeOrdered(ctx, topic, "", m)
+}
+
+// invokeOrdered publishes the message with a partition key, messages with the same key
+// keep their order through the broker and the job. The trace context in ctx is handed
+// to the job in the headers of the message.
+func (s *Service) invokeOrdered(ctx context.Context, topic, key string, m marshaler) error {
+	ctx, span := tracing.Start(ctx, topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(tracing.String("messaging.destination", topic)))
+	defer span.End()
+
 	body, err := m.Marshal()
 	if err != nil {
 		return err
 	}
 
+	message := &broker.Message{
+		Header: make(map[string]string),
+		Body:   body,
+	}
+	if key != "" {
+		message.Header[brokerx.PartitionKey] = key
+	}
+	tracing.InjectMessage(ctx, message)
 	s.brokerMessageChan <- &PublishMessage{
-		Topic: topic,
-		Message: &broker.Message{
-			Body: body,
-		},
+		Topic:   topic,
+		Message: message,
 	}
 	return nil
 }
@@ -275,6 +467,42 @@ func AuthenticateClientToken(srv Servicer) server.HandlerWrapper {
 	}
 }
 
+// RequestLogger hands the handlers a logger with the endpoint, the client, session and
+// user IDs and the trace of the request, it must wrap the handlers after the client is
+// authenticated.
+func RequestLogger(l log.Logger) server.HandlerWrapper {
+	return func(fn server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			rl := l.New("endpoint", req.Endpoint())
+			if clientID, ok := ClientIDFromContext(ctx); ok {
+				rl = rl.New("client_id", clientID)
+			}
+			v := reflect.ValueOf(req.Body())
+			if v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Struct {
+				v = v.Elem()
+				if f := v.FieldByName("SID"); f.Kind() == reflect.String && f.String() != "" {
+					rl = rl.New("sid", f.String())
+				}
+				for _, name := range []string{"UID", "Sender"} {
+					if f := v.FieldByName(name); f.Kind() == reflect.String && f.String() != "" {
+						rl = rl.New(log.UIDKey, f.String())
+						break
+					}
+				}
+			}
+			return fn(log.NewContext(ctx, tracing.Logger(ctx, rl)), req, rsp)
+		}
+	}
+}
+
+// logger returns the logger of the request in ctx.
+func (s *Service) logger(ctx context.Context) log.Logger {
+	if l, ok := log.FromContext(ctx); ok {
+		return l
+	}
+	return tracing.Logger(ctx, s.log)
+}
+
 func (s *Service) Authenticate(token string, out interface{}) (lifetime string, err error) {
 	lifetime, err = s.token.Authenticate(token, out)
 	if err != nil {
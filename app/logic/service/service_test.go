@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"mercury/app/logic/persistence/memory"
+	"mercury/config"
+	"mercury/x/log"
+)
+
+// newTestConfig returns the config of the services of the tests, the topics of the
+// users are only written by flushTopics.
+func newTestConfig() *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.Database.PurgeInterval = 0
+	cfg.Database.TopicFlushInterval = time.Hour
+	return cfg
+}
+
+// newTestService returns a service of newTestConfig on the memory cache and persister,
+// the options may replace them.
+func newTestService(tb testing.TB, opts ...Option) *Service {
+	return newTestServiceConfig(tb, newTestConfig(), opts...)
+}
+
+func newTestServiceConfig(tb testing.TB, cfg *config.Config, opts ...Option) *Service {
+	l := log.New()
+	l.SetHandler(log.DiscardHandler())
+	opts = append([]Option{WithCacher(memory.NewCache()), WithPersister(memory.NewPersister())}, opts...)
+	s, err := NewService(config.NewProviderConfig(cfg), l, opts...)
+	require.NoError(tb, err)
+	return s
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"mercury/app/logic/persistence"
+	"mercury/x/types"
+)
+
+const (
+	// The count of pending topics making them written before the flush interval
+	topicBatchSize = 500
+	// The count of users rebuilt at a time
+	rebuildBatchSize = 500
+)
+
+// topicBuffer holds the topics of the users and their read cursors not yet written to
+// the persister, a topic added again keeps the greatest last sequence.
+type topicBuffer struct {
+	mu      sync.Mutex
+	count   int
+	pending map[persistence.TopicUser]map[string]int64
+	// Signaled when the buffer holds topicBatchSize topics
+	full chan struct{}
+}
+
+func newTopicBuffer() *topicBuffer {
+	return &topicBuffer{
+		pending: make(map[persistence.TopicUser]map[string]int64),
+		full:    make(chan struct{}, 1),
+	}
+}
+
+// add adds the topics and reports whether the buffer holds topicBatchSize topics.
+func (b *topicBuffer) add(topics ...*persistence.UserTopic) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, topic := range topics {
+		user := persistence.TopicUser{ClientID: topic.ClientID, UserID: topic.UserID}
+		sequences, ok := b.pending[user]
+		if !ok {
+			sequences = make(map[string]int64)
+			b.pending[user] = sequences
+		}
+		sequence, ok := sequences[topic.Topic]
+		if !ok {
+			b.count++
+		}
+		if !ok || topic.LastSequence > sequence {
+			sequences[topic.Topic] = topic.LastSequence
+		}
+	}
+	return b.count >= topicBatchSize
+}
+
+// take empties the buffer and returns its topics.
+func (b *topicBuffer) take() []*persistence.UserTopic {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	topics := make([]*persistence.UserTopic, 0, b.count)
+	for user, sequences := range b.pending {
+		for topic, sequence := range sequences {
+			topics = append(topics, &persistence.UserTopic{ClientID: user.ClientID, UserID: user.UserID, Topic: topic, LastSequence: sequence})
+		}
+	}
+	b.pending = make(map[persistence.TopicUser]map[string]int64)
+	b.count = 0
+	return topics
+}
+
+// userTopics returns the pending topics of the user.
+func (b *topicBuffer) userTopics(clientID string, userID int64) map[string]int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	pending := b.pending[persistence.TopicUser{ClientID: clientID, UserID: userID}]
+	topics := make(map[string]int64, len(pending))
+	for topic, sequence := range pending {
+		topics[topic] = sequence
+	}
+	return topics
+}
+
+// flushTopicsLoop writes the pending topics every interval, or once the buffer is full,
+// until the service is closed.
+func (s *Service) flushTopicsLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.topics.full:
+		case <-s.topicsDone:
+			return
+		}
+		_ = s.flushTopics(context.Background())
+	}
+}
+
+// flushTopics writes the pending topics to the persister, they are kept pending if it
+// fails.
+func (s *Service) flushTopics(ctx context.Context) error {
+	topics := s.topics.take()
+	if len(topics) == 0 {
+		return nil
+	}
+	if err := s.persister.Topic().Save(ctx, topics...); err != nil {
+		s.logger(ctx).Error("[FlushTopics] failed to save topics", "count", len(topics), "error", err)
+		s.topics.add(topics...)
+		return err
+	}
+	return nil
+}
+
+// saveTopics writes the topics to the persister, in the next flush if the writes are
+// batched.
+func (s *Service) saveTopics(ctx context.Context, topics ...*persistence.UserTopic) error {
+	if s.config.Database().TopicFlushInterval > 0 {
+		if s.topics.add(topics...) {
+			select {
+			case s.topics.full <- struct{}{}:
+			default:
+			}
+		}
+		return nil
+	}
+	return s.persister.Topic().Save(ctx, topics...)
+}
+
+// addUsersTopic adds the topic to the users, the errors are only logged.
+func (s *Service) addUsersTopic(ctx context.Context, clientID string, uids []string, topic string) {
+	topics := make([]*persistence.UserTopic, 0, len(uids))
+	for _, uid := range uids {
+		topics = append(topics, &persistence.UserTopic{ClientID: clientID, UserID: s.DecodeID(types.ParseUID(uid)), Topic: topic})
+	}
+	if err := s.saveTopics(ctx, topics...); err != nil {
+		s.logger(ctx).Error("[AddUsersTopic] failed to save topic", "topic", topic, "error", err)
+	}
+	if err := s.cache.SetUsersTopic(clientID, uids, topic); err != nil {
+		s.logger(ctx).Error("[AddUsersTopic] failed to cache topic", "topic", topic, "error", err)
+	}
+}
+
+// setLastSequence sets the sequence of the last message of the topic the user read.
+func (s *Service) setLastSequence(ctx context.Context, clientID, uid, topic string, sequence int64) error {
+	err := s.saveTopics(ctx, &persistence.UserTopic{ClientID: clientID, UserID: s.DecodeID(types.ParseUID(uid)), Topic: topic, LastSequence: sequence})
+	if err != nil {
+		return err
+	}
+	return s.cache.SetUserTopicLastSequence(clientID, uid, topic, sequence)
+}
+
+// topicSequence returns the sequence of the last message of the topic in the cache, 0 if
+// it is not cached.
+func (s *Service) topicSequence(clientID, topic string) int64 {
+	sequence, err := s.cache.GetTopicSequence(clientID, topic)
+	if err != nil {
+		return 0
+	}
+	return sequence
+}
+
+// userTopicsLastSequence returns the topics of the user with the last sequences it read,
+// 0 if none. A user without topics in the cache is taken for a miss, the cache of the
+// user is rebuilt from the persister.
+func (s *Service) userTopicsLastSequence(ctx context.Context, clientID, uid string) (map[string]int64, error) {
+	topics, err := s.cache.GetUserTopics(clientID, uid)
+	if err != nil {
+		return nil, err
+	}
+	sequences, err := s.cache.GetUserTopicsLastSequence(clientID, uid)
+	if err != nil {
+		return nil, err
+	}
+	if len(topics) == 0 {
+		return s.loadUserTopics(ctx, clientID, uid, sequences)
+	}
+
+	for _, topic := range topics {
+		if _, ok := sequences[topic]; !ok {
+			sequences[topic] = 0
+		}
+	}
+	return sequences, nil
+}
+
+// loadUserTopics merges the topics of the user in the persister, its groups and its
+// pending topics into sequences, and caches them.
+func (s *Service) loadUserTopics(ctx context.Context, clientID, uid string, sequences map[string]int64) (map[string]int64, error) {
+	userID := s.DecodeID(types.ParseUID(uid))
+	merge := func(topic string, sequence int64) {
+		if last, ok := sequences[topic]; !ok || sequence > last {
+			sequences[topic] = sequence
+		}
+	}
+
+	topics, err := s.persister.Topic().GetUserTopics(ctx, clientID, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, topic := range topics {
+		merge(topic.Topic, topic.LastSequence)
+	}
+	groups, err := s.persister.Group().GetGroups(ctx, clientID, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		merge(group.GID, 0)
+	}
+	for topic, sequence := range s.topics.userTopics(clientID, userID) {
+		merge(topic, sequence)
+	}
+
+	if err := s.cache.SetUserTopics(clientID, uid, sequences); err != nil {
+		s.logger(ctx).Error("[LoadUserTopics] failed to cache topics", "uid", uid, "error", err)
+	}
+	return sequences, nil
+}
+
+// RebuildCache writes the topics of all the users and their read cursors from the
+// persister to the cache, it recovers the cache after its data is lost. It returns the
+// count of users rebuilt.
+func (s *Service) RebuildCache(ctx context.Context) (int64, error) {
+	if err := s.flushTopics(ctx); err != nil {
+		return 0, err
+	}
+
+	var (
+		rebuilt int64
+		after   int64
+	)
+	for {
+		users, err := s.persister.Topic().GetUsers(ctx, after, rebuildBatchSize)
+		if err != nil || len(users) == 0 {
+			return rebuilt, err
+		}
+		for _, user := range users {
+			topics, err := s.persister.Topic().GetUserTopics(ctx, user.ClientID, user.UserID)
+			if err != nil {
+				return rebuilt, err
+			}
+			sequences := make(map[string]int64, len(topics))
+			for _, topic := range topics {
+				sequences[topic.Topic] = topic.LastSequence
+			}
+			if err := s.cache.SetUserTopics(user.ClientID, s.EncodeID(user.UserID).UID(), sequences); err != nil {
+				return rebuilt, err
+			}
+			rebuilt++
+		}
+		after = users[len(users)-1].UserID
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"mercury/app/logic/api"
+	"mercury/app/logic/persistence"
+	"mercury/app/logic/persistence/memory"
+	"mercury/x/types"
+)
+
+func TestUserTopics(t *testing.T) {
+	p := memory.NewPersister()
+	s := newTestService(t, WithPersister(p))
+
+	ctx := context.Background()
+	clientID, _, err := s.CreateClient(ctx, &api.CreateClientReq{Name: "client", TokenSecret: "secret"})
+	require.NoError(t, err)
+	cctx := ContextWithClientID(ctx, clientID)
+	alice, err := s.CreateUser(cctx, &api.CreateUserReq{Name: "alice"})
+	require.NoError(t, err)
+	bob, err := s.CreateUser(cctx, &api.CreateUserReq{Name: "bob"})
+	require.NoError(t, err)
+	aliceID := s.DecodeID(types.ParseUID(alice))
+
+	topic := types.ParseUID(alice).P2PName(types.ParseUID(bob))
+	for seq := int64(1); seq <= 3; seq++ {
+		require.NoError(t, p.Message().Add(ctx, &persistence.Message{
+			ClientID:    clientID,
+			Topic:       topic,
+			Sequence:    seq,
+			MessageType: types.MessageTypeSingle,
+			ContentType: types.ContentTypeText,
+			Body:        []byte(`{}`),
+		}))
+	}
+	s.addUsersTopic(ctx, clientID, []string{alice, bob}, topic)
+	require.NoError(t, s.setLastSequence(ctx, clientID, alice, topic, 2))
+
+	// The writes are batched
+	topics, err := p.Topic().GetUserTopics(ctx, clientID, aliceID)
+	require.NoError(t, err)
+	require.Empty(t, topics)
+	sequences, err := s.userTopicsLastSequence(ctx, clientID, alice)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{topic: 2}, sequences)
+
+	// A lost cache is rebuilt from the pending topics
+	s.cache = memory.NewCache()
+	sequences, err = s.userTopicsLastSequence(ctx, clientID, alice)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{topic: 2}, sequences)
+
+	require.NoError(t, s.flushTopics(ctx))
+	topics, err = p.Topic().GetUserTopics(ctx, clientID, aliceID)
+	require.NoError(t, err)
+	require.Equal(t, []*persistence.UserTopic{{ClientID: clientID, UserID: aliceID, Topic: topic, LastSequence: 2}}, topics)
+
+	// and from the persister, the messages read are not pulled again
+	s.cache = memory.NewCache()
+	pulled, err := s.PullMessage(ctx, &api.PullMessageReq{ClientID: clientID, UID: alice})
+	require.NoError(t, err)
+	require.Len(t, pulled, 1)
+	require.Equal(t, int64(1), pulled[0].Count)
+	require.Equal(t, int64(3), pulled[0].Messages[0].Sequence)
+	cached, err := s.cache.GetUserTopics(clientID, alice)
+	require.NoError(t, err)
+	require.Equal(t, []string{topic}, cached)
+
+	// A last sequence never goes back in the persister
+	require.NoError(t, s.setLastSequence(ctx, clientID, alice, topic, 1))
+	require.NoError(t, s.setLastSequence(ctx, clientID, alice, topic, 3))
+	require.NoError(t, s.setLastSequence(ctx, clientID, alice, topic, 1))
+	require.NoError(t, s.flushTopics(ctx))
+	topics, err = p.Topic().GetUserTopics(ctx, clientID, aliceID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), topics[0].LastSequence)
+}
+
+func TestRebuildCache(t *testing.T) {
+	p := memory.NewPersister()
+	s := newTestService(t, WithPersister(p))
+
+	ctx := context.Background()
+	uids := []string{s.EncodeID(1).UID(), s.EncodeID(2).UID(), s.EncodeID(3).UID()}
+	s.addUsersTopic(ctx, "client", uids, "group")
+	s.addUsersTopic(ctx, "client", uids[:2], "p2p")
+	s.addUsersTopic(ctx, "other", []string{s.EncodeID(4).UID()}, "other")
+	require.NoError(t, s.setLastSequence(ctx, "client", uids[0], "group", 5))
+
+	s.cache = memory.NewCache()
+	rebuilt, err := s.RebuildCache(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), rebuilt)
+
+	topics, err := s.cache.GetUserTopics("client", uids[0])
+	require.NoError(t, err)
+	require.Equal(t, []string{"group", "p2p"}, topics)
+	sequences, err := s.cache.GetUserTopicsLastSequence("client", uids[0])
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"group": 5}, sequences)
+	topics, err = s.cache.GetUserTopics("client", uids[2])
+	require.NoError(t, err)
+	require.Equal(t, []string{"group"}, topics)
+	// The users are rebuilt in the keys of their clients
+	topics, err = s.cache.GetUserTopics("other", s.EncodeID(4).UID())
+	require.NoError(t, err)
+	require.Equal(t, []string{"other"}, topics)
+	topics, err = s.cache.GetUserTopics("client", s.EncodeID(4).UID())
+	require.NoError(t, err)
+	require.Empty(t, topics)
+}
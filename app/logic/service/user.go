@@ -17,7 +17,7 @@ func (s *Service) CreateUser(ctx context.Context, req *api.CreateUserReq) (strin
 		UID:      id.UID(),
 	}
 	if err := s.persister.User().Create(ctx, in); err != nil {
-		s.log.Error("[CreateUser] failed to create user", "client_id", clientID, "name", req.Name, "error", err)
+		s.logger(ctx).Error("[CreateUser] failed to create user", "name", req.Name, "error", err)
 		return "", err
 	}
 
@@ -25,8 +25,9 @@ func (s *Service) CreateUser(ctx context.Context, req *api.CreateUserReq) (strin
 }
 
 func (s *Service) UpdateActivated(ctx context.Context, uid string, activated bool) error {
-	if err := s.persister.User().UpdateActivated(ctx, s.DecodeID(types.ParseUID(uid)), activated); err != nil {
-		s.log.Error("[UpdateActivated] failed to update user activated", "uid", uid, "activated", activated, "error", err)
+	clientID := MustClientIDFromContext(ctx)
+	if err := s.persister.User().UpdateActivated(ctx, clientID, s.DecodeID(types.ParseUID(uid)), activated); err != nil {
+		s.logger(ctx).Error("[UpdateActivated] failed to update user activated", "uid", uid, "activated", activated, "error", err)
 		return err
 	}
 
@@ -34,8 +35,9 @@ func (s *Service) UpdateActivated(ctx context.Context, uid string, activated boo
 }
 
 func (s *Service) DeleteUser(ctx context.Context, uid string) error {
-	if err := s.persister.User().Delete(ctx, s.DecodeID(types.ParseUID(uid))); err != nil {
-		s.log.Error("[DeleteUser] failed to delete client", "uid", uid, "error", err)
+	clientID := MustClientIDFromContext(ctx)
+	if err := s.persister.User().Delete(ctx, clientID, s.DecodeID(types.ParseUID(uid))); err != nil {
+		s.logger(ctx).Error("[DeleteUser] failed to delete client", "uid", uid, "error", err)
 		return err
 	}
 
@@ -50,7 +52,7 @@ func (s *Service) AddFriend(ctx context.Context, uid, friendUID string) error {
 		FriendUserID: s.idGen.DecodeID(types.ParseUID(friendUID)),
 	}
 	if err := s.persister.User().AddFriend(ctx, in); err != nil {
-		s.log.Error("[DeleteUser] failed to delete client", "uid", uid, "error", err)
+		s.logger(ctx).Error("[DeleteUser] failed to delete client", "uid", uid, "error", err)
 		return err
 	}
 
@@ -65,7 +67,7 @@ func (s *Service) DeleteFriend(ctx context.Context, uid, friendUID string) error
 		FriendUserID: s.idGen.DecodeID(types.ParseUID(friendUID)),
 	}
 	if err := s.persister.User().DeleteFriend(ctx, in); err != nil {
-		s.log.Error("[DeleteUser] failed to delete client", "uid", uid, "error", err)
+		s.logger(ctx).Error("[DeleteUser] failed to delete client", "uid", uid, "error", err)
 		return err
 	}
 
@@ -76,13 +78,13 @@ func (s *Service) GenerateUserToken(ctx context.Context, uid string) (string, st
 	clientID := MustClientIDFromContext(ctx)
 	client, err := s.getClient(ctx, clientID)
 	if err != nil {
-		s.log.Error("[GenerateUserToken] failed to get client", "client_id", clientID, "error", err)
+		s.logger(ctx).Error("[GenerateUserToken] failed to get client", "error", err)
 		return "", "", err
 	}
 
 	token, lifetime, err := s.jwt.GenerateToken(client.Name, client.TokenSecret, client.TokenExpire, uid)
 	if err != nil {
-		s.log.Error("[GenerateUserToken] failed to generate token", "uid", uid, "error", err)
+		s.logger(ctx).Error("[GenerateUserToken] failed to generate token", "uid", uid, "error", err)
 		return "", "", err
 	}
 
@@ -93,9 +95,9 @@ func (s *Service) GenerateUserToken(ctx context.Context, uid string) (string, st
 
 func (s *Service) GetFriends(ctx context.Context, uid string) ([]string, error) {
 	clientID := MustClientIDFromContext(ctx)
-	friendIDs, err := s.persister.User().GetFriends(ctx, s.DecodeID(types.ParseUID(uid)))
+	friendIDs, err := s.persister.User().GetFriends(ctx, clientID, s.DecodeID(types.ParseUID(uid)))
 	if err != nil {
-		s.log.Error("[GetFriends] failed to get friends", "client_id", clientID, "uid", uid, "error", err)
+		s.logger(ctx).Error("[GetFriends] failed to get friends", "client_id", clientID, "uid", uid, "error", err)
 		return nil, err
 	}
 
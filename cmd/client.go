@@ -51,6 +51,9 @@ func NewClientCommand(f Factory) *cobra.Command {
 		},
 	}
 
+	pullMessage.Flags().StringVar(&o.ClientID, "client_id", "", "the id of client")
+	_ = pullMessage.MarkFlagRequired("client_id")
+
 	cmd.AddCommand(token, pullMessage)
 	return cmd
 }
@@ -98,7 +101,8 @@ func (o *ClientOptions) Token() error {
 
 func (o *ClientOptions) PullMessage() error {
 	messages, err := o.Service.PullMessage(context.Background(), &api.PullMessageReq{
-		UID: o.args[0],
+		ClientID: o.ClientID,
+		UID:      o.args[0],
 	})
 	if err != nil {
 		return err
@@ -53,13 +53,17 @@ type Options struct {
 	inst *lib.Instance
 
 	infra bool
+	// Name of the credential the config is fetched from infra with, see
+	// config.CredentialFromEnv
+	credential string
 }
 
 // NewOptions creates an options object
 func NewOptions(repoPath string) *Options {
 	return &Options{
-		doneCh:   make(chan struct{}),
-		repoPath: repoPath,
+		doneCh:     make(chan struct{}),
+		repoPath:   repoPath,
+		credential: "mercury.logic",
 	}
 }
 
@@ -76,14 +80,17 @@ func (o *Options) Init() (err error) {
 			return
 		}
 	} else {
-		o.inst, err = lib.NewInstanceWithInfraUrl(ctx, o.infraUrl)
+		o.inst, err = lib.NewInstanceWithInfraUrl(ctx, o.infraUrl, config.CredentialFromEnv(o.credential))
 	}
 	if err != nil {
 		return err
 	}
 
+	// The level may be changed at runtime through the /debug/log endpoint of the servers
 	lvl, _ := log.LvlFromString(o.inst.Config().LogLevel)
-	log.Root().SetHandler(log.LvlFilterHandler(lvl, log.StreamHandler(os.Stdout, log.TerminalFormat(true))))
+	log.Levels().SetLevel(lvl)
+	log.Levels().SetHandler(log.StreamHandler(os.Stdout, log.TerminalFormat(true)))
+	log.Root().SetHandler(log.Levels())
 
 	o.log = log.New()
 	return
@@ -124,6 +131,7 @@ func (o *Options) InfraServer() (*lib.InfraServer, error) {
 }
 
 func (o *Options) JobServer() (*lib.JobServer, error) {
+	o.credential = "mercury.job"
 	if err := o.Init(); err != nil {
 		return nil, err
 	}
@@ -131,6 +139,7 @@ func (o *Options) JobServer() (*lib.JobServer, error) {
 }
 
 func (o *Options) CometServer() (*lib.CometServer, error) {
+	o.credential = "mercury.comet"
 	if err := o.Init(); err != nil {
 		return nil, err
 	}
@@ -138,6 +147,7 @@ func (o *Options) CometServer() (*lib.CometServer, error) {
 }
 
 func (o *Options) LogicServer() (*lib.LogicServer, error) {
+	o.credential = "mercury.logic"
 	if err := o.Init(); err != nil {
 		return nil, err
 	}
@@ -145,6 +155,7 @@ func (o *Options) LogicServer() (*lib.LogicServer, error) {
 }
 
 func (o *Options) AdminServer() (*lib.AdminServer, error) {
+	o.credential = "mercury.admin"
 	if err := o.Init(); err != nil {
 		return nil, err
 	}
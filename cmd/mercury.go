@@ -24,9 +24,13 @@ func NewMercuryCommand(repoPath string) (*cobra.Command, func() <-chan error) {
 		NewCometCommand(opt),
 		NewLogicCommand(opt),
 		NewAdminCommand(opt),
+		NewStandaloneCommand(opt),
 
 		NewClientCommand(opt),
 		NewUserCommand(opt),
+		NewMigrateCommand(opt),
+		NewRecoverCommand(opt),
+		NewSecretCommand(),
 	)
 	return cmd, opt.Shutdown
 }
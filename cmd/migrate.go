@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/cobra"
+	"mercury/app/logic/persistence/sql"
+	"mercury/config"
+	"mercury/x/database/migrate"
+	"mercury/x/database/sqlx"
+	"time"
+)
+
+func NewMigrateCommand(f Factory) *cobra.Command {
+	o := MigrateOptions{}
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the schema of the database",
+		Long:  ``,
+		Annotations: map[string]string{
+			"group": "migrate",
+		},
+	}
+
+	up := &cobra.Command{
+		Use:   "up",
+		Short: "Apply the pending migrations",
+		Long:  ``,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(f); err != nil {
+				return err
+			}
+			defer o.Close()
+			return o.Up()
+		},
+	}
+
+	down := &cobra.Command{
+		Use:   "down",
+		Short: "Revert the last migrations applied",
+		Long:  ``,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(f); err != nil {
+				return err
+			}
+			defer o.Close()
+			return o.Down()
+		},
+	}
+	down.Flags().IntVar(&o.Steps, "steps", 1, "the number of migrations to revert")
+
+	status := &cobra.Command{
+		Use:   "status",
+		Short: "Show the migrations and whether they are applied",
+		Long:  ``,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(f); err != nil {
+				return err
+			}
+			defer o.Close()
+			return o.Status()
+		},
+	}
+
+	cmd.AddCommand(up, down, status)
+	return cmd
+}
+
+// MigrateOptions encapsulates state for the migrate command & subcommands
+type MigrateOptions struct {
+	Steps    int
+	Migrator *migrate.Migrator
+
+	db *sqlx.DB
+}
+
+// Complete adds any missing configuration that can only be added just before calling Run
+func (o *MigrateOptions) Complete(f Factory) (err error) {
+	cfg, err := f.Config()
+	if err != nil {
+		return err
+	}
+
+	if o.db, err = sqlx.Open(config.NewProviderConfig(cfg)); err != nil {
+		return err
+	}
+	o.Migrator, err = sql.NewMigrator(o.db)
+	return err
+}
+
+func (o *MigrateOptions) Close() {
+	if o.db != nil {
+		_ = o.db.Close()
+	}
+}
+
+func (o *MigrateOptions) Up() error {
+	applied, err := o.Migrator.Up(context.Background())
+	for _, m := range applied {
+		fmt.Printf("applied %d %s\n", m.Version, m.Name)
+	}
+	if err == nil && len(applied) == 0 {
+		fmt.Println("no pending migrations")
+	}
+	return err
+}
+
+func (o *MigrateOptions) Down() error {
+	reverted, err := o.Migrator.Down(context.Background(), o.Steps)
+	for _, m := range reverted {
+		fmt.Printf("reverted %d %s\n", m.Version, m.Name)
+	}
+	return err
+}
+
+func (o *MigrateOptions) Status() error {
+	status, err := o.Migrator.Status(context.Background())
+	if err != nil {
+		return err
+	}
+	for _, s := range status {
+		appliedAt := "pending"
+		if s.Applied {
+			appliedAt = "applied at " + time.Unix(s.AppliedAt, 0).Format(time.RFC3339)
+		}
+		fmt.Printf("%d %s: %s\n", s.Version, s.Name, appliedAt)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/cobra"
+	"mercury/app/logic/service"
+	"mercury/config"
+)
+
+func NewRecoverCommand(f Factory) *cobra.Command {
+	o := RecoverOptions{}
+	cmd := &cobra.Command{
+		Use:   "recover",
+		Short: "Rebuild the topics of the users and their read cursors in redis from the database",
+		Long:  ``,
+		Annotations: map[string]string{
+			"group": "recover",
+		},
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(f); err != nil {
+				return err
+			}
+			defer o.Close()
+			return o.Run()
+		},
+	}
+
+	return cmd
+}
+
+// RecoverOptions encapsulates state for the recover command
+type RecoverOptions struct {
+	Service *service.Service
+}
+
+// Complete adds any missing configuration that can only be added just before calling Run
+func (o *RecoverOptions) Complete(f Factory) (err error) {
+	if err = f.Init(); err != nil {
+		return
+	}
+
+	cfg, err := f.Config()
+	if err != nil {
+		return err
+	}
+
+	o.Service, err = service.NewService(config.NewProviderConfig(cfg), f.Logger().New("service", "mercury.logic"))
+	return err
+}
+
+func (o *RecoverOptions) Close() {
+	if o.Service != nil {
+		_ = o.Service.Close()
+	}
+}
+
+func (o *RecoverOptions) Run() error {
+	rebuilt, err := o.Service.RebuildCache(context.Background())
+	fmt.Printf("rebuilt %d users\n", rebuilt)
+	return err
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/spf13/cobra"
+	"mercury/config"
+	"mercury/x/secretboxer"
+	"os"
+)
+
+func NewSecretCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secret",
+		Short: "Manage the secrets of the config",
+		Long:  ``,
+		Annotations: map[string]string{
+			"group": "secret",
+		},
+	}
+
+	seal := &cobra.Command{
+		Use:   "seal",
+		Short: "Seal a field of config.yaml with the master key of " + config.MasterKeyEnv,
+		Long:  ``,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sealed, err := config.SealSecret(os.Getenv(config.MasterKeyEnv), args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Println(sealed)
+			return nil
+		},
+	}
+
+	generate := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate a random secret, such as the one of an infra credential",
+		Long:  ``,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, err := secretboxer.GenerateKey()
+			if err != nil {
+				return err
+			}
+			fmt.Println(base64.RawURLEncoding.EncodeToString(key))
+			return nil
+		},
+	}
+
+	cmd.AddCommand(seal, generate)
+	return cmd
+}
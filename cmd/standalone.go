@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"github.com/spf13/cobra"
+	"mercury/app/logic/persistence/memory"
+	"mercury/app/logic/service"
+	"mercury/lib"
+)
+
+func NewStandaloneCommand(f Factory) *cobra.Command {
+	o := StandaloneOptions{}
+	cmd := &cobra.Command{
+		Use:   "standalone",
+		Short: "run logic, job and comet in one process",
+		Long: `Standalone runs the logic, job and comet servers in one process. Together with
+the memory broker it needs neither a message broker nor more than one binary, which
+suits development and small deployments. With --memory logic keeps its cache and its
+data in memory as well, nothing is persisted.`,
+		Annotations: map[string]string{
+			"group": "server",
+		},
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(f, args); err != nil {
+				return err
+			}
+			return o.Run()
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.Memory, "memory", false, "keep the cache and the data of logic in memory instead of redis and the database")
+
+	return cmd
+}
+
+// StandaloneOptions encapsulates state for the standalone command
+type StandaloneOptions struct {
+	// Logic runs on the memory cache and persister
+	Memory bool
+
+	LogicServer *lib.LogicServer
+	JobServer   *lib.JobServer
+	CometServer *lib.CometServer
+}
+
+// Complete adds any missing configuration that can only be added just before calling Run
+func (o *StandaloneOptions) Complete(f Factory, args []string) (err error) {
+	if o.Memory {
+		o.LogicServer = lib.NewLogicServer(f.Instance(), f.Logger().New("lib", "logic"),
+			service.WithCacher(memory.NewCache()), service.WithPersister(memory.NewPersister()))
+	} else if o.LogicServer, err = f.LogicServer(); err != nil {
+		return
+	}
+	if o.JobServer, err = f.JobServer(); err != nil {
+		return
+	}
+	o.CometServer, err = f.CometServer()
+	return
+}
+
+// Run executes the standalone command with currently configured state, it returns
+// when one of the servers stopped.
+func (o *StandaloneOptions) Run() error {
+	ctx := context.Background()
+	if err := o.JobServer.Serve(ctx); err != nil {
+		return err
+	}
+
+	errCh := make(chan error, 2)
+	go func() {
+		errCh <- o.LogicServer.Serve(ctx)
+	}()
+	go func() {
+		errCh <- o.CometServer.Serve(ctx)
+	}()
+	return <-errCh
+}
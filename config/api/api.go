@@ -23,6 +23,9 @@ type API struct {
 	cookies        []*http.Cookie
 	requestTimeout time.Duration
 	debug          bool
+	// Credential the requests are signed with
+	credentialName   string
+	credentialSecret string
 }
 
 var (
@@ -63,6 +66,13 @@ func (api *API) WithRequestTimeout(requestTimeout time.Duration) *API {
 	return api
 }
 
+// WithCredential signs every request with the credential name and secret.
+func (api *API) WithCredential(name, secret string) *API {
+	api.credentialName = name
+	api.credentialSecret = secret
+	return api
+}
+
 func (api *API) Debug() *API {
 	api.debug = true
 	return api
@@ -86,6 +96,16 @@ func (api *API) LoadConfig(ctx context.Context) (out *LoadConfigResp, err error)
 	return
 }
 
+// WatchConfig waits up to wait for the config to change from version, the config is
+// returned as soon as its version differs. It is returned unchanged once wait is over.
+func (api *API) WatchConfig(ctx context.Context, version string, wait time.Duration) (out *LoadConfigResp, err error) {
+	query := url.Values{}
+	query.Set("version", version)
+	query.Set("wait", wait.String())
+	err = api.get(ctx, loadConfigEndpoint, query, &out)
+	return
+}
+
 func (api *API) get(ctx context.Context, endpoint string, query url.Values, out interface{}) error {
 	if api.url == "" {
 		return ecode.NewError("api url has not been set")
@@ -113,6 +133,9 @@ func (api *API) call(ctx context.Context, req *http.Request, out interface{}) er
 			}
 			req.Header[k] = append(req.Header[k], v...)
 		}
+		if api.credentialName != "" {
+			signRequest(req, api.credentialName, api.credentialSecret, time.Now())
+		}
 
 		if api.debug {
 			// Useful when debugging API calls
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"mercury/x/ecode"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers of a signed request
+const (
+	HeaderCredential = "X-Mercury-Credential"
+	HeaderTimestamp  = "X-Mercury-Timestamp"
+	HeaderSignature  = "X-Mercury-Signature"
+)
+
+// How far the timestamp of a signed request may be off
+const signatureWindow = 5 * time.Minute
+
+var ErrInvalidSignature = ecode.NewError("invalid signature")
+
+// Sign returns the signature of a request made at timestamp (unix seconds) with secret.
+func Sign(secret, method, requestURI, timestamp string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(method + "\n" + requestURI + "\n" + timestamp))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signRequest sets the signature headers of req.
+func signRequest(req *http.Request, name, secret string, now time.Time) {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set(HeaderCredential, name)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(secret, req.Method, req.URL.RequestURI(), timestamp))
+}
+
+// Verify checks that req was signed with secret at most five minutes before or after now.
+func Verify(req *http.Request, secret string, now time.Time) error {
+	timestamp := req.Header.Get(HeaderTimestamp)
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if d := now.Sub(time.Unix(ts, 0)); d > signatureWindow || d < -signatureWindow {
+		return ErrInvalidSignature
+	}
+
+	expected := Sign(secret, req.Method, req.URL.RequestURI(), timestamp)
+	if !hmac.Equal([]byte(expected), []byte(req.Header.Get(HeaderSignature))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -1,5 +1,9 @@
 package api
 
 type LoadConfigResp struct {
+	// Config sealed with the data key
 	Ciphertext string `json:"ciphertext"`
+	// Data key sealed with the secret of the credential
+	Key     string `json:"key"`
+	Version string `json:"version"`
 }
@@ -1,7 +1,37 @@
 package config
 
+// Broker types
+const (
+	BrokerTypeStan        = "stan"
+	BrokerTypeJetStream   = "jetstream"
+	BrokerTypeKafka       = "kafka"
+	BrokerTypeRedisStream = "redis_stream"
+	BrokerTypeMemory      = "memory"
+	// The default broker of go-micro
+	BrokerTypeDefault = "default"
+)
+
 type Broker struct {
-	Stan BrokerStan `json:"stan"`
+	// One of stan, jetstream, kafka, redis_stream, memory or default
+	Type string `json:"type"`
+	// Number of times a message is delivered to a subscriber before it is dropped
+	MaxDeliver  int               `json:"max_deliver"`
+	Stan        BrokerStan        `json:"stan"`
+	JetStream   BrokerJetStream   `json:"jetstream"`
+	Kafka       BrokerKafka       `json:"kafka"`
+	RedisStream BrokerRedisStream `json:"redis_stream"`
+}
+
+// Kind returns the broker type. Configurations without a type keep using stan if it is
+// enabled and the default broker of go-micro otherwise.
+func (b *Broker) Kind() string {
+	if b.Type != "" {
+		return b.Type
+	}
+	if b.Stan.Enable {
+		return BrokerTypeStan
+	}
+	return BrokerTypeDefault
 }
 
 type BrokerStan struct {
@@ -11,8 +41,28 @@ type BrokerStan struct {
 	DurableName string   `json:"durable_name"`
 }
 
+type BrokerJetStream struct {
+	Addresses   []string `json:"addresses"`
+	DurableName string   `json:"durable_name"`
+}
+
+type BrokerKafka struct {
+	Addresses   []string `json:"addresses"`
+	DurableName string   `json:"durable_name"`
+}
+
+type BrokerRedisStream struct {
+	Address     string `json:"address"`
+	Password    string `json:"password"`
+	DB          int    `json:"db"`
+	MaxLen      int64  `json:"max_len"`
+	DurableName string `json:"durable_name"`
+}
+
 func DefaultBroker() *Broker {
 	return &Broker{
+		Type:       BrokerTypeStan,
+		MaxDeliver: 5,
 		Stan: BrokerStan{
 			Enable: true,
 			Addresses: []string{
@@ -21,5 +71,22 @@ func DefaultBroker() *Broker {
 			ClusterID:   "test-cluster",
 			DurableName: "mercury-durable",
 		},
+		JetStream: BrokerJetStream{
+			Addresses: []string{
+				"nats://localhost:4222",
+			},
+			DurableName: "mercury-durable",
+		},
+		Kafka: BrokerKafka{
+			Addresses: []string{
+				"localhost:9092",
+			},
+			DurableName: "mercury-durable",
+		},
+		RedisStream: BrokerRedisStream{
+			Address:     "localhost:6379",
+			MaxLen:      100000,
+			DurableName: "mercury-durable",
+		},
 	}
 }
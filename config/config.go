@@ -5,9 +5,9 @@ import (
 	"github.com/ghodss/yaml"
 	"io/ioutil"
 	"mercury/config/api"
+	"mercury/x/ecode"
 	"mercury/x/fill"
 	"mercury/x/secretboxer"
-	"strconv"
 )
 
 // CurrentConfigRevision is the latest configuration revision configurations
@@ -20,18 +20,24 @@ const DefaultLogLevel = "debug"
 // Config encapsulates all configuration details for mercury
 type Config struct {
 	path string
+	// Version of the content, it changes whenever the content does
+	version string
 
 	Revision      int            `json:"revision"`
 	LogLevel      string         `json:"log_level"`
+	LogSampling   *LogSampling   `json:"log_sampling"`
 	Services      []*Service     `json:"services"`
 	Registry      *Registry      `json:"registry"`
 	Broker        *Broker        `json:"broker"`
 	Database      *Database      `json:"database"`
+	Search        *Search        `json:"search"`
 	Redis         *Redis         `json:"redis"`
 	Authenticator *Authenticator `json:"authenticator"`
 	Hasher        *Hasher        `json:"hasher"`
 	Generator     *Generator     `json:"generator"`
 	Topic         Topic          `json:"topic"`
+	Tracing       *Tracing       `json:"tracing"`
+	Infra         *Infra         `json:"infra"`
 }
 
 func (cfg Config) GetService(name string) (*Service, bool) {
@@ -44,6 +50,20 @@ func (cfg Config) GetService(name string) (*Service, bool) {
 	return nil, false
 }
 
+// ServiceName returns the registry name of the service configured as name.
+func (cfg Config) ServiceName(name string) string {
+	if srvCfg, ok := cfg.GetService(name); ok {
+		return srvCfg.ServiceName()
+	}
+	return name
+}
+
+// Version identifies the content of the configuration, it is empty for a configuration
+// which was not read from a file or infra.
+func (cfg Config) Version() string {
+	return cfg.version
+}
+
 // WriteToFile encodes a configration to YAML and writes it to path
 func (cfg Config) WriteToFile(path string) error {
 	data, err := yaml.Marshal(cfg)
@@ -54,7 +74,8 @@ func (cfg Config) WriteToFile(path string) error {
 	return ioutil.WriteFile(path, data, 06777)
 }
 
-// ReadFromFile reads a YAML configuration file from path
+// ReadFromFile reads a YAML configuration file from path, the secret fields are opened
+// with the master key of the environment.
 func ReadFromFile(path string) (*Config, error) {
 	data, err := ioutil.ReadFile(path)
 	if err != nil {
@@ -66,7 +87,11 @@ func ReadFromFile(path string) (*Config, error) {
 		return nil, err
 	}
 
-	cfg := &Config{path: path}
+	if _, err = openSecrets(fields, masterKey()); err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{path: path, version: VersionOf(data)}
 
 	if rev, ok := fields["revision"]; ok {
 		cfg.Revision = (int)(rev.(float64))
@@ -78,14 +103,32 @@ func ReadFromFile(path string) (*Config, error) {
 	return cfg, nil
 }
 
-func ReadFromRemote(ctx context.Context, url string) (*Config, error) {
-	resp, err := api.New(url).LoadConfig(ctx)
+// ReadFromRemote fetches the configuration served by infra to the credential.
+func ReadFromRemote(ctx context.Context, url string, cred Credential) (*Config, error) {
+	resp, err := remoteAPI(url, cred).LoadConfig(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	boxer := secretboxer.NewPassphraseBoxer(strconv.Itoa(CurrentConfigRevision), secretboxer.EncodingTypeStd)
-	data, err := boxer.Open(resp.Ciphertext)
+	return decodeRemote(resp, cred)
+}
+
+func remoteAPI(url string, cred Credential) *api.API {
+	return api.New(url).WithCredential(cred.Name, cred.Secret)
+}
+
+// decodeRemote decrypts the configuration served by infra, the data key it is sealed
+// with is sealed with the secret of the credential.
+func decodeRemote(resp *api.LoadConfigResp, cred Credential) (*Config, error) {
+	key, err := secretboxer.NewPassphraseBoxer(cred.Secret, secretboxer.EncodingTypeStd).Decrypt(resp.Key)
+	if err != nil {
+		return nil, ecode.NewErrorf("failed to open the data key of the config: %s", err)
+	}
+	boxer, err := secretboxer.NewKeyBoxer(key, secretboxer.EncodingTypeStd)
+	if err != nil {
+		return nil, err
+	}
+	data, err := boxer.Decrypt(resp.Ciphertext)
 	if err != nil {
 		return nil, err
 	}
@@ -94,6 +137,10 @@ func ReadFromRemote(ctx context.Context, url string) (*Config, error) {
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, err
 	}
+	cfg.version = resp.Version
+	if cfg.version == "" {
+		cfg.version = VersionOf(data)
+	}
 
 	return &cfg, nil
 }
@@ -102,14 +149,18 @@ func DefaultConfig() *Config {
 	return &Config{
 		Revision:      CurrentConfigRevision,
 		LogLevel:      DefaultLogLevel,
+		LogSampling:   DefaultLogSampling(),
 		Services:      DefaultServices(),
 		Registry:      DefaultRegistry(),
 		Broker:        DefaultBroker(),
 		Database:      DefaultDatabase(),
+		Search:        DefaultSearch(),
 		Redis:         DefaultRedis(),
 		Authenticator: DefaultAuthenticator(),
 		Hasher:        DefaultHasher(),
 		Generator:     DefaultGenerator(),
 		Topic:         DefaultTopic(),
+		Tracing:       DefaultTracing(),
+		Infra:         DefaultInfra(),
 	}
 }
@@ -7,6 +7,6 @@ import (
 )
 
 func TestReadFromRemote(t *testing.T) {
-	_, err := ReadFromRemote(context.TODO(), "http://localhost:9600/infra/v1")
+	_, err := ReadFromRemote(context.TODO(), "http://localhost:9600/infra/v1", CredentialFromEnv("mercury.logic"))
 	require.NoError(t, err)
 }
@@ -3,19 +3,32 @@ package config
 import "time"
 
 type Database struct {
+	// One of postgres (or CockroachDB), mysql or sqlite3
 	Driver      string        `json:"driver"`
 	DSN         string        `json:"dsn"`
 	Active      int           `json:"active"`
 	Idle        int           `json:"idle"`
 	IdleTimeout time.Duration `json:"idle_timeout"`
+	// Applies the pending migrations when logic starts
+	AutoMigrate bool `json:"auto_migrate"`
+	// Interval of purging the messages beyond the retention of the clients, 0 disables it
+	PurgeInterval time.Duration `json:"purge_interval"`
+	// Directory of the archive keeping the purged messages for the history, they are
+	// dropped if it is empty
+	ArchiveDir string `json:"archive_dir"`
+	// Interval of writing the topics of the users and their read cursors to the database
+	// in batches, 0 writes them at once
+	TopicFlushInterval time.Duration `json:"topic_flush_interval"`
 }
 
 func DefaultDatabase() *Database {
 	return &Database{
-		Driver:      "postgres",
-		DSN:         "postgresql://root@localhost:26257/mercury?sslmode=disable",
-		Active:      10,
-		Idle:        5,
-		IdleTimeout: 4 * time.Hour,
+		Driver:             "postgres",
+		DSN:                "postgresql://root@localhost:26257/mercury?sslmode=disable",
+		Active:             10,
+		Idle:               5,
+		IdleTimeout:        4 * time.Hour,
+		PurgeInterval:      time.Hour,
+		TopicFlushInterval: time.Second,
 	}
 }
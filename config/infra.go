@@ -0,0 +1,137 @@
+package config
+
+import (
+	"github.com/ghodss/yaml"
+	"mercury/config/api"
+	"mercury/x/secretboxer"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// Environment variables holding the keys which are kept out of the repo
+const (
+	// Passphrase the secret fields of config.yaml are sealed with
+	MasterKeyEnv = "MERCURY_MASTER_KEY"
+	// Name and secret of the credential the config is fetched from infra with
+	InfraNameEnv   = "MERCURY_INFRA_NAME"
+	InfraSecretEnv = "MERCURY_INFRA_SECRET"
+)
+
+// Sections served to the credentials without sections, by credential name. The other
+// names are served no section unless their credential lists some.
+var defaultViews = map[string][]string{
+	"mercury.logic": {"log_level", "log_sampling", "services", "registry", "broker", "database", "search",
+		"redis", "authenticator", "hasher", "generator", "topic", "tracing"},
+	"mercury.job":   {"log_level", "log_sampling", "services", "registry", "broker", "topic", "tracing"},
+	"mercury.comet": {"log_level", "log_sampling", "services", "registry", "tracing"},
+	"mercury.admin": {"log_level", "log_sampling", "services", "registry", "tracing"},
+}
+
+// Infra configures who may fetch the config from infra, the config is served to no one
+// unless credentials are configured.
+type Infra struct {
+	Credentials []*InfraCredential `json:"credentials"`
+}
+
+type InfraCredential struct {
+	Name string `json:"name"`
+	// Signs the requests and seals the config served, it should be a secret field
+	Secret string `json:"secret"`
+	// Sections of the config served, the default ones of the name if empty, none if the
+	// name has no default ones
+	Sections []string `json:"sections"`
+}
+
+func DefaultInfra() *Infra {
+	return &Infra{}
+}
+
+// Credential returns the credential with the name.
+func (c *Infra) Credential(name string) (*InfraCredential, bool) {
+	if c == nil {
+		return nil, false
+	}
+	for _, cred := range c.Credentials {
+		if cred.Name == name {
+			return cred, true
+		}
+	}
+	return nil, false
+}
+
+// View returns the part of cfg served to the credential. The revision is always served
+// and the infra section never is.
+func (c *InfraCredential) View(cfg *Config) *Config {
+	sections := c.Sections
+	if len(sections) == 0 {
+		sections = defaultViews[c.Name]
+	}
+
+	view := &Config{Revision: cfg.Revision}
+	cv, vv := reflect.ValueOf(cfg).Elem(), reflect.ValueOf(view).Elem()
+	t := cv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("json")
+		if f.PkgPath != "" || f.Name == "Infra" {
+			continue
+		}
+		if containsString(sections, name) {
+			vv.Field(i).Set(cv.Field(i))
+		}
+	}
+	return view
+}
+
+// Seal seals the view of cfg with a new data key, which is sealed with the secret of the
+// credential. The version is the one of the view, so it only changes with the view.
+func (c *InfraCredential) Seal(cfg *Config) (*api.LoadConfigResp, error) {
+	data, err := yaml.Marshal(c.View(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := secretboxer.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	boxer, err := secretboxer.NewKeyBoxer(key, secretboxer.EncodingTypeStd)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := boxer.Encrypt(data)
+	if err != nil {
+		return nil, err
+	}
+	sealedKey, err := secretboxer.NewPassphraseBoxer(c.Secret, secretboxer.EncodingTypeStd).Encrypt(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.LoadConfigResp{Ciphertext: ciphertext, Key: sealedKey, Version: VersionOf(data)}, nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Credential identifies a server to infra.
+type Credential struct {
+	Name   string
+	Secret string
+}
+
+// CredentialFromEnv returns the credential of the environment, name is used unless
+// the environment names one.
+func CredentialFromEnv(name string) Credential {
+	if n := strings.TrimSpace(os.Getenv(InfraNameEnv)); n != "" {
+		name = n
+	}
+	return Credential{Name: name, Secret: os.Getenv(InfraSecretEnv)}
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfraCredentialView(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Infra.Credentials = []*InfraCredential{{Name: "mercury.comet", Secret: "comet"}}
+
+	view := (&InfraCredential{Name: "mercury.comet"}).View(cfg)
+	require.Equal(t, cfg.Revision, view.Revision)
+	require.Equal(t, cfg.Services, view.Services)
+	require.Equal(t, cfg.Registry, view.Registry)
+	require.Nil(t, view.Database)
+	require.Nil(t, view.Redis)
+	require.Nil(t, view.Authenticator)
+	require.Nil(t, view.Infra)
+
+	view = (&InfraCredential{Name: "mercury.logic"}).View(cfg)
+	require.Equal(t, cfg.Redis, view.Redis)
+	require.Nil(t, view.Infra)
+
+	view = (&InfraCredential{Name: "mercury.comet", Sections: []string{"redis"}}).View(cfg)
+	require.Equal(t, cfg.Redis, view.Redis)
+	require.Nil(t, view.Services)
+
+	// Unknown names are served the revision only
+	view = (&InfraCredential{Name: "mercury.other"}).View(cfg)
+	require.Equal(t, &Config{Revision: cfg.Revision}, view)
+}
+
+func TestInfraCredentialSeal(t *testing.T) {
+	cfg := DefaultConfig()
+	cred := &InfraCredential{Name: "mercury.job", Secret: "job-secret"}
+
+	resp, err := cred.Seal(cfg)
+	require.NoError(t, err)
+	require.NotContains(t, resp.Ciphertext, "mercury.job")
+
+	opened, err := decodeRemote(resp, Credential{Name: "mercury.job", Secret: "job-secret"})
+	require.NoError(t, err)
+	require.Equal(t, resp.Version, opened.Version())
+	require.Equal(t, cfg.Topic, opened.Topic)
+	require.Nil(t, opened.Database)
+
+	_, err = decodeRemote(resp, Credential{Name: "mercury.job", Secret: "other"})
+	require.Error(t, err)
+
+	// The version only changes with the view
+	again, err := cred.Seal(cfg)
+	require.NoError(t, err)
+	require.Equal(t, resp.Version, again.Version)
+	require.NotEqual(t, resp.Ciphertext, again.Ciphertext)
+}
+
+func TestReadSecrets(t *testing.T) {
+	password, err := SealSecret("master", "redis-password")
+	require.NoError(t, err)
+
+	cfg := DefaultConfig()
+	cfg.Redis.Password = password
+	path := writeConfig(t, cfg)
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.False(t, strings.Contains(string(data), "redis-password"))
+
+	os.Unsetenv(MasterKeyEnv)
+	_, err = ReadFromFile(path)
+	require.Equal(t, ErrNoMasterKey, err)
+
+	os.Setenv(MasterKeyEnv, "master")
+	defer os.Unsetenv(MasterKeyEnv)
+	read, err := ReadFromFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "redis-password", read.Redis.Password)
+
+	os.Setenv(MasterKeyEnv, "other")
+	_, err = ReadFromFile(path)
+	require.Error(t, err)
+}
@@ -0,0 +1,16 @@
+package config
+
+// LogSampling limits the records of the hot paths, such as the pushes received by the
+// comet, the first records of every message in a second are written and every
+// thereafter-th record after them.
+type LogSampling struct {
+	First      int `json:"first"`
+	Thereafter int `json:"thereafter"`
+}
+
+func DefaultLogSampling() *LogSampling {
+	return &LogSampling{
+		First:      10,
+		Thereafter: 100,
+	}
+}
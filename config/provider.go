@@ -1,24 +1,54 @@
 package config
 
+import "sync/atomic"
+
 var _ Provider = new(ProviderConfig)
 
 type Provider interface {
 	GetService(name string) (*Service, bool)
+	ServiceName(name string) string
 	Revision() int
 	LogLevel() string
+	LogSampling() *LogSampling
 	Services() []*Service
 	Registry() *Registry
 	Broker() *Broker
 	Database() *Database
+	Search() *Search
 	Redis() *Redis
 	Authenticator() *Authenticator
 	Hasher() *Hasher
 	Generator() *Generator
 	Topic() Topic
+	Tracing() *Tracing
+	Infra() *Infra
 }
 
 type ProviderConfig struct {
 	*Config
+	// Config with the live sections of the latest update
+	live atomic.Value
+}
+
+// Update applies the sections of cfg which may change without a restart, see Live.
+// Updates must not be concurrent.
+func (p *ProviderConfig) Update(cfg *Config) {
+	p.live.Store(Live(p.current(), cfg))
+}
+
+func (p *ProviderConfig) current() *Config {
+	if cfg, ok := p.live.Load().(*Config); ok {
+		return cfg
+	}
+	return p.Config
+}
+
+func (p *ProviderConfig) GetService(name string) (*Service, bool) {
+	return p.current().GetService(name)
+}
+
+func (p *ProviderConfig) ServiceName(name string) string {
+	return p.current().ServiceName(name)
 }
 
 func (p *ProviderConfig) Revision() int {
@@ -26,15 +56,19 @@ func (p *ProviderConfig) Revision() int {
 }
 
 func (p *ProviderConfig) LogLevel() string {
-	return p.Config.LogLevel
+	return p.current().LogLevel
+}
+
+func (p *ProviderConfig) LogSampling() *LogSampling {
+	return p.Config.LogSampling
 }
 
 func (p *ProviderConfig) Services() []*Service {
-	return p.Config.Services
+	return p.current().Services
 }
 
 func (p *ProviderConfig) Registry() *Registry {
-	return p.Config.Registry
+	return p.current().Registry
 }
 
 func (p *ProviderConfig) Broker() *Broker {
@@ -45,6 +79,10 @@ func (p *ProviderConfig) Database() *Database {
 	return p.Config.Database
 }
 
+func (p *ProviderConfig) Search() *Search {
+	return p.Config.Search
+}
+
 func (p *ProviderConfig) Redis() *Redis {
 	return p.Config.Redis
 }
@@ -62,9 +100,17 @@ func (p *ProviderConfig) Generator() *Generator {
 }
 
 func (p *ProviderConfig) Topic() Topic {
-	return p.Config.Topic
+	return p.current().Topic
+}
+
+func (p *ProviderConfig) Tracing() *Tracing {
+	return p.Config.Tracing
+}
+
+func (p *ProviderConfig) Infra() *Infra {
+	return p.current().Infra
 }
 
 func NewProviderConfig(cfg *Config) *ProviderConfig {
-	return &ProviderConfig{cfg}
+	return &ProviderConfig{Config: cfg}
 }
@@ -2,16 +2,33 @@ package config
 
 import "time"
 
+// Redis modes
+const (
+	RedisModeSingle   = "single"
+	RedisModeSentinel = "sentinel"
+	RedisModeCluster  = "cluster"
+)
+
 type Redis struct {
-	Address     string        `json:"address"`
-	Username    string        `json:"username"`
-	Password    string        `json:"password"`
+	// One of single, the node of Address, sentinel, the master of MasterName failed over by
+	// the sentinels of Addresses, or cluster, the cluster of the seed nodes of Addresses.
+	// It is single if empty
+	Mode       string   `json:"mode"`
+	Address    string   `json:"address"`
+	Addresses  []string `json:"addresses"`
+	MasterName string   `json:"master_name"`
+	Username   string   `json:"username"`
+	Password   string   `json:"password"`
+	// Password of the sentinels, if they require another one than the master
+	SentinelPassword string `json:"sentinel_password"`
+	// Not supported by the cluster
 	DB          int           `json:"db"`
 	IdleTimeout time.Duration `json:"idle_timeout"`
 }
 
 func DefaultRedis() *Redis {
 	return &Redis{
+		Mode:        RedisModeSingle,
 		Address:     "localhost:6379",
 		Username:    "",
 		Password:    "",
@@ -2,8 +2,35 @@ package config
 
 import "time"
 
+// Registry types
+const (
+	RegistryTypeETCD       = "etcd"
+	RegistryTypeConsul     = "consul"
+	RegistryTypeKubernetes = "kubernetes"
+	RegistryTypeStatic     = "static"
+	RegistryTypeMDNS       = "mdns"
+	RegistryTypeMemory     = "memory"
+)
+
 type Registry struct {
-	ETCD RegistryETCD `json:"etcd"`
+	// One of etcd, consul, kubernetes, static, mdns or memory
+	Type       string             `json:"type"`
+	ETCD       RegistryETCD       `json:"etcd"`
+	Consul     RegistryConsul     `json:"consul"`
+	Kubernetes RegistryKubernetes `json:"kubernetes"`
+	Static     RegistryStatic     `json:"static"`
+}
+
+// Kind returns the registry type. Configurations without a type keep using etcd if it
+// is enabled and mdns otherwise.
+func (r *Registry) Kind() string {
+	if r.Type != "" {
+		return r.Type
+	}
+	if r.ETCD.Enable {
+		return RegistryTypeETCD
+	}
+	return RegistryTypeMDNS
 }
 
 type RegistryETCD struct {
@@ -12,6 +39,29 @@ type RegistryETCD struct {
 	Timeout   time.Duration `json:"timeout"`
 }
 
+type RegistryConsul struct {
+	Addresses []string      `json:"addresses"`
+	Timeout   time.Duration `json:"timeout"`
+}
+
+type RegistryKubernetes struct {
+	// Addresses of the API server, the in-cluster address is used if empty
+	Addresses []string      `json:"addresses"`
+	Timeout   time.Duration `json:"timeout"`
+}
+
+// RegistryStatic is a fixed list of nodes, registering does nothing.
+type RegistryStatic struct {
+	Nodes []RegistryStaticNode `json:"nodes"`
+}
+
+type RegistryStaticNode struct {
+	// Registry name of the service the node belongs to
+	Service string `json:"service"`
+	ID      string `json:"id"`
+	Address string `json:"address"`
+}
+
 func DefaultRegistry() *Registry {
 	return &Registry{}
 }
@@ -0,0 +1,18 @@
+package config
+
+// Search index types
+const (
+	SearchTypePostgres = "postgres"
+	SearchTypeEmbedded = "embedded"
+)
+
+type Search struct {
+	// One of postgres, on the database, or embedded, messages are not searchable if empty
+	Type string `json:"type"`
+	// Directory of the embedded index, it is only kept in memory if empty
+	Dir string `json:"dir"`
+}
+
+func DefaultSearch() *Search {
+	return &Search{}
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"mercury/x/ecode"
+	"mercury/x/secretboxer"
+	"os"
+	"strings"
+)
+
+// Prefix of the fields of config.yaml which are sealed with the master key
+const secretPrefix = "secret:"
+
+var ErrNoMasterKey = ecode.NewError("config contains secrets but " + MasterKeyEnv + " is not set")
+
+// SealSecret seals value with the master key, the result may replace value in config.yaml.
+func SealSecret(masterKey, value string) (string, error) {
+	if masterKey == "" {
+		return "", ErrNoMasterKey
+	}
+	boxer := secretboxer.NewPassphraseBoxer(masterKey, secretboxer.EncodingTypeStd)
+	ciphertext, err := boxer.Encrypt([]byte(value))
+	if err != nil {
+		return "", err
+	}
+	return secretPrefix + ciphertext, nil
+}
+
+// openSecrets replaces the sealed strings of the decoded YAML v by their value.
+func openSecrets(v interface{}, masterKey string) (interface{}, error) {
+	switch val := v.(type) {
+	case string:
+		if !strings.HasPrefix(val, secretPrefix) {
+			return val, nil
+		}
+		if masterKey == "" {
+			return nil, ErrNoMasterKey
+		}
+		boxer := secretboxer.NewPassphraseBoxer(masterKey, secretboxer.EncodingTypeStd)
+		b, err := boxer.Decrypt(strings.TrimPrefix(val, secretPrefix))
+		if err != nil {
+			return nil, ecode.NewErrorf("failed to open a secret of the config: %s", err)
+		}
+		return string(b), nil
+	case map[string]interface{}:
+		for k, e := range val {
+			o, err := openSecrets(e, masterKey)
+			if err != nil {
+				return nil, err
+			}
+			val[k] = o
+		}
+	case []interface{}:
+		for i, e := range val {
+			o, err := openSecrets(e, masterKey)
+			if err != nil {
+				return nil, err
+			}
+			val[i] = o
+		}
+	}
+	return v, nil
+}
+
+func masterKey() string {
+	return os.Getenv(MasterKeyEnv)
+}
@@ -10,6 +10,36 @@ const (
 	defaultRegisterTTL      = "30s"
 	defaultRegisterInterval = "15s"
 	defaultHost             = "0.0.0.0"
+
+	defaultDrainBatchSize     = 500
+	defaultDrainBatchInterval = "1s"
+	defaultDrainDeadline      = "30s"
+
+	defaultPushMode = PushModeSID
+
+	defaultRoomRateLimit     = 0
+	defaultRoomSendRateLimit = 5
+	defaultRoomSampleRate    = 1
+
+	defaultReorderTimeout = "200ms"
+
+	defaultLocalCacheTTL = "2s"
+
+	defaultDispatchQueueSize      = 1024
+	defaultDispatchWorkers        = 32
+	defaultDispatchBatchSize      = 64
+	defaultDispatchBatchInterval  = "10ms"
+	defaultDispatchMaxRetries     = 3
+	defaultDispatchEnqueueTimeout = "100ms"
+)
+
+// Push modes of logic.
+const (
+	// Logic resolves the sessions of every recipient and publishes them per comet.
+	PushModeSID = "sid"
+	// Logic publishes a message once for its topic, every comet delivers it to its local
+	// sessions in the topic. Notifications to a few users are published once with their UIDs.
+	PushModeTopic = "topic"
 )
 
 type ServiceConfig map[string]interface{}
@@ -19,7 +49,12 @@ type Service struct {
 	Config ServiceConfig `json:"config"`
 }
 
+// ServiceName is the name the service registers with, it defaults to the name of the config.
 func (s Service) ServiceName() string {
+	v, ok := s.Config["service_name"]
+	if ok {
+		return v.(string)
+	}
 	return s.Name
 }
 
@@ -83,6 +118,178 @@ func (s Service) RpcAddress() string {
 	return fmt.Sprintf("%s:%d", s.Host(), s.RpcPort())
 }
 
+// HealthPort is the internal port of the server, it serves the health endpoints of servers
+// without an HTTP server and the endpoints changing the server at runtime, such as draining
+// a comet. It must not be reachable from the outside, the endpoints are disabled if it is 0.
+func (s Service) HealthPort() int {
+	v, ok := s.Config["health_port"]
+	if ok {
+		return int(v.(float64))
+	}
+	return 0
+}
+
+func (s Service) HealthAddress() string {
+	return fmt.Sprintf("%s:%d", s.Host(), s.HealthPort())
+}
+
+// DrainBatchSize is the number of sessions told to reconnect elsewhere at a time while draining.
+func (s Service) DrainBatchSize() int {
+	v, ok := s.Config["drain_batch_size"]
+	if ok {
+		return int(v.(float64))
+	}
+	return defaultDrainBatchSize
+}
+
+// DrainBatchInterval is the pause between two batches of reconnect operations while draining.
+func (s Service) DrainBatchInterval() time.Duration {
+	str := defaultDrainBatchInterval
+	v, ok := s.Config["drain_batch_interval"]
+	if ok {
+		str = v.(string)
+	}
+	d, _ := time.ParseDuration(str)
+	return d
+}
+
+// DrainDeadline is how long a draining server waits for its sessions to go away before exiting.
+func (s Service) DrainDeadline() time.Duration {
+	str := defaultDrainDeadline
+	v, ok := s.Config["drain_deadline"]
+	if ok {
+		str = v.(string)
+	}
+	d, _ := time.ParseDuration(str)
+	return d
+}
+
+// PushMode is how logic routes pushes to comets, one of PushModeSID and PushModeTopic.
+func (s Service) PushMode() string {
+	v, ok := s.Config["push_mode"]
+	if ok {
+		return v.(string)
+	}
+	return defaultPushMode
+}
+
+// RoomRateLimit is the maximum number of messages a comet delivers to a room per second,
+// the rest are dropped. Zero means unlimited. Every comet receives all the messages of a
+// room and limits them on its own, the limit is not shared by the cluster: the viewers
+// on different comets may get different messages once the room is over it.
+func (s Service) RoomRateLimit() int {
+	v, ok := s.Config["room_rate_limit"]
+	if ok {
+		return int(v.(float64))
+	}
+	return defaultRoomRateLimit
+}
+
+// RoomSendRateLimit is the maximum number of messages a session may send to rooms per second.
+// Zero means unlimited.
+func (s Service) RoomSendRateLimit() int {
+	v, ok := s.Config["room_send_rate_limit"]
+	if ok {
+		return int(v.(float64))
+	}
+	return defaultRoomSendRateLimit
+}
+
+// RoomSampleRate is the fraction of the sessions in a room receiving a message sent by a user.
+func (s Service) RoomSampleRate() float64 {
+	v, ok := s.Config["room_sample_rate"]
+	if ok {
+		return v.(float64)
+	}
+	return defaultRoomSampleRate
+}
+
+// ReorderTimeout is how long a comet holds back the messages of a topic waiting for a
+// missing sequence, the messages are delivered with the gap after that.
+func (s Service) ReorderTimeout() time.Duration {
+	str := defaultReorderTimeout
+	v, ok := s.Config["reorder_timeout"]
+	if ok {
+		str = v.(string)
+	}
+	d, _ := time.ParseDuration(str)
+	return d
+}
+
+// LocalCacheTTL is how long logic keeps the members of the groups and the sessions of
+// the users in its memory, 0 disables it. The logic changing them through the service,
+// e.g. a user connecting or a member added, has every logic drop them through the topic
+// invalidate_local_cache. The changes made elsewhere, e.g. in the database, or while the
+// topic is not configured are seen after the TTL.
+func (s Service) LocalCacheTTL() time.Duration {
+	str := defaultLocalCacheTTL
+	v, ok := s.Config["local_cache_ttl"]
+	if ok {
+		str = v.(string)
+	}
+	d, _ := time.ParseDuration(str)
+	return d
+}
+
+// DispatchQueueSize is the capacity of each dispatch queue of job per comet.
+func (s Service) DispatchQueueSize() int {
+	v, ok := s.Config["dispatch_queue_size"]
+	if ok {
+		return int(v.(float64))
+	}
+	return defaultDispatchQueueSize
+}
+
+// DispatchWorkers is the number of goroutines of job sending requests to each comet.
+func (s Service) DispatchWorkers() int {
+	v, ok := s.Config["dispatch_workers"]
+	if ok {
+		return int(v.(float64))
+	}
+	return defaultDispatchWorkers
+}
+
+// DispatchBatchSize is the maximum number of pushes job sends to a comet in one request.
+func (s Service) DispatchBatchSize() int {
+	v, ok := s.Config["dispatch_batch_size"]
+	if ok {
+		return int(v.(float64))
+	}
+	return defaultDispatchBatchSize
+}
+
+// DispatchBatchInterval is how long job waits for a batch of pushes to fill up.
+func (s Service) DispatchBatchInterval() time.Duration {
+	str := defaultDispatchBatchInterval
+	v, ok := s.Config["dispatch_batch_interval"]
+	if ok {
+		str = v.(string)
+	}
+	d, _ := time.ParseDuration(str)
+	return d
+}
+
+// DispatchMaxRetries is how many times job retries a failed request before dead-lettering it.
+func (s Service) DispatchMaxRetries() int {
+	v, ok := s.Config["dispatch_max_retries"]
+	if ok {
+		return int(v.(float64))
+	}
+	return defaultDispatchMaxRetries
+}
+
+// DispatchEnqueueTimeout is how long job waits for room in a full dispatch queue
+// before dead-lettering the request.
+func (s Service) DispatchEnqueueTimeout() time.Duration {
+	str := defaultDispatchEnqueueTimeout
+	v, ok := s.Config["dispatch_enqueue_timeout"]
+	if ok {
+		str = v.(string)
+	}
+	d, _ := time.ParseDuration(str)
+	return d
+}
+
 func DefaultServices() []*Service {
 	return []*Service{
 		{
@@ -93,6 +300,7 @@ func DefaultServices() []*Service {
 				"register_interval": defaultRegisterInterval,
 				"host":              defaultHost,
 				"port":              9600,
+				"health_port":       9601,
 			},
 		},
 		{
@@ -103,17 +311,25 @@ func DefaultServices() []*Service {
 				"register_interval": defaultRegisterInterval,
 				"host":              defaultHost,
 				"port":              9000,
+				"health_port":       9004,
 			},
 		},
 		{
 			Name: "mercury.comet",
 			Config: map[string]interface{}{
-				"version":           defaultVersion,
-				"register_ttl":      defaultRegisterTTL,
-				"register_interval": defaultRegisterInterval,
-				"host":              defaultHost,
-				"port":              9001,
-				"rpc_port":          9002,
+				"version":              defaultVersion,
+				"register_ttl":         defaultRegisterTTL,
+				"register_interval":    defaultRegisterInterval,
+				"host":                 defaultHost,
+				"port":                 9001,
+				"rpc_port":             9002,
+				"health_port":          9003,
+				"drain_batch_size":     defaultDrainBatchSize,
+				"drain_batch_interval": defaultDrainBatchInterval,
+				"drain_deadline":       defaultDrainDeadline,
+				"room_rate_limit":      defaultRoomRateLimit,
+				"room_send_rate_limit": defaultRoomSendRateLimit,
+				"reorder_timeout":      defaultReorderTimeout,
 			},
 		},
 		{
@@ -124,16 +340,27 @@ func DefaultServices() []*Service {
 				"register_interval": defaultRegisterInterval,
 				"host":              defaultHost,
 				"port":              9011,
+				"health_port":       9012,
+				"push_mode":         defaultPushMode,
+				"room_sample_rate":  defaultRoomSampleRate,
+				"local_cache_ttl":   defaultLocalCacheTTL,
 			},
 		},
 		{
 			Name: "mercury.job",
 			Config: map[string]interface{}{
-				"version":           defaultVersion,
-				"register_ttl":      defaultRegisterTTL,
-				"register_interval": defaultRegisterInterval,
-				"host":              defaultHost,
-				"port":              9111,
+				"version":                  defaultVersion,
+				"register_ttl":             defaultRegisterTTL,
+				"register_interval":        defaultRegisterInterval,
+				"host":                     defaultHost,
+				"port":                     9111,
+				"health_port":              9112,
+				"dispatch_queue_size":      defaultDispatchQueueSize,
+				"dispatch_workers":         defaultDispatchWorkers,
+				"dispatch_batch_size":      defaultDispatchBatchSize,
+				"dispatch_batch_interval":  defaultDispatchBatchInterval,
+				"dispatch_max_retries":     defaultDispatchMaxRetries,
+				"dispatch_enqueue_timeout": defaultDispatchEnqueueTimeout,
 			},
 		},
 	}
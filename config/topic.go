@@ -12,7 +12,12 @@ func (r *Topic) Get(name string) (string, bool) {
 
 func DefaultTopic() Topic {
 	return Topic{
-		"push_message":      "mercury-push-message",
-		"broadcast_message": "mercury-broadcast-message",
+		"push_message":           "mercury-push-message",
+		"push_uid_message":       "mercury-push-uid-message",
+		"push_topic_message":     "mercury-push-topic-message",
+		"broadcast_message":      "mercury-broadcast-message",
+		"broadcast_room_message": "mercury-broadcast-room-message",
+		"dead_letter":            "mercury-dead-letter",
+		"invalidate_local_cache": "mercury-invalidate-local-cache",
 	}
 }
@@ -0,0 +1,24 @@
+package config
+
+// Tracing exporters
+const (
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+)
+
+type Tracing struct {
+	// One of otlp or stdout, spans are not exported if empty. The trace context is
+	// propagated either way.
+	Exporter string `json:"exporter"`
+	// URL of the traces endpoint of the OTLP/HTTP collector
+	Endpoint string `json:"endpoint"`
+	// Fraction of the traces started by mercury which are sampled
+	SampleRatio float64 `json:"sample_ratio"`
+}
+
+func DefaultTracing() *Tracing {
+	return &Tracing{
+		Endpoint:    "http://localhost:4318/v1/traces",
+		SampleRatio: 1,
+	}
+}
@@ -0,0 +1,267 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/fsnotify/fsnotify"
+	"mercury/config/api"
+	"mercury/x/ecode"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// How long infra holds a watch request of the config while the config is unchanged
+const remoteWatchWait = 30 * time.Second
+
+// Keys of the service configs which are applied without a restart
+var liveServiceKeys = []string{"room_rate_limit", "room_send_rate_limit", "room_sample_rate"}
+
+var (
+	ErrWatcherClosed    = ecode.NewError("config watcher is closed")
+	ErrWatchUnsupported = ecode.NewError("infra does not support watching the config")
+)
+
+// VersionOf returns the version of the content of a configuration.
+func VersionOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Watcher reports the changes of a configuration.
+type Watcher interface {
+	// Next blocks until the configuration changed and returns the new one.
+	Next(ctx context.Context) (*Config, error)
+	Close() error
+}
+
+type fileWatcher struct {
+	path    string
+	version string
+	fw      *fsnotify.Watcher
+}
+
+// NewFileWatcher watches the configuration file read with ReadFromFile. The directory
+// of the file is watched, so that files replaced by editors or mounted from a
+// Kubernetes ConfigMap are followed.
+func NewFileWatcher(cfg *Config) (Watcher, error) {
+	if cfg.path == "" {
+		return nil, ecode.NewError("config was not read from a file")
+	}
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.Add(filepath.Dir(cfg.path)); err != nil {
+		fw.Close()
+		return nil, err
+	}
+	return &fileWatcher{path: cfg.path, version: cfg.version, fw: fw}, nil
+}
+
+func (w *fileWatcher) Next(ctx context.Context) (*Config, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err, ok := <-w.fw.Errors:
+			if !ok {
+				return nil, ErrWatcherClosed
+			}
+			return nil, err
+		case _, ok := <-w.fw.Events:
+			if !ok {
+				return nil, ErrWatcherClosed
+			}
+			// Editors write a file in several steps, wait for them to settle
+			w.settle(ctx)
+
+			cfg, err := ReadFromFile(w.path)
+			if err != nil {
+				return nil, err
+			}
+			if cfg.version == w.version {
+				continue
+			}
+			w.version = cfg.version
+			return cfg, nil
+		}
+	}
+}
+
+func (w *fileWatcher) settle(ctx context.Context) {
+	timer := time.NewTimer(100 * time.Millisecond)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			return
+		case <-w.fw.Events:
+		}
+	}
+}
+
+func (w *fileWatcher) Close() error {
+	return w.fw.Close()
+}
+
+type remoteWatcher struct {
+	api     *api.API
+	cred    Credential
+	version string
+}
+
+// NewRemoteWatcher watches the configuration served by infra at url to the credential,
+// infra holds every request until the configuration changes.
+func NewRemoteWatcher(url string, cred Credential, cfg *Config) Watcher {
+	return &remoteWatcher{api: remoteAPI(url, cred), cred: cred, version: cfg.version}
+}
+
+func (w *remoteWatcher) Next(ctx context.Context) (*Config, error) {
+	for {
+		wctx, cancel := context.WithTimeout(ctx, remoteWatchWait+10*time.Second)
+		resp, err := w.api.WatchConfig(wctx, w.version, remoteWatchWait)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		if resp.Version == "" {
+			return nil, ErrWatchUnsupported
+		}
+		if resp.Version == w.version {
+			continue
+		}
+
+		cfg, err := decodeRemote(resp, w.cred)
+		if err != nil {
+			return nil, err
+		}
+		w.version = cfg.version
+		return cfg, nil
+	}
+}
+
+func (w *remoteWatcher) Close() error {
+	return nil
+}
+
+// Changes returns the sections which differ between the configurations, the sections
+// in live are applied by Live while the ones in restart take effect after a restart.
+func Changes(old, new *Config) (live, restart []string) {
+	ov, nv := reflect.ValueOf(*old), reflect.ValueOf(*new)
+	t := ov.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := f.Tag.Get("json")
+		if reflect.DeepEqual(ov.Field(i).Interface(), nv.Field(i).Interface()) {
+			continue
+		}
+
+		switch f.Name {
+		case "LogLevel", "Topic", "Infra":
+			live = append(live, name)
+		case "Registry":
+			if registryNodesOnly(old.Registry, new.Registry) {
+				live = append(live, name)
+			} else {
+				restart = append(restart, name)
+			}
+		case "Services":
+			l, r := serviceChanges(old.Services, new.Services)
+			live = append(live, l...)
+			restart = append(restart, r...)
+		default:
+			restart = append(restart, name)
+		}
+	}
+	return
+}
+
+// Live returns a copy of old with the sections of new which are applied without a
+// restart.
+func Live(old, new *Config) *Config {
+	cfg := *old
+	cfg.LogLevel = new.LogLevel
+	cfg.Topic = new.Topic
+	cfg.Infra = new.Infra
+	if registryNodesOnly(old.Registry, new.Registry) {
+		cfg.Registry = new.Registry
+	}
+
+	cfg.Services = make([]*Service, len(old.Services))
+	for i, s := range old.Services {
+		cfg.Services[i] = s
+		ns, ok := new.GetService(s.Name)
+		if !ok {
+			continue
+		}
+		c := make(ServiceConfig, len(s.Config))
+		for k, v := range s.Config {
+			c[k] = v
+		}
+		for _, k := range liveServiceKeys {
+			if v, ok := ns.Config[k]; ok {
+				c[k] = v
+			} else {
+				delete(c, k)
+			}
+		}
+		cfg.Services[i] = &Service{Name: s.Name, Config: c}
+	}
+	return &cfg
+}
+
+// registryNodesOnly reports whether the static nodes are the only difference.
+func registryNodesOnly(old, new *Registry) bool {
+	if old == nil || new == nil || old.Kind() != RegistryTypeStatic {
+		return false
+	}
+	o, n := *old, *new
+	o.Static, n.Static = RegistryStatic{}, RegistryStatic{}
+	return reflect.DeepEqual(o, n)
+}
+
+func serviceChanges(old, new []*Service) (live, restart []string) {
+	names := make(map[string]struct{})
+	for _, s := range append(append([]*Service(nil), old...), new...) {
+		names[s.Name] = struct{}{}
+	}
+	oldCfg, newCfg := Config{Services: old}, Config{Services: new}
+	for name := range names {
+		o, ook := oldCfg.GetService(name)
+		n, nok := newCfg.GetService(name)
+		if !ook || !nok {
+			restart = append(restart, "services."+name)
+			continue
+		}
+		if reflect.DeepEqual(o.Config, n.Config) {
+			continue
+		}
+		if reflect.DeepEqual(withoutLiveKeys(o.Config), withoutLiveKeys(n.Config)) {
+			live = append(live, "services."+name)
+		} else {
+			restart = append(restart, "services."+name)
+		}
+	}
+	sort.Strings(live)
+	sort.Strings(restart)
+	return
+}
+
+func withoutLiveKeys(c ServiceConfig) ServiceConfig {
+	out := make(ServiceConfig, len(c))
+	for k, v := range c {
+		out[k] = v
+	}
+	for _, k := range liveServiceKeys {
+		delete(out, k)
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChanges(t *testing.T) {
+	base := DefaultConfig()
+	base.Registry = &Registry{Type: RegistryTypeStatic}
+	path := writeConfig(t, base)
+	old, err := ReadFromFile(path)
+	require.NoError(t, err)
+	cfg, err := ReadFromFile(path)
+	require.NoError(t, err)
+	live, restart := Changes(old, cfg)
+	require.Empty(t, live)
+	require.Empty(t, restart)
+
+	cfg.LogLevel = "info"
+	cfg.Topic = Topic{"push_message": "push"}
+	cfg.Registry = &Registry{Type: RegistryTypeStatic, Static: RegistryStatic{
+		Nodes: []RegistryStaticNode{{Service: "mercury.comet", Address: "10.0.0.1:9002"}},
+	}}
+	comet, _ := cfg.GetService("mercury.comet")
+	comet.Config["room_rate_limit"] = float64(100)
+	logic, _ := cfg.GetService("mercury.logic")
+	logic.Config["push_mode"] = PushModeTopic
+	cfg.Redis = &Redis{}
+
+	live, restart = Changes(old, cfg)
+	require.Equal(t, []string{"log_level", "services.mercury.comet", "registry", "topic"}, live)
+	require.Equal(t, []string{"services.mercury.logic", "redis"}, restart)
+
+	applied := Live(old, cfg)
+	require.Equal(t, "info", applied.LogLevel)
+	require.Equal(t, cfg.Topic, applied.Topic)
+	require.Len(t, applied.Registry.Static.Nodes, 1)
+	comet, _ = applied.GetService("mercury.comet")
+	require.Equal(t, 100, comet.RoomRateLimit())
+	logic, _ = applied.GetService("mercury.logic")
+	require.Equal(t, PushModeSID, logic.PushMode())
+	require.Equal(t, old.Redis, applied.Redis)
+
+	// The sections of old are untouched
+	comet, _ = old.GetService("mercury.comet")
+	require.Equal(t, defaultRoomRateLimit, comet.RoomRateLimit())
+}
+
+func TestFileWatcher(t *testing.T) {
+	path := writeConfig(t, DefaultConfig())
+	cfg, err := ReadFromFile(path)
+	require.NoError(t, err)
+	require.NotEmpty(t, cfg.Version())
+
+	w, err := NewFileWatcher(cfg)
+	require.NoError(t, err)
+	defer w.Close()
+
+	changed := DefaultConfig()
+	changed.LogLevel = "warn"
+	require.NoError(t, changed.WriteToFile(path))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	next, err := w.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "warn", next.LogLevel)
+	require.NotEqual(t, cfg.Version(), next.Version())
+
+	p := NewProviderConfig(cfg)
+	p.Update(next)
+	require.Equal(t, "warn", p.LogLevel())
+	require.Equal(t, DefaultLogLevel, cfg.LogLevel)
+}
+
+func writeConfig(t *testing.T, cfg *Config) string {
+	dir, err := ioutil.TempDir("", "mercury-config")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, cfg.WriteToFile(path))
+	return path
+}
@@ -3,18 +3,18 @@ package lib
 import (
 	"context"
 	"github.com/gin-gonic/gin"
-	"github.com/micro/go-micro/v2/registry"
 	"github.com/micro/go-micro/v2/web"
-	"github.com/micro/go-plugins/registry/etcdv3/v2"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"mercury/app/admin/model"
 	"mercury/app/admin/service"
-	"mercury/x"
+	"mercury/config"
 	"mercury/x/ecode"
 	"mercury/x/ginx"
+	"mercury/x/health"
 	"mercury/x/log"
 	"mercury/x/microx"
+	"mercury/x/registryx"
 	"net/http"
-	"strings"
 )
 
 type AdminServer struct {
@@ -34,30 +34,24 @@ func NewAdminServer(inst *Instance, l log.Logger) *AdminServer {
 
 func (s *AdminServer) Serve(ctx context.Context) error {
 	cfg := s.inst.cfg
-	var err error
-	if s.srv, err = service.NewService(s.log.New("service", "mercury.admin")); err != nil {
-		return err
-	}
-
 	srvCfg, founded := cfg.GetService("mercury.admin")
 	if !founded {
 		return ecode.NewError("can not found \"mercury.admin\" service config")
 	}
+	defer startTracing(s.log, s.inst.cfg.Tracing, srvCfg.ServiceName())()
 
-	opts := microx.DefaultWebOptions(srvCfg)
-	if cfg.Registry.ETCD.Enable {
-		r := etcdv3.NewRegistry(func(op *registry.Options) {
-			var addresses []string
-			for _, v := range cfg.Registry.ETCD.Addresses {
-				v = strings.TrimSpace(v)
-				addresses = append(addresses, x.ReplaceHttpOrHttps(v))
-			}
-
-			op.Addrs = addresses
-		})
-		opts = append(opts, web.Registry(r))
+	r, err := registryx.NewRegistry(s.inst.Provider())
+	if err != nil {
+		return err
+	}
+	s.inst.OnChange(func(c *config.Config) { registryx.Update(r, c.Registry) })
+	if s.srv, err = service.NewService(cfg.ServiceName("mercury.logic"), r, s.log.New("service", "mercury.admin")); err != nil {
+		return err
 	}
 
+	opts := microx.IsolatedWebOptions(r)
+	opts = append(opts, microx.DefaultWebOptions(srvCfg)...)
+
 	microWeb := web.NewService(opts...)
 	if err = microWeb.Init(); err != nil {
 		return err
@@ -65,6 +59,18 @@ func (s *AdminServer) Serve(ctx context.Context) error {
 
 	s.registerRouter()
 	microWeb.Handle("/", s)
+	microWeb.Handle("/metrics", promhttp.Handler())
+
+	checker := health.NewChecker()
+	checker.Add("registry", registeredCheck(r, srvCfg.ServiceName(), srvCfg.ServiceName()+"-"+microWeb.Options().Id))
+	checker.Add("logic", availableCheck(r, cfg.ServiceName("mercury.logic")))
+	checker.Register(microWeb)
+
+	// The log level is changed on the internal port only
+	internal := http.NewServeMux()
+	checker.Register(internal)
+	internal.HandleFunc(logLevelPath, serveLogLevel)
+	go serveInternal(s.log, internal, srvCfg)
 
 	return microWeb.Run()
 }
@@ -8,70 +8,90 @@ import (
 	"github.com/micro/go-micro/v2/server"
 	"github.com/micro/go-micro/v2/server/grpc"
 	"github.com/micro/go-micro/v2/web"
-	"github.com/micro/go-plugins/registry/etcdv3/v2"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"mercury/app/comet/api"
 	"mercury/app/comet/service"
 	"mercury/app/comet/stats"
-	"mercury/x"
+	"mercury/config"
 	"mercury/x/ecode"
 	"mercury/x/ginx"
+	"mercury/x/health"
 	"mercury/x/log"
 	"mercury/x/microx"
+	"mercury/x/registryx"
+	"mercury/x/tracing"
 	"mercury/x/types"
 	"mercury/x/websocket"
 	"net/http"
-	"strings"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
 )
 
 type CometServer struct {
-	id     string
-	inst   *Instance
-	log    log.Logger
-	engine *gin.Engine
-	srv    service.Servicer
+	id       string
+	inst     *Instance
+	log      log.Logger
+	engine   *gin.Engine
+	srv      service.Servicer
+	registry registry.Registry
+	rpc      server.Server
+	// Logs the pushes, only a sample of them is written
+	pushLog log.Logger
+	// Set to 1 once the server started draining
+	draining int32
+	cancel   context.CancelFunc
 }
 
 func NewCometServer(inst *Instance, l log.Logger) *CometServer {
 	return &CometServer{
-		id:     uuid.New().String(),
-		inst:   inst,
-		log:    l,
-		engine: gin.New(),
+		id:      uuid.New().String(),
+		inst:    inst,
+		log:     l,
+		pushLog: sampledLogger(l, inst.cfg.LogSampling),
+		engine:  gin.New(),
 	}
 }
 
 func (s *CometServer) Serve(ctx context.Context) error {
 	cfg := s.inst.cfg
-	var err error
-	if s.srv, err = service.NewService(s.log.New("service", "mercury.comet")); err != nil {
-		return err
-	}
-
 	srvCfg, founded := cfg.GetService("mercury.comet")
 	if !founded {
 		return ecode.NewError("can not found \"mercury.job\" service config")
 	}
+	defer startTracing(s.log, s.inst.cfg.Tracing, srvCfg.ServiceName())()
 
-	webOpts := microx.DefaultWebOptions(srvCfg)
-	webOpts = append(webOpts, web.Id(s.id))
+	r, err := registryx.NewRegistry(s.inst.Provider())
+	if err != nil {
+		return err
+	}
+	if s.srv, err = service.NewService(srvCfg, cfg.ServiceName("mercury.logic"), r, s.log.New("service", "mercury.comet")); err != nil {
+		return err
+	}
+
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	webOpts := microx.IsolatedWebOptions(r)
+	webOpts = append(webOpts, microx.DefaultWebOptions(srvCfg)...)
+	webOpts = append(webOpts, web.Id(s.id), web.Context(ctx), web.HandleSignal(false), web.RegisterCheck(s.registerCheck))
 	srvOpts := microx.DefaultServerOptions(srvCfg)
-	srvOpts = append(srvOpts, server.Id(s.id), server.Address(srvCfg.RpcAddress()), server.WrapHandler(ecode.MicroHandlerFunc))
-	if cfg.Registry.ETCD.Enable {
-		r := etcdv3.NewRegistry(func(op *registry.Options) {
-			var addresses []string
-			for _, v := range cfg.Registry.ETCD.Addresses {
-				v = strings.TrimSpace(v)
-				addresses = append(addresses, x.ReplaceHttpOrHttps(v))
-			}
+	srvOpts = append(srvOpts, server.Id(s.id), server.Address(srvCfg.RpcAddress()), server.WrapHandler(microx.MetricsHandlerWrapper), server.WrapHandler(ecode.MicroHandlerFunc))
+	s.inst.OnChange(func(c *config.Config) {
+		registryx.Update(r, c.Registry)
+		if srvCfg, ok := c.GetService("mercury.comet"); ok {
+			s.srv.SessionStore().SetRateLimits(srvCfg.RoomRateLimit(), srvCfg.RoomSendRateLimit())
+		}
+	})
+	s.registry = r
+	srvOpts = append(srvOpts, server.Registry(r))
 
-			op.Addrs = addresses
-		})
-		webOpts = append(webOpts, web.Registry(r))
-		srvOpts = append(srvOpts, server.Registry(r))
+	// The rpc server is started before draining can stop it
+	if err = s.RegisterRPC(srvOpts...); err != nil {
+		return err
 	}
 
-	go s.RegisterRPC(srvOpts...)
-
 	microWeb := web.NewService(webOpts...)
 	if err = microWeb.Init(); err != nil {
 		return err
@@ -80,8 +100,113 @@ func (s *CometServer) Serve(ctx context.Context) error {
 	s.registerRouter()
 	microWeb.Handle("/", s)
 	microWeb.Handle("/debug/vars", stats.Handler)
+	microWeb.Handle("/metrics", promhttp.Handler())
+
+	checker := health.NewChecker()
+	checker.Add("draining", s.registerCheck)
+	checker.Add("registry", registeredCheck(r, srvCfg.ServiceName(), srvCfg.ServiceName()+"-"+s.id))
+	checker.Add("logic", availableCheck(r, cfg.ServiceName("mercury.logic")))
+	checker.Register(microWeb)
+
+	// Draining and the log level are changed on the internal port only, the clients reach the public one
+	internal := http.NewServeMux()
+	checker.Register(internal)
+	internal.HandleFunc(drainPath, s.serveDrain)
+	internal.HandleFunc(logLevelPath, serveLogLevel)
+	go serveInternal(s.log, internal, srvCfg)
+
+	go s.handleSignal(srvCfg)
+
+	err = microWeb.Run()
+	// Draining stops the rpc server itself
+	if atomic.LoadInt32(&s.draining) == 0 {
+		if err := s.rpc.Stop(); err != nil {
+			s.log.Error("[Serve] failed to stop rpc server", "error", err)
+		}
+	}
+	return err
+}
+
+// registerCheck keeps the web service from registering itself again once draining started.
+func (s *CometServer) registerCheck(context.Context) error {
+	if atomic.LoadInt32(&s.draining) == 1 {
+		return ecode.ErrServiceUnavailable.ResetMessage("comet is draining")
+	}
+	return nil
+}
+
+// handleSignal drains the server on SIGTERM and shuts it down immediately on SIGINT or SIGQUIT.
+func (s *CometServer) handleSignal(srvCfg *config.Service) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT)
+
+	sig := <-ch
+	s.log.Info("[Signal] received signal", "signal", sig)
+	if sig == syscall.SIGTERM {
+		s.Drain(srvCfg)
+		return
+	}
+
+	s.srv.Close()
+	s.cancel()
+}
+
+// drainPath triggers draining through a POST request on the internal port.
+const drainPath = "/debug/drain"
+
+// serveDrain serves drainPath, it returns immediately.
+func (s *CometServer) serveDrain(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
 
-	return microWeb.Run()
+	srvCfg, _ := s.inst.cfg.GetService("mercury.comet")
+	go s.Drain(srvCfg)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Drain takes the comet out of service for a rolling deploy. It deregisters the comet so that
+// job stops routing pushes to it, asks the clients to reconnect to another comet in paced
+// batches and then waits for the sessions to go away, at most until the drain deadline.
+func (s *CometServer) Drain(srvCfg *config.Service) {
+	if !atomic.CompareAndSwapInt32(&s.draining, 0, 1) {
+		return
+	}
+	s.log.Info("[Drain] comet is draining", "id", s.id, "sessions", s.srv.SessionStore().Count())
+
+	// Stopping the rpc server also stops its registration loop.
+	if err := s.rpc.Stop(); err != nil {
+		s.log.Error("[Drain] failed to stop rpc server", "error", err)
+	}
+	if err := s.registry.Deregister(&registry.Service{
+		Name:    srvCfg.ServiceName(),
+		Version: srvCfg.Version(),
+		Nodes:   []*registry.Node{{Id: srvCfg.ServiceName() + "-" + s.id}},
+	}); err != nil {
+		s.log.Error("[Drain] failed to deregister", "error", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), srvCfg.DrainDeadline())
+	defer cancel()
+
+	s.srv.SessionStore().Drain(ctx, srvCfg.DrainBatchSize(), srvCfg.DrainBatchInterval())
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for s.srv.SessionStore().Count() > 0 {
+		select {
+		case <-ctx.Done():
+			s.log.Warn("[Drain] deadline exceeded", "sessions", s.srv.SessionStore().Count())
+			s.srv.Close()
+			s.cancel()
+			return
+		case <-ticker.C:
+		}
+	}
+
+	s.log.Info("[Drain] comet drained", "id", s.id)
+	s.cancel()
 }
 
 func (s *CometServer) registerRouter() {
@@ -94,6 +219,11 @@ func (s *CometServer) registerRouter() {
 }
 
 func (s *CometServer) serveWebSocket(c *ginx.Context) {
+	if atomic.LoadInt32(&s.draining) == 1 {
+		c.Error(ecode.ErrServiceUnavailable)
+		return
+	}
+
 	conn, err := websocket.Upgrade(c.Writer, c.Request)
 	if err != nil {
 		c.Error(err)
@@ -111,41 +241,113 @@ func (s *CometServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	s.engine.ServeHTTP(w, req)
 }
 
-func (s *CometServer) RegisterRPC(opts ...server.Option) {
+// RegisterRPC starts the rpc server, it returns once the server listens.
+func (s *CometServer) RegisterRPC(opts ...server.Option) error {
 	microServer := grpc.NewServer(opts...)
 	if err := microServer.Init(); err != nil {
-		panic("unable to initialize server:" + err.Error())
+		return err
 	}
 
 	if err := api.RegisterChatHandler(microServer, s); err != nil {
-		panic("unable to register grpc server:" + err.Error())
+		return err
 	}
 
 	if err := microServer.Start(); err != nil {
-		panic("unable to start server:" + err.Error())
+		return err
 	}
+	s.rpc = microServer
+	return nil
 }
 
 func (s *CometServer) PushMessage(ctx context.Context, req *api.PushMessageReq, resp *api.Empty) error {
-	s.log.Info("[PushMessage] request is received")
+	s.pushLog.Info("[PushMessage] request is received")
 
+	mctx := tracing.Extract(ctx, req.Trace)
 	for _, sid := range req.SIDs {
 		session := s.srv.SessionStore().Get(sid)
 		if session != nil {
-			go session.QueueOut(types.Operation(req.Operation), req.Data)
+			session.QueueOrdered(mctx, types.Operation(req.Operation), req.Topic, req.Sequence, req.PushedAt, req.Data)
 		}
 	}
 	return nil
 }
 
-func (s *CometServer) BroadcastMessage(ctx context.Context, req *api.BroadcastMessageReq, resp *api.Empty) error {
-	s.log.Info("[BroadcastMessage] request is received")
+func (s *CometServer) PushMessages(ctx context.Context, req *api.PushMessagesReq, resp *api.Empty) error {
+	s.pushLog.Info("[PushMessages] request is received", "count", len(req.Messages))
 
-	sessions := s.srv.SessionStore().GetAll()
-	for _, s := range sessions {
-		if s != nil {
-			go s.QueueOut(types.OperationBroadcast, req.Data)
+	for _, m := range req.Messages {
+		mctx := tracing.Extract(ctx, m.Trace)
+		for _, sid := range m.SIDs {
+			session := s.srv.SessionStore().Get(sid)
+			if session != nil {
+				session.QueueOrdered(mctx, types.Operation(m.Operation), m.Topic, m.Sequence, m.PushedAt, m.Data)
+			}
 		}
 	}
 	return nil
 }
+
+func (s *CometServer) PushUIDMessage(ctx context.Context, req *api.PushUIDMessageReq, resp *api.Empty) error {
+	s.pushLog.Info("[PushUIDMessage] request is received")
+
+	mctx := tracing.Extract(ctx, req.Trace)
+	for _, uid := range req.UIDs {
+		for _, session := range s.srv.SessionStore().GetByUID(uid) {
+			if session.SID() != req.SkipSID {
+				session.QueueOrdered(mctx, types.Operation(req.Operation), req.Topic, req.Sequence, req.PushedAt, req.Data)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *CometServer) PushTopicMessage(ctx context.Context, req *api.PushTopicMessageReq, resp *api.Empty) error {
+	s.pushLog.Info("[PushTopicMessage] request is received", "topic", req.Topic)
+
+	store := s.srv.SessionStore()
+	store.JoinTopic(req.ClientID, req.Topic, req.JoinUIDs...)
+	if len(req.Data) == 0 {
+		return nil
+	}
+
+	mctx := tracing.Extract(ctx, req.Trace)
+	for _, session := range store.GetByTopic(req.ClientID, req.Topic) {
+		if session.SID() != req.SkipSID {
+			session.QueueOrdered(mctx, types.Operation(req.Operation), req.Topic, req.Sequence, req.PushedAt, req.Data)
+		}
+	}
+	return nil
+}
+
+func (s *CometServer) BroadcastRoom(ctx context.Context, req *api.BroadcastRoomReq, resp *api.Empty) error {
+	store := s.srv.SessionStore()
+	if !store.AllowRoom(req.Room) {
+		s.log.Debug("[BroadcastRoom] rate limit exceeded, message dropped", "room", req.Room)
+		return nil
+	}
+
+	// The viewers with a full queue miss the message, a slow viewer never holds up the room.
+	for _, session := range service.Sample(store.GetByRoom(req.Room), req.SampleRate) {
+		session.TryQueueOut(types.OperationRoom, req.Data)
+	}
+	return nil
+}
+
+func (s *CometServer) BroadcastMessage(ctx context.Context, req *api.BroadcastMessageReq, resp *api.Empty) error {
+	s.pushLog.Info("[BroadcastMessage] request is received", "clientID", req.ClientID)
+
+	if req.ClientID == "" {
+		return ecode.ErrBadRequest.ResetMessage("client id can not be empty")
+	}
+
+	filter := &service.BroadcastFilter{
+		ClientID:   req.ClientID,
+		Platforms:  req.Platforms,
+		MinVersion: req.MinVersion,
+		UIDs:       req.UIDs,
+	}
+	for _, s := range filter.Filter(s.srv.SessionStore()) {
+		s.TryQueueOut(types.OperationBroadcast, req.Data)
+	}
+	return nil
+}
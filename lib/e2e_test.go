@@ -0,0 +1,272 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+	comet "mercury/app/comet/service"
+	"mercury/app/logic/api"
+	"mercury/app/logic/persistence/memory"
+	logic "mercury/app/logic/service"
+	"mercury/config"
+	"mercury/x/ecode"
+	"mercury/x/log"
+	"mercury/x/registryx"
+	"mercury/x/types"
+)
+
+// How long the test waits for the servers and the messages
+const e2eTimeout = 10 * time.Second
+
+// TestEndToEnd runs logic, job and comet in one process on the memory broker, registry,
+// cache and persister, the users talk to comet over websocket.
+func TestEndToEnd(t *testing.T) {
+	h := newHarness(t)
+	defer h.close(t)
+
+	ctx := context.Background()
+	alice, bob := h.createUser(t, "alice"), h.createUser(t, "bob")
+	aliceConn, bobConn := h.dial(t, alice), h.dial(t, bob)
+
+	// Push
+	aliceConn.request(t, types.OperationPush, fmt.Sprintf(
+		`{"mid": "push", "message_type": "single", "receiver": "%s", "content_type": "text", "body": {"content": "Hello, Bob!"}}`, bob))
+	var pushed types.Message
+	bobConn.expect(t, types.OperationPush, &pushed)
+	require.Equal(t, alice, pushed.Sender)
+	require.Equal(t, bob, pushed.Receiver)
+	require.Equal(t, int64(1), pushed.Sequence)
+	require.JSONEq(t, `{"content": "Hello, Bob!"}`, string(pushed.Body))
+
+	// Pull
+	var topics []*api.TopicMessages
+	require.Eventually(t, func() bool {
+		var err error
+		topics, err = h.logic.srv.PullMessage(ctx, &api.PullMessageReq{ClientID: h.clientID, UID: bob})
+		require.NoError(t, err)
+		return len(topics) > 0
+	}, e2eTimeout, 10*time.Millisecond)
+	require.Len(t, topics, 1)
+	require.Equal(t, pushed.Topic, topics[0].Topic)
+	require.Equal(t, int64(1), topics[0].Count)
+	require.Equal(t, pushed.ID, topics[0].Messages[0].ID)
+	require.Equal(t, alice, topics[0].Messages[0].Sender)
+
+	// Read
+	bobConn.request(t, types.OperationNotification, fmt.Sprintf(
+		`{"mid": "read", "what": "read", "topic": "%s", "sequence": %d}`, pushed.Topic, pushed.Sequence))
+	var read types.Notification
+	aliceConn.expect(t, types.OperationNotification, &read)
+	require.Equal(t, types.WhatTypeRead, read.What)
+	require.Equal(t, pushed.Topic, read.Topic)
+	require.Equal(t, pushed.Sequence, read.Sequence)
+	require.Equal(t, pushed.ID, read.MessageID)
+
+	// Nothing is left to pull once the message is read
+	topics, err := h.logic.srv.PullMessage(ctx, &api.PullMessageReq{ClientID: h.clientID, UID: bob})
+	require.NoError(t, err)
+	require.Len(t, topics, 1)
+	require.Zero(t, topics[0].Count)
+}
+
+type harness struct {
+	cache     *memory.Cache
+	logic     *LogicServer
+	job       *JobServer
+	comet     *CometServer
+	cometAddr string
+	clientID  string
+	cancel    context.CancelFunc
+}
+
+func newHarness(t *testing.T) *harness {
+	cfg := config.DefaultConfig()
+	cfg.Registry = &config.Registry{Type: config.RegistryTypeMemory}
+	cfg.Broker = &config.Broker{Type: config.BrokerTypeMemory}
+	cometPort := freePort(t)
+	for _, s := range cfg.Services {
+		s.Config["host"] = "127.0.0.1"
+		s.Config["port"] = 0
+		delete(s.Config, "health_port")
+		if s.Name == "mercury.comet" {
+			s.Config["port"] = cometPort
+			s.Config["rpc_port"] = 0
+		}
+		// The config read from a file holds the numbers as float64
+		for k, v := range s.Config {
+			if n, ok := v.(int); ok {
+				s.Config[k] = float64(n)
+			}
+		}
+	}
+
+	// The micro services of logic and comet parse the command line, which holds the test flags
+	os.Args = os.Args[:1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	inst := newInstance(cfg, cancel)
+	l := log.New()
+	l.SetHandler(log.DiscardHandler())
+
+	h := &harness{
+		cache:     memory.NewCache(),
+		cometAddr: fmt.Sprintf("127.0.0.1:%d", cometPort),
+		cancel:    cancel,
+	}
+	h.logic = NewLogicServer(inst, l, logic.WithCacher(h.cache), logic.WithPersister(memory.NewPersister()))
+	h.job = NewJobServer(inst, l)
+	h.comet = NewCometServer(inst, l)
+
+	// Job serves in the background once Serve returns. The servers set the default
+	// registry and broker of go-micro, so they are started one after the other.
+	require.NoError(t, h.job.Serve(ctx))
+	go func() {
+		if err := h.logic.Serve(ctx); err != nil && ctx.Err() == nil {
+			t.Errorf("logic stopped: %v", err)
+		}
+	}()
+	require.Eventually(t, func() bool {
+		return registryx.Available(registryx.DefaultMemoryRegistry, "mercury.logic") == nil
+	}, e2eTimeout, 10*time.Millisecond, "logic is not registered")
+	go func() {
+		if err := h.comet.Serve(ctx); err != nil && ctx.Err() == nil {
+			t.Errorf("comet stopped: %v", err)
+		}
+	}()
+	// Job routes the pushes to the comets it synced from the registry
+	require.Eventually(t, func() bool {
+		return h.job.srv.CometCount() > 0
+	}, e2eTimeout, 10*time.Millisecond, "comet is not synced")
+
+	var err error
+	h.clientID, _, err = h.logic.srv.CreateClient(ctx, &api.CreateClientReq{
+		Name:        "e2e",
+		TokenSecret: "0123456789abcdef0123456789abcdef",
+		TokenExpire: 3600,
+	})
+	require.NoError(t, err)
+	return h
+}
+
+// close stops the servers and waits for them to deregister, as the servers of the next
+// harness share the memory registry and the IDs of the process.
+func (h *harness) close(t *testing.T) {
+	h.cancel()
+	for _, name := range []string{"mercury.logic", "mercury.comet"} {
+		require.Eventually(t, func() bool {
+			return registryx.Available(registryx.DefaultMemoryRegistry, name) != nil
+		}, e2eTimeout, 10*time.Millisecond, name+" is still registered")
+	}
+}
+
+// createUser creates a user of the client and returns its UID.
+func (h *harness) createUser(t *testing.T, name string) string {
+	ctx := logic.ContextWithClientID(context.Background(), h.clientID)
+	uid, err := h.logic.srv.CreateUser(ctx, &api.CreateUserReq{Name: name})
+	require.NoError(t, err)
+	return uid
+}
+
+// dial connects the user to comet and shakes hands.
+func (h *harness) dial(t *testing.T, uid string) *wsClient {
+	ctx := logic.ContextWithClientID(context.Background(), h.clientID)
+	token, _, err := h.logic.srv.GenerateUserToken(ctx, uid)
+	require.NoError(t, err)
+	// The token is cached in the background
+	require.Eventually(t, func() bool {
+		return h.cache.GetClientID(token) == h.clientID
+	}, e2eTimeout, 10*time.Millisecond)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws://"+h.cometAddr+"/chat/v1/channels", nil)
+	require.NoError(t, err)
+	c := &wsClient{conn: conn, frames: make(chan *comet.Protocol, 16)}
+	go c.readLoop()
+
+	c.request(t, types.OperationHandshake, fmt.Sprintf(
+		`{"mid": "handshake", "version": "%s", "user_agent": "e2e", "token": "%s"}`, comet.MinSupportedVersion, token))
+	return c
+}
+
+type wsClient struct {
+	conn   *websocket.Conn
+	frames chan *comet.Protocol
+}
+
+func (c *wsClient) readLoop() {
+	defer close(c.frames)
+	for {
+		_, raw, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var p comet.Protocol
+		if err := p.Unmarshal(raw); err != nil {
+			return
+		}
+		c.frames <- &p
+	}
+}
+
+// next returns the next frame of the operation, frames of other operations are skipped.
+func (c *wsClient) next(t *testing.T, op types.Operation) *comet.Protocol {
+	timeout := time.After(e2eTimeout)
+	for {
+		select {
+		case p, ok := <-c.frames:
+			require.True(t, ok, "connection is closed")
+			if p.Operation == op {
+				return p
+			}
+		case <-timeout:
+			require.FailNow(t, "no frame is received", "operation %s", op)
+		}
+	}
+}
+
+// request sends the request and waits for its successful response.
+func (c *wsClient) request(t *testing.T, op types.Operation, body string) {
+	data, err := (&comet.Protocol{Operation: op, Body: []byte(body)}).Marshal()
+	require.NoError(t, err)
+	require.NoError(t, c.conn.WriteMessage(websocket.BinaryMessage, data))
+
+	var req, resp struct {
+		MID     string `json:"mid"`
+		Code    int    `json:"code,string"`
+		Message string `json:"message"`
+	}
+	require.NoError(t, jsoniter.Unmarshal([]byte(body), &req))
+	for resp.MID != req.MID {
+		require.NoError(t, jsoniter.Unmarshal(c.next(t, op).Body, &resp))
+	}
+	require.Equal(t, ecode.OK.Code(), resp.Code, resp.Message)
+}
+
+// expect waits for a message pushed by the server and decodes it into v.
+func (c *wsClient) expect(t *testing.T, op types.Operation, v interface{}) {
+	var resp struct {
+		MID string `json:"mid"`
+	}
+	for {
+		p := c.next(t, op)
+		// Responses to the requests of the client carry their mid
+		if err := jsoniter.Unmarshal(p.Body, &resp); err == nil && resp.MID != "" {
+			continue
+		}
+		require.NoError(t, jsoniter.Unmarshal(p.Body, v))
+		return
+	}
+}
+
+func freePort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
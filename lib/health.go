@@ -0,0 +1,57 @@
+package lib
+
+import (
+	"context"
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"mercury/config"
+	"mercury/x/brokerx"
+	"mercury/x/health"
+	"mercury/x/log"
+	"mercury/x/registryx"
+	"net/http"
+)
+
+// serveMonitoring serves the health endpoints, /metrics and the log level on the health
+// port of the service, for servers without an HTTP server of their own.
+func serveMonitoring(l log.Logger, checker *health.Checker, srvCfg *config.Service) {
+	mux := http.NewServeMux()
+	checker.Register(mux)
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc(logLevelPath, serveLogLevel)
+	serveInternal(l, mux, srvCfg)
+}
+
+// serveInternal serves mux on the health port of the service, nothing is served if the
+// port is 0. The endpoints changing the server at runtime are served there rather than on
+// the public port, the health port must be reachable from inside the cluster only.
+func serveInternal(l log.Logger, mux *http.ServeMux, srvCfg *config.Service) {
+	if srvCfg.HealthPort() == 0 {
+		return
+	}
+	if err := http.ListenAndServe(srvCfg.HealthAddress(), mux); err != nil {
+		l.Error("[Health] failed to serve health endpoints", "address", srvCfg.HealthAddress(), "error", err)
+	}
+}
+
+// brokerCheck checks that the broker is reachable.
+func brokerCheck(b broker.Broker) health.Check {
+	return func(context.Context) error {
+		return brokerx.Ping(b)
+	}
+}
+
+// registeredCheck checks that the node of the service is registered.
+func registeredCheck(r registry.Registry, name, nodeID string) health.Check {
+	return func(context.Context) error {
+		return registryx.Registered(r, name, nodeID)
+	}
+}
+
+// availableCheck checks that a service the server depends on has a node.
+func availableCheck(r registry.Registry, name string) health.Check {
+	return func(context.Context) error {
+		return registryx.Available(r, name)
+	}
+}
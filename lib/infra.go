@@ -4,12 +4,15 @@ import (
 	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/micro/go-micro/v2/web"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"mercury/app/infra/service"
 	"mercury/x/ecode"
 	"mercury/x/ginx"
+	"mercury/x/health"
 	"mercury/x/log"
 	"mercury/x/microx"
 	"net/http"
+	"time"
 )
 
 type InfraServer struct {
@@ -34,10 +37,13 @@ func (s *InfraServer) Serve(ctx context.Context) error {
 		return err
 	}
 
+	s.inst.OnChange(s.srv.SetConfig)
+
 	srvCfg, founded := cfg.GetService("mercury.infra")
 	if !founded {
 		return ecode.NewError("can not found \"mercury.infra\" service config")
 	}
+	defer startTracing(s.log, s.inst.cfg.Tracing, srvCfg.ServiceName())()
 	microWeb := web.NewService(microx.DefaultWebOptions(srvCfg)...)
 	if err = microWeb.Init(); err != nil {
 		return err
@@ -45,6 +51,19 @@ func (s *InfraServer) Serve(ctx context.Context) error {
 
 	s.registerRouter()
 	microWeb.Handle("/", s)
+	microWeb.Handle("/metrics", promhttp.Handler())
+
+	checker := health.NewChecker()
+	checker.Add("config", func(context.Context) error {
+		return s.srv.Check()
+	})
+	checker.Register(microWeb)
+
+	// The log level is changed on the internal port only
+	internal := http.NewServeMux()
+	checker.Register(internal)
+	internal.HandleFunc(logLevelPath, serveLogLevel)
+	go serveInternal(s.log, internal, srvCfg)
 
 	return microWeb.Run()
 }
@@ -62,8 +81,42 @@ func (s *InfraServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	s.engine.ServeHTTP(w, req)
 }
 
+// Longest time a request for the config is held while the config is unchanged
+const maxConfigWait = time.Minute
+
+// loadConfig serves the config to the credential which signed the request. With the
+// version query the request is held until the config differs from the version, or up to
+// the duration of the wait query.
 func (s *InfraServer) loadConfig(c *ginx.Context) {
-	resp, err := s.srv.LoadConfig()
+	cred, err := s.srv.Authenticate(c.Request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	version, watch := c.GetQuery("version")
+	if !watch {
+		resp, err := s.srv.LoadConfig(cred)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.Success(resp)
+		return
+	}
+
+	wait, err := time.ParseDuration(c.DefaultQuery("wait", "30s"))
+	if err != nil || wait <= 0 {
+		c.Error(ecode.ErrBadRequest)
+		return
+	}
+	if wait > maxConfigWait {
+		wait = maxConfigWait
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), wait)
+	defer cancel()
+	resp, err := s.srv.WatchConfig(ctx, cred, version)
 	if err != nil {
 		c.Error(err)
 		return
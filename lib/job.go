@@ -2,18 +2,15 @@ package lib
 
 import (
 	"context"
-	"github.com/micro/go-micro/v2/broker"
-	"github.com/micro/go-micro/v2/registry"
 	"github.com/micro/go-micro/v2/server"
-	"github.com/micro/go-plugins/broker/stan/v2"
-	"github.com/micro/go-plugins/registry/etcdv3/v2"
 	"mercury/app/job/service"
 	"mercury/config"
-	"mercury/x"
+	"mercury/x/brokerx"
 	"mercury/x/ecode"
+	"mercury/x/health"
 	"mercury/x/log"
 	"mercury/x/microx"
-	"strings"
+	"mercury/x/registryx"
 )
 
 type JobServer struct {
@@ -30,7 +27,7 @@ func NewJobServer(inst *Instance, l log.Logger) *JobServer {
 }
 
 func (s *JobServer) Serve(ctx context.Context) error {
-	cfg := config.NewProviderConfig(s.inst.cfg)
+	cfg := s.inst.Provider()
 	var err error
 	if s.srv, err = service.NewService(cfg, s.log.New("service", "mercury.job")); err != nil {
 		return err
@@ -40,51 +37,53 @@ func (s *JobServer) Serve(ctx context.Context) error {
 	if !founded {
 		return ecode.NewError("can not found \"mercury.job\" service config")
 	}
+	// The job keeps running after Serve returns, the batcher exports the spans meanwhile
+	startTracing(s.log, cfg.Tracing(), srvCfg.ServiceName())
 
 	opts := microx.DefaultServerOptions(srvCfg)
-	// 判断是否使用了etcd作为服务注册
-	if cfg.Registry().ETCD.Enable {
-		r := etcdv3.NewRegistry(func(op *registry.Options) {
-			var addresses []string
-			for _, v := range cfg.Registry().ETCD.Addresses {
-				v = strings.TrimSpace(v)
-				addresses = append(addresses, x.ReplaceHttpOrHttps(v))
-			}
-
-			op.Addrs = addresses
-		})
-		opts = append(opts, server.Registry(r))
+	// 创建配置中选择的服务注册实例
+	r, err := registryx.NewRegistry(cfg)
+	if err != nil {
+		return err
 	}
+	s.inst.OnChange(func(c *config.Config) {
+		registryx.Update(r, c.Registry)
+		s.srv.Resubscribe()
+	})
+	opts = append(opts, server.Registry(r))
 
-	if cfg.Broker().Stan.Enable {
-		// 创建一个新stanBroker实例
-		b := stan.NewBroker(
-			// 设置stan集群的地址
-			broker.Addrs(cfg.Broker().Stan.Addresses...),
-			stan.ConnectRetry(true),
-			// 设置stan集群标识
-			stan.ClusterID(cfg.Broker().Stan.ClusterID),
-			// 设置订阅者使用的永久名
-			stan.DurableName(cfg.Broker().Stan.DurableName),
-		)
-
-		if err := b.Init(); err != nil {
-			panic("unable to init stan broker:" + err.Error())
-		}
+	// 创建配置中选择的broker实例
+	b, err := brokerx.NewBroker(cfg)
+	if err != nil {
+		return err
+	}
 
-		if err := b.Connect(); err != nil {
-			panic("unable to connect to stan broker:" + err.Error())
-		}
+	if err := b.Init(); err != nil {
+		panic("unable to init broker:" + err.Error())
+	}
 
-		broker.DefaultBroker = b
-		opts = append(opts, server.Broker(b))
+	if err := b.Connect(); err != nil {
+		panic("unable to connect to broker:" + err.Error())
 	}
 
+	opts = append(opts, server.Broker(b))
+
 	microServer := server.NewServer(opts...)
 	if err := microServer.Init(); err != nil {
 		return err
 	}
 	s.srv.Init(microServer.Options())
 
+	checker := health.NewChecker()
+	checker.Add("broker", brokerCheck(b))
+	checker.Add("registry", registeredCheck(r, srvCfg.ServiceName(), srvCfg.ServiceName()+"-"+microServer.Options().Id))
+	checker.Add("comet", func(context.Context) error {
+		if s.srv.CometCount() == 0 {
+			return ecode.NewError("no comet is synced")
+		}
+		return nil
+	})
+	go serveMonitoring(s.log, checker, srvCfg)
+
 	return microServer.Start()
 }
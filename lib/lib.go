@@ -4,10 +4,16 @@ import (
 	"context"
 	"mercury/config"
 	"mercury/x/ecode"
+	"mercury/x/log"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 )
 
+// How long the instance waits to watch the config again after an error
+const watchRetryInterval = 5 * time.Second
+
 var (
 	// ErrNoRepo is an error for  when a repo does not exist at a given path
 	ErrNoRepo = ecode.NewError("no repo exists")
@@ -15,6 +21,13 @@ var (
 
 type Instance struct {
 	cfg *config.Config
+	// Provides the live sections of the latest config to the servers
+	provider *config.ProviderConfig
+
+	mu sync.Mutex
+	// Latest config read by the watcher
+	latest    *config.Config
+	listeners []func(cfg *config.Config)
 
 	cancel context.CancelFunc
 	doneCh chan struct{}
@@ -47,17 +60,18 @@ func NewInstanceWithRepoPath(ctx context.Context, repoPath string) (inst *Instan
 		return
 	}
 
-	inst = &Instance{
-		cancel: cancel,
-		doneCh: make(chan struct{}),
-		cfg:    cfg,
+	inst = newInstance(cfg, cancel)
+	if w, wErr := config.NewFileWatcher(cfg); wErr != nil {
+		log.Warn("[Config] unable to watch the config file", "error", wErr)
+	} else {
+		go inst.watch(ctx, w)
 	}
 
 	ok = true
 	return
 }
 
-func NewInstanceWithInfraUrl(ctx context.Context, infraUrl string) (inst *Instance, err error) {
+func NewInstanceWithInfraUrl(ctx context.Context, infraUrl string, cred config.Credential) (inst *Instance, err error) {
 	ctx, cancel := context.WithCancel(ctx)
 	ok := false
 	defer func() {
@@ -72,7 +86,7 @@ func NewInstanceWithInfraUrl(ctx context.Context, infraUrl string) (inst *Instan
 	}
 
 	var cfg *config.Config
-	if cfg, err = loadRemoteConfig(ctx, infraUrl); err != nil {
+	if cfg, err = loadRemoteConfig(ctx, infraUrl, cred); err != nil {
 		return
 	}
 
@@ -81,16 +95,23 @@ func NewInstanceWithInfraUrl(ctx context.Context, infraUrl string) (inst *Instan
 		return
 	}
 
-	inst = &Instance{
-		cancel: cancel,
-		doneCh: make(chan struct{}),
-		cfg:    cfg,
-	}
+	inst = newInstance(cfg, cancel)
+	go inst.watch(ctx, config.NewRemoteWatcher(infraUrl, cred, cfg))
 
 	ok = true
 	return
 }
 
+func newInstance(cfg *config.Config, cancel context.CancelFunc) *Instance {
+	return &Instance{
+		cancel:   cancel,
+		doneCh:   make(chan struct{}),
+		cfg:      cfg,
+		provider: config.NewProviderConfig(cfg),
+		latest:   cfg,
+	}
+}
+
 // Config provides methods for manipulating Mercury configuration
 func (inst *Instance) Config() *config.Config {
 	if inst == nil {
@@ -99,6 +120,74 @@ func (inst *Instance) Config() *config.Config {
 	return inst.cfg
 }
 
+// Provider returns the config of the servers, its live sections follow the changes of
+// the config.
+func (inst *Instance) Provider() *config.ProviderConfig {
+	return inst.provider
+}
+
+// OnChange calls fn with every new config once its live sections are applied to the
+// Provider. Servers apply the changes they hold on to themselves in fn.
+func (inst *Instance) OnChange(fn func(cfg *config.Config)) {
+	inst.mu.Lock()
+	inst.listeners = append(inst.listeners, fn)
+	inst.mu.Unlock()
+}
+
+// watch applies the changes of the config until ctx is done. Changes which need a
+// restart are reported only.
+func (inst *Instance) watch(ctx context.Context, w config.Watcher) {
+	defer w.Close()
+	for {
+		cfg, err := w.Next(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == config.ErrWatchUnsupported || err == config.ErrWatcherClosed {
+			log.Warn("[Config] stopped watching the config", "error", err)
+			return
+		}
+		if err != nil {
+			log.Warn("[Config] failed to watch the config, retrying", "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(watchRetryInterval):
+			}
+			continue
+		}
+		inst.apply(cfg)
+	}
+}
+
+func (inst *Instance) apply(cfg *config.Config) {
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+
+	live, restart := config.Changes(inst.latest, cfg)
+	inst.latest = cfg
+	if len(restart) > 0 {
+		log.Warn("[Config] changes need a restart to take effect", "version", cfg.Version(), "sections", restart)
+	}
+	if len(live) == 0 {
+		return
+	}
+
+	inst.provider.Update(cfg)
+	for _, section := range live {
+		if section != "log_level" {
+			continue
+		}
+		if lvl, err := log.LvlFromString(cfg.LogLevel); err == nil {
+			log.Levels().SetLevel(lvl)
+		}
+	}
+	for _, fn := range inst.listeners {
+		fn(cfg)
+	}
+	log.Info("[Config] changes are applied", "version", cfg.Version(), "sections", live)
+}
+
 func (inst *Instance) Shutdown() <-chan error {
 	errCh := make(chan error)
 	go func() {
@@ -119,6 +208,6 @@ func loadRepoConfig(repoPath string) (*config.Config, error) {
 	return config.ReadFromFile(path)
 }
 
-func loadRemoteConfig(ctx context.Context, url string) (*config.Config, error) {
-	return config.ReadFromRemote(ctx, url)
+func loadRemoteConfig(ctx context.Context, url string, cred config.Credential) (*config.Config, error) {
+	return config.ReadFromRemote(ctx, url, cred)
 }
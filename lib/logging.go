@@ -0,0 +1,71 @@
+package lib
+
+import (
+	"encoding/json"
+	"mercury/config"
+	"mercury/x/log"
+	"net/http"
+	"time"
+)
+
+// logLevelPath changes the level of the logs at runtime:
+//
+//	GET    /debug/log                 shows the level and the debugged users
+//	PUT    /debug/log?level=info      changes the level
+//	PUT    /debug/log?debug_uid=<uid> writes the debug records of the user
+//	DELETE /debug/log?debug_uid=<uid> stops writing the debug records of the user
+//
+// The change applies to the servers of the process only. It is served on the internal
+// health port of the servers, never on their public one.
+const logLevelPath = "/debug/log"
+
+type logLevelStatus struct {
+	Level     string   `json:"level"`
+	DebugUIDs []string `json:"debug_uids"`
+}
+
+// serveLogLevel serves logLevelPath for the levels of the process.
+func serveLogLevel(w http.ResponseWriter, req *http.Request) {
+	levels := log.Levels()
+	query := req.URL.Query()
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if s := query.Get("level"); s != "" {
+			lvl, err := log.LvlFromString(s)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			levels.SetLevel(lvl)
+			log.Info("[Log] level is changed", "level", lvl.String())
+		}
+		if uid := query.Get("debug_uid"); uid != "" {
+			levels.DebugUID(uid)
+			log.Info("[Log] user is debugged", "uid", uid)
+		}
+	case http.MethodDelete:
+		if uid := query.Get("debug_uid"); uid != "" {
+			levels.UndebugUID(uid)
+			log.Info("[Log] user is no longer debugged", "uid", uid)
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&logLevelStatus{
+		Level:     levels.Level().String(),
+		DebugUIDs: levels.DebugUIDs(),
+	})
+}
+
+// sampledLogger returns a logger for the hot paths of the server, which writes only a
+// sample of the records of every message.
+func sampledLogger(l log.Logger, c *config.LogSampling) log.Logger {
+	if c == nil {
+		return l
+	}
+	return log.Sampled(l, c.First, c.Thereafter, time.Second)
+}
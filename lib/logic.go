@@ -3,91 +3,84 @@ package lib
 import (
 	"context"
 	"github.com/micro/go-micro/v2"
-	"github.com/micro/go-micro/v2/broker"
-	"github.com/micro/go-micro/v2/registry"
-	"github.com/micro/go-plugins/broker/stan/v2"
-	"github.com/micro/go-plugins/registry/etcdv3/v2"
 	ratelimit "github.com/micro/go-plugins/wrapper/ratelimiter/uber/v2"
 	"mercury/app/logic/api"
 	"mercury/app/logic/service"
 	"mercury/config"
-	"mercury/x"
+	"mercury/x/brokerx"
 	"mercury/x/ecode"
+	"mercury/x/health"
 	"mercury/x/log"
 	"mercury/x/microx"
-	"strings"
+	"mercury/x/registryx"
+	"mercury/x/tracing"
 )
 
 type LogicServer struct {
 	inst *Instance
 	log  log.Logger
 	srv  service.Servicer
+	// Options of the service, e.g. the cache and the persister to use
+	opts []service.Option
 }
 
-func NewLogicServer(inst *Instance, l log.Logger) *LogicServer {
+func NewLogicServer(inst *Instance, l log.Logger, opts ...service.Option) *LogicServer {
 	return &LogicServer{
 		inst: inst,
 		log:  l,
+		opts: opts,
 	}
 }
 
 func (s *LogicServer) Serve(ctx context.Context) error {
-	cfg := config.NewProviderConfig(s.inst.cfg)
-	var err error
-	if s.srv, err = service.NewService(cfg, s.log.New("service", "mercury.logic")); err != nil {
-		return err
-	}
-
+	cfg := s.inst.Provider()
 	srvCfg, founded := cfg.GetService("mercury.logic")
 	if !founded {
 		return ecode.NewError("can not found \"mercury.job\" service config")
 	}
+	defer startTracing(s.log, cfg.Tracing(), srvCfg.ServiceName())()
+
+	// 创建配置中选择的服务注册实例
+	r, err := registryx.NewRegistry(cfg)
+	if err != nil {
+		return err
+	}
+	s.inst.OnChange(func(c *config.Config) { registryx.Update(r, c.Registry) })
+
+	// 创建配置中选择的broker实例
+	b, err := brokerx.NewBroker(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := b.Init(); err != nil {
+		panic("unable to init broker:" + err.Error())
+	}
 
-	opts := microx.DefaultMicroOptions(srvCfg)
-	opts = append(opts, micro.WrapHandler(
+	if err := b.Connect(); err != nil {
+		panic("unable to connect to broker:" + err.Error())
+	}
+
+	// The options of the server may replace the broker
+	opts := append([]service.Option{service.WithBroker(b)}, s.opts...)
+	if s.srv, err = service.NewService(cfg, s.log.New("service", "mercury.logic"), opts...); err != nil {
+		return err
+	}
+
+	// The registry and the broker are the service's own, not the defaults of go-micro
+	microOpts := microx.IsolatedMicroOptions(r, b)
+	microOpts = append(microOpts, microx.DefaultMicroOptions(srvCfg)...)
+	// The server stops once ctx is done
+	microOpts = append(microOpts, micro.Context(ctx))
+	microOpts = append(microOpts, micro.WrapHandler(
+		microx.MetricsHandlerWrapper,
+		tracing.HandlerWrapper,
 		ratelimit.NewHandlerWrapper(1024),
 		service.AuthenticateClientToken(s.srv),
+		service.RequestLogger(s.log.New("service", "mercury.logic")),
 	))
 
-	// 判断是否使用了etcd作为服务注册
-	if cfg.Registry().ETCD.Enable {
-		r := etcdv3.NewRegistry(func(op *registry.Options) {
-			var addresses []string
-			for _, v := range cfg.Registry().ETCD.Addresses {
-				v = strings.TrimSpace(v)
-				addresses = append(addresses, x.ReplaceHttpOrHttps(v))
-			}
-
-			op.Addrs = addresses
-		})
-		opts = append(opts, micro.Registry(r))
-	}
-
-	if cfg.Broker().Stan.Enable {
-		// 创建一个新stanBroker实例
-		b := stan.NewBroker(
-			// 设置stan集群的地址
-			broker.Addrs(cfg.Broker().Stan.Addresses...),
-			stan.ConnectRetry(true),
-			// 设置stan集群标识
-			stan.ClusterID(cfg.Broker().Stan.ClusterID),
-			// 设置订阅者使用的永久名
-			stan.DurableName(cfg.Broker().Stan.DurableName),
-		)
-
-		if err := b.Init(); err != nil {
-			panic("unable to init stan broker:" + err.Error())
-		}
-
-		if err := b.Connect(); err != nil {
-			panic("unable to connect to stan broker:" + err.Error())
-		}
-
-		broker.DefaultBroker = b
-		opts = append(opts, micro.Broker(b))
-	}
-
-	microServer := micro.NewService(opts...)
+	microServer := micro.NewService(microOpts...)
 	microServer.Init()
 
 	if err := api.RegisterChatAdminHandler(microServer.Server(), s); err != nil {
@@ -100,6 +93,13 @@ func (s *LogicServer) Serve(ctx context.Context) error {
 		panic("unable to register grpc service:" + err.Error())
 	}
 
+	checker := health.NewChecker()
+	checker.Add("cache", func(context.Context) error { return s.srv.PingCache() })
+	checker.Add("persister", func(context.Context) error { return s.srv.PingPersister() })
+	checker.Add("broker", brokerCheck(b))
+	checker.Add("registry", registeredCheck(r, srvCfg.ServiceName(), srvCfg.ServiceName()+"-"+microServer.Server().Options().Id))
+	go serveMonitoring(s.log, checker, srvCfg)
+
 	return microServer.Run()
 }
 
@@ -256,6 +256,24 @@ func (s *LogicServer) GetMembers(ctx context.Context, req *api.GetMembersReq, re
 	return nil
 }
 
+func (s *LogicServer) Broadcast(ctx context.Context, req *api.BroadcastReq, resp *api.Empty) error {
+	err := s.srv.Broadcast(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *LogicServer) BroadcastRoom(ctx context.Context, req *api.BroadcastRoomReq, resp *api.Empty) error {
+	err := s.srv.BroadcastRoom(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *LogicServer) Listen(ctx context.Context, req *api.ListenReq, stream api.ChatClientAdmin_ListenStream) error {
 	err := s.srv.Listen(ctx, req.Token, stream)
 	if err != nil {
@@ -265,13 +283,14 @@ func (s *LogicServer) Listen(ctx context.Context, req *api.ListenReq, stream api
 }
 
 func (s *LogicServer) Connect(ctx context.Context, req *api.ConnectReq, resp *api.ConnectResp) error {
-	clientID, uid, err := s.srv.Connect(ctx, req)
+	connected, err := s.srv.Connect(ctx, req)
 	if err != nil {
 		return err
 	}
 
-	resp.ClientID = clientID
-	resp.UID = uid
+	resp.ClientID = connected.ClientID
+	resp.UID = connected.UID
+	resp.Topics = connected.Topics
 	return nil
 }
 
@@ -294,13 +313,14 @@ func (s *LogicServer) Heartbeat(ctx context.Context, req *api.HeartbeatReq, resp
 }
 
 func (s *LogicServer) PushMessage(ctx context.Context, req *api.PushMessageReq, resp *api.PushMessageResp) error {
-	id, sequence, err := s.srv.PushMessage(ctx, req)
+	pushed, err := s.srv.PushMessage(ctx, req)
 	if err != nil {
 		return err
 	}
 
-	resp.MessageId = id
-	resp.Sequence = sequence
+	resp.MessageId = pushed.MessageId
+	resp.Sequence = pushed.Sequence
+	resp.Topic = pushed.Topic
 	return nil
 }
 
@@ -323,6 +343,16 @@ func (s *LogicServer) ReadMessage(ctx context.Context, req *api.ReadMessageReq,
 	return nil
 }
 
+func (s *LogicServer) SearchMessages(ctx context.Context, req *api.SearchMessagesReq, resp *api.SearchMessagesResp) error {
+	messages, err := s.srv.SearchMessages(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	resp.Messages = messages
+	return nil
+}
+
 func (s *LogicServer) Keypress(ctx context.Context, req *api.KeypressReq, resp *api.Empty) error {
 	err := s.srv.Keypress(ctx, req)
 	if err != nil {
@@ -331,3 +361,12 @@ func (s *LogicServer) Keypress(ctx context.Context, req *api.KeypressReq, resp *
 
 	return nil
 }
+
+func (s *LogicServer) PushRoomMessage(ctx context.Context, req *api.PushRoomMessageReq, resp *api.Empty) error {
+	err := s.srv.PushRoomMessage(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package lib
+
+import (
+	"context"
+	"mercury/config"
+	"mercury/x/log"
+	"mercury/x/tracing"
+	"time"
+)
+
+// startTracing installs the tracer provider of the service, the returned function
+// flushes the spans when the server stops. Servers keep running if tracing fails.
+func startTracing(l log.Logger, c *config.Tracing, serviceName string) func() {
+	shutdown, err := tracing.Init(c, serviceName)
+	if err != nil {
+		l.Error("[Tracing] failed to initialize tracing", "error", err)
+		return func() {}
+	}
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			l.Warn("[Tracing] failed to flush spans", "error", err)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package brokerx
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v7"
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/stretchr/testify/require"
+	"mercury/config"
+)
+
+// The kafka broker is tested against the kafka server of this variable, the topics of the
+// tests are left behind.
+const kafkaAddrEnv = "MERCURY_TEST_KAFKA_ADDR"
+
+// How long the tests wait for the messages of a broker
+const testBrokerWait = 10 * time.Second
+
+// testTopic returns a topic no other test uses.
+func testTopic() string {
+	return "test-" + uuid.New().String()
+}
+
+// counter counts the messages handled by a subscriber.
+func counter(n *int32) broker.Handler {
+	return func(e broker.Event) error {
+		atomic.AddInt32(n, 1)
+		return nil
+	}
+}
+
+// testBroker runs the tests every broker passes, newBroker returns a connected broker
+// with MaxDeliver(3).
+func testBroker(t *testing.T, newBroker func(t *testing.T) broker.Broker) {
+	t.Run("Queue", func(t *testing.T) {
+		b := newBroker(t)
+		topic := testTopic()
+
+		var q1, q2, fanout int32
+		for _, s := range []struct {
+			n    *int32
+			opts []broker.SubscribeOption
+		}{
+			{&q1, []broker.SubscribeOption{broker.Queue("job")}},
+			{&q2, []broker.SubscribeOption{broker.Queue("job")}},
+			{&fanout, nil},
+		} {
+			sub, err := b.Subscribe(topic, counter(s.n), s.opts...)
+			require.NoError(t, err)
+			defer sub.Unsubscribe()
+		}
+
+		for i := 0; i < 10; i++ {
+			require.NoError(t, b.Publish(topic, &broker.Message{Body: []byte("hello")}))
+		}
+		// Members of a queue share the messages, the other subscribers get them all
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&q1)+atomic.LoadInt32(&q2) == 10 && atomic.LoadInt32(&fanout) == 10
+		}, testBrokerWait, 10*time.Millisecond)
+	})
+
+	t.Run("Redeliver", func(t *testing.T) {
+		b := newBroker(t)
+		topic := testTopic()
+
+		var delivered, handled int32
+		sub, err := b.Subscribe(topic, func(e broker.Event) error {
+			if string(e.Message().Body) == "fail" {
+				atomic.AddInt32(&delivered, 1)
+				return errors.New("failed")
+			}
+			atomic.AddInt32(&handled, 1)
+			return nil
+		}, broker.Queue("job"))
+		require.NoError(t, err)
+		defer sub.Unsubscribe()
+
+		require.NoError(t, b.Publish(topic, &broker.Message{Body: []byte("fail")}))
+		require.NoError(t, b.Publish(topic, &broker.Message{Body: []byte("ok")}))
+		// A failed message is delivered MaxDeliver times, then dropped
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&handled) == 1 && atomic.LoadInt32(&delivered) == 3
+		}, testBrokerWait, 10*time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
+		require.Equal(t, int32(3), atomic.LoadInt32(&delivered))
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		b := newBroker(t)
+		if b.String() == "memory" {
+			t.Skip("the memory broker keeps no messages without subscribers")
+		}
+		topic := testTopic()
+
+		var n int32
+		sub, err := b.Subscribe(topic, counter(&n), broker.Queue("job"))
+		require.NoError(t, err)
+		require.NoError(t, b.Publish(topic, &broker.Message{}))
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&n) == 1
+		}, testBrokerWait, 10*time.Millisecond)
+		require.NoError(t, sub.Unsubscribe())
+
+		// The group resumes after the last message it handled
+		require.NoError(t, b.Publish(topic, &broker.Message{}))
+		sub, err = b.Subscribe(topic, counter(&n), broker.Queue("job"))
+		require.NoError(t, err)
+		defer sub.Unsubscribe()
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&n) == 2
+		}, testBrokerWait, 10*time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
+		require.Equal(t, int32(2), atomic.LoadInt32(&n))
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		b := newBroker(t)
+		topic := testTopic()
+
+		var mux sync.Mutex
+		var received []string
+		sub, err := b.Subscribe(topic, func(e broker.Event) error {
+			mux.Lock()
+			defer mux.Unlock()
+			received = append(received, string(e.Message().Body))
+			return nil
+		}, broker.Queue("job"))
+		require.NoError(t, err)
+		defer sub.Unsubscribe()
+
+		var sent []string
+		for i := 0; i < 20; i++ {
+			body := strconv.Itoa(i)
+			sent = append(sent, body)
+			require.NoError(t, b.Publish(topic, &broker.Message{
+				Header: map[string]string{PartitionKey: "key"},
+				Body:   []byte(body),
+			}))
+		}
+		// The messages of a key keep their order for a group with a single subscriber
+		require.Eventually(t, func() bool {
+			mux.Lock()
+			defer mux.Unlock()
+			return len(received) == len(sent)
+		}, testBrokerWait, 10*time.Millisecond)
+		mux.Lock()
+		defer mux.Unlock()
+		require.Equal(t, sent, received)
+	})
+}
+
+func TestMemoryBroker(t *testing.T) {
+	testBroker(t, func(t *testing.T) broker.Broker {
+		return newTestMemoryBroker(t)
+	})
+}
+
+func newTestRedisStreamBroker(t *testing.T, addr string) broker.Broker {
+	b := NewRedisStreamBroker(broker.Addrs(addr), MaxDeliver(3))
+	require.NoError(t, b.Connect())
+	t.Cleanup(func() {
+		_ = b.Disconnect()
+	})
+	return b
+}
+
+func TestRedisStreamBroker(t *testing.T) {
+	block := redisStreamBlock
+	redisStreamBlock = 100 * time.Millisecond
+	defer func() {
+		redisStreamBlock = block
+	}()
+
+	m := miniredis.RunT(t)
+	testBroker(t, func(t *testing.T) broker.Broker {
+		return newTestRedisStreamBroker(t, m.Addr())
+	})
+}
+
+func TestRedisStreamBrokerClaim(t *testing.T) {
+	block := redisStreamBlock
+	redisStreamBlock = 100 * time.Millisecond
+	defer func() {
+		redisStreamBlock = block
+	}()
+
+	m := miniredis.RunT(t)
+	b := newTestRedisStreamBroker(t, m.Addr())
+	client := redis.NewClient(&redis.Options{Addr: m.Addr()})
+	defer client.Close()
+	topic := testTopic()
+	group := "mercury-durable-job"
+
+	// A consumer of a stopped instance read the message and never acknowledged it
+	var n int32
+	sub, err := b.Subscribe(topic, counter(&n), broker.Queue("job"))
+	require.NoError(t, err)
+	require.NoError(t, sub.Unsubscribe())
+	require.NoError(t, b.Publish(topic, &broker.Message{}))
+	start := time.Now()
+	m.SetTime(start)
+	_, err = client.XReadGroup(&redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: "stopped",
+		Streams:  []string{topic, ">"},
+		Count:    1,
+	}).Result()
+	require.NoError(t, err)
+
+	// Another consumer takes it over once it was pending long enough
+	m.SetTime(start.Add(redisStreamClaimIdle))
+	sub, err = b.Subscribe(topic, counter(&n), broker.Queue("job"))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&n) == 1
+	}, testBrokerWait, 10*time.Millisecond)
+	pending, err := client.XPending(topic, group).Result()
+	require.NoError(t, err)
+	require.Zero(t, pending.Count)
+
+	// The consumers are named after the instance and leave the group when they stop
+	hostname, _ := os.Hostname()
+	consumer := hostname + "-" + defaultInstanceID
+	require.Equal(t, consumer, sub.(*redisStreamSubscriber).consumer)
+	require.NoError(t, sub.Unsubscribe())
+	consumers, err := client.Do("XINFO", "CONSUMERS", topic, group).Result()
+	require.NoError(t, err)
+	for _, c := range consumers.([]interface{}) {
+		require.NotContains(t, c, consumer)
+	}
+}
+
+func TestJetStreamBroker(t *testing.T) {
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	require.NoError(t, err)
+	go srv.Start()
+	defer srv.Shutdown()
+	require.True(t, srv.ReadyForConnections(testBrokerWait))
+
+	testBroker(t, func(t *testing.T) broker.Broker {
+		b := NewJetStreamBroker(broker.Addrs(srv.ClientURL()), MaxDeliver(3))
+		require.NoError(t, b.Connect())
+		t.Cleanup(func() {
+			_ = b.Disconnect()
+		})
+		return b
+	})
+}
+
+func TestKafkaBroker(t *testing.T) {
+	addr := os.Getenv(kafkaAddrEnv)
+	if addr == "" {
+		t.Skip(kafkaAddrEnv + " is not set")
+	}
+	testBroker(t, func(t *testing.T) broker.Broker {
+		b := NewKafkaBroker(broker.Addrs(addr), MaxDeliver(3))
+		require.NoError(t, b.Connect())
+		t.Cleanup(func() {
+			_ = b.Disconnect()
+		})
+		return b
+	})
+}
+
+func TestNewBroker(t *testing.T) {
+	cfg := config.DefaultBroker()
+	cfg.Type = ""
+	cfg.Stan.Enable = false
+	// Without a type or stan the default broker of go-micro is kept
+	b, err := NewBroker(brokerConfig{cfg})
+	require.NoError(t, err)
+	require.Equal(t, broker.DefaultBroker, b)
+
+	cfg.Type = config.BrokerTypeRedisStream
+	b, err = NewBroker(brokerConfig{cfg})
+	require.NoError(t, err)
+	require.Equal(t, "redis_stream", b.String())
+
+	cfg.Type = "unknown"
+	_, err = NewBroker(brokerConfig{cfg})
+	require.Error(t, err)
+}
+
+type brokerConfig struct {
+	cfg *config.Broker
+}
+
+func (c brokerConfig) Broker() *config.Broker {
+	return c.cfg
+}
@@ -0,0 +1,308 @@
+// Package brokerx provides the message brokers mercury can run on. Every broker
+// implements broker.Broker of go-micro with the same subscription semantics:
+//
+// A subscriber with a queue joins the durable consumer group named after the queue,
+// each message is handled by one subscriber of the group and the group resumes
+// from the last acknowledged message after a restart. A subscriber without a queue
+// gets a copy of every message.
+//
+// A message is acknowledged when the handler returns nil (or when the handler acks
+// it if auto ack is disabled). A message whose handler failed is delivered again after
+// a growing delay, up to MaxDeliver times in total, and is dropped after that.
+//
+// Messages published with the same PartitionKey header are handled in publish order
+// by the kafka and memory brokers, which send them to the same subscriber of a group.
+// The jetstream and redis_stream brokers keep that order for a group with a single
+// subscriber only.
+package brokerx
+
+import (
+	"context"
+	"hash/fnv"
+	"mercury/config"
+	"mercury/x/ecode"
+	"mercury/x/log"
+	"mercury/x/stat"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/micro/go-micro/v2/codec/json"
+	"github.com/micro/go-plugins/broker/stan/v2"
+)
+
+type ConfigProvider interface {
+	Broker() *config.Broker
+}
+
+// Default number of times a message is delivered
+const defaultMaxDeliver = 5
+
+// PartitionKey is the header of a message holding its partition key, messages with the
+// same key keep their order.
+const PartitionKey = "Partition-Key"
+
+// NewBroker creates the broker selected by the config, the broker still needs to be
+// initialized and connected.
+func NewBroker(c ConfigProvider) (broker.Broker, error) {
+	cfg := c.Broker()
+	switch cfg.Kind() {
+	case config.BrokerTypeStan:
+		return stan.NewBroker(
+			broker.Addrs(cfg.Stan.Addresses...),
+			stan.ConnectRetry(true),
+			stan.ClusterID(cfg.Stan.ClusterID),
+			stan.DurableName(cfg.Stan.DurableName),
+		), nil
+	case config.BrokerTypeJetStream:
+		return NewJetStreamBroker(
+			broker.Addrs(cfg.JetStream.Addresses...),
+			DurableName(cfg.JetStream.DurableName),
+			MaxDeliver(cfg.MaxDeliver),
+		), nil
+	case config.BrokerTypeKafka:
+		return NewKafkaBroker(
+			broker.Addrs(cfg.Kafka.Addresses...),
+			DurableName(cfg.Kafka.DurableName),
+			MaxDeliver(cfg.MaxDeliver),
+		), nil
+	case config.BrokerTypeRedisStream:
+		return NewRedisStreamBroker(
+			broker.Addrs(cfg.RedisStream.Address),
+			DurableName(cfg.RedisStream.DurableName),
+			MaxDeliver(cfg.MaxDeliver),
+			RedisStream(cfg.RedisStream.Password, cfg.RedisStream.DB, cfg.RedisStream.MaxLen),
+		), nil
+	case config.BrokerTypeMemory:
+		b := DefaultMemoryBroker
+		return b, b.Init(MaxDeliver(cfg.MaxDeliver))
+	case config.BrokerTypeDefault:
+		return broker.DefaultBroker, nil
+	default:
+		return nil, ecode.NewError("unknown broker type: " + cfg.Kind())
+	}
+}
+
+type durableNameKey struct{}
+
+// DurableName sets the prefix of the consumer groups of the subscribers.
+func DurableName(name string) broker.Option {
+	return setBrokerOption(durableNameKey{}, name)
+}
+
+type instanceIDKey struct{}
+
+// InstanceID sets the ID of the instance running the broker, it names the consumers of
+// the instance in the consumer groups. A random ID is used if it is not set.
+func InstanceID(id string) broker.Option {
+	return setBrokerOption(instanceIDKey{}, id)
+}
+
+type maxDeliverKey struct{}
+
+// MaxDeliver sets the number of times a message is delivered before it is dropped.
+func MaxDeliver(n int) broker.Option {
+	return setBrokerOption(maxDeliverKey{}, n)
+}
+
+type redisStreamKey struct{}
+
+type redisStreamOptions struct {
+	password string
+	db       int
+	maxLen   int64
+}
+
+// RedisStream sets the password and database of the redis server and the approximate
+// maximum length of the streams.
+func RedisStream(password string, db int, maxLen int64) broker.Option {
+	return setBrokerOption(redisStreamKey{}, redisStreamOptions{password: password, db: db, maxLen: maxLen})
+}
+
+func setBrokerOption(k, v interface{}) broker.Option {
+	return func(o *broker.Options) {
+		if o.Context == nil {
+			o.Context = context.Background()
+		}
+		o.Context = context.WithValue(o.Context, k, v)
+	}
+}
+
+func durableName(opts broker.Options) string {
+	if opts.Context != nil {
+		if name, ok := opts.Context.Value(durableNameKey{}).(string); ok && name != "" {
+			return name
+		}
+	}
+	return "mercury-durable"
+}
+
+// defaultInstanceID is the instance ID of the brokers without one.
+var defaultInstanceID = uuid.New().String()
+
+func instanceID(opts broker.Options) string {
+	if opts.Context != nil {
+		if id, ok := opts.Context.Value(instanceIDKey{}).(string); ok && id != "" {
+			return id
+		}
+	}
+	return defaultInstanceID
+}
+
+func maxDeliver(opts broker.Options) int {
+	if opts.Context != nil {
+		if n, ok := opts.Context.Value(maxDeliverKey{}).(int); ok && n > 0 {
+			return n
+		}
+	}
+	return defaultMaxDeliver
+}
+
+func newOptions(opts ...broker.Option) broker.Options {
+	options := broker.Options{
+		Codec:   json.Marshaler{},
+		Context: context.Background(),
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+	return options
+}
+
+func newSubscribeOptions(opts ...broker.SubscribeOption) broker.SubscribeOptions {
+	options := broker.SubscribeOptions{
+		AutoAck: true,
+		Context: context.Background(),
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+	return options
+}
+
+// group returns the consumer group of a subscriber, subscribers without a queue get a
+// group of their own.
+func group(opts broker.Options, sopts broker.SubscribeOptions, id string) string {
+	if sopts.Queue != "" {
+		return durableName(opts) + "-" + sopts.Queue
+	}
+	return durableName(opts) + "-" + id
+}
+
+// Subscribers report their lag at most once per lagInterval.
+const lagInterval = 10 * time.Second
+
+// lagReporter reports the number of messages waiting in the broker for the consumer group
+// of a queue subscriber. Subscribers without a queue are not reported, their groups only
+// live as long as the subscriber.
+type lagReporter struct {
+	topic string
+	group string
+	last  time.Time
+}
+
+func newLagReporter(topic string, opts broker.Options, sopts broker.SubscribeOptions) *lagReporter {
+	if sopts.Queue == "" {
+		return nil
+	}
+	return &lagReporter{topic: topic, group: group(opts, sopts, "")}
+}
+
+// due reports whether it is time to report the lag again.
+func (r *lagReporter) due() bool {
+	if r == nil || time.Since(r.last) < lagInterval {
+		return false
+	}
+	r.last = time.Now()
+	return true
+}
+
+func (r *lagReporter) report(lag int64) {
+	stat.BrokerLag.State(r.topic, lag, r.group)
+}
+
+func partitionKey(m *broker.Message) string {
+	if m == nil || m.Header == nil {
+		return ""
+	}
+	return m.Header[PartitionKey]
+}
+
+// hash returns the FNV-1a hash of the key.
+func hash(key string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return h.Sum32()
+}
+
+// sanitize replaces the characters which are not allowed in stream and consumer names.
+func sanitize(name string) string {
+	return strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_", ":", "_").Replace(name)
+}
+
+// event is a message delivered to a handler.
+type event struct {
+	topic string
+	m     *broker.Message
+	err   error
+	acked bool
+}
+
+func (e *event) Topic() string {
+	return e.topic
+}
+
+func (e *event) Message() *broker.Message {
+	return e.m
+}
+
+func (e *event) Ack() error {
+	e.acked = true
+	return nil
+}
+
+func (e *event) Error() error {
+	return e.err
+}
+
+// A message which failed to be handled is delivered again after redeliverDelay, which
+// doubles after every failure up to maxRedeliverDelay.
+const (
+	redeliverDelay    = 100 * time.Millisecond
+	maxRedeliverDelay = 5 * time.Second
+)
+
+// deliver hands the message to the handler until it is acknowledged or it has been
+// delivered maxDeliver times, waiting longer after every failure. It stops waiting once
+// stop is closed. It reports whether the message was acknowledged.
+func deliver(stop <-chan struct{}, topic string, m *broker.Message, h broker.Handler, sopts broker.SubscribeOptions, maxDeliver int) bool {
+	delay := redeliverDelay
+	for i := 0; i < maxDeliver; i++ {
+		if i > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-stop:
+				timer.Stop()
+				log.Warn("[Broker] stopped redelivering message", "topic", topic, "delivered", i)
+				return false
+			}
+			if delay *= 2; delay > maxRedeliverDelay {
+				delay = maxRedeliverDelay
+			}
+		}
+
+		e := &event{topic: topic, m: m}
+		e.err = h(e)
+		if e.err == nil && sopts.AutoAck {
+			e.acked = true
+		}
+		if e.acked {
+			return true
+		}
+		log.Warn("[Broker] failed to handle message", "topic", topic, "delivered", i+1, "error", e.err)
+	}
+	log.Error("[Broker] dropped message", "topic", topic, "delivered", maxDeliver)
+	return false
+}
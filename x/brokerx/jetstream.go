@@ -0,0 +1,211 @@
+package brokerx
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/nats-io/nats.go"
+	"mercury/x/log"
+)
+
+// jetStreamBroker keeps every topic in a stream of the same name. Consumer groups are
+// durable consumers, redelivery is done by the server.
+type jetStreamBroker struct {
+	opts broker.Options
+
+	mux     sync.RWMutex
+	conn    *nats.Conn
+	js      nats.JetStreamContext
+	streams map[string]bool
+}
+
+type jetStreamSubscriber struct {
+	topic string
+	opts  broker.SubscribeOptions
+	sub   *nats.Subscription
+}
+
+func NewJetStreamBroker(opts ...broker.Option) broker.Broker {
+	return &jetStreamBroker{
+		opts:    newOptions(opts...),
+		streams: make(map[string]bool),
+	}
+}
+
+func (b *jetStreamBroker) Init(opts ...broker.Option) error {
+	for _, o := range opts {
+		o(&b.opts)
+	}
+	return nil
+}
+
+func (b *jetStreamBroker) Options() broker.Options {
+	return b.opts
+}
+
+func (b *jetStreamBroker) Address() string {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	if b.conn != nil && b.conn.IsConnected() {
+		return b.conn.ConnectedUrl()
+	}
+	return strings.Join(b.opts.Addrs, ",")
+}
+
+func (b *jetStreamBroker) Connect() error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.conn != nil {
+		return nil
+	}
+
+	url := nats.DefaultURL
+	if len(b.opts.Addrs) > 0 {
+		url = strings.Join(b.opts.Addrs, ",")
+	}
+	conn, err := nats.Connect(url, nats.RetryOnFailedConnect(true), nats.MaxReconnects(-1))
+	if err != nil {
+		return err
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	b.conn = conn
+	b.js = js
+	return nil
+}
+
+func (b *jetStreamBroker) Disconnect() error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.conn == nil {
+		return nil
+	}
+	err := b.conn.Drain()
+	b.conn = nil
+	b.js = nil
+	b.streams = make(map[string]bool)
+	return err
+}
+
+// stream returns the JetStream context once the stream of the topic exists.
+func (b *jetStreamBroker) stream(topic string) (nats.JetStreamContext, error) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.js == nil {
+		return nil, errNotConnected
+	}
+	if b.streams[topic] {
+		return b.js, nil
+	}
+
+	name := sanitize(topic)
+	if _, err := b.js.StreamInfo(name); err != nil {
+		if _, err := b.js.AddStream(&nats.StreamConfig{
+			Name:     name,
+			Subjects: []string{topic},
+		}); err != nil {
+			return nil, err
+		}
+	}
+	b.streams[topic] = true
+	return b.js, nil
+}
+
+func (b *jetStreamBroker) Publish(topic string, m *broker.Message, opts ...broker.PublishOption) error {
+	js, err := b.stream(topic)
+	if err != nil {
+		return err
+	}
+	data, err := b.opts.Codec.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = js.Publish(topic, data)
+	return err
+}
+
+func (b *jetStreamBroker) Subscribe(topic string, h broker.Handler, opts ...broker.SubscribeOption) (broker.Subscriber, error) {
+	js, err := b.stream(topic)
+	if err != nil {
+		return nil, err
+	}
+
+	sopts := newSubscribeOptions(opts...)
+	max := maxDeliver(b.opts)
+	lag := newLagReporter(topic, b.opts, sopts)
+	cb := func(msg *nats.Msg) {
+		if lag.due() {
+			if md, err := msg.Metadata(); err == nil {
+				lag.report(int64(md.NumPending))
+			}
+		}
+
+		e := &event{topic: topic, m: new(broker.Message)}
+		if err := b.opts.Codec.Unmarshal(msg.Data, e.m); err != nil {
+			log.Error("[Broker] failed to decode message", "topic", topic, "error", err)
+			_ = msg.Ack()
+			return
+		}
+
+		e.err = h(e)
+		if e.err == nil && sopts.AutoAck {
+			e.acked = true
+		}
+		if e.acked {
+			_ = msg.Ack()
+			return
+		}
+
+		delivered := 1
+		if md, err := msg.Metadata(); err == nil {
+			delivered = int(md.NumDelivered)
+		}
+		log.Warn("[Broker] failed to handle message", "topic", topic, "delivered", delivered, "error", e.err)
+		if delivered >= max {
+			log.Error("[Broker] dropped message", "topic", topic, "delivered", delivered)
+		}
+		_ = msg.Nak()
+	}
+
+	subOpts := []nats.SubOpt{nats.ManualAck(), nats.AckExplicit(), nats.MaxDeliver(max)}
+	var sub *nats.Subscription
+	if sopts.Queue != "" {
+		name := sanitize(group(b.opts, sopts, ""))
+		subOpts = append(subOpts, nats.Durable(name), nats.DeliverAll())
+		sub, err = js.QueueSubscribe(topic, name, cb, subOpts...)
+	} else {
+		// A subscriber without a queue has an ephemeral consumer of its own.
+		sub, err = js.Subscribe(topic, cb, append(subOpts, nats.DeliverNew())...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &jetStreamSubscriber{topic: topic, opts: sopts, sub: sub}, nil
+}
+
+func (b *jetStreamBroker) String() string {
+	return "jetstream"
+}
+
+func (s *jetStreamSubscriber) Options() broker.SubscribeOptions {
+	return s.opts
+}
+
+func (s *jetStreamSubscriber) Topic() string {
+	return s.topic
+}
+
+func (s *jetStreamSubscriber) Unsubscribe() error {
+	if s.opts.Queue != "" {
+		// Unsubscribing deletes the consumer, draining keeps the durable consumer of the
+		// group, which resumes from it.
+		return s.sub.Drain()
+	}
+	return s.sub.Unsubscribe()
+}
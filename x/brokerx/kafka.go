@@ -0,0 +1,244 @@
+package brokerx
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/segmentio/kafka-go"
+	"mercury/x/log"
+)
+
+// kafkaBroker maps consumer groups to kafka consumer groups, offsets are committed
+// once a message was acknowledged or dropped. A subscriber without a queue joins no
+// group, it reads the partitions the topic had when it subscribed from their end.
+type kafkaBroker struct {
+	opts broker.Options
+
+	mux       sync.Mutex
+	connected bool
+	writers   map[string]*kafka.Writer
+}
+
+type kafkaSubscriber struct {
+	topic string
+	opts  broker.SubscribeOptions
+	// The reader of the consumer group of a queue subscriber, or the readers of all the
+	// partitions of the topic for a subscriber without a queue
+	readers []*kafka.Reader
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	lag     *lagReporter
+}
+
+func NewKafkaBroker(opts ...broker.Option) broker.Broker {
+	return &kafkaBroker{
+		opts:    newOptions(opts...),
+		writers: make(map[string]*kafka.Writer),
+	}
+}
+
+func (b *kafkaBroker) Init(opts ...broker.Option) error {
+	for _, o := range opts {
+		o(&b.opts)
+	}
+	return nil
+}
+
+func (b *kafkaBroker) Options() broker.Options {
+	return b.opts
+}
+
+func (b *kafkaBroker) Address() string {
+	return strings.Join(b.opts.Addrs, ",")
+}
+
+func (b *kafkaBroker) Connect() error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if len(b.opts.Addrs) == 0 {
+		b.opts.Addrs = []string{"localhost:9092"}
+	}
+	b.connected = true
+	return nil
+}
+
+func (b *kafkaBroker) Disconnect() error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	var err error
+	for topic, w := range b.writers {
+		if cErr := w.Close(); cErr != nil {
+			err = cErr
+		}
+		delete(b.writers, topic)
+	}
+	b.connected = false
+	return err
+}
+
+func (b *kafkaBroker) writer(topic string) (*kafka.Writer, error) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if !b.connected {
+		return nil, errNotConnected
+	}
+	w, ok := b.writers[topic]
+	if !ok {
+		w = kafka.NewWriter(kafka.WriterConfig{
+			Brokers: b.opts.Addrs,
+			Topic:   topic,
+			// Messages with the same key go to the same partition
+			Balancer: &kafka.Hash{},
+		})
+		b.writers[topic] = w
+	}
+	return w, nil
+}
+
+func (b *kafkaBroker) Publish(topic string, m *broker.Message, opts ...broker.PublishOption) error {
+	w, err := b.writer(topic)
+	if err != nil {
+		return err
+	}
+	data, err := b.opts.Codec.Marshal(m)
+	if err != nil {
+		return err
+	}
+	msg := kafka.Message{Value: data}
+	if key := partitionKey(m); key != "" {
+		msg.Key = []byte(key)
+	}
+	return w.WriteMessages(context.Background(), msg)
+}
+
+func (b *kafkaBroker) Subscribe(topic string, h broker.Handler, opts ...broker.SubscribeOption) (broker.Subscriber, error) {
+	b.mux.Lock()
+	connected := b.connected
+	b.mux.Unlock()
+	if !connected {
+		return nil, errNotConnected
+	}
+
+	sopts := newSubscribeOptions(opts...)
+	var readers []*kafka.Reader
+	if sopts.Queue != "" {
+		readers = append(readers, kafka.NewReader(kafka.ReaderConfig{
+			Brokers: b.opts.Addrs,
+			GroupID: group(b.opts, sopts, ""),
+			Topic:   topic,
+		}))
+	} else {
+		// A consumer group of its own would be left behind in kafka, the subscriber reads
+		// the partitions itself and only gets the messages published from now on.
+		partitions, err := b.partitions(topic)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range partitions {
+			r := kafka.NewReader(kafka.ReaderConfig{
+				Brokers:   b.opts.Addrs,
+				Topic:     topic,
+				Partition: p.ID,
+			})
+			if err := r.SetOffset(kafka.LastOffset); err != nil {
+				return nil, err
+			}
+			readers = append(readers, r)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &kafkaSubscriber{
+		topic:   topic,
+		opts:    sopts,
+		readers: readers,
+		cancel:  cancel,
+	}
+	s.lag = newLagReporter(topic, b.opts, sopts)
+	for _, r := range readers {
+		s.wg.Add(1)
+		go s.run(ctx, r, h, b.opts, maxDeliver(b.opts))
+	}
+	return s, nil
+}
+
+// partitions returns the partitions of the topic from the first broker which answers.
+func (b *kafkaBroker) partitions(topic string) ([]kafka.Partition, error) {
+	var err error
+	for _, addr := range b.opts.Addrs {
+		var conn *kafka.Conn
+		if conn, err = kafka.Dial("tcp", addr); err != nil {
+			continue
+		}
+		var partitions []kafka.Partition
+		partitions, err = conn.ReadPartitions(topic)
+		_ = conn.Close()
+		if err == nil {
+			return partitions, nil
+		}
+	}
+	return nil, err
+}
+
+func (b *kafkaBroker) String() string {
+	return "kafka"
+}
+
+func (s *kafkaSubscriber) run(ctx context.Context, r *kafka.Reader, h broker.Handler, opts broker.Options, maxDeliver int) {
+	defer s.wg.Done()
+	for {
+		msg, err := r.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Error("[Broker] failed to fetch message", "topic", s.topic, "error", err)
+			select {
+			case <-time.After(time.Second):
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+		if s.lag.due() {
+			s.lag.report(r.Stats().Lag)
+		}
+
+		m := new(broker.Message)
+		if err := opts.Codec.Unmarshal(msg.Value, m); err != nil {
+			log.Error("[Broker] failed to decode message", "topic", s.topic, "error", err)
+		} else {
+			deliver(ctx.Done(), s.topic, m, h, s.opts, maxDeliver)
+		}
+
+		if s.opts.Queue == "" {
+			continue
+		}
+		if err := r.CommitMessages(ctx, msg); err != nil && ctx.Err() == nil {
+			log.Error("[Broker] failed to commit message", "topic", s.topic, "offset", msg.Offset, "error", err)
+		}
+	}
+}
+
+func (s *kafkaSubscriber) Options() broker.SubscribeOptions {
+	return s.opts
+}
+
+func (s *kafkaSubscriber) Topic() string {
+	return s.topic
+}
+
+func (s *kafkaSubscriber) Unsubscribe() error {
+	s.cancel()
+	s.wg.Wait()
+	var err error
+	for _, r := range s.readers {
+		if cErr := r.Close(); cErr != nil {
+			err = cErr
+		}
+	}
+	return err
+}
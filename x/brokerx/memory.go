@@ -0,0 +1,192 @@
+package brokerx
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/broker"
+)
+
+// DefaultMemoryBroker is shared by the servers running in the same process.
+var DefaultMemoryBroker = NewMemoryBroker()
+
+// Number of messages buffered for a subscriber
+const memoryBufferSize = 1024
+
+var errNotConnected = errors.New("broker is not connected")
+
+// memoryBroker delivers messages between the servers of one process. Nothing is
+// persisted, the messages are lost when the process exits.
+type memoryBroker struct {
+	opts broker.Options
+
+	mux       sync.RWMutex
+	connected bool
+	// Subscribers by topic, then by consumer group
+	groups map[string]map[string]*memoryGroup
+}
+
+type memoryGroup struct {
+	next        int
+	subscribers []*memorySubscriber
+}
+
+type memorySubscriber struct {
+	id      string
+	topic   string
+	group   string
+	b       *memoryBroker
+	handler broker.Handler
+	opts    broker.SubscribeOptions
+	ch      chan *broker.Message
+	done    chan struct{}
+	once    sync.Once
+	lag     *lagReporter
+}
+
+func NewMemoryBroker(opts ...broker.Option) broker.Broker {
+	return &memoryBroker{
+		opts:   newOptions(opts...),
+		groups: make(map[string]map[string]*memoryGroup),
+	}
+}
+
+func (b *memoryBroker) Init(opts ...broker.Option) error {
+	for _, o := range opts {
+		o(&b.opts)
+	}
+	return nil
+}
+
+func (b *memoryBroker) Options() broker.Options {
+	return b.opts
+}
+
+func (b *memoryBroker) Address() string {
+	return ""
+}
+
+func (b *memoryBroker) Connect() error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	b.connected = true
+	return nil
+}
+
+// Disconnect keeps the broker running, other servers of the process may still use it.
+func (b *memoryBroker) Disconnect() error {
+	return nil
+}
+
+func (b *memoryBroker) Publish(topic string, m *broker.Message, opts ...broker.PublishOption) error {
+	b.mux.Lock()
+	if !b.connected {
+		b.mux.Unlock()
+		return errNotConnected
+	}
+	key := partitionKey(m)
+	var targets []*memorySubscriber
+	for _, g := range b.groups[topic] {
+		if len(g.subscribers) == 0 {
+			continue
+		}
+		if key != "" {
+			targets = append(targets, g.subscribers[hash(key)%uint32(len(g.subscribers))])
+			continue
+		}
+		targets = append(targets, g.subscribers[g.next%len(g.subscribers)])
+		g.next++
+	}
+	b.mux.Unlock()
+
+	for _, s := range targets {
+		select {
+		case s.ch <- m:
+		case <-s.done:
+		}
+	}
+	return nil
+}
+
+func (b *memoryBroker) Subscribe(topic string, h broker.Handler, opts ...broker.SubscribeOption) (broker.Subscriber, error) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if !b.connected {
+		return nil, errNotConnected
+	}
+
+	s := &memorySubscriber{
+		id:      uuid.New().String(),
+		topic:   topic,
+		b:       b,
+		handler: h,
+		opts:    newSubscribeOptions(opts...),
+		ch:      make(chan *broker.Message, memoryBufferSize),
+		done:    make(chan struct{}),
+	}
+	s.group = group(b.opts, s.opts, s.id)
+
+	groups, ok := b.groups[topic]
+	if !ok {
+		groups = make(map[string]*memoryGroup)
+		b.groups[topic] = groups
+	}
+	g, ok := groups[s.group]
+	if !ok {
+		g = new(memoryGroup)
+		groups[s.group] = g
+	}
+	g.subscribers = append(g.subscribers, s)
+
+	s.lag = newLagReporter(topic, b.opts, s.opts)
+	go s.run(maxDeliver(b.opts))
+	return s, nil
+}
+
+func (b *memoryBroker) String() string {
+	return "memory"
+}
+
+func (s *memorySubscriber) run(maxDeliver int) {
+	for {
+		select {
+		case m := <-s.ch:
+			if s.lag.due() {
+				s.lag.report(int64(len(s.ch)))
+			}
+			deliver(s.done, s.topic, m, s.handler, s.opts, maxDeliver)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *memorySubscriber) Options() broker.SubscribeOptions {
+	return s.opts
+}
+
+func (s *memorySubscriber) Topic() string {
+	return s.topic
+}
+
+func (s *memorySubscriber) Unsubscribe() error {
+	s.b.mux.Lock()
+	defer s.b.mux.Unlock()
+
+	if g, ok := s.b.groups[s.topic][s.group]; ok {
+		for i, sub := range g.subscribers {
+			if sub == s {
+				g.subscribers = append(g.subscribers[:i], g.subscribers[i+1:]...)
+				break
+			}
+		}
+		if len(g.subscribers) == 0 {
+			delete(s.b.groups[s.topic], s.group)
+		}
+	}
+	s.once.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
@@ -0,0 +1,149 @@
+package brokerx
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMemoryBroker(t *testing.T) broker.Broker {
+	b := NewMemoryBroker(MaxDeliver(3))
+	require.NoError(t, b.Connect())
+	return b
+}
+
+func TestMemoryBrokerQueue(t *testing.T) {
+	b := newTestMemoryBroker(t)
+
+	var q1, q2, fanout int32
+	_, err := b.Subscribe("topic", func(e broker.Event) error {
+		atomic.AddInt32(&q1, 1)
+		return nil
+	}, broker.Queue("job"))
+	require.NoError(t, err)
+	_, err = b.Subscribe("topic", func(e broker.Event) error {
+		atomic.AddInt32(&q2, 1)
+		return nil
+	}, broker.Queue("job"))
+	require.NoError(t, err)
+	_, err = b.Subscribe("topic", func(e broker.Event) error {
+		atomic.AddInt32(&fanout, 1)
+		return nil
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, b.Publish("topic", &broker.Message{Body: []byte("hello")}))
+	}
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&q1)+atomic.LoadInt32(&q2) == 10 && atomic.LoadInt32(&fanout) == 10
+	}, time.Second, 10*time.Millisecond)
+	// Members of a queue share the messages
+	require.Equal(t, int32(5), atomic.LoadInt32(&q1))
+	require.Equal(t, int32(5), atomic.LoadInt32(&q2))
+}
+
+func TestMemoryBrokerRedeliver(t *testing.T) {
+	b := newTestMemoryBroker(t)
+
+	var delivered, handled int32
+	_, err := b.Subscribe("topic", func(e broker.Event) error {
+		if string(e.Message().Body) == "fail" {
+			atomic.AddInt32(&delivered, 1)
+			return errors.New("failed")
+		}
+		atomic.AddInt32(&handled, 1)
+		return nil
+	}, broker.Queue("job"))
+	require.NoError(t, err)
+
+	require.NoError(t, b.Publish("topic", &broker.Message{Body: []byte("fail")}))
+	require.NoError(t, b.Publish("topic", &broker.Message{Body: []byte("ok")}))
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&handled) == 1
+	}, time.Second, 10*time.Millisecond)
+	// A failed message is delivered MaxDeliver times, then dropped
+	require.Equal(t, int32(3), atomic.LoadInt32(&delivered))
+}
+
+func TestMemoryBrokerRedeliverDelay(t *testing.T) {
+	b := newTestMemoryBroker(t)
+
+	delivered := make(chan time.Time, 3)
+	s, err := b.Subscribe("topic", func(e broker.Event) error {
+		delivered <- time.Now()
+		return errors.New("failed")
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, b.Publish("topic", &broker.Message{}))
+	first := <-delivered
+	second := <-delivered
+	require.GreaterOrEqual(t, int64(second.Sub(first)), int64(redeliverDelay))
+
+	// The message is not delivered again once the subscriber is stopped
+	require.NoError(t, s.Unsubscribe())
+	select {
+	case <-delivered:
+		t.Fatal("message is delivered after unsubscribing")
+	case <-time.After(3 * redeliverDelay):
+	}
+}
+
+func TestMemoryBrokerUnsubscribe(t *testing.T) {
+	b := newTestMemoryBroker(t)
+
+	var n int32
+	s, err := b.Subscribe("topic", func(e broker.Event) error {
+		atomic.AddInt32(&n, 1)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Unsubscribe())
+	require.NoError(t, s.Unsubscribe())
+
+	require.NoError(t, b.Publish("topic", &broker.Message{}))
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, int32(0), atomic.LoadInt32(&n))
+}
+
+func TestMemoryBrokerPartitionKey(t *testing.T) {
+	b := newTestMemoryBroker(t)
+
+	var mux sync.Mutex
+	received := make(map[string]map[int]bool)
+	for i := 0; i < 4; i++ {
+		i := i
+		_, err := b.Subscribe("topic", func(e broker.Event) error {
+			mux.Lock()
+			defer mux.Unlock()
+			key := e.Message().Header[PartitionKey]
+			if received[key] == nil {
+				received[key] = make(map[int]bool)
+			}
+			received[key][i] = true
+			return nil
+		}, broker.Queue("job"))
+		require.NoError(t, err)
+	}
+
+	for i := 0; i < 40; i++ {
+		key := "k" + strconv.Itoa(i%5)
+		require.NoError(t, b.Publish("topic", &broker.Message{Header: map[string]string{PartitionKey: key}}))
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// All messages of a key go to the same subscriber
+	mux.Lock()
+	defer mux.Unlock()
+	require.Len(t, received, 5)
+	for _, subscribers := range received {
+		require.Len(t, subscribers, 1)
+	}
+}
@@ -0,0 +1,76 @@
+package brokerx
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/segmentio/kafka-go"
+)
+
+// Timeout of dialing a broker to check it is reachable
+const pingTimeout = 2 * time.Second
+
+// Ping returns an error if the broker can not be reached. Brokers without a way to check
+// their connection are checked by dialing their address.
+func Ping(b broker.Broker) error {
+	if p, ok := b.(interface{ Ping() error }); ok {
+		return p.Ping()
+	}
+	return dial(b.Address())
+}
+
+func dial(address string) error {
+	if i := strings.Index(address, "://"); i >= 0 {
+		address = address[i+3:]
+	}
+	if i := strings.Index(address, ","); i >= 0 {
+		address = address[:i]
+	}
+	conn, err := net.DialTimeout("tcp", address, pingTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func (b *memoryBroker) Ping() error {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	if !b.connected {
+		return errNotConnected
+	}
+	return nil
+}
+
+func (b *jetStreamBroker) Ping() error {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	if b.conn == nil || !b.conn.IsConnected() {
+		return errNotConnected
+	}
+	return nil
+}
+
+func (b *kafkaBroker) Ping() error {
+	if len(b.opts.Addrs) == 0 {
+		return errNotConnected
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	conn, err := kafka.DialContext(ctx, "tcp", b.opts.Addrs[0])
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func (b *redisStreamBroker) Ping() error {
+	client, err := b.redis()
+	if err != nil {
+		return err
+	}
+	return client.Ping().Err()
+}
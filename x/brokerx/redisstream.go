@@ -0,0 +1,325 @@
+package brokerx
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v7"
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/broker"
+	"mercury/x/log"
+)
+
+// Field of a stream entry holding the encoded message
+const redisStreamField = "message"
+
+// How long a read blocks waiting for new entries, a subscriber stops within it
+var redisStreamBlock = 5 * time.Second
+
+// How long an entry stays pending with another consumer before a consumer of the group
+// claims it
+const redisStreamClaimIdle = time.Minute
+
+// redisStreamBroker keeps every topic in a stream of the same name and maps consumer
+// groups to stream consumer groups. Entries are acknowledged once a message was
+// acknowledged or dropped.
+//
+// A queue subscriber is a consumer named after the host and the instance ID. The entries
+// a consumer read but did not acknowledge, like the ones of a stopped instance, are
+// claimed by another consumer of the group once they were pending for
+// redisStreamClaimIdle.
+type redisStreamBroker struct {
+	opts broker.Options
+
+	mux    sync.RWMutex
+	client *redis.Client
+}
+
+type redisStreamSubscriber struct {
+	topic    string
+	group    string
+	consumer string
+	opts     broker.SubscribeOptions
+	client   *redis.Client
+	cancel   context.CancelFunc
+	done     chan struct{}
+	lag      *lagReporter
+	// Next time to claim the entries left pending by other consumers
+	nextClaim time.Time
+}
+
+func NewRedisStreamBroker(opts ...broker.Option) broker.Broker {
+	return &redisStreamBroker{
+		opts: newOptions(opts...),
+	}
+}
+
+func (b *redisStreamBroker) Init(opts ...broker.Option) error {
+	for _, o := range opts {
+		o(&b.opts)
+	}
+	return nil
+}
+
+func (b *redisStreamBroker) Options() broker.Options {
+	return b.opts
+}
+
+func (b *redisStreamBroker) Address() string {
+	return strings.Join(b.opts.Addrs, ",")
+}
+
+func (b *redisStreamBroker) streamOptions() redisStreamOptions {
+	if o, ok := b.opts.Context.Value(redisStreamKey{}).(redisStreamOptions); ok {
+		return o
+	}
+	return redisStreamOptions{}
+}
+
+func (b *redisStreamBroker) Connect() error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.client != nil {
+		return nil
+	}
+
+	addr := "localhost:6379"
+	if len(b.opts.Addrs) > 0 && b.opts.Addrs[0] != "" {
+		addr = b.opts.Addrs[0]
+	}
+	so := b.streamOptions()
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: so.password,
+		DB:       so.db,
+	})
+	if err := client.Ping().Err(); err != nil {
+		_ = client.Close()
+		return err
+	}
+	b.client = client
+	return nil
+}
+
+func (b *redisStreamBroker) Disconnect() error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.client == nil {
+		return nil
+	}
+	err := b.client.Close()
+	b.client = nil
+	return err
+}
+
+func (b *redisStreamBroker) redis() (*redis.Client, error) {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	if b.client == nil {
+		return nil, errNotConnected
+	}
+	return b.client, nil
+}
+
+func (b *redisStreamBroker) Publish(topic string, m *broker.Message, opts ...broker.PublishOption) error {
+	client, err := b.redis()
+	if err != nil {
+		return err
+	}
+	data, err := b.opts.Codec.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return client.XAdd(&redis.XAddArgs{
+		Stream:       topic,
+		MaxLenApprox: b.streamOptions().maxLen,
+		Values:       map[string]interface{}{redisStreamField: data},
+	}).Err()
+}
+
+func (b *redisStreamBroker) Subscribe(topic string, h broker.Handler, opts ...broker.SubscribeOption) (broker.Subscriber, error) {
+	client, err := b.redis()
+	if err != nil {
+		return nil, err
+	}
+
+	sopts := newSubscribeOptions(opts...)
+	id := uuid.New().String()
+	s := &redisStreamSubscriber{
+		topic:  topic,
+		group:  group(b.opts, sopts, id),
+		opts:   sopts,
+		client: client,
+		done:   make(chan struct{}),
+	}
+
+	start := "0"
+	if sopts.Queue == "" {
+		// A subscriber without a queue only gets the messages published from now on.
+		start = "$"
+		s.consumer = id
+	} else {
+		// Instances on the same host are different consumers.
+		hostname, _ := os.Hostname()
+		s.consumer = hostname + "-" + instanceID(b.opts)
+	}
+	if err := client.XGroupCreateMkStream(topic, s.group, start).Err(); err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return nil, err
+	}
+
+	var ctx context.Context
+	ctx, s.cancel = context.WithCancel(context.Background())
+	s.lag = newLagReporter(topic, b.opts, sopts)
+	go s.run(ctx, h, b.opts, maxDeliver(b.opts))
+	return s, nil
+}
+
+func (b *redisStreamBroker) String() string {
+	return "redis_stream"
+}
+
+func (s *redisStreamSubscriber) run(ctx context.Context, h broker.Handler, opts broker.Options, maxDeliver int) {
+	defer close(s.done)
+
+	// Deliver the entries which were read but not acknowledged before, then the new ones.
+	id := "0"
+	for ctx.Err() == nil {
+		streams, err := s.client.XReadGroup(&redis.XReadGroupArgs{
+			Group:    s.group,
+			Consumer: s.consumer,
+			Streams:  []string{s.topic, id},
+			Count:    64,
+			Block:    redisStreamBlock,
+		}).Result()
+		if err != nil {
+			if err != redis.Nil && ctx.Err() == nil {
+				log.Error("[Broker] failed to read stream", "topic", s.topic, "error", err)
+				time.Sleep(time.Second)
+			}
+			continue
+		}
+
+		var n int
+		for _, stream := range streams {
+			for _, entry := range stream.Messages {
+				n++
+				s.handle(ctx, entry, h, opts, maxDeliver)
+			}
+		}
+		if id == "0" && n == 0 {
+			id = ">"
+		}
+		if s.opts.Queue != "" && time.Now().After(s.nextClaim) {
+			s.claim(ctx, h, opts, maxDeliver)
+			s.nextClaim = time.Now().Add(redisStreamClaimIdle)
+		}
+		if s.lag.due() {
+			s.reportLag()
+		}
+	}
+}
+
+// reportLag reports the entries of the stream which were not acknowledged by the group yet.
+// Redis 7 reports the entries not delivered to the group as well, older versions only the
+// pending ones.
+func (s *redisStreamSubscriber) reportLag() {
+	groups, err := s.client.Do("XINFO", "GROUPS", s.topic).Result()
+	if err != nil {
+		log.Warn("[Broker] failed to get stream groups", "topic", s.topic, "error", err)
+		return
+	}
+	list, _ := groups.([]interface{})
+	for _, g := range list {
+		fields, _ := g.([]interface{})
+		info := make(map[string]interface{}, len(fields)/2)
+		for i := 0; i+1 < len(fields); i += 2 {
+			if key, ok := fields[i].(string); ok {
+				info[key] = fields[i+1]
+			}
+		}
+		if info["name"] != s.group {
+			continue
+		}
+		pending, _ := info["pending"].(int64)
+		lag, _ := info["lag"].(int64)
+		s.lag.report(pending + lag)
+		return
+	}
+}
+
+// claim takes over the entries other consumers of the group left pending for
+// redisStreamClaimIdle and handles them.
+func (s *redisStreamSubscriber) claim(ctx context.Context, h broker.Handler, opts broker.Options, maxDeliver int) {
+	pending, err := s.client.XPendingExt(&redis.XPendingExtArgs{
+		Stream: s.topic,
+		Group:  s.group,
+		Start:  "-",
+		End:    "+",
+		Count:  64,
+	}).Result()
+	if err != nil {
+		log.Warn("[Broker] failed to get pending entries", "topic", s.topic, "error", err)
+		return
+	}
+
+	var ids []string
+	for _, p := range pending {
+		if p.Consumer != s.consumer && p.Idle >= redisStreamClaimIdle {
+			ids = append(ids, p.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	entries, err := s.client.XClaim(&redis.XClaimArgs{
+		Stream:   s.topic,
+		Group:    s.group,
+		Consumer: s.consumer,
+		MinIdle:  redisStreamClaimIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		log.Warn("[Broker] failed to claim pending entries", "topic", s.topic, "error", err)
+		return
+	}
+	for _, entry := range entries {
+		s.handle(ctx, entry, h, opts, maxDeliver)
+	}
+}
+
+func (s *redisStreamSubscriber) handle(ctx context.Context, entry redis.XMessage, h broker.Handler, opts broker.Options, maxDeliver int) {
+	m := new(broker.Message)
+	data, _ := entry.Values[redisStreamField].(string)
+	if err := opts.Codec.Unmarshal([]byte(data), m); err != nil {
+		log.Error("[Broker] failed to decode message", "topic", s.topic, "id", entry.ID, "error", err)
+	} else if !deliver(ctx.Done(), s.topic, m, h, s.opts, maxDeliver) && ctx.Err() != nil {
+		// Left pending, another consumer of the group claims it
+		return
+	}
+
+	if err := s.client.XAck(s.topic, s.group, entry.ID).Err(); err != nil {
+		log.Error("[Broker] failed to ack message", "topic", s.topic, "id", entry.ID, "error", err)
+	}
+}
+
+func (s *redisStreamSubscriber) Options() broker.SubscribeOptions {
+	return s.opts
+}
+
+func (s *redisStreamSubscriber) Topic() string {
+	return s.topic
+}
+
+func (s *redisStreamSubscriber) Unsubscribe() error {
+	s.cancel()
+	<-s.done
+	if s.opts.Queue == "" {
+		return s.client.XGroupDestroy(s.topic, s.group).Err()
+	}
+	// The entries the consumer read were all acknowledged, the consumer is not needed
+	// any more.
+	return s.client.XGroupDelConsumer(s.topic, s.group, s.consumer).Err()
+}
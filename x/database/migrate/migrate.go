@@ -0,0 +1,308 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Table recording the versions applied
+const versionTable = "schema_migrations"
+
+// Key of the advisory lock held by the migrators of PostgreSQL
+var postgresLockKey = int64(crc32.ChecksumIEEE([]byte(versionTable)))
+
+// Migration changes the schema from the previous version to Version with Up, Down
+// reverts it.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status of a migration in a database.
+type Status struct {
+	Migration
+	Applied bool
+	// Unix time the migration was applied at, zero if it is not applied
+	AppliedAt int64
+}
+
+// Migrator applies the migrations to a database. Every migration runs in a transaction
+// along with the record of its version, which only makes it atomic where DDL is
+// transactional: MySQL commits every DDL statement implicitly. The servers migrating the
+// same database at the same time are kept apart by the lock of the migrator, without it
+// they may run the same migration twice, see Locker.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	// Formats the n-th (1-based) argument of a statement
+	placeholder func(n int) string
+	locker      Locker
+	// Whether the statements of a migration are executed one by one
+	split bool
+}
+
+// Locker takes a lock of the database on a connection, which is held until it is
+// released on the same connection. Up and Down hold it while they change the database.
+type Locker interface {
+	Lock(ctx context.Context, conn *sql.Conn) error
+	Unlock(ctx context.Context, conn *sql.Conn) error
+}
+
+// Option configures a Migrator.
+type Option func(m *Migrator)
+
+// Placeholder sets the format of the arguments of the statements of the migrator, the
+// default is the one of PostgreSQL ($1, $2...).
+func Placeholder(f func(n int) string) Option {
+	return func(m *Migrator) {
+		m.placeholder = f
+	}
+}
+
+// Lock sets the locker of the migrator, the database is not locked by default.
+func Lock(l Locker) Option {
+	return func(m *Migrator) {
+		m.locker = l
+	}
+}
+
+// SplitStatements makes the migrator execute the statements of a migration one by one,
+// for the drivers which do not execute several statements at once, such as the one of
+// MySQL without multiStatements. Every migration is executed at once by default.
+//
+// The statements are split at the semicolons ending a line, so a statement holding one,
+// e.g. the body of a function, a trigger or a procedure, is cut into invalid statements.
+// The migrations of a split migrator can not have such statements.
+func SplitStatements() Option {
+	return func(m *Migrator) {
+		m.split = true
+	}
+}
+
+// PostgresLocker locks a PostgreSQL database with a session advisory lock.
+type PostgresLocker struct{}
+
+func (PostgresLocker) Lock(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1);", postgresLockKey)
+	return err
+}
+
+func (PostgresLocker) Unlock(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1);", postgresLockKey)
+	return err
+}
+
+// MySQLLocker locks a MySQL database with a named lock, the name is the one of the
+// version table qualified with the database, as the names are shared by the server.
+type MySQLLocker struct{}
+
+func (MySQLLocker) Lock(ctx context.Context, conn *sql.Conn) error {
+	var locked sql.NullInt64
+	err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(CONCAT(DATABASE(), '."+versionTable+"'), -1);").Scan(&locked)
+	if err != nil {
+		return err
+	}
+	if locked.Int64 != 1 {
+		return fmt.Errorf("lock of %s not taken", versionTable)
+	}
+	return nil
+}
+
+func (MySQLLocker) Unlock(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(CONCAT(DATABASE(), '."+versionTable+"'));")
+	return err
+}
+
+// New returns a migrator of db, migrations may be in any order but their versions must
+// be positive and distinct.
+func New(db *sql.DB, migrations []Migration, opts ...Option) (*Migrator, error) {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	for i, mig := range sorted {
+		if mig.Version <= 0 {
+			return nil, fmt.Errorf("migration %q has an invalid version %d", mig.Name, mig.Version)
+		}
+		if i > 0 && sorted[i-1].Version == mig.Version {
+			return nil, fmt.Errorf("migrations %q and %q have the same version %d", sorted[i-1].Name, mig.Name, mig.Version)
+		}
+	}
+
+	m := &Migrator{
+		db:          db,
+		migrations:  sorted,
+		placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m, nil
+}
+
+// lock takes the lock of the migrator on a connection of its own and returns the
+// function releasing it.
+func (m *Migrator) lock(ctx context.Context) (func(), error) {
+	if m.locker == nil {
+		return func() {}, nil
+	}
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.locker.Lock(ctx, conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return func() {
+		if err := m.locker.Unlock(context.Background(), conn); err != nil {
+			// The lock is released along with the session, which must not go back to the pool
+			_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+		_ = conn.Close()
+	}, nil
+}
+
+func (m *Migrator) init(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+versionTable+` (
+    version BIGINT NOT NULL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at BIGINT NOT NULL
+);`)
+	return err
+}
+
+// applied returns the unix time each applied version was applied at.
+func (m *Migrator) applied(ctx context.Context) (map[int64]int64, error) {
+	if err := m.init(ctx); err != nil {
+		return nil, err
+	}
+	rows, err := m.db.QueryContext(ctx, "SELECT version, applied_at FROM "+versionTable+";")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]int64)
+	for rows.Next() {
+		var version, at int64
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
+
+// Status returns the status of every migration, by version.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]Status, len(m.migrations))
+	for i, mig := range m.migrations {
+		at, ok := applied[mig.Version]
+		status[i] = Status{Migration: mig, Applied: ok, AppliedAt: at}
+	}
+	return status, nil
+}
+
+// Up applies the migrations which are not applied yet and returns them.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+		err := m.run(ctx, mig.Up, fmt.Sprintf("INSERT INTO %s (version, name, applied_at) VALUES (%s, %s, %s);",
+			versionTable, m.placeholder(1), m.placeholder(2), m.placeholder(3)), mig.Version, mig.Name, time.Now().Unix())
+		if err != nil {
+			return done, fmt.Errorf("migration %d %s: %s", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// Down reverts the last steps migrations applied and returns them.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		mig := m.migrations[i]
+		if _, ok := applied[mig.Version]; !ok {
+			continue
+		}
+		err := m.run(ctx, mig.Down, fmt.Sprintf("DELETE FROM %s WHERE version = %s;", versionTable, m.placeholder(1)), mig.Version)
+		if err != nil {
+			return done, fmt.Errorf("migration %d %s: %s", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// split splits statements at the semicolons ending a line, see SplitStatements.
+func split(statements string) []string {
+	var out []string
+	for _, stmt := range strings.SplitAfter(statements, ";\n") {
+		if stmt = strings.TrimSpace(stmt); stmt != "" && stmt != ";" {
+			out = append(out, stmt)
+		}
+	}
+	return out
+}
+
+// run executes the statements and records the version in one transaction.
+func (m *Migrator) run(ctx context.Context, statements, record string, args ...interface{}) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	var stmts []string
+	if m.split {
+		stmts = split(statements)
+	} else if strings.TrimSpace(statements) != "" {
+		stmts = []string{statements}
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,117 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	m, err := New(nil, []Migration{{Version: 2, Name: "second"}, {Version: 1, Name: "first"}})
+	require.NoError(t, err)
+	require.Equal(t, "first", m.migrations[0].Name)
+	require.Equal(t, "$2", m.placeholder(2))
+
+	_, err = New(nil, []Migration{{Version: 1, Name: "first"}, {Version: 1, Name: "again"}})
+	require.Error(t, err)
+	_, err = New(nil, []Migration{{Version: 0, Name: "zero"}})
+	require.Error(t, err)
+
+	m, err = New(nil, nil, Placeholder(func(int) string { return "?" }))
+	require.NoError(t, err)
+	require.Equal(t, "?", m.placeholder(1))
+}
+
+func TestSplit(t *testing.T) {
+	require.Equal(t, []string{"CREATE TABLE a (id INT);", "CREATE INDEX a_idx ON a (id);"},
+		split("\nCREATE TABLE a (id INT);\nCREATE INDEX a_idx ON a (id);\n"))
+	require.Equal(t, []string{"DROP INDEX a_idx;"}, split("DROP INDEX a_idx;"))
+	require.Empty(t, split("  \n"))
+}
+
+func TestRunStatements(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "migrate.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	ctx := context.Background()
+
+	// The body of the trigger holds semicolons ending a line, it is executed at once
+	migrations := []Migration{{Version: 1, Name: "trigger", Up: `
+CREATE TABLE a (id INT);
+CREATE TABLE b (id INT);
+CREATE TRIGGER a_insert AFTER INSERT ON a
+BEGIN
+    INSERT INTO b (id) VALUES (new.id);
+END;
+`}}
+	m, err := New(db, migrations)
+	require.NoError(t, err)
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO a (id) VALUES (1);")
+	require.NoError(t, err)
+	var id int
+	require.NoError(t, db.QueryRow("SELECT id FROM b;").Scan(&id))
+	require.Equal(t, 1, id)
+
+	// A split migrator executes the statements one by one
+	migrations = append(migrations, Migration{Version: 2, Name: "split", Up: "CREATE TABLE c (id INT);\nCREATE TABLE d (id INT);\n"})
+	m, err = New(db, migrations, SplitStatements())
+	require.NoError(t, err)
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO d (id) VALUES (1);")
+	require.NoError(t, err)
+}
+
+// testLocker records the connections it locked and unlocked.
+type testLocker struct {
+	err              error
+	locked, unlocked []*sql.Conn
+}
+
+func (l *testLocker) Lock(ctx context.Context, conn *sql.Conn) error {
+	if l.err != nil {
+		return l.err
+	}
+	l.locked = append(l.locked, conn)
+	return nil
+}
+
+func (l *testLocker) Unlock(ctx context.Context, conn *sql.Conn) error {
+	l.unlocked = append(l.unlocked, conn)
+	return nil
+}
+
+func TestLock(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "migrate.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	migrations := []Migration{{Version: 1, Name: "first", Up: "CREATE TABLE a (id INT);", Down: "DROP TABLE a;"}}
+
+	// Up and Down hold the lock on a connection of their own
+	locker := &testLocker{}
+	m, err := New(db, migrations, Placeholder(func(int) string { return "?" }), Lock(locker))
+	require.NoError(t, err)
+	done, err := m.Up(context.Background())
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+	done, err = m.Down(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+	require.Len(t, locker.locked, 2)
+	require.Equal(t, locker.locked, locker.unlocked)
+
+	// Nothing is applied without the lock
+	locker.err = errors.New("locked")
+	_, err = m.Up(context.Background())
+	require.Equal(t, locker.err, err)
+	status, err := m.Status(context.Background())
+	require.NoError(t, err)
+	require.False(t, status[0].Applied)
+}
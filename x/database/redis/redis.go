@@ -2,6 +2,7 @@ package redis
 
 import (
 	"mercury/config"
+	"mercury/x/ecode"
 
 	"github.com/go-redis/redis/v7"
 )
@@ -10,8 +11,21 @@ var (
 	RedisNil = redis.Nil
 )
 
+// Script is a Lua script run with EVALSHA, EVAL loads it if redis does not have it yet.
+type Script = redis.Script
+
+func NewScript(src string) *Script {
+	return redis.NewScript(src)
+}
+
+// Pipeliner queues the commands sent at once by Pipelined.
+type Pipeliner = redis.Pipeliner
+
+// Client is a client of a single node, of the master failed over by sentinels or of a
+// cluster. In a cluster the keys of a script or a transaction must be in the same slot,
+// they are hashed by their hash tags, the part between { and }, if any.
 type Client struct {
-	*redis.Client
+	redis.UniversalClient
 }
 
 type ConfigProvider interface {
@@ -20,19 +34,58 @@ type ConfigProvider interface {
 
 func NewClient(c ConfigProvider) (*Client, error) {
 	rc := c.Redis()
-	options := &redis.Options{
+
+	var client redis.UniversalClient
+	switch rc.Mode {
+	case config.RedisModeSingle, "":
+		client = redis.NewClient(singleOptions(rc))
+	case config.RedisModeSentinel:
+		if rc.MasterName == "" {
+			return nil, ecode.NewError("master name of the sentinels is missing")
+		}
+		client = redis.NewFailoverClient(failoverOptions(rc))
+	case config.RedisModeCluster:
+		client = redis.NewClusterClient(clusterOptions(rc))
+	default:
+		return nil, ecode.NewError("unknown redis mode " + rc.Mode)
+	}
+
+	if err := client.Ping().Err(); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	return &Client{client}, nil
+}
+
+func singleOptions(rc *config.Redis) *redis.Options {
+	return &redis.Options{
 		Addr:        rc.Address,
 		Username:    rc.Username,
 		Password:    rc.Password,
 		DB:          rc.DB,
 		IdleTimeout: rc.IdleTimeout,
 	}
+}
 
-	client := redis.NewClient(options)
-
-	if err := client.Ping().Err(); err != nil {
-		return nil, err
+func failoverOptions(rc *config.Redis) *redis.FailoverOptions {
+	return &redis.FailoverOptions{
+		MasterName:       rc.MasterName,
+		SentinelAddrs:    rc.Addresses,
+		SentinelPassword: rc.SentinelPassword,
+		Username:         rc.Username,
+		Password:         rc.Password,
+		DB:               rc.DB,
+		IdleTimeout:      rc.IdleTimeout,
 	}
+}
 
-	return &Client{client}, nil
+// clusterOptions leaves the DB out, a cluster only has the DB 0.
+func clusterOptions(rc *config.Redis) *redis.ClusterOptions {
+	return &redis.ClusterOptions{
+		Addrs:       rc.Addresses,
+		Username:    rc.Username,
+		Password:    rc.Password,
+		IdleTimeout: rc.IdleTimeout,
+	}
 }
@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+	"mercury/config"
+)
+
+type testConfig struct {
+	redis *config.Redis
+}
+
+func (c testConfig) Redis() *config.Redis {
+	return c.redis
+}
+
+func TestNewClientMode(t *testing.T) {
+	_, err := NewClient(testConfig{&config.Redis{Mode: "replicas"}})
+	require.EqualError(t, err, "unknown redis mode replicas")
+
+	_, err = NewClient(testConfig{&config.Redis{Mode: config.RedisModeSentinel, Addresses: []string{"localhost:26379"}}})
+	require.EqualError(t, err, "master name of the sentinels is missing")
+}
+
+func TestNewClientOptions(t *testing.T) {
+	rc := &config.Redis{
+		Address:          "localhost:6379",
+		Addresses:        []string{"localhost:26379", "localhost:26380"},
+		MasterName:       "mymaster",
+		Username:         "mercury",
+		Password:         "password",
+		SentinelPassword: "sentinel-password",
+		DB:               2,
+		IdleTimeout:      time.Minute,
+	}
+
+	single := singleOptions(rc)
+	require.Equal(t, rc.Address, single.Addr)
+	require.Equal(t, rc.Password, single.Password)
+	require.Equal(t, rc.DB, single.DB)
+
+	// The sentinels of Addresses fail over the master of MasterName
+	failover := failoverOptions(rc)
+	require.Equal(t, rc.MasterName, failover.MasterName)
+	require.Equal(t, rc.Addresses, failover.SentinelAddrs)
+	require.Equal(t, rc.SentinelPassword, failover.SentinelPassword)
+	require.Equal(t, rc.Username, failover.Username)
+	require.Equal(t, rc.Password, failover.Password)
+	require.Equal(t, rc.DB, failover.DB)
+	require.Equal(t, rc.IdleTimeout, failover.IdleTimeout)
+
+	// The nodes of Addresses seed the cluster
+	cluster := clusterOptions(rc)
+	require.Equal(t, rc.Addresses, cluster.Addrs)
+	require.Equal(t, rc.Username, cluster.Username)
+	require.Equal(t, rc.Password, cluster.Password)
+	require.Equal(t, rc.IdleTimeout, cluster.IdleTimeout)
+}
+
+func TestNewClient(t *testing.T) {
+	m := miniredis.RunT(t)
+	for _, mode := range []string{"", config.RedisModeSingle, config.RedisModeCluster} {
+		rc := &config.Redis{Mode: mode, Address: m.Addr(), Addresses: []string{m.Addr()}}
+		c, err := NewClient(testConfig{rc})
+		require.NoError(t, err, mode)
+		require.NoError(t, c.Set("key", mode, 0).Err())
+		require.NoError(t, c.Close())
+	}
+
+	// The client is not returned if the server cannot be reached
+	addr := m.Addr()
+	m.Close()
+	_, err := NewClient(testConfig{&config.Redis{Address: addr}})
+	require.Error(t, err)
+}
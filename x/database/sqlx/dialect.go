@@ -0,0 +1,88 @@
+package sqlx
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialects of the supported drivers
+const (
+	DialectPostgres = "postgres"
+	DialectMySQL    = "mysql"
+	DialectSQLite   = "sqlite3"
+)
+
+// Dialect adapts the statements, written for PostgreSQL with $n placeholders and
+// identifiers quoted with double quotes, to a driver.
+type Dialect struct {
+	name string
+}
+
+// DialectOf returns the dialect of a database driver, the drivers which are not known
+// are assumed to speak PostgreSQL, such as CockroachDB.
+func DialectOf(driver string) Dialect {
+	switch driver {
+	case DialectMySQL:
+		return Dialect{name: DialectMySQL}
+	case DialectSQLite, "sqlite":
+		return Dialect{name: DialectSQLite}
+	default:
+		return Dialect{name: DialectPostgres}
+	}
+}
+
+func (d Dialect) Name() string {
+	return d.name
+}
+
+// Returning reports whether the dialect supports INSERT ... RETURNING.
+func (d Dialect) Returning() bool {
+	return d.name == DialectPostgres
+}
+
+// Placeholder returns the n-th (1-based) placeholder of a statement.
+func (d Dialect) Placeholder(n int) string {
+	if d.name == DialectPostgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// Rebind rewrites a statement and its arguments for the dialect. The $n placeholders
+// become ? along with the arguments, which are repeated as often as they are referred
+// to, and the identifiers quoted with double quotes are quoted with backquotes by MySQL.
+func (d Dialect) Rebind(query string, args []interface{}) (string, []interface{}) {
+	if d.name == DialectPostgres {
+		return query, args
+	}
+
+	var (
+		b        strings.Builder
+		out      = make([]interface{}, 0, len(args))
+		inString bool
+	)
+	b.Grow(len(query))
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'':
+			inString = !inString
+		case inString:
+		case c == '"' && d.name == DialectMySQL:
+			c = '`'
+		case c == '$':
+			j := i + 1
+			for j < len(query) && query[j] >= '0' && query[j] <= '9' {
+				j++
+			}
+			if n, err := strconv.Atoi(query[i+1 : j]); err == nil && n >= 1 && n <= len(args) {
+				b.WriteByte('?')
+				out = append(out, args[n-1])
+				i = j - 1
+				continue
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String(), out
+}
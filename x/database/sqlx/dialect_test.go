@@ -0,0 +1,33 @@
+package sqlx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialectRebind(t *testing.T) {
+	query := `INSERT INTO "group" (id, created_at, updated_at, name) VALUES ($1, $2, $2, '$3 "x"');`
+	args := []interface{}{int64(1), int64(100)}
+
+	q, a := DialectOf("postgres").Rebind(query, args)
+	require.Equal(t, query, q)
+	require.Equal(t, args, a)
+
+	q, a = DialectOf("sqlite").Rebind(query, args)
+	require.Equal(t, `INSERT INTO "group" (id, created_at, updated_at, name) VALUES (?, ?, ?, '$3 "x"');`, q)
+	require.Equal(t, []interface{}{int64(1), int64(100), int64(100)}, a)
+
+	q, a = DialectOf("mysql").Rebind(`UPDATE "user" SET activated = $2 WHERE id = $1;`, []interface{}{int64(1), true})
+	require.Equal(t, "UPDATE `user` SET activated = ? WHERE id = ?;", q)
+	require.Equal(t, []interface{}{true, int64(1)}, a)
+}
+
+func TestDialectOf(t *testing.T) {
+	require.Equal(t, DialectPostgres, DialectOf("cockroach").Name())
+	require.Equal(t, DialectSQLite, DialectOf("sqlite3").Name())
+	require.True(t, DialectOf("postgres").Returning())
+	require.False(t, DialectOf("mysql").Returning())
+	require.Equal(t, "$2", DialectOf("postgres").Placeholder(2))
+	require.Equal(t, "?", DialectOf("mysql").Placeholder(2))
+}
@@ -7,11 +7,13 @@ import (
 	"mercury/config"
 	"mercury/x/log"
 	"reflect"
+	"strings"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
 	"github.com/pkg/errors"
-
-	_ "github.com/lib/pq"
 )
 
 var (
@@ -21,12 +23,31 @@ var (
 type DB struct {
 	*sql.DB
 	rdbChan chan *sql.DB
+	dialect Dialect
 }
 
 func IsErrNoRows(err error) bool {
 	return err == ErrNoRows
 }
 
+// IsErrUniqueViolation reports whether err was caused by a unique index.
+func IsErrUniqueViolation(err error) bool {
+	var (
+		pqErr     *pq.Error
+		mysqlErr  *mysql.MySQLError
+		sqliteErr sqlite3.Error
+	)
+	switch {
+	case errors.As(err, &pqErr):
+		return pqErr.Code == "23505"
+	case errors.As(err, &mysqlErr):
+		return mysqlErr.Number == 1062
+	case errors.As(err, &sqliteErr):
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	}
+	return false
+}
+
 func (db *DB) Close() error {
 	Close(db.DB)
 	return nil
@@ -36,20 +57,59 @@ type ConfigProvider interface {
 	Database() *config.Database
 }
 
+// Open opens the database of the config, the driver is one of postgres, mysql or sqlite3.
 func Open(c ConfigProvider) (*DB, error) {
 	dbc := c.Database()
-	db, err := sql.Open(dbc.Driver, dbc.DSN)
+	dialect := DialectOf(dbc.Driver)
+	driver, dsn := dbc.Driver, dbc.DSN
+	switch dialect.Name() {
+	case DialectMySQL:
+		// The rows matched rather than the rows changed are counted as affected, as
+		// the other databases do
+		mc, err := mysql.ParseDSN(dsn)
+		if err != nil {
+			return nil, err
+		}
+		mc.ClientFoundRows = true
+		dsn = mc.FormatDSN()
+	case DialectSQLite:
+		driver = DialectSQLite
+	}
+
+	db, err := sql.Open(driver, dsn)
 	if err != nil {
 		return nil, err
 	}
-	db.SetMaxOpenConns(dbc.Active)
-	db.SetMaxIdleConns(dbc.Idle)
-	db.SetConnMaxLifetime(dbc.IdleTimeout)
+	if dialect.Name() == DialectSQLite {
+		// SQLite serializes the writes anyway, a single connection keeps them from
+		// failing with "database is locked" and an in-memory database alive
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+	} else {
+		db.SetMaxOpenConns(dbc.Active)
+		db.SetMaxIdleConns(dbc.Idle)
+		db.SetConnMaxLifetime(dbc.IdleTimeout)
+	}
 
 	rdbChan := make(chan *sql.DB, 1)
 	rdbChan <- db
 
-	return &DB{db, rdbChan}, nil
+	return &DB{db, rdbChan, dialect}, nil
+}
+
+// Dialect returns the dialect of the database.
+func (db *DB) Dialect() Dialect {
+	return db.dialect
+}
+
+// InsertID executes an INSERT statement and returns the id of the row inserted.
+func (db *DB) InsertID(query string, args ...interface{}) (int64, error) {
+	if db.dialect.Returning() {
+		var id int64
+		err := db.QueryRow(strings.TrimSuffix(strings.TrimSpace(query), ";")+" RETURNING id;", args...).Scan(&id)
+		return id, err
+	}
+	return db.ExecX(query, 1, args...)
 }
 
 func Close(closer io.Closer) {
@@ -65,6 +125,7 @@ func Rollback(tx *Tx) {
 }
 
 func (db *DB) Exec(sql string, affect int64, args ...interface{}) error {
+	sql, args = db.dialect.Rebind(sql, args)
 	rs, err := db.DB.Exec(sql, args...)
 	if err != nil {
 		return err
@@ -82,6 +143,7 @@ func (db *DB) Exec(sql string, affect int64, args ...interface{}) error {
 }
 
 func (db *DB) ExecX(sql string, affect int64, args ...interface{}) (int64, error) {
+	sql, args = db.dialect.Rebind(sql, args)
 	rs, err := db.DB.Exec(sql, args...)
 	if err != nil {
 		return 0, err
@@ -99,12 +161,14 @@ func (db *DB) ExecX(sql string, affect int64, args ...interface{}) (int64, error
 }
 
 func (db *DB) QueryRow(sql string, args ...interface{}) *Row {
+	sql, args = db.dialect.Rebind(sql, args)
 	rdb := <-db.rdbChan
 	db.rdbChan <- rdb
 	return &Row{rdb.QueryRow(sql, args...)}
 }
 
 func (db *DB) Query(sql string, args ...interface{}) (*Rows, error) {
+	sql, args = db.dialect.Rebind(sql, args)
 	rdb := <-db.rdbChan
 	db.rdbChan <- rdb
 	rows, err := rdb.Query(sql, args...)
@@ -119,7 +183,7 @@ func (db *DB) Begin() (*Tx, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Tx{tx}, nil
+	return &Tx{tx, db.dialect}, nil
 }
 
 type Row struct {
@@ -158,6 +222,7 @@ func (r *Rows) Err() error {
 
 type Tx struct {
 	*sql.Tx
+	dialect Dialect
 }
 
 func (tx *Tx) Commit() error {
@@ -168,6 +233,7 @@ func (tx *Tx) Commit() error {
 }
 
 func (tx *Tx) Exec(sql string, affect int64, args ...interface{}) error {
+	sql, args = tx.dialect.Rebind(sql, args)
 	rs, err := tx.Tx.Exec(sql, args...)
 	if err != nil {
 		return err
@@ -185,6 +251,7 @@ func (tx *Tx) Exec(sql string, affect int64, args ...interface{}) error {
 }
 
 func (tx *Tx) ExecX(sql string, affect int64, args ...interface{}) (int64, error) {
+	sql, args = tx.dialect.Rebind(sql, args)
 	rs, err := tx.Tx.Exec(sql, args...)
 	if err != nil {
 		return 0, err
@@ -202,10 +269,12 @@ func (tx *Tx) ExecX(sql string, affect int64, args ...interface{}) (int64, error
 }
 
 func (tx *Tx) QueryRow(sql string, args ...interface{}) *Row {
+	sql, args = tx.dialect.Rebind(sql, args)
 	return &Row{tx.Tx.QueryRow(sql, args...)}
 }
 
 func (tx *Tx) Query(sql string, args ...interface{}) (*Rows, error) {
+	sql, args = tx.dialect.Rebind(sql, args)
 	rows, err := tx.Tx.Query(sql, args...)
 	if err != nil {
 		return nil, err
@@ -22,6 +22,8 @@ var (
 	ErrTooManyRequests = add(429, "too many requests")
 	// Internal server
 	ErrInternalServer = add(500, "internal server")
+	// Service unavailable
+	ErrServiceUnavailable = add(503, "service unavailable")
 
 	// Invalid token
 	ErrInvalidToken = add(1001, "invalid token")
@@ -52,21 +52,27 @@ func FormatMicroError(err error) error {
 
 // 自定义请求错误重试
 func RetryOnMicroError(ctx context.Context, req client.Request, retryCount int, err error) (bool, error) {
+	return Retryable(err), nil
+}
+
+// Retryable reports whether the request which failed with err may succeed on a retry,
+// errors without a code are taken for transport errors.
+func Retryable(err error) bool {
 	if err == nil {
-		return false, nil
+		return false
 	}
 
 	code, ok := errors.Cause(err).(Coder)
 	if !ok {
-		return true, nil
+		return true
 	}
 
 	switch code.Code() {
 	// retry on timeout or internal server error
 	case 408, 500:
-		return true, nil
+		return true
 	default:
-		return false, nil
+		return false
 	}
 }
 
@@ -0,0 +1,156 @@
+// Package health serves the liveness and readiness endpoints of the servers.
+//
+// /healthz reports that the process is up. /readyz runs the checks of the
+// dependencies the server uses and answers 503 if any of them failed.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Default time a check may take before it fails
+const defaultTimeout = 3 * time.Second
+
+// Check reports whether a dependency is usable.
+type Check func(ctx context.Context) error
+
+// Result is the outcome of one check.
+type Result struct {
+	Name    string  `json:"name"`
+	Status  string  `json:"status"`
+	Latency float64 `json:"latency_ms"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// Report is the outcome of all checks.
+type Report struct {
+	Status string    `json:"status"`
+	Checks []*Result `json:"checks"`
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs the readiness checks of a server.
+type Checker struct {
+	timeout time.Duration
+
+	mux    sync.RWMutex
+	checks []namedCheck
+}
+
+func NewChecker() *Checker {
+	return &Checker{timeout: defaultTimeout}
+}
+
+// Add adds a check, checks run in the order they were added.
+func (c *Checker) Add(name string, check Check) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// Run runs all checks concurrently.
+func (c *Checker) Run(ctx context.Context) *Report {
+	c.mux.RLock()
+	checks := append([]namedCheck(nil), c.checks...)
+	c.mux.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	report := &Report{Status: StatusOK, Checks: make([]*Result, len(checks))}
+	var wg sync.WaitGroup
+	for i, nc := range checks {
+		wg.Add(1)
+		go func(i int, nc namedCheck) {
+			defer wg.Done()
+			report.Checks[i] = run(ctx, nc)
+		}(i, nc)
+	}
+	wg.Wait()
+
+	for _, r := range report.Checks {
+		if r.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+func run(ctx context.Context, nc namedCheck) *Result {
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- nc.check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	r := &Result{
+		Name:    nc.name,
+		Status:  StatusOK,
+		Latency: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		r.Status = StatusFail
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// ServeReady answers with the report of the checks, the status is 503 if a check failed.
+func (c *Checker) ServeReady(w http.ResponseWriter, req *http.Request) {
+	report := c.Run(req.Context())
+	code := http.StatusOK
+	if report.Status != StatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	writeJSON(w, code, report)
+}
+
+// ServeLive answers as long as the process is able to serve requests.
+func ServeLive(w http.ResponseWriter, req *http.Request) {
+	writeJSON(w, http.StatusOK, &Report{Status: StatusOK, Checks: []*Result{}})
+}
+
+// Handler is the subset of http.ServeMux the endpoints are registered on.
+type Handler interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
+// Register adds /healthz and /readyz to the handler.
+func (c *Checker) Register(h Handler) {
+	h.HandleFunc("/healthz", ServeLive)
+	h.HandleFunc("/readyz", c.ServeReady)
+}
+
+// ListenAndServe serves the endpoints on their own address, for servers without an
+// HTTP server of their own.
+func (c *Checker) ListenAndServe(addr string) error {
+	mux := http.NewServeMux()
+	c.Register(mux)
+	return http.ListenAndServe(addr, mux)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker(t *testing.T) {
+	c := NewChecker()
+	c.Add("ok", func(context.Context) error { return nil })
+	c.Add("fail", func(context.Context) error { return errors.New("unreachable") })
+
+	mux := http.NewServeMux()
+	c.Register(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	report := new(Report)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), report))
+	require.Equal(t, StatusFail, report.Status)
+	require.Len(t, report.Checks, 2)
+	require.Equal(t, "ok", report.Checks[0].Name)
+	require.Equal(t, StatusOK, report.Checks[0].Status)
+	require.Equal(t, "fail", report.Checks[1].Name)
+	require.Equal(t, "unreachable", report.Checks[1].Error)
+}
+
+func TestCheckerTimeout(t *testing.T) {
+	c := NewChecker()
+	c.timeout = 20 * time.Millisecond
+	c.Add("slow", func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	start := time.Now()
+	report := c.Run(context.Background())
+	require.Less(t, int64(time.Since(start)), int64(500*time.Millisecond))
+	require.Equal(t, StatusFail, report.Status)
+	require.Equal(t, context.DeadlineExceeded.Error(), report.Checks[0].Error)
+}
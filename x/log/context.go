@@ -0,0 +1,16 @@
+package log
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns ctx carrying the logger of the request.
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger of the request in ctx.
+func FromContext(ctx context.Context) (Logger, bool) {
+	l, ok := ctx.Value(contextKey{}).(Logger)
+	return l, ok
+}
@@ -0,0 +1,105 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// UIDKey is the context key of the user ID in the records, the records of the users
+// debugged with LevelHandler.DebugUID are written at any level.
+const UIDKey = "uid"
+
+var levels = NewLevelHandler(LvlDebug, DiscardHandler())
+
+// Levels returns the LevelHandler shared by the servers of the process.
+func Levels() *LevelHandler {
+	return levels
+}
+
+// LevelHandler writes the records up to a level which may be changed at runtime to
+// the wrapped Handler, and the records of debugged users regardless of their level.
+type LevelHandler struct {
+	lvl int32
+	h   swapHandler
+
+	mu   sync.RWMutex
+	uids map[string]struct{}
+}
+
+// NewLevelHandler creates a LevelHandler writing the records up to lvl to h.
+func NewLevelHandler(lvl Lvl, h Handler) *LevelHandler {
+	l := &LevelHandler{lvl: int32(lvl), uids: make(map[string]struct{})}
+	l.h.Swap(h)
+	return l
+}
+
+// SetHandler replaces the wrapped Handler.
+func (l *LevelHandler) SetHandler(h Handler) {
+	l.h.Swap(h)
+}
+
+// Level returns the maximum level of the records written.
+func (l *LevelHandler) Level() Lvl {
+	return Lvl(atomic.LoadInt32(&l.lvl))
+}
+
+// SetLevel changes the maximum level of the records written.
+func (l *LevelHandler) SetLevel(lvl Lvl) {
+	atomic.StoreInt32(&l.lvl, int32(lvl))
+}
+
+// DebugUID writes the records of the user at any level.
+func (l *LevelHandler) DebugUID(uid string) {
+	l.mu.Lock()
+	l.uids[uid] = struct{}{}
+	l.mu.Unlock()
+}
+
+// UndebugUID stops writing the records of the user beyond the level.
+func (l *LevelHandler) UndebugUID(uid string) {
+	l.mu.Lock()
+	delete(l.uids, uid)
+	l.mu.Unlock()
+}
+
+// DebugUIDs returns the debugged users in order.
+func (l *LevelHandler) DebugUIDs() []string {
+	l.mu.RLock()
+	uids := make([]string, 0, len(l.uids))
+	for uid := range l.uids {
+		uids = append(uids, uid)
+	}
+	l.mu.RUnlock()
+	sort.Strings(uids)
+	return uids
+}
+
+func (l *LevelHandler) Log(r *Record) error {
+	if r.Lvl <= l.Level() || l.debugged(r) {
+		return l.h.Log(r)
+	}
+	return nil
+}
+
+func (l *LevelHandler) debugged(r *Record) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if len(l.uids) == 0 {
+		return false
+	}
+	for i := 0; i+1 < len(r.Ctx); i += 2 {
+		if r.Ctx[i] != UIDKey {
+			continue
+		}
+		uid, ok := r.Ctx[i+1].(string)
+		if !ok {
+			uid = fmt.Sprint(r.Ctx[i+1])
+		}
+		if _, ok := l.uids[uid]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package log
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelHandler(t *testing.T) {
+	var msgs []string
+	h := NewLevelHandler(LvlInfo, FuncHandler(func(r *Record) error {
+		msgs = append(msgs, r.Msg)
+		return nil
+	}))
+	l := New()
+	l.SetHandler(h)
+	user := l.New(UIDKey, "u1")
+
+	l.Debug("debug")
+	user.Debug("user debug")
+	l.Info("info")
+	require.Equal(t, []string{"info"}, msgs)
+
+	h.DebugUID("u1")
+	require.Equal(t, []string{"u1"}, h.DebugUIDs())
+	l.Debug("debug")
+	user.Debug("user debug")
+	require.Equal(t, []string{"info", "user debug"}, msgs)
+
+	h.UndebugUID("u1")
+	h.SetLevel(LvlDebug)
+	require.Equal(t, LvlDebug, h.Level())
+	l.Debug("debug")
+	user.Trace("user trace")
+	require.Equal(t, []string{"info", "user debug", "debug"}, msgs)
+}
+
+func TestSampleHandler(t *testing.T) {
+	counts := make(map[string]int)
+	l := New()
+	l.SetHandler(SampleHandler(2, 3, time.Hour, FuncHandler(func(r *Record) error {
+		counts[r.Msg]++
+		return nil
+	})))
+
+	for i := 0; i < 11; i++ {
+		l.Info("received")
+		l.Debug("dispatched")
+		l.Warn("failed")
+	}
+	// The first 2, then the 5th, 8th and 11th
+	require.Equal(t, 5, counts["received"])
+	require.Equal(t, 5, counts["dispatched"])
+	require.Equal(t, 11, counts["failed"])
+}
+
+func TestContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	require.False(t, ok)
+
+	l := New("sid", "s1")
+	got, ok := FromContext(NewContext(context.Background(), l))
+	require.True(t, ok)
+	require.Equal(t, l, got)
+}
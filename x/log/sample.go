@@ -0,0 +1,58 @@
+package log
+
+import (
+	"sync"
+	"time"
+)
+
+// SampleHandler returns a Handler which writes the first records of every message in
+// each interval, and every thereafter-th record after them, to the wrapped Handler.
+// Warnings and errors are always written. It keeps hot paths from flooding the log:
+//
+//	log.SampleHandler(10, 100, time.Second, log.StdoutHandler)
+func SampleHandler(first, thereafter int, interval time.Duration, h Handler) Handler {
+	s := &sampler{
+		first:      first,
+		thereafter: thereafter,
+		interval:   interval,
+		counts:     make(map[string]int),
+	}
+	return FilterHandler(s.pass, h)
+}
+
+type sampler struct {
+	first      int
+	thereafter int
+	interval   time.Duration
+
+	mu     sync.Mutex
+	reset  time.Time
+	counts map[string]int
+}
+
+func (s *sampler) pass(r *Record) bool {
+	if r.Lvl <= LvlWarn {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Time.After(s.reset) {
+		s.reset = r.Time.Add(s.interval)
+		s.counts = make(map[string]int, len(s.counts))
+	}
+	n := s.counts[r.Msg] + 1
+	s.counts[r.Msg] = n
+	if n <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.first)%s.thereafter == 0
+}
+
+// Sampled returns a logger with the context of l writing a sample of its records to the
+// handler of l, see SampleHandler.
+func Sampled(l Logger, first, thereafter int, interval time.Duration) Logger {
+	sampled := l.New()
+	sampled.SetHandler(SampleHandler(first, thereafter, interval, l.GetHandler()))
+	return sampled
+}
@@ -0,0 +1,51 @@
+package microx
+
+import (
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/micro/go-micro/v2/client"
+	gclient "github.com/micro/go-micro/v2/client/grpc"
+	"github.com/micro/go-micro/v2/client/selector"
+	"github.com/micro/go-micro/v2/config/cmd"
+	"github.com/micro/go-micro/v2/registry"
+	gserver "github.com/micro/go-micro/v2/server/grpc"
+	"github.com/micro/go-micro/v2/web"
+)
+
+// The defaults of go-micro, such as the server, the client, the selector and the registry,
+// are shared by the servers of a process, e.g. standalone runs logic, job and comet in
+// one. The services and clients are built with their own instead, so that the servers
+// neither race on them nor find the services in the registry of another.
+
+// NewClient returns a grpc client finding the services in r with a selector of its own.
+func NewClient(r registry.Registry, opts ...client.Option) client.Client {
+	opts = append([]client.Option{
+		client.Selector(selector.NewSelector(selector.Registry(r))),
+		client.Registry(r),
+	}, opts...)
+	return gclient.NewClient(opts...)
+}
+
+// IsolatedMicroOptions returns the options giving a micro service a grpc server, a
+// client, a command line and an auth of its own, along with r and b. They come before
+// the other options, which configure the server.
+func IsolatedMicroOptions(r registry.Registry, b broker.Broker) []micro.Option {
+	return []micro.Option{
+		micro.Server(gserver.NewServer()),
+		micro.Client(NewClient(r)),
+		micro.Cmd(cmd.NewCmd()),
+		micro.Auth(auth.NewAuth()),
+		micro.Broker(b),
+		micro.Registry(r),
+	}
+}
+
+// IsolatedWebOptions returns the options giving a web service a micro service of its own
+// registering it in r. The micro service is never run, so its broker is never connected.
+func IsolatedWebOptions(r registry.Registry) []web.Option {
+	return []web.Option{
+		web.MicroService(micro.NewService(IsolatedMicroOptions(r, broker.NewBroker())...)),
+		web.Registry(r),
+	}
+}
@@ -0,0 +1,31 @@
+package microx
+
+import (
+	"context"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/server"
+	"mercury/x/ecode"
+	"mercury/x/stat"
+	"strconv"
+	"time"
+)
+
+// MetricsHandlerWrapper records the response time and the ecode of every rpc request.
+func MetricsHandlerWrapper(fn server.HandlerFunc) server.HandlerFunc {
+	return func(ctx context.Context, req server.Request, rsp interface{}) error {
+		start := time.Now()
+		err := fn(ctx, req, rsp)
+		stat.RPCServer.Timing(req.Endpoint(), int64(time.Since(start)/time.Millisecond))
+		stat.RPCServer.Incr(req.Endpoint(), strconv.Itoa(errorCode(err)))
+		return err
+	}
+}
+
+// errorCode returns the ecode of the error, errors of go-micro such as the ones of the
+// rate limiter keep their own code.
+func errorCode(err error) int {
+	if e, ok := err.(*errors.Error); ok {
+		return int(e.Code)
+	}
+	return ecode.Cause(err).Code()
+}
@@ -0,0 +1,165 @@
+package registryx
+
+import (
+	"sync"
+
+	"github.com/micro/go-micro/v2/registry"
+)
+
+// DefaultMemoryRegistry is shared by the servers running in the same process.
+var DefaultMemoryRegistry = NewMemoryRegistry()
+
+// memoryRegistry keeps the nodes registered by the servers of one process. The nodes
+// stay until they are deregistered, their TTL is ignored. The watchers are told about
+// the nodes created and deleted like the ones of the static registry.
+type memoryRegistry struct {
+	opts registry.Options
+
+	mu       sync.RWMutex
+	services map[string]*registry.Service
+	watchers map[*staticWatcher]struct{}
+}
+
+func NewMemoryRegistry(opts ...registry.Option) registry.Registry {
+	r := &memoryRegistry{
+		services: make(map[string]*registry.Service),
+		watchers: make(map[*staticWatcher]struct{}),
+	}
+	_ = r.Init(opts...)
+	return r
+}
+
+func (r *memoryRegistry) Init(opts ...registry.Option) error {
+	for _, o := range opts {
+		o(&r.opts)
+	}
+	return nil
+}
+
+func (r *memoryRegistry) Options() registry.Options {
+	return r.opts
+}
+
+// Register adds the nodes of s to the service, registering a node again refreshes it.
+func (r *memoryRegistry) Register(s *registry.Service, _ ...registry.RegisterOption) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, ok := r.services[s.Name]
+	if !ok {
+		old = &registry.Service{Name: s.Name}
+	}
+	merged := copyService(s)
+	merged.Nodes = nil
+	for _, n := range old.Nodes {
+		if !hasNode(s, n.Id) {
+			merged.Nodes = append(merged.Nodes, n)
+		}
+	}
+	merged.Nodes = append(merged.Nodes, copyService(s).Nodes...)
+	r.services[s.Name] = merged
+
+	if created := diffNodes(s, old); created != nil {
+		r.notify(&registry.Result{Action: "create", Service: created})
+	}
+	return nil
+}
+
+// Deregister removes the nodes of s from the service.
+func (r *memoryRegistry) Deregister(s *registry.Service, _ ...registry.DeregisterOption) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, ok := r.services[s.Name]
+	if !ok {
+		return nil
+	}
+	deleted := &registry.Service{Name: s.Name, Version: old.Version}
+	var nodes []*registry.Node
+	for _, n := range old.Nodes {
+		if hasNode(s, n.Id) {
+			deleted.Nodes = append(deleted.Nodes, n)
+			continue
+		}
+		nodes = append(nodes, n)
+	}
+	if len(nodes) == 0 {
+		delete(r.services, s.Name)
+	} else {
+		old.Nodes = nodes
+	}
+
+	if len(deleted.Nodes) > 0 {
+		r.notify(&registry.Result{Action: "delete", Service: deleted})
+	}
+	return nil
+}
+
+func hasNode(s *registry.Service, id string) bool {
+	for _, n := range s.Nodes {
+		if n.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// notify sends the result to the watchers of the service, it must be called with the
+// lock held.
+func (r *memoryRegistry) notify(res *registry.Result) {
+	for w := range r.watchers {
+		if w.service != "" && w.service != res.Service.Name {
+			continue
+		}
+		select {
+		case w.next <- res:
+		default:
+		}
+	}
+}
+
+func (r *memoryRegistry) GetService(name string, _ ...registry.GetOption) ([]*registry.Service, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.services[name]
+	if !ok {
+		return nil, registry.ErrNotFound
+	}
+	return []*registry.Service{copyService(s)}, nil
+}
+
+func (r *memoryRegistry) ListServices(_ ...registry.ListOption) ([]*registry.Service, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	services := make([]*registry.Service, 0, len(r.services))
+	for _, s := range r.services {
+		services = append(services, copyService(s))
+	}
+	return services, nil
+}
+
+func (r *memoryRegistry) Watch(opts ...registry.WatchOption) (registry.Watcher, error) {
+	var wo registry.WatchOptions
+	for _, o := range opts {
+		o(&wo)
+	}
+	w := &staticWatcher{
+		service: wo.Service,
+		next:    make(chan *registry.Result, staticWatcherBuffer),
+		exit:    make(chan struct{}),
+	}
+	r.mu.Lock()
+	r.watchers[w] = struct{}{}
+	r.mu.Unlock()
+	go func() {
+		<-w.exit
+		r.mu.Lock()
+		delete(r.watchers, w)
+		r.mu.Unlock()
+	}()
+	return w, nil
+}
+
+func (r *memoryRegistry) String() string {
+	return "memory"
+}
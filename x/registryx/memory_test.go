@@ -0,0 +1,50 @@
+package registryx
+
+import (
+	"testing"
+
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRegistry(t *testing.T) {
+	r := NewMemoryRegistry()
+	w, err := r.Watch(registry.WatchService("mercury.comet"))
+	require.NoError(t, err)
+	defer w.Stop()
+
+	comet1 := &registry.Service{Name: "mercury.comet", Nodes: []*registry.Node{{Id: "mercury.comet-1", Address: "10.0.0.1:9002"}}}
+	comet2 := &registry.Service{Name: "mercury.comet", Nodes: []*registry.Node{{Id: "mercury.comet-2", Address: "10.0.0.2:9002"}}}
+	require.NoError(t, r.Register(comet1))
+	require.NoError(t, r.Register(comet2))
+	// Registering again only refreshes the node
+	require.NoError(t, r.Register(comet1))
+	require.NoError(t, r.Register(&registry.Service{Name: "mercury.logic", Nodes: []*registry.Node{{Id: "mercury.logic-1"}}}))
+
+	services, err := r.GetService("mercury.comet")
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	require.Len(t, services[0].Nodes, 2)
+	require.NoError(t, Registered(r, "mercury.comet", "mercury.comet-2"))
+	all, err := r.ListServices()
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+
+	for _, id := range []string{"mercury.comet-1", "mercury.comet-2"} {
+		res, err := w.Next()
+		require.NoError(t, err)
+		require.Equal(t, "create", res.Action)
+		require.Equal(t, id, res.Service.Nodes[0].Id)
+	}
+
+	require.NoError(t, r.Deregister(comet1))
+	res, err := w.Next()
+	require.NoError(t, err)
+	require.Equal(t, "delete", res.Action)
+	require.Equal(t, "mercury.comet-1", res.Service.Nodes[0].Id)
+	require.Error(t, Registered(r, "mercury.comet", "mercury.comet-1"))
+
+	require.NoError(t, r.Deregister(comet2))
+	_, err = r.GetService("mercury.comet")
+	require.Equal(t, registry.ErrNotFound, err)
+}
@@ -0,0 +1,90 @@
+// Package registryx provides the service registries mercury can run on.
+package registryx
+
+import (
+	"mercury/config"
+	"mercury/x"
+	"mercury/x/ecode"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/registry/mdns"
+	"github.com/micro/go-plugins/registry/consul/v2"
+	"github.com/micro/go-plugins/registry/etcdv3/v2"
+	"github.com/micro/go-plugins/registry/kubernetes/v2"
+)
+
+type ConfigProvider interface {
+	Registry() *config.Registry
+}
+
+// NewRegistry creates the registry selected by the config.
+func NewRegistry(c ConfigProvider) (registry.Registry, error) {
+	cfg := c.Registry()
+	switch cfg.Kind() {
+	case config.RegistryTypeETCD:
+		return etcdv3.NewRegistry(options(cfg.ETCD.Addresses, cfg.ETCD.Timeout)...), nil
+	case config.RegistryTypeConsul:
+		return consul.NewRegistry(options(cfg.Consul.Addresses, cfg.Consul.Timeout)...), nil
+	case config.RegistryTypeKubernetes:
+		// The API server address keeps its scheme.
+		opts := []registry.Option{registry.Addrs(cfg.Kubernetes.Addresses...)}
+		if cfg.Kubernetes.Timeout > 0 {
+			opts = append(opts, registry.Timeout(cfg.Kubernetes.Timeout))
+		}
+		return kubernetes.NewRegistry(opts...), nil
+	case config.RegistryTypeStatic:
+		return NewStaticRegistry(cfg.Static.Nodes), nil
+	case config.RegistryTypeMDNS:
+		return mdns.NewRegistry(), nil
+	case config.RegistryTypeMemory:
+		return DefaultMemoryRegistry, nil
+	default:
+		return nil, ecode.NewError("unknown registry type: " + cfg.Kind())
+	}
+}
+
+func options(addresses []string, timeout time.Duration) []registry.Option {
+	var addrs []string
+	for _, v := range addresses {
+		v = strings.TrimSpace(v)
+		addrs = append(addrs, x.ReplaceHttpOrHttps(v))
+	}
+
+	opts := []registry.Option{registry.Addrs(addrs...)}
+	if timeout > 0 {
+		opts = append(opts, registry.Timeout(timeout))
+	}
+	return opts
+}
+
+// Registered returns an error unless the node of the service is in the registry.
+func Registered(r registry.Registry, name, nodeID string) error {
+	services, err := r.GetService(name)
+	if err != nil {
+		return err
+	}
+	for _, s := range services {
+		for _, n := range s.Nodes {
+			if n.Id == nodeID {
+				return nil
+			}
+		}
+	}
+	return ecode.NewError("node " + nodeID + " is not registered")
+}
+
+// Available returns an error unless the service has a node in the registry.
+func Available(r registry.Registry, name string) error {
+	services, err := r.GetService(name)
+	if err != nil {
+		return err
+	}
+	for _, s := range services {
+		if len(s.Nodes) > 0 {
+			return nil
+		}
+	}
+	return ecode.NewError("service " + name + " has no nodes")
+}
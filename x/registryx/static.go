@@ -0,0 +1,211 @@
+package registryx
+
+import (
+	"sync"
+
+	"github.com/micro/go-micro/v2/registry"
+	"mercury/config"
+)
+
+// Number of changes a watcher of the static registry buffers, further changes are
+// dropped until the watcher catches up.
+const staticWatcherBuffer = 16
+
+// staticRegistry serves a fixed list of nodes from the config. Services register and
+// deregister without effect, the list only changes with the config.
+type staticRegistry struct {
+	opts registry.Options
+
+	mu       sync.RWMutex
+	services map[string]*registry.Service
+	watchers map[*staticWatcher]struct{}
+}
+
+type staticWatcher struct {
+	service string
+	next    chan *registry.Result
+	once    sync.Once
+	exit    chan struct{}
+}
+
+// NewStaticRegistry creates a registry serving the nodes. A node without an ID gets
+// the service name and its address as ID.
+func NewStaticRegistry(nodes []config.RegistryStaticNode) registry.Registry {
+	return &staticRegistry{
+		services: staticServices(nodes),
+		watchers: make(map[*staticWatcher]struct{}),
+	}
+}
+
+func staticServices(nodes []config.RegistryStaticNode) map[string]*registry.Service {
+	services := make(map[string]*registry.Service)
+	for _, n := range nodes {
+		s, ok := services[n.Service]
+		if !ok {
+			s = &registry.Service{Name: n.Service}
+			services[n.Service] = s
+		}
+		id := n.ID
+		if id == "" {
+			id = n.Service + "-" + n.Address
+		}
+		s.Nodes = append(s.Nodes, &registry.Node{Id: id, Address: n.Address})
+	}
+	return services
+}
+
+// Update replaces the nodes of a static registry with the nodes of the config, the
+// watchers are told about the nodes created and deleted. Other registries are left as
+// they are, it reports whether r was updated.
+func Update(r registry.Registry, c *config.Registry) bool {
+	sr, ok := r.(*staticRegistry)
+	if !ok || c.Kind() != config.RegistryTypeStatic {
+		return false
+	}
+	sr.update(c.Static.Nodes)
+	return true
+}
+
+func (r *staticRegistry) update(nodes []config.RegistryStaticNode) {
+	services := staticServices(nodes)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var results []*registry.Result
+	for name, old := range r.services {
+		if deleted := diffNodes(old, services[name]); deleted != nil {
+			results = append(results, &registry.Result{Action: "delete", Service: deleted})
+		}
+	}
+	for name, s := range services {
+		if created := diffNodes(s, r.services[name]); created != nil {
+			results = append(results, &registry.Result{Action: "create", Service: created})
+		}
+	}
+	r.services = services
+
+	for w := range r.watchers {
+		for _, res := range results {
+			if w.service != "" && w.service != res.Service.Name {
+				continue
+			}
+			select {
+			case w.next <- res:
+			default:
+			}
+		}
+	}
+}
+
+// diffNodes returns a copy of s with the nodes which are not in other, nil if there
+// are none.
+func diffNodes(s, other *registry.Service) *registry.Service {
+	known := make(map[string]string)
+	if other != nil {
+		for _, n := range other.Nodes {
+			known[n.Id] = n.Address
+		}
+	}
+	diff := &registry.Service{Name: s.Name}
+	for _, n := range s.Nodes {
+		if addr, ok := known[n.Id]; !ok || addr != n.Address {
+			node := *n
+			diff.Nodes = append(diff.Nodes, &node)
+		}
+	}
+	if len(diff.Nodes) == 0 {
+		return nil
+	}
+	return diff
+}
+
+func (r *staticRegistry) Init(opts ...registry.Option) error {
+	for _, o := range opts {
+		o(&r.opts)
+	}
+	return nil
+}
+
+func (r *staticRegistry) Options() registry.Options {
+	return r.opts
+}
+
+func (r *staticRegistry) Register(*registry.Service, ...registry.RegisterOption) error {
+	return nil
+}
+
+func (r *staticRegistry) Deregister(*registry.Service, ...registry.DeregisterOption) error {
+	return nil
+}
+
+func (r *staticRegistry) GetService(name string, opts ...registry.GetOption) ([]*registry.Service, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.services[name]
+	if !ok {
+		return nil, registry.ErrNotFound
+	}
+	return []*registry.Service{copyService(s)}, nil
+}
+
+func (r *staticRegistry) ListServices(opts ...registry.ListOption) ([]*registry.Service, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	services := make([]*registry.Service, 0, len(r.services))
+	for _, s := range r.services {
+		services = append(services, copyService(s))
+	}
+	return services, nil
+}
+
+// Watch returns a watcher which reports the nodes changed by Update.
+func (r *staticRegistry) Watch(opts ...registry.WatchOption) (registry.Watcher, error) {
+	var wo registry.WatchOptions
+	for _, o := range opts {
+		o(&wo)
+	}
+	w := &staticWatcher{
+		service: wo.Service,
+		next:    make(chan *registry.Result, staticWatcherBuffer),
+		exit:    make(chan struct{}),
+	}
+	r.mu.Lock()
+	r.watchers[w] = struct{}{}
+	r.mu.Unlock()
+	go func() {
+		<-w.exit
+		r.mu.Lock()
+		delete(r.watchers, w)
+		r.mu.Unlock()
+	}()
+	return w, nil
+}
+
+func (r *staticRegistry) String() string {
+	return "static"
+}
+
+func copyService(s *registry.Service) *registry.Service {
+	c := *s
+	c.Nodes = make([]*registry.Node, len(s.Nodes))
+	for i, n := range s.Nodes {
+		node := *n
+		c.Nodes[i] = &node
+	}
+	return &c
+}
+
+func (w *staticWatcher) Next() (*registry.Result, error) {
+	select {
+	case res := <-w.next:
+		return res, nil
+	case <-w.exit:
+		return nil, registry.ErrWatcherStopped
+	}
+}
+
+func (w *staticWatcher) Stop() {
+	w.once.Do(func() {
+		close(w.exit)
+	})
+}
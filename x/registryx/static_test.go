@@ -0,0 +1,94 @@
+package registryx
+
+import (
+	"testing"
+
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/stretchr/testify/require"
+	"mercury/config"
+)
+
+func TestStaticRegistry(t *testing.T) {
+	r := NewStaticRegistry([]config.RegistryStaticNode{
+		{Service: "mercury.comet", Address: "10.0.0.1:9002"},
+		{Service: "mercury.comet", ID: "mercury.comet-2", Address: "10.0.0.2:9002"},
+		{Service: "mercury.logic", Address: "10.0.0.3:9011"},
+	})
+
+	services, err := r.GetService("mercury.comet")
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	require.Len(t, services[0].Nodes, 2)
+	require.Equal(t, "mercury.comet-10.0.0.1:9002", services[0].Nodes[0].Id)
+	require.Equal(t, "mercury.comet-2", services[0].Nodes[1].Id)
+
+	// Callers can not change the nodes
+	services[0].Nodes[0].Address = ""
+	services, err = r.GetService("mercury.comet")
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1:9002", services[0].Nodes[0].Address)
+
+	_, err = r.GetService("mercury.job")
+	require.Equal(t, registry.ErrNotFound, err)
+
+	// Registering has no effect
+	require.NoError(t, r.Register(&registry.Service{Name: "mercury.job"}))
+	all, err := r.ListServices()
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+
+	w, err := r.Watch()
+	require.NoError(t, err)
+	w.Stop()
+	_, err = w.Next()
+	require.Equal(t, registry.ErrWatcherStopped, err)
+}
+
+func TestStaticRegistryUpdate(t *testing.T) {
+	r := NewStaticRegistry([]config.RegistryStaticNode{
+		{Service: "mercury.comet", Address: "10.0.0.1:9002"},
+		{Service: "mercury.logic", Address: "10.0.0.3:9011"},
+	})
+	w, err := r.Watch(registry.WatchService("mercury.comet"))
+	require.NoError(t, err)
+	defer w.Stop()
+
+	require.False(t, Update(registry.NewRegistry(), &config.Registry{Type: config.RegistryTypeStatic}))
+	require.True(t, Update(r, &config.Registry{Type: config.RegistryTypeStatic, Static: config.RegistryStatic{
+		Nodes: []config.RegistryStaticNode{
+			{Service: "mercury.comet", Address: "10.0.0.2:9002"},
+			{Service: "mercury.logic", Address: "10.0.0.3:9011"},
+		},
+	}}))
+
+	services, err := r.GetService("mercury.comet")
+	require.NoError(t, err)
+	require.Len(t, services[0].Nodes, 1)
+	require.Equal(t, "10.0.0.2:9002", services[0].Nodes[0].Address)
+
+	// Only the changes of the watched service are reported
+	actions := make(map[string]string)
+	for i := 0; i < 2; i++ {
+		res, err := w.Next()
+		require.NoError(t, err)
+		require.Equal(t, "mercury.comet", res.Service.Name)
+		actions[res.Action] = res.Service.Nodes[0].Address
+	}
+	require.Equal(t, map[string]string{"delete": "10.0.0.1:9002", "create": "10.0.0.2:9002"}, actions)
+}
+
+func TestNewRegistry(t *testing.T) {
+	cfg := config.NewProviderConfig(config.DefaultConfig())
+	r, err := NewRegistry(cfg)
+	require.NoError(t, err)
+	require.Equal(t, "mdns", r.String())
+
+	cfg.Config.Registry.Type = config.RegistryTypeStatic
+	r, err = NewRegistry(cfg)
+	require.NoError(t, err)
+	require.Equal(t, "static", r.String())
+
+	cfg.Config.Registry.Type = "zookeeper"
+	_, err = NewRegistry(cfg)
+	require.Error(t, err)
+}
@@ -0,0 +1,97 @@
+package secretboxer
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+const WrapTypeKey = "key"
+
+// KeyBoxer seals with a random key instead of a key derived from a passphrase, it is
+// meant for data keys which are themselves wrapped by a PassphraseBoxer.
+type KeyBoxer struct {
+	key          [keyLength]byte
+	encodingType string
+}
+
+// GenerateKey returns a random key for NewKeyBoxer.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, keyLength)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func NewKeyBoxer(key []byte, encodingType string) (*KeyBoxer, error) {
+	if len(key) != keyLength {
+		return nil, fmt.Errorf("key must be %d bytes", keyLength)
+	}
+	if encodingType == "" {
+		encodingType = EncodingTypeStd
+	}
+	b := &KeyBoxer{encodingType: encodingType}
+	copy(b.key[:], key)
+	return b, nil
+}
+
+// Return the wrap type of KeyBoxer.
+func (b *KeyBoxer) WrapType() string {
+	return WrapTypeKey
+}
+
+// Return the encoding type of KeyBoxer.
+func (b *KeyBoxer) EncodingType() string {
+	return b.encodingType
+}
+
+// Encrypt the byte fragment and return a base64 string.
+func (b *KeyBoxer) Encrypt(in []byte) (string, error) {
+	var nonce [nonceLength]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return "", err
+	}
+	cipherText := secretbox.Seal(nonce[:], in, &nonce, &b.key)
+	return encode(b.encodingType, cipherText), nil
+}
+
+// Decrypt the encrypted base64 string and return the decrypted byte fragment.
+func (b *KeyBoxer) Decrypt(in string) ([]byte, error) {
+	buf, err := decode(b.encodingType, in)
+	if err != nil {
+		return []byte{}, err
+	}
+	if len(buf) < nonceLength {
+		return []byte{}, fmt.Errorf("failed to decrypt")
+	}
+
+	var nonce [nonceLength]byte
+	copy(nonce[:], buf[:nonceLength])
+	decrypted, ok := secretbox.Open(nil, buf[nonceLength:], &nonce, &b.key)
+	if !ok {
+		return []byte{}, fmt.Errorf("failed to decrypt")
+	}
+	return decrypted, nil
+}
+
+func encode(encodingType string, b []byte) string {
+	switch encodingType {
+	case EncodingTypeURL:
+		return base64.RawURLEncoding.EncodeToString(b)
+	default:
+		return base64.RawStdEncoding.EncodeToString(b)
+	}
+}
+
+func decode(encodingType, s string) ([]byte, error) {
+	switch encodingType {
+	case EncodingTypeURL:
+		return base64.RawURLEncoding.DecodeString(s)
+	default:
+		return base64.RawStdEncoding.DecodeString(s)
+	}
+}
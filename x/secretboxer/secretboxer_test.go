@@ -48,3 +48,35 @@ func TestPassphraseBoxer_Open(t *testing.T) {
 		t.Errorf("secret boxer open seal is failed, expecting 666666, but actually getting %s", string(openSeal))
 	}
 }
+
+func TestKeyBoxer(t *testing.T) {
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	boxer, err := NewKeyBoxer(key, EncodingTypeURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seal, err := boxer.Encrypt([]byte("/chat/v1/channels"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	openSeal, err := boxer.Decrypt(seal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(openSeal) != "/chat/v1/channels" {
+		t.Errorf("key boxer open seal is failed, expecting /chat/v1/channels, but actually getting %s", string(openSeal))
+	}
+
+	other, _ := GenerateKey()
+	otherBoxer, _ := NewKeyBoxer(other, EncodingTypeURL)
+	if _, err := otherBoxer.Decrypt(seal); err == nil {
+		t.Error("key boxer opened a seal of another key")
+	}
+	if _, err := NewKeyBoxer(key[:16], EncodingTypeURL); err == nil {
+		t.Error("key boxer accepted a short key")
+	}
+}
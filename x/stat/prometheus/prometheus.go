@@ -14,6 +14,31 @@ var (
 	RPCServer = New().
 			WithTimer("rpc_server_response_time_seconds", []string{"method"}).
 			WithCounter("rpc_server_request_total", []string{"method", "code"})
+	// JobDispatch for requests dispatched from job to comet
+	JobDispatch = New().
+			WithTimer("job_dispatch_response_time_seconds", []string{"server_id", "endpoint"}).
+			WithCounter("job_dispatch_total", []string{"server_id", "endpoint", "result"})
+	// JobQueue for dispatch queues of job
+	JobQueue = New().
+			WithState("job_dispatch_queue_length", []string{"server_id", "queue"})
+	// CometSequence for ordered messages delivered by comet
+	CometSequence = New().
+			WithCounter("comet_sequence_total", []string{"result"})
+	// CometDropped for messages comet dropped as the queue of the session was full
+	CometDropped = New().
+			WithCounter("comet_dropped_total", []string{"operation"})
+	// CometSessions for live sessions of comet by client
+	CometSessions = New().
+			WithState("comet_sessions", []string{"client_id"})
+	// PushLatency for messages from logic publishing them to comet queueing them to the session
+	PushLatency = New().
+			WithTimer("push_latency_seconds", []string{"operation"})
+	// Queue for in-process queues
+	Queue = New().
+		WithState("queue_length", []string{"service", "queue"})
+	// BrokerLag for messages waiting in the broker for a consumer group
+	BrokerLag = New().
+			WithState("broker_lag", []string{"topic", "group"})
 )
 
 // Prometheus struct info
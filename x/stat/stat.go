@@ -15,4 +15,17 @@ var (
 	HTTPServer Stat = prometheus.HTTPServer
 	// rpc
 	RPCServer Stat = prometheus.RPCServer
+	// job
+	JobDispatch Stat = prometheus.JobDispatch
+	JobQueue    Stat = prometheus.JobQueue
+	// comet
+	CometSequence Stat = prometheus.CometSequence
+	CometDropped  Stat = prometheus.CometDropped
+	CometSessions Stat = prometheus.CometSessions
+	// push
+	PushLatency Stat = prometheus.PushLatency
+	// queue
+	Queue Stat = prometheus.Queue
+	// broker
+	BrokerLag Stat = prometheus.BrokerLag
 )
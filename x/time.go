@@ -0,0 +1,8 @@
+package x
+
+import "time"
+
+// UnixMilli returns t as a Unix time in milliseconds.
+func UnixMilli(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
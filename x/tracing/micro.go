@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/server"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HandlerWrapper continues the trace of the caller in a server span for every rpc request.
+func HandlerWrapper(fn server.HandlerFunc) server.HandlerFunc {
+	return func(ctx context.Context, req server.Request, rsp interface{}) error {
+		if md, ok := metadata.FromContext(ctx); ok {
+			ctx = Extract(ctx, md)
+		}
+		ctx, span := Start(ctx, req.Endpoint(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(String("rpc.service", req.Service())))
+		err := fn(ctx, req, rsp)
+		End(span, err)
+		return err
+	}
+}
+
+// CallWrapper starts a client span for every rpc call and hands its trace context to
+// the server in the metadata of the request.
+func CallWrapper(fn client.CallFunc) client.CallFunc {
+	return func(ctx context.Context, node *registry.Node, req client.Request, rsp interface{}, opts client.CallOptions) error {
+		ctx, span := Start(ctx, req.Endpoint(),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(String("rpc.service", req.Service())))
+		md := make(metadata.Metadata)
+		Inject(ctx, md)
+		err := fn(metadata.MergeContext(ctx, md, true), node, req, rsp, opts)
+		End(span, err)
+		return err
+	}
+}
+
+// InjectMessage writes the trace context in ctx to the headers of the message.
+func InjectMessage(ctx context.Context, m *broker.Message) {
+	if m.Header == nil {
+		m.Header = make(map[string]string)
+	}
+	Inject(ctx, m.Header)
+}
+
+// StartConsumer continues the trace of the publisher of the message in a consumer span.
+func StartConsumer(topic string, m *broker.Message) (context.Context, trace.Span) {
+	ctx := context.Background()
+	if m != nil && m.Header != nil {
+		ctx = Extract(ctx, m.Header)
+	}
+	return Start(ctx, topic+" receive",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(String("messaging.destination", topic)))
+}
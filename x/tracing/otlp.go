@@ -0,0 +1,226 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// otlpExporter exports spans to an OTLP/HTTP collector in the JSON encoding. The protobuf
+// exporters of OpenTelemetry require a newer grpc than the one go-micro is pinned to.
+type otlpExporter struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewOTLPExporter creates an exporter posting the spans to the traces endpoint of an
+// OTLP/HTTP collector, such as http://localhost:4318/v1/traces.
+func NewOTLPExporter(endpoint string) sdktrace.SpanExporter {
+	return &otlpExporter{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (e *otlpExporter) ExportSpans(ctx context.Context, ss []*sdktrace.SpanSnapshot) error {
+	if len(ss) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(otlpRequest(ss))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("otlp collector responded %s", resp.Status)
+	}
+	return nil
+}
+
+func (e *otlpExporter) Shutdown(ctx context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// Status codes of OTLP, they are numbered differently than the codes of OpenTelemetry.
+const (
+	otlpStatusUnset = 0
+	otlpStatusOK    = 1
+	otlpStatusError = 2
+)
+
+// otlpRequest groups the spans by resource and instrumentation library.
+func otlpRequest(ss []*sdktrace.SpanSnapshot) *otlpTraces {
+	type scopeKey struct {
+		resource attribute.Distinct
+		name     string
+		version  string
+	}
+
+	traces := new(otlpTraces)
+	resources := make(map[attribute.Distinct]int)
+	scopes := make(map[scopeKey]int)
+	for _, s := range ss {
+		var rk attribute.Distinct
+		var resource otlpResource
+		if s.Resource != nil {
+			rk = s.Resource.Equivalent()
+			resource.Attributes = otlpAttributes(s.Resource.Attributes())
+		}
+		ri, ok := resources[rk]
+		if !ok {
+			ri = len(traces.ResourceSpans)
+			resources[rk] = ri
+			traces.ResourceSpans = append(traces.ResourceSpans, otlpResourceSpans{Resource: resource})
+		}
+
+		lib := s.InstrumentationLibrary
+		sk := scopeKey{resource: rk, name: lib.Name, version: lib.Version}
+		si, ok := scopes[sk]
+		if !ok {
+			si = len(traces.ResourceSpans[ri].ScopeSpans)
+			scopes[sk] = si
+			traces.ResourceSpans[ri].ScopeSpans = append(traces.ResourceSpans[ri].ScopeSpans, otlpScopeSpans{
+				Scope: otlpScope{Name: lib.Name, Version: lib.Version},
+			})
+		}
+
+		scope := &traces.ResourceSpans[ri].ScopeSpans[si]
+		scope.Spans = append(scope.Spans, otlpSpanOf(s))
+	}
+	return traces
+}
+
+func otlpSpanOf(s *sdktrace.SpanSnapshot) otlpSpan {
+	span := otlpSpan{
+		TraceID:           s.SpanContext.TraceID().String(),
+		SpanID:            s.SpanContext.SpanID().String(),
+		Name:              s.Name,
+		Kind:              int(s.SpanKind),
+		StartTimeUnixNano: strconv.FormatInt(s.StartTime.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.EndTime.UnixNano(), 10),
+		Attributes:        otlpAttributes(s.Attributes),
+		Status:            otlpStatus{Code: otlpStatusUnset},
+	}
+	if s.Parent.SpanID().IsValid() {
+		span.ParentSpanID = s.Parent.SpanID().String()
+	}
+	switch s.StatusCode {
+	case codes.Ok:
+		span.Status.Code = otlpStatusOK
+	case codes.Error:
+		span.Status = otlpStatus{Code: otlpStatusError, Message: s.StatusMessage}
+	}
+	for _, event := range s.MessageEvents {
+		span.Events = append(span.Events, otlpEvent{
+			TimeUnixNano: strconv.FormatInt(event.Time.UnixNano(), 10),
+			Name:         event.Name,
+			Attributes:   otlpAttributes(event.Attributes),
+		})
+	}
+	return span
+}
+
+func otlpAttributes(kvs []attribute.KeyValue) []otlpKeyValue {
+	attrs := make([]otlpKeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		var v otlpValue
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			b := kv.Value.AsBool()
+			v.BoolValue = &b
+		case attribute.INT64:
+			i := strconv.FormatInt(kv.Value.AsInt64(), 10)
+			v.IntValue = &i
+		case attribute.FLOAT64:
+			f := kv.Value.AsFloat64()
+			v.DoubleValue = &f
+		default:
+			str := kv.Value.Emit()
+			v.StringValue = &str
+		}
+		attrs = append(attrs, otlpKeyValue{Key: string(kv.Key), Value: v})
+	}
+	return attrs
+}
@@ -0,0 +1,139 @@
+// Package tracing follows a message through the servers with OpenTelemetry.
+//
+// The trace context travels in the go-micro metadata of the rpc requests and in the
+// headers of the broker messages, every hop starts a span of its own. Spans are exported
+// to an OTLP/HTTP collector or to stdout as configured, the trace context is propagated
+// even if spans are not exported.
+package tracing
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+	"mercury/config"
+	"mercury/x/ecode"
+	"mercury/x/log"
+	"strings"
+)
+
+const instrumentationName = "mercury"
+
+func init() {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// ShutdownFunc flushes the spans which were not exported yet.
+type ShutdownFunc func(ctx context.Context) error
+
+// Init installs the tracer provider of the service. Servers running in the same process
+// share the provider installed last.
+func Init(c *config.Tracing, serviceName string) (ShutdownFunc, error) {
+	noop := func(context.Context) error { return nil }
+	if c == nil || c.Exporter == "" {
+		return noop, nil
+	}
+
+	var exporter sdktrace.SpanExporter
+	switch c.Exporter {
+	case config.TracingExporterOTLP:
+		exporter = NewOTLPExporter(c.Endpoint)
+	case config.TracingExporterStdout:
+		e, err := stdout.NewExporter(stdout.WithPrettyPrint(), stdout.WithoutMetricExport())
+		if err != nil {
+			return nil, err
+		}
+		exporter = e
+	default:
+		return nil, ecode.NewError("unknown tracing exporter: " + c.Exporter)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SampleRatio))),
+		sdktrace.WithResource(sdkresource.NewWithAttributes(semconv.ServiceNameKey.String(serviceName))),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// Start starts a span, it is a child of the span in ctx if there is one.
+func Start(ctx context.Context, name string, opts ...trace.SpanOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End records the error, if any, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceID returns the ID of the trace in ctx or an empty string.
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.TraceID().IsValid() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// Logger adds the trace and span IDs in ctx to the records of the logger.
+func Logger(ctx context.Context, l log.Logger) log.Logger {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return l
+	}
+	return l.New("trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+}
+
+// Inject writes the trace context in ctx to the carrier, such as the metadata of a request
+// or the headers of a message.
+func Inject(ctx context.Context, carrier map[string]string) {
+	otel.GetTextMapPropagator().Inject(ctx, mapCarrier(carrier))
+}
+
+// Extract returns ctx with the trace context read from the carrier.
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, mapCarrier(carrier))
+}
+
+// String returns an attribute of the span.
+func String(key, value string) attribute.KeyValue {
+	return attribute.String(key, value)
+}
+
+// mapCarrier looks up keys case-insensitively, go-micro capitalizes the metadata keys.
+type mapCarrier map[string]string
+
+func (c mapCarrier) Get(key string) string {
+	if v, ok := c[key]; ok {
+		return v
+	}
+	for k, v := range c {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
+func (c mapCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c mapCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestPropagation(t *testing.T) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+	ctx, span := Start(context.Background(), "publish", trace.WithSpanKind(trace.SpanKindProducer))
+	m := &broker.Message{Body: []byte("hello")}
+	InjectMessage(ctx, m)
+	span.End()
+	require.NotEmpty(t, m.Header["traceparent"])
+
+	// go-micro capitalizes the metadata keys
+	header := map[string]string{"Traceparent": m.Header["traceparent"]}
+	require.Equal(t, TraceID(ctx), TraceID(Extract(context.Background(), header)))
+
+	cctx, cspan := StartConsumer("mercury-push-message", m)
+	defer cspan.End()
+	require.Equal(t, TraceID(ctx), TraceID(cctx))
+	require.NotEqual(t, span.SpanContext().SpanID(), cspan.SpanContext().SpanID())
+
+	_, orphan := StartConsumer("mercury-push-message", &broker.Message{})
+	defer orphan.End()
+	require.NotEqual(t, span.SpanContext().TraceID(), orphan.SpanContext().TraceID())
+}
+
+func TestOTLPExporter(t *testing.T) {
+	received := make(chan otlpTraces, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		var traces otlpTraces
+		require.NoError(t, json.Unmarshal(body, &traces))
+		received <- traces
+	}))
+	defer srv.Close()
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(NewOTLPExporter(srv.URL)))
+	defer tp.Shutdown(context.Background())
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "Chat.PushMessage")
+	_, child := tp.Tracer("test").Start(ctx, "websocket write", trace.WithAttributes(String("sid", "s1")))
+	End(child, context.DeadlineExceeded)
+
+	traces := <-received
+	require.Len(t, traces.ResourceSpans, 1)
+	require.Len(t, traces.ResourceSpans[0].ScopeSpans, 1)
+	spans := traces.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 1)
+	require.Equal(t, "websocket write", spans[0].Name)
+	require.Equal(t, parent.SpanContext().TraceID().String(), spans[0].TraceID)
+	require.Equal(t, parent.SpanContext().SpanID().String(), spans[0].ParentSpanID)
+	require.Equal(t, otlpStatusError, spans[0].Status.Code)
+	require.Equal(t, "sid", spans[0].Attributes[0].Key)
+	require.Equal(t, "s1", *spans[0].Attributes[0].Value.StringValue)
+	parent.End()
+	require.Equal(t, "Chat.PushMessage", (<-received).ResourceSpans[0].ScopeSpans[0].Spans[0].Name)
+}
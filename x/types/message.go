@@ -271,6 +271,19 @@ type Message struct {
 	Mentions    []string    `json:"mentions,omitempty"`
 }
 
+// RoomMessage is an ephemeral message sent to a room, it is never persisted.
+type RoomMessage struct {
+	CreatedAt int64   `json:"created_at,string"`
+	Room      string  `json:"room"`
+	Sender    string  `json:"sender,omitempty"`
+	Body      Content `json:"body"`
+}
+
+// RoomKey scopes the room name to the client, rooms of different clients never mix.
+func RoomKey(clientID, room string) string {
+	return clientID + ":" + room
+}
+
 /*
 {
 	"content": "Hello, World!"
@@ -17,6 +17,10 @@ const (
 	OperationPush
 	OperationNotification
 	OperationBroadcast
+	OperationReconnect
+	OperationJoinRoom
+	OperationLeaveRoom
+	OperationRoom
 )
 
 // String implements Stringer interface: gets human-readable name for a numeric operation.
@@ -50,6 +54,14 @@ func (o Operation) MarshalText() ([]byte, error) {
 		return []byte("notification"), nil
 	case OperationBroadcast:
 		return []byte("broadcast"), nil
+	case OperationReconnect:
+		return []byte("reconnect"), nil
+	case OperationJoinRoom:
+		return []byte("join_room"), nil
+	case OperationLeaveRoom:
+		return []byte("leave_room"), nil
+	case OperationRoom:
+		return []byte("room"), nil
 	default:
 		return []byte("unknown"), nil
 	}
@@ -70,6 +82,14 @@ func (o *Operation) UnmarshalText(b []byte) error {
 		*o = OperationNotification
 	case "broadcast":
 		*o = OperationBroadcast
+	case "reconnect":
+		*o = OperationReconnect
+	case "join_room":
+		*o = OperationJoinRoom
+	case "leave_room":
+		*o = OperationLeaveRoom
+	case "room":
+		*o = OperationRoom
 	default:
 		*o = OperationUnknown
 	}
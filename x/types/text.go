@@ -0,0 +1,65 @@
+package types
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"strings"
+	"unicode"
+)
+
+// Text returns the text of the body of a message to be searched: the content of text
+// and quote messages, the address of locations and the filename of the others.
+func Text(contentType ContentType, body []byte) string {
+	switch contentType {
+	case ContentTypeText:
+		var m TextMessage
+		if jsoniter.Unmarshal(body, &m) == nil {
+			return m.Content
+		}
+	case ContentTypeQuote:
+		var m QuoteMessage
+		if jsoniter.Unmarshal(body, &m) == nil {
+			return m.Content
+		}
+	case ContentTypeLocation:
+		var m LocationMessage
+		if jsoniter.Unmarshal(body, &m) == nil {
+			return m.Address
+		}
+	case ContentTypeImage, ContentTypeAudio, ContentTypeVideo, ContentTypeFile:
+		var m struct {
+			FileStat FileStat `json:"file_stat"`
+		}
+		if jsoniter.Unmarshal(body, &m) == nil {
+			return m.FileStat.Filename
+		}
+	}
+	return ""
+}
+
+// Tokenize splits the text into lower case words. The ideographs, which are written
+// without spaces, are a word each.
+func Tokenize(text string) []string {
+	var (
+		tokens []string
+		word   strings.Builder
+	)
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
@@ -33,6 +33,9 @@ type Connection struct {
 	outChan   chan *out
 	closeChan chan struct{}
 	once      *sync.Once
+	// Starts reading once the connection is read, so that the read limit, the deadline and
+	// the pong handler are set before
+	readOnce *sync.Once
 }
 
 func dial(api string) (*websocket.Conn, error) {
@@ -52,6 +55,7 @@ func Dial(api string) (*Connection, error) {
 		outChan:   make(chan *out, 1000),
 		closeChan: make(chan struct{}),
 		once:      &sync.Once{},
+		readOnce:  &sync.Once{},
 	}
 	go connection.listen()
 	return connection, nil
@@ -85,15 +89,14 @@ func Upgrade(w http.ResponseWriter, r *http.Request) (*Connection, error) {
 		outChan:   make(chan *out, 1000),
 		closeChan: make(chan struct{}),
 		once:      &sync.Once{},
+		readOnce:  &sync.Once{},
 	}
 	go connection.listen()
 	return connection, nil
 }
 
 func (c *Connection) listen() {
-	// 启动读协程
-	go c.readLoop()
-	// 启动写协程
+	// 启动写协程, 读协程由第一次ReadMessage启动
 	c.writeLoop()
 }
 
@@ -110,6 +113,9 @@ func (c *Connection) SetPongHandler(h func(appData string) error) {
 }
 
 func (c *Connection) ReadMessage() ([]byte, error) {
+	c.readOnce.Do(func() {
+		go c.readLoop()
+	})
 	select {
 	case data := <-c.inChan:
 		return data, nil